@@ -47,6 +47,8 @@ type Error struct {
 	Code         int
 	RequestError error
 	DecodeError  error
+	// Body is the response body of a failed request, when there was a response
+	Body []byte
 }
 
 func (e Error) Error() string {
@@ -508,3 +508,27 @@ func Test_buildURL(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryRequestErrorBody(t *testing.T) {
+
+	l, err := NewDefaultDependencies()
+	require.NoError(t, err, "NewDefaultDependencies returns without error")
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`[{"code":"invalid_action","message":"you cannot move that direction"}]`))
+	}))
+	defer server.Close()
+
+	cl, err := NewClient(l)
+	require.NoError(t, err, "NewClient returns without error")
+	cl.Host = server.URL
+
+	err = cl.Get("/api/collections", nil, &Response{})
+	require.Error(t, err, "Get returns with error")
+
+	clientErr, ok := err.(Error)
+	require.True(t, ok, "Error is a client error")
+	require.Equal(t, http.StatusBadRequest, clientErr.Code, "Error code equals response status")
+	require.Equal(t, `[{"code":"invalid_action","message":"you cannot move that direction"}]`, string(clientErr.Body), "Error body equals response body")
+}
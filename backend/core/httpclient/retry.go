@@ -60,6 +60,7 @@ RETRY:
 						return err
 					}
 				}
+				clientErr.Body = buf.Bytes()
 				respData := buf.String()
 				l.Warn("client request failed retries >%d< >%v< response >%s<", retries, err, respData)
 
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
@@ -26,20 +27,26 @@ func (rnr *Runner) AuthenMiddleware(hc HandlerConfig, h Handle) (Handle, error)
 			return h(w, r, pp, qp, l, m)
 		}
 
-		auth := r.Header.Get("Authorization")
-		if auth == "" {
+		if r.Header.Get("Authorization") == "" && r.Header.Get(HeaderXAPIKey) == "" {
 			l.Warn(unauthErr.Error())
 			WriteError(l, w, unauthErr)
 			return unauthErr
 		}
 
-		authenticatedRequest, err := rnr.AuthenticateRequestFunc(l, m, auth)
+		authenticatedRequest, err := rnr.AuthenticateRequestFunc(l, m, r)
 		if err != nil {
 			l.Warn(unauthErr.Error())
 			WriteError(l, w, unauthErr)
 			return err
 		}
 
+		if !isAuthenticatedTypeAllowed(handlerAuthenTypes, authenticatedRequest.Type) {
+			err := fmt.Errorf("handler name >%s< does not allow authenticated type >%s<", hc.Name, authenticatedRequest.Type)
+			l.Warn(err.Error())
+			WriteError(l, w, unauthErr)
+			return err
+		}
+
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, ctxKeyAuth, authenticatedRequest)
 		r = r.WithContext(ctx)
@@ -50,6 +57,20 @@ func (rnr *Runner) AuthenMiddleware(hc HandlerConfig, h Handle) (Handle, error)
 	return handle, nil
 }
 
+// isAuthenticatedTypeAllowed returns whether a handler allows requests authenticated
+// as the authenticated type, users authenticate with a JWT and API keys with an API key
+func isAuthenticatedTypeAllowed(handlerAuthenTypes map[AuthenticationType]struct{}, authenticatedType AuthenticatedType) bool {
+	switch authenticatedType {
+	case AuthenticatedTypeUser:
+		_, ok := handlerAuthenTypes[AuthenticationTypeJWT]
+		return ok
+	case AuthenticatedTypeAPIKey:
+		_, ok := handlerAuthenTypes[AuthenticationTypeAPIKey]
+		return ok
+	}
+	return true
+}
+
 func ToAuthenticationSet(authen ...AuthenticationType) map[AuthenticationType]struct{} {
 	set := map[AuthenticationType]struct{}{}
 	for _, p := range authen {
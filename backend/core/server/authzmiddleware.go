@@ -32,6 +32,11 @@ func (rnr *Runner) AuthzMiddleware(hc HandlerConfig, h Handle) (Handle, error) {
 			return err
 		}
 
+		if len(authzPermissions) == 0 {
+			l.Debug("handler name >%s< requires no permissions", hc.Name)
+			return h(w, r, pp, qp, l, m)
+		}
+
 		for _, permission := range authenticatedRequest.Permissions {
 			if _, ok := authzPermissions[permission]; ok {
 				return h(w, r, pp, qp, l, m)
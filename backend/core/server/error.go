@@ -9,6 +9,12 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
 )
 
+// ErrorLocaliser is implemented by response writers that translate error
+// messages into the language of the request
+type ErrorLocaliser interface {
+	LocaliseError(e coreerror.Error) coreerror.Error
+}
+
 func WriteError(l logger.Logger, w http.ResponseWriter, errs ...error) {
 	if len(errs) == 0 {
 		// This is a logic error!
@@ -39,6 +45,12 @@ func WriteError(l logger.Logger, w http.ResponseWriter, errs ...error) {
 		l.Error(results[0].Error())
 	}
 
+	if el, ok := w.(ErrorLocaliser); ok {
+		for idx := range results {
+			results[idx] = el.LocaliseError(results[idx])
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 
 	w.WriteHeader(status)
@@ -90,6 +102,10 @@ func writeSystemError(l logger.Logger, w http.ResponseWriter) {
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 
+	if el, ok := w.(ErrorLocaliser); ok {
+		e = el.LocaliseError(e)
+	}
+
 	status := e.HttpStatusCode
 	w.WriteHeader(e.HttpStatusCode)
 
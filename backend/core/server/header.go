@@ -7,6 +7,8 @@ import (
 
 const (
 	HeaderXPagination = "X-Pagination"
+	// HeaderXAPIKey authenticates requests that do not provide an Authorization header
+	HeaderXAPIKey = "X-API-Key"
 )
 
 func XPaginationHeader(collectionLen int, pageSize int) func(http.ResponseWriter) error {
@@ -57,7 +57,7 @@ func (rnr *Runner) RunHTTP(args map[string]interface{}) (*http.Server, error) {
 
 	allowedHeaders := []string{
 		"X-ProgramID", "X-ProgramName", "Content-Type",
-		"Authorization", "X-Authorization-Token",
+		"Authorization", "X-Authorization-Token", HeaderXAPIKey,
 		"Origin", "X-Requested-With", "Accept",
 		"X-CSRF-Token",
 	}
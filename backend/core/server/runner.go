@@ -62,7 +62,10 @@ type Runner struct {
 	HandlerMiddlewareFuncs func() []MiddlewareFunc
 
 	// Service feature callbacks
-	AuthenticateRequestFunc func(l logger.Logger, m modeller.Modeller, apiKey string) (AuthenticatedRequest, error)
+
+	// AuthenticateRequestFunc authenticates a request that provides either an
+	// Authorization header or an X-API-Key header
+	AuthenticateRequestFunc func(l logger.Logger, m modeller.Modeller, r *http.Request) (AuthenticatedRequest, error)
 
 	// Domain layer
 	ModellerFunc func(l logger.Logger) (modeller.Modeller, error)
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
@@ -38,11 +40,16 @@ func (rnr *Runner) TxMiddleware(hc HandlerConfig, h Handle) (Handle, error) {
 			return err
 		}
 
+		// NOTE: Handlers that hold a request open, such as those streaming
+		// responses, end the transaction early so a database connection is not
+		// held for the lifetime of the request. A transaction that has already
+		// ended is not an error.
+
 		err = h(w, r, pp, qp, l, m)
 		if err != nil {
 			l.Warn("rolling back database transaction")
 
-			if err := m.Rollback(); err != nil {
+			if err := m.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
 				l.Warn("failed Tx rollback >%v<", err)
 				return err
 			}
@@ -52,7 +59,7 @@ func (rnr *Runner) TxMiddleware(hc HandlerConfig, h Handle) (Handle, error) {
 		l.Debug("committing database transaction")
 
 		if r.Header.Get(HeaderXTxRollback) != "" {
-			if err = m.Rollback(); err != nil {
+			if err = m.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
 				l.Warn("failed Tx commit >%v<", err)
 				WriteSystemError(l, w, err)
 				return err
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/schema"
+)
+
+// AccountResponse -
+type AccountResponse struct {
+	schema.Response
+	Data []AccountData `json:"data"`
+}
+
+// AccountData -
+type AccountData struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// AccountRequest -
+type AccountRequest struct {
+	schema.Request
+	Data AccountRequestData `json:"data"`
+}
+
+// AccountRequestData -
+type AccountRequestData struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// AccountRoleRequest -
+type AccountRoleRequest struct {
+	schema.Request
+	Data AccountRoleRequestData `json:"data"`
+}
+
+// AccountRoleRequestData -
+type AccountRoleRequestData struct {
+	Role string `json:"role"`
+}
+
+// LoginRequest -
+type LoginRequest struct {
+	schema.Request
+	Data LoginRequestData `json:"data"`
+}
+
+// LoginRequestData -
+type LoginRequestData struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse -
+type LoginResponse struct {
+	schema.Response
+	Data []LoginData `json:"data"`
+}
+
+// LoginData -
+type LoginData struct {
+	AccountID string    `json:"account_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
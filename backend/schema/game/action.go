@@ -19,10 +19,11 @@ type ActionResponseData struct {
 	Command         string           `json:"command"`
 	Narrative       string           `json:"narrative"`
 	TurnNumber      int              `json:"turn_number"`
-	SerialNumber    int16            `json:"serial_number"`
+	SerialNumber    int32            `json:"serial_number"`
 	Location        ActionLocation   `json:"location"`
 	Character       *ActionCharacter `json:"character,omitempty"`
 	Monster         *ActionMonster   `json:"monster,omitempty"`
+	SystemActor     string           `json:"system_actor,omitempty"`
 	EquippedObject  *ActionObject    `json:"equipped_object,omitempty"`
 	StashedObject   *ActionObject    `json:"stashed_object,omitempty"`
 	DroppedObject   *ActionObject    `json:"dropped_object,omitempty"`
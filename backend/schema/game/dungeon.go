@@ -48,3 +48,21 @@ type DungeonExitRequest struct {
 type DungeonExitData struct {
 	CharacterID string `json:"character_id,omitempty"`
 }
+
+// DungeonGenerateRequest -
+type DungeonGenerateRequest struct {
+	schema.Request
+	Data DungeonGenerateData `json:"data"`
+}
+
+// DungeonGenerateData - Monster and object chances are pointers so a chance of
+// zero can be told apart from a chance that was not provided
+type DungeonGenerateData struct {
+	Name            string `json:"name"`
+	Seed            int64  `json:"seed,omitempty"`
+	Size            int    `json:"size,omitempty"`
+	BranchingFactor int    `json:"branching_factor,omitempty"`
+	Levels          int    `json:"levels,omitempty"`
+	MonsterChance   *int   `json:"monster_chance,omitempty"`
+	ObjectChance    *int   `json:"object_chance,omitempty"`
+}
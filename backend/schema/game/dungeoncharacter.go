@@ -15,6 +15,7 @@ type DungeonCharacterResponse struct {
 // DungeonCharacterData -
 type DungeonCharacterData struct {
 	ID                  string                        `json:"id,omitempty"`
+	AccountID           string                        `json:"account_id,omitempty"`
 	Name                string                        `json:"name"`
 	Strength            int                           `json:"strength"`
 	Dexterity           int                           `json:"dexterity"`
@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/schema"
+)
+
+// DungeonInstanceResponse -
+type DungeonInstanceResponse struct {
+	schema.Response
+	Data []DungeonInstanceData `json:"data"`
+}
+
+// DungeonInstanceData -
+type DungeonInstanceData struct {
+	ID            string                        `json:"id"`
+	DungeonID     string                        `json:"dungeon_id"`
+	Name          string                        `json:"name"`
+	Description   string                        `json:"description"`
+	FailureCount  int                           `json:"failure_count"`
+	LastError     string                        `json:"last_error,omitempty"`
+	LastErrorAt   *time.Time                    `json:"last_error_at,omitempty"`
+	QuarantinedAt *time.Time                    `json:"quarantined_at,omitempty"`
+	TurnNumber    int                           `json:"turn_number"`
+	Locations     []DungeonInstanceLocationData `json:"locations,omitempty"`
+	Characters    []DungeonInstanceOccupantData `json:"characters,omitempty"`
+	Monsters      []DungeonInstanceOccupantData `json:"monsters,omitempty"`
+	CreatedAt     time.Time                     `json:"created_at,omitempty"`
+	UpdatedAt     time.Time                     `json:"updated_at,omitempty"`
+}
+
+// DungeonInstanceLocationData -
+type DungeonInstanceLocationData struct {
+	ID         string `json:"id"`
+	LocationID string `json:"location_id"`
+	Name       string `json:"name"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+// DungeonInstanceOccupantData is a character or monster instance in a dungeon instance
+type DungeonInstanceOccupantData struct {
+	ID                 string `json:"id"`
+	CharacterID        string `json:"character_id,omitempty"`
+	MonsterID          string `json:"monster_id,omitempty"`
+	Name               string `json:"name"`
+	LocationInstanceID string `json:"location_instance_id"`
+	LocationName       string `json:"location_name"`
+	Health             int    `json:"health"`
+	CurrentHealth      int    `json:"current_health"`
+}
+
+// DungeonInstanceTeleportRequest -
+type DungeonInstanceTeleportRequest struct {
+	schema.Request
+	Data DungeonInstanceTeleportRequestData `json:"data"`
+}
+
+// DungeonInstanceTeleportRequestData -
+type DungeonInstanceTeleportRequestData struct {
+	LocationInstanceID string `json:"location_instance_id"`
+}
+
+// DungeonInstanceSpawnRequest -
+type DungeonInstanceSpawnRequest struct {
+	schema.Request
+	Data DungeonInstanceSpawnRequestData `json:"data"`
+}
+
+// DungeonInstanceSpawnRequestData -
+type DungeonInstanceSpawnRequestData struct {
+	LocationInstanceID string `json:"location_instance_id"`
+	MonsterID          string `json:"monster_id,omitempty"`
+	ObjectID           string `json:"object_id,omitempty"`
+}
@@ -0,0 +1,19 @@
+package schema
+
+import (
+	"gitlab.com/alienspaces/go-mud/backend/schema"
+)
+
+// DungeonLintResponse -
+type DungeonLintResponse struct {
+	schema.Response
+	Data []DungeonLintData `json:"data"`
+}
+
+// DungeonLintData is an issue found when linting a dungeon
+type DungeonLintData struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
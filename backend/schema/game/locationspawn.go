@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/schema"
+)
+
+// LocationMonsterResponse -
+type LocationMonsterResponse struct {
+	schema.Response
+	Data []LocationMonsterData `json:"data"`
+}
+
+// LocationMonsterRequest -
+type LocationMonsterRequest struct {
+	schema.Request
+	Data LocationMonsterData `json:"data"`
+}
+
+// LocationMonsterData is a monster that spawns at a location
+type LocationMonsterData struct {
+	ID                 string    `json:"id,omitempty"`
+	LocationID         string    `json:"location_id,omitempty"`
+	MonsterID          string    `json:"monster_id,omitempty"`
+	Name               string    `json:"name,omitempty"`
+	SpawnMinutes       int       `json:"spawn_minutes"`
+	SpawnPercentChance int       `json:"spawn_percent_chance"`
+	CreatedAt          time.Time `json:"created_at,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at,omitempty"`
+}
+
+// LocationObjectResponse -
+type LocationObjectResponse struct {
+	schema.Response
+	Data []LocationObjectData `json:"data"`
+}
+
+// LocationObjectRequest -
+type LocationObjectRequest struct {
+	schema.Request
+	Data LocationObjectData `json:"data"`
+}
+
+// LocationObjectData is an object that spawns at a location
+type LocationObjectData struct {
+	ID                 string    `json:"id,omitempty"`
+	LocationID         string    `json:"location_id,omitempty"`
+	ObjectID           string    `json:"object_id,omitempty"`
+	Name               string    `json:"name,omitempty"`
+	SpawnMinutes       int       `json:"spawn_minutes"`
+	SpawnPercentChance int       `json:"spawn_percent_chance"`
+	CreatedAt          time.Time `json:"created_at,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at,omitempty"`
+}
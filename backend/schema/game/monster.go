@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/schema"
+)
+
+// MonsterResponse -
+type MonsterResponse struct {
+	schema.Response
+	Data []MonsterData `json:"data"`
+}
+
+// MonsterRequest -
+type MonsterRequest struct {
+	schema.Request
+	Data MonsterData `json:"data"`
+}
+
+// MonsterData -
+type MonsterData struct {
+	ID               string    `json:"id,omitempty"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	Strength         int       `json:"strength"`
+	Dexterity        int       `json:"dexterity"`
+	Intelligence     int       `json:"intelligence"`
+	Health           int       `json:"health"`
+	Fatigue          int       `json:"fatigue"`
+	Coins            int       `json:"coins"`
+	ExperiencePoints int       `json:"experience_points"`
+	AttributePoints  int       `json:"attribute_points"`
+	CreatedAt        time.Time `json:"created_at,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at,omitempty"`
+}
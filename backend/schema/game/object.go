@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/schema"
+)
+
+// ObjectResponse -
+type ObjectResponse struct {
+	schema.Response
+	Data []ObjectData `json:"data"`
+}
+
+// ObjectRequest -
+type ObjectRequest struct {
+	schema.Request
+	Data ObjectData `json:"data"`
+}
+
+// ObjectData -
+type ObjectData struct {
+	ID                  string    `json:"id,omitempty"`
+	Name                string    `json:"name"`
+	Description         string    `json:"description"`
+	DescriptionDetailed string    `json:"description_detailed"`
+	CreatedAt           time.Time `json:"created_at,omitempty"`
+	UpdatedAt           time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/log"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/client"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repl"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/terminal"
+)
+
+func main() {
+
+	host := flag.String("host", defaultHost(), "game server URL")
+	email := flag.String("email", "", "account email, prompts for the password and logs in on start")
+	language := flag.String("language", "", "language of dungeon content, narratives and command verbs")
+	noColour := flag.Bool("no-colour", false, "do not colour text with ANSI escape codes")
+	logLevel := flag.String("log-level", "error", "log level of API requests")
+	flag.Parse()
+
+	// Logs are written to standard output along with the game so only errors
+	// are logged by default
+	l, err := log.NewLoggerWithConfig(log.Config{Level: *logLevel})
+	if err != nil {
+		fmt.Printf("Failed new logger >%v<\n", err)
+		os.Exit(1)
+	}
+
+	c, err := client.NewClient(l, *host)
+	if err != nil {
+		fmt.Printf("Failed new client >%v<\n", err)
+		os.Exit(1)
+	}
+
+	e := repl.NewLineEditor(os.Stdin, os.Stdout)
+
+	// Lines are edited by the client when standard input is a terminal and
+	// read as they are when input is piped
+	restore, err := repl.MakeRaw()
+	if err == nil {
+		e.Raw = true
+		defer func() {
+			if err := restore(); err != nil {
+				fmt.Printf("Failed restoring terminal >%v<\n", err)
+			}
+		}()
+	}
+
+	lang := ""
+	if *language != "" {
+		lang = locale.Negotiate(*language)
+	}
+
+	r := repl.NewREPL(c, e, os.Stdout, terminal.Options{
+		ANSI:     e.Raw && !*noColour,
+		Width:    repl.Width(),
+		Language: lang,
+	})
+
+	if *email != "" {
+		password, err := e.ReadPassword("Password: ")
+		if err == nil {
+			err = r.Login(*email, password)
+		}
+		if err != nil {
+			fmt.Printf("Failed login >%s<\n", client.ErrorMessage(err))
+		}
+	}
+
+	if err := r.Run(); err != nil {
+		fmt.Printf("Failed client run >%v<\n", err)
+	}
+}
+
+// defaultHost is the local development server
+func defaultHost() string {
+	port := os.Getenv("APP_SERVER_PORT")
+	if port == "" {
+		port = "8084"
+	}
+	return "http://localhost:" + port
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/log"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/load"
+)
+
+func main() {
+
+	cfg := load.Config{}
+
+	flag.StringVar(&cfg.Host, "host", defaultHost(), "game server URL")
+	flag.IntVar(&cfg.Bots, "bots", 10, "number of bot players")
+	flag.StringVar(&cfg.Dungeon, "dungeon", "", "name of the dungeon bots enter, the first dungeon when empty")
+	flag.StringVar(&cfg.Strategy, "strategy", load.StrategyMonster, "strategy bots play with, one of "+strings.Join(load.StrategyNames(), ", "))
+	flag.DurationVar(&cfg.Duration, "duration", time.Minute, "how long bots play for")
+	flag.IntVar(&cfg.Actions, "actions", 0, "most actions each bot submits, unlimited when zero")
+	flag.DurationVar(&cfg.Think, "think", time.Second, "average pause between a bot's actions")
+	flag.DurationVar(&cfg.RampUp, "ramp-up", 10*time.Second, "time bot starts are spread over")
+	flag.StringVar(&cfg.Email, "email", "", "account email bot characters are created for, an account is registered when empty")
+	flag.StringVar(&cfg.Password, "password", "", "account password")
+	flag.Int64Var(&cfg.Seed, "seed", time.Now().UnixNano(), "seed of the random choices of bots")
+	asJSON := flag.Bool("json", false, "write the report as JSON")
+	logLevel := flag.String("log-level", "error", "log level of API requests")
+	flag.Parse()
+
+	l, err := log.NewLoggerWithConfig(log.Config{Level: *logLevel})
+	if err != nil {
+		fmt.Printf("Failed new logger >%v<\n", err)
+		os.Exit(1)
+	}
+
+	// Interrupting ends the run early and still reports
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := load.Run(ctx, l, cfg)
+	if err != nil {
+		fmt.Printf("Failed load run >%v<\n", err)
+		os.Exit(1)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Printf("Failed writing report >%v<\n", err)
+		os.Exit(1)
+	}
+}
+
+// defaultHost is the local development server
+func defaultHost() string {
+	port := os.Getenv("APP_SERVER_PORT")
+	if port == "" {
+		port = "8084"
+	}
+	return "http://localhost:" + port
+}
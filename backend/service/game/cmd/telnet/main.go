@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	runner "gitlab.com/alienspaces/go-mud/backend/service/game/internal/runner/server"
+)
+
+func main() {
+
+	c, l, s, err := dependencies.Default()
+	if err != nil {
+		fmt.Printf("Failed default dependencies >%v<\n", err)
+		os.Exit(0)
+	}
+
+	r, err := runner.NewRunner(c, l)
+	if err != nil {
+		fmt.Printf("Failed new runner >%v<\n", err)
+		os.Exit(0)
+	}
+
+	// The telnet server shares the server runner model and transaction layer
+	err = r.Init(s)
+	if err != nil {
+		fmt.Printf("Failed runner init >%v<\n", err)
+		os.Exit(0)
+	}
+
+	args := make(map[string]interface{})
+
+	err = r.RunTelnet(args)
+	if err != nil {
+		fmt.Printf("Failed telnet run >%v<\n", err)
+		os.Exit(0)
+	}
+
+	os.Exit(1)
+}
@@ -0,0 +1,258 @@
+package client
+
+// Client is a game API client for players. It wraps core/httpclient with the
+// requests a player makes to register, log in, manage characters and play.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/httpclient"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+)
+
+// API paths
+const (
+	pathAccounts              string = "/api/v1/accounts"
+	pathLogin                 string = "/api/v1/login"
+	pathDungeons              string = "/api/v1/dungeons"
+	pathCharacters            string = "/api/v1/characters"
+	pathDungeonCharacterEnter string = "/api/v1/dungeons/:dungeon_id/characters/:character_id/enter"
+	pathDungeonCharacterExit  string = "/api/v1/dungeons/:dungeon_id/characters/:character_id/exit"
+	pathActions               string = "/api/v1/dungeons/:dungeon_id/characters/:character_id/actions"
+)
+
+// Client -
+type Client struct {
+	Log logger.Logger
+	// HTTPClient is exported so callers may set request headers and verbosity
+	HTTPClient *httpclient.Client
+}
+
+// NewClient returns a client for the game server at the host, for example
+// http://localhost:8084
+func NewClient(l logger.Logger, host string) (*Client, error) {
+
+	hc, err := httpclient.NewClient(l)
+	if err != nil {
+		return nil, err
+	}
+
+	hc.Host = strings.TrimSuffix(host, "/")
+	// Actions are not idempotent, a failed action must never be resent
+	hc.MaxRetries = 1
+	hc.RequestHeaders = map[string]string{}
+
+	c := Client{
+		Log:        l,
+		HTTPClient: hc,
+	}
+
+	return &c, nil
+}
+
+// SetLanguage sets the language of dungeon content and narratives
+func (c *Client) SetLanguage(language string) {
+	if language == "" {
+		delete(c.HTTPClient.RequestHeaders, "Accept-Language")
+		return
+	}
+	c.HTTPClient.RequestHeaders["Accept-Language"] = language
+}
+
+// SetToken sets the token requests are authenticated with
+func (c *Client) SetToken(token string) {
+	c.HTTPClient.AuthToken = token
+}
+
+// Register creates an account
+func (c *Client) Register(name, email, password string) (*schema.AccountData, error) {
+
+	req := &schema.AccountRequest{
+		Data: schema.AccountRequestData{
+			Name:     name,
+			Email:    email,
+			Password: password,
+		},
+	}
+	resp := &schema.AccountResponse{}
+
+	err := c.HTTPClient.Create(pathAccounts, nil, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("register response has no account")
+	}
+
+	return &resp.Data[0], nil
+}
+
+// Login authenticates the account and authenticates further requests with
+// the returned token
+func (c *Client) Login(email, password string) (*schema.LoginData, error) {
+
+	req := &schema.LoginRequest{
+		Data: schema.LoginRequestData{
+			Email:    email,
+			Password: password,
+		},
+	}
+	resp := &schema.LoginResponse{}
+
+	err := c.HTTPClient.Create(pathLogin, nil, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("login response has no token")
+	}
+
+	c.SetToken(resp.Data[0].Token)
+
+	return &resp.Data[0], nil
+}
+
+// Dungeons returns all dungeons
+func (c *Client) Dungeons() ([]schema.DungeonData, error) {
+
+	resp := &schema.DungeonResponse{}
+
+	err := c.HTTPClient.Get(pathDungeons, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// Characters returns the characters of the authenticated account
+func (c *Client) Characters() ([]schema.DungeonCharacterData, error) {
+
+	resp := &schema.CharacterResponse{}
+
+	err := c.HTTPClient.Get(pathCharacters, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// CreateCharacter creates a character for the authenticated account
+func (c *Client) CreateCharacter(name string, strength, dexterity, intelligence int) (*schema.DungeonCharacterData, error) {
+
+	req := &schema.CharacterRequest{
+		Data: schema.DungeonCharacterData{
+			Name:         name,
+			Strength:     strength,
+			Dexterity:    dexterity,
+			Intelligence: intelligence,
+		},
+	}
+	resp := &schema.CharacterResponse{}
+
+	err := c.HTTPClient.Create(pathCharacters, nil, req, resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("create character response has no character")
+	}
+
+	return &resp.Data[0], nil
+}
+
+// EnterDungeon enters the character into the dungeon
+func (c *Client) EnterDungeon(dungeonID, characterID string) (*schema.DungeonCharacterData, error) {
+	return c.dungeonCharacter(pathDungeonCharacterEnter, dungeonID, characterID)
+}
+
+// ExitDungeon exits the character from the dungeon
+func (c *Client) ExitDungeon(dungeonID, characterID string) (*schema.DungeonCharacterData, error) {
+	return c.dungeonCharacter(pathDungeonCharacterExit, dungeonID, characterID)
+}
+
+func (c *Client) dungeonCharacter(path, dungeonID, characterID string) (*schema.DungeonCharacterData, error) {
+
+	params := map[string]string{
+		"dungeon_id":   dungeonID,
+		"character_id": characterID,
+	}
+	resp := &schema.DungeonCharacterResponse{}
+
+	// Entering and exiting have no request data though core/httpclient
+	// requires some to post
+	err := c.HTTPClient.Create(path, params, &schema.DungeonCharacterResponse{}, resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("dungeon character response has no character")
+	}
+
+	return &resp.Data[0], nil
+}
+
+// Action submits a sentence for the character and returns the actions that
+// have occurred since the character's previous action, ending with the
+// character's own action
+func (c *Client) Action(dungeonID, characterID, sentence string) ([]schema.ActionResponseData, error) {
+
+	params := map[string]string{
+		"dungeon_id":   dungeonID,
+		"character_id": characterID,
+	}
+	req := &schema.ActionRequest{
+		Data: schema.ActionRequestData{
+			Sentence: sentence,
+		},
+	}
+	resp := &schema.ActionResponse{}
+
+	err := c.HTTPClient.Create(pathActions, params, req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+// ErrorCode returns the API error code of a failed request, or an empty code
+// when the request failed without an API error
+func ErrorCode(err error) coreerror.ErrorCode {
+	if e := apiError(err); e != nil {
+		return e.ErrorCode
+	}
+	return ""
+}
+
+// ErrorMessage returns the message of the API error of a failed request, or
+// the error itself when the request failed without an API error
+func ErrorMessage(err error) string {
+	if e := apiError(err); e != nil {
+		return e.Message
+	}
+	return err.Error()
+}
+
+// apiError returns the first API error of the response body of a failed
+// request
+func apiError(err error) *coreerror.Error {
+
+	var clientErr httpclient.Error
+	if !errors.As(err, &clientErr) || len(clientErr.Body) == 0 {
+		return nil
+	}
+
+	errs := []coreerror.Error{}
+	if err := json.Unmarshal(clientErr.Body, &errs); err != nil || len(errs) == 0 {
+		return nil
+	}
+
+	return &errs[0]
+}
@@ -9,6 +9,53 @@ import (
 
 const (
 	AppServerTurnDuration string = "APP_SERVER_TURN_DURATION"
+	// AppServerDaemonID uniquely identifies a server daemon when claiming
+	// dungeon instance leases, defaults to the host name.
+	AppServerDaemonID string = "APP_SERVER_DAEMON_ID"
+	// AppServerDaemonLeaseDuration is the number of milliseconds a dungeon
+	// instance lease is held before it expires unless renewed.
+	AppServerDaemonLeaseDuration string = "APP_SERVER_DAEMON_LEASE_DURATION"
+	// AppServerDaemonMaxInstances is the maximum number of dungeon instances
+	// a single server daemon will process, zero is unlimited.
+	AppServerDaemonMaxInstances string = "APP_SERVER_DAEMON_MAX_INSTANCES"
+	// AppServerDaemonWorkers is the number of dungeon instances a single server
+	// daemon will process concurrently.
+	AppServerDaemonWorkers string = "APP_SERVER_DAEMON_WORKERS"
+	// AppServerDaemonMaxFailures is the number of consecutive times a dungeon
+	// instance may fail processing before it is quarantined, zero never quarantines.
+	AppServerDaemonMaxFailures string = "APP_SERVER_DAEMON_MAX_FAILURES"
+	// AppServerDaemonEmptyGracePeriod is the number of milliseconds a dungeon
+	// instance may be empty of characters before it is shutdown.
+	AppServerDaemonEmptyGracePeriod string = "APP_SERVER_DAEMON_EMPTY_GRACE_PERIOD"
+	// AppServerDaemonIdleCharacterTimeout is the number of milliseconds a character
+	// may go without performing an action before it exits the dungeon, zero never
+	// exits idle characters.
+	AppServerDaemonIdleCharacterTimeout string = "APP_SERVER_DAEMON_IDLE_CHARACTER_TIMEOUT"
+	// AppServerDaemonConsistencyCheckCycles is the number of daemon cycles between
+	// dungeon instance invariant checks, zero never checks.
+	AppServerDaemonConsistencyCheckCycles string = "APP_SERVER_DAEMON_CONSISTENCY_CHECK_CYCLES"
+	// AppServerDaemonConsistencyRepair repairs dungeon instance invariant
+	// violations found by the daemon when true, otherwise violations are only
+	// reported.
+	AppServerDaemonConsistencyRepair string = "APP_SERVER_DAEMON_CONSISTENCY_REPAIR"
+	// AppServerJWTPrivateKeyPath is the path to a PEM encoded RSA private key used
+	// to sign and verify account tokens. Required unless an ephemeral key is
+	// enabled with AppServerJWTEphemeralKey.
+	AppServerJWTPrivateKeyPath string = "APP_SERVER_JWT_PRIVATE_KEY_PATH"
+	// AppServerJWTEphemeralKey generates a key on startup when no private key path
+	// is configured. For development and testing only, tokens are only valid until
+	// the server is restarted and only on the server that issued them.
+	AppServerJWTEphemeralKey string = "APP_SERVER_JWT_EPHEMERAL_KEY"
+	// AppServerJWTTokenDuration is the number of milliseconds an account token is
+	// valid for after login.
+	AppServerJWTTokenDuration string = "APP_SERVER_JWT_TOKEN_DURATION"
+	// AppServerNarrativeFlavourPath is the path to a YAML file of monster and
+	// object flavour text used when narrating actions. When not provided the
+	// flavour text for the seed dungeons is used.
+	AppServerNarrativeFlavourPath string = "APP_SERVER_NARRATIVE_FLAVOUR_PATH"
+	// AppServerTelnetPort is the port the telnet server listens on for classic
+	// MUD clients, defaults to 4000.
+	AppServerTelnetPort string = "APP_SERVER_TELNET_PORT"
 )
 
 type Config struct {
@@ -29,6 +76,24 @@ func NewConfig() (*Config, error) {
 		AppServerTurnDuration,
 	}, true)...)
 
+	// Additional service optional items
+	items = append(items, config.NewItems([]string{
+		AppServerDaemonID,
+		AppServerDaemonLeaseDuration,
+		AppServerDaemonMaxInstances,
+		AppServerDaemonWorkers,
+		AppServerDaemonMaxFailures,
+		AppServerDaemonEmptyGracePeriod,
+		AppServerDaemonIdleCharacterTimeout,
+		AppServerDaemonConsistencyCheckCycles,
+		AppServerDaemonConsistencyRepair,
+		AppServerJWTPrivateKeyPath,
+		AppServerJWTEphemeralKey,
+		AppServerJWTTokenDuration,
+		AppServerNarrativeFlavourPath,
+		AppServerTelnetPort,
+	}, false)...)
+
 	cc, err := config.NewConfig(items, false)
 	if err != nil {
 		return nil, fmt.Errorf("NewConfig failed >%v<", err)
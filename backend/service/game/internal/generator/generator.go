@@ -0,0 +1,345 @@
+package generator
+
+// The generator produces dungeon definitions from a seed and a handful of
+// parameters so dungeons with replayable variety do not all have to be written
+// by hand. The same seed and parameters always produce the same dungeon.
+
+import (
+	"fmt"
+	"math/rand"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+const (
+	RarityCommon    string = "common"
+	RarityUncommon  string = "uncommon"
+	RarityRare      string = "rare"
+	RarityLegendary string = "legendary"
+)
+
+// rarityWeights is how likely each rarity is to be picked relative to the others
+var rarityWeights = map[string]int{
+	RarityCommon:    16,
+	RarityUncommon:  8,
+	RarityRare:      3,
+	RarityLegendary: 1,
+}
+
+const (
+	DefaultSize            int = 10
+	DefaultBranchingFactor int = 2
+	DefaultLevels          int = 1
+	DefaultMonsterChance   int = 30
+	DefaultObjectChance    int = 30
+)
+
+// Args are the parameters a dungeon is generated from
+type Args struct {
+	Name string
+	Seed int64
+	// Size is the number of locations on each level
+	Size int
+	// BranchingFactor is the most exits leading on from a location to new
+	// locations, higher values produce bushier levels, a value of one
+	// produces a single winding passage
+	BranchingFactor int
+	// Levels are stacked and joined by up and down exits
+	Levels int
+	// MonsterChance and ObjectChance are the percent chance each location
+	// other than the default location has a monster or object spawn
+	MonsterChance int
+	ObjectChance  int
+	// Pool defaults to the default pool when it has no monsters or objects
+	Pool Pool
+}
+
+// Pool is the monsters and objects a generated dungeon picks from
+type Pool struct {
+	Monsters []PoolMonster `json:"monsters" yaml:"monsters"`
+	Objects  []PoolObject  `json:"objects" yaml:"objects"`
+}
+
+type PoolMonster struct {
+	model.MonsterDefinition `yaml:",inline"`
+	Rarity                  string `json:"rarity,omitempty" yaml:"rarity,omitempty"`
+}
+
+type PoolObject struct {
+	model.ObjectDefinition `yaml:",inline"`
+	Rarity                 string `json:"rarity,omitempty" yaml:"rarity,omitempty"`
+}
+
+// cell is a position on a level grid
+type cell struct {
+	x int
+	y int
+}
+
+// compass are the directions locations on the same level are joined by
+var compass = []struct {
+	direction string
+	opposite  string
+	dx        int
+	dy        int
+}{
+	{"north", "south", 0, -1},
+	{"northeast", "southwest", 1, -1},
+	{"east", "west", 1, 0},
+	{"southeast", "northwest", 1, 1},
+	{"south", "north", 0, 1},
+	{"southwest", "northeast", -1, 1},
+	{"west", "east", -1, 0},
+	{"northwest", "southeast", -1, -1},
+}
+
+// Generate returns a dungeon definition generated from the arguments
+func Generate(args Args) (*model.DungeonDefinition, error) {
+
+	args, err := resolveArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(args.Seed))
+
+	d := &model.DungeonDefinition{
+		Name:        args.Name,
+		Description: fmt.Sprintf("%s %s.", article(pick(rnd, dungeonAdjectives)), pick(rnd, dungeonNouns)),
+	}
+
+	names := map[string]int{}
+
+	locations := []*model.LocationDefinition{}
+
+	var upLocation *model.LocationDefinition
+	for level := 1; level <= args.Levels; level++ {
+		levelLocations := generateLevel(rnd, args, level, names)
+
+		// The first location of a level is where the level is entered
+		if level == 1 {
+			levelLocations[0].Default = true
+		} else {
+			upLocation.Exits.Down = levelLocations[0].Name
+			levelLocations[0].Exits.Up = upLocation.Name
+		}
+
+		// The way down is never the way in
+		if level < args.Levels {
+			upLocation = levelLocations[1+rnd.Intn(len(levelLocations)-1)]
+		}
+
+		locations = append(locations, levelLocations...)
+	}
+
+	for _, location := range locations {
+		d.Locations = append(d.Locations, *location)
+	}
+
+	monsters := map[string]struct{}{}
+	objects := map[string]struct{}{}
+
+	for idx := range d.Locations {
+		location := &d.Locations[idx]
+		if location.Default {
+			continue
+		}
+
+		if len(args.Pool.Monsters) > 0 && rnd.Intn(100) < args.MonsterChance {
+			monster := args.Pool.Monsters[pickRarity(rnd, len(args.Pool.Monsters), func(i int) string {
+				return args.Pool.Monsters[i].Rarity
+			})]
+			location.Monsters = append(location.Monsters, model.LocationSpawnDefinition{
+				Name:               monster.Name,
+				SpawnPercentChance: 100,
+			})
+			if _, ok := monsters[monster.Name]; !ok {
+				monsters[monster.Name] = struct{}{}
+				d.Monsters = append(d.Monsters, monster.MonsterDefinition)
+			}
+		}
+
+		if len(args.Pool.Objects) > 0 && rnd.Intn(100) < args.ObjectChance {
+			object := args.Pool.Objects[pickRarity(rnd, len(args.Pool.Objects), func(i int) string {
+				return args.Pool.Objects[i].Rarity
+			})]
+			location.Objects = append(location.Objects, model.LocationSpawnDefinition{
+				Name:               object.Name,
+				SpawnPercentChance: 100,
+			})
+			if _, ok := objects[object.Name]; !ok {
+				objects[object.Name] = struct{}{}
+				d.Objects = append(d.Objects, object.ObjectDefinition)
+			}
+		}
+	}
+
+	// Monster equipment must be defined in the dungeon as well
+	for _, monster := range d.Monsters {
+		for _, equipment := range monster.Equipment {
+			if _, ok := objects[equipment.Name]; ok {
+				continue
+			}
+			for _, object := range args.Pool.Objects {
+				if object.Name == equipment.Name {
+					objects[object.Name] = struct{}{}
+					d.Objects = append(d.Objects, object.ObjectDefinition)
+					break
+				}
+			}
+		}
+	}
+
+	d.Sort()
+
+	err = d.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// generateLevel grows a level outwards from its first location. Each new
+// location is joined to a location already on the level that has not yet
+// reached the branching factor.
+func generateLevel(rnd *rand.Rand, args Args, level int, names map[string]int) []*model.LocationDefinition {
+
+	locations := []*model.LocationDefinition{}
+	cells := []cell{}
+	children := []int{}
+	occupied := map[cell]int{}
+
+	add := func(c cell) *model.LocationDefinition {
+		location := &model.LocationDefinition{}
+		location.Name, location.Description = locationName(rnd, level, names)
+		occupied[c] = len(locations)
+		locations = append(locations, location)
+		cells = append(cells, c)
+		children = append(children, 0)
+		return location
+	}
+
+	add(cell{})
+
+	for len(locations) < args.Size {
+
+		// Locations that may grow a new exit
+		growable := []int{}
+		for idx := range locations {
+			if children[idx] < args.BranchingFactor && len(freeDirections(cells[idx], occupied)) > 0 {
+				growable = append(growable, idx)
+			}
+		}
+
+		// Crowded levels allow any location to grow
+		if len(growable) == 0 {
+			for idx := range locations {
+				if len(freeDirections(cells[idx], occupied)) > 0 {
+					growable = append(growable, idx)
+				}
+			}
+		}
+
+		// Favour growing from recent locations so levels wind outwards
+		// rather than clumping around the first location
+		from := growable[len(growable)-1-int(float64(len(growable))*rnd.Float64()*rnd.Float64())]
+
+		free := freeDirections(cells[from], occupied)
+		dir := compass[free[rnd.Intn(len(free))]]
+
+		next := cell{x: cells[from].x + dir.dx, y: cells[from].y + dir.dy}
+		location := add(next)
+
+		*locations[from].Exits.Direction(dir.direction) = location.Name
+		*location.Exits.Direction(dir.opposite) = locations[from].Name
+		children[from]++
+	}
+
+	return locations
+}
+
+// freeDirections returns the index of each compass direction that leads to an
+// unoccupied cell
+func freeDirections(c cell, occupied map[cell]int) []int {
+	free := []int{}
+	for idx, dir := range compass {
+		if _, ok := occupied[cell{x: c.x + dir.dx, y: c.y + dir.dy}]; !ok {
+			free = append(free, idx)
+		}
+	}
+	return free
+}
+
+// pickRarity returns the index of a randomly picked item weighted by rarity
+func pickRarity(rnd *rand.Rand, count int, rarity func(i int) string) int {
+	total := 0
+	for i := 0; i < count; i++ {
+		total += rarityWeight(rarity(i))
+	}
+
+	n := rnd.Intn(total)
+	for i := 0; i < count; i++ {
+		n -= rarityWeight(rarity(i))
+		if n < 0 {
+			return i
+		}
+	}
+
+	return count - 1
+}
+
+func rarityWeight(rarity string) int {
+	if rarity == "" {
+		return rarityWeights[RarityCommon]
+	}
+	return rarityWeights[rarity]
+}
+
+func resolveArgs(args Args) (Args, error) {
+
+	if args.Name == "" {
+		return args, fmt.Errorf("dungeon name is required")
+	}
+	if args.Size == 0 {
+		args.Size = DefaultSize
+	}
+	if args.BranchingFactor == 0 {
+		args.BranchingFactor = DefaultBranchingFactor
+	}
+	if args.Levels == 0 {
+		args.Levels = DefaultLevels
+	}
+	if len(args.Pool.Monsters) == 0 && len(args.Pool.Objects) == 0 {
+		args.Pool = DefaultPool()
+	}
+
+	if args.Size < 2 {
+		return args, fmt.Errorf("size >%d< must be at least 2 locations", args.Size)
+	}
+	if args.BranchingFactor < 1 || args.BranchingFactor > len(compass) {
+		return args, fmt.Errorf("branching factor >%d< must be between 1 and %d", args.BranchingFactor, len(compass))
+	}
+	if args.Levels < 1 {
+		return args, fmt.Errorf("levels >%d< must be at least 1", args.Levels)
+	}
+	if args.MonsterChance < 0 || args.MonsterChance > 100 {
+		return args, fmt.Errorf("monster chance >%d< must be between 0 and 100", args.MonsterChance)
+	}
+	if args.ObjectChance < 0 || args.ObjectChance > 100 {
+		return args, fmt.Errorf("object chance >%d< must be between 0 and 100", args.ObjectChance)
+	}
+
+	for _, monster := range args.Pool.Monsters {
+		if _, ok := rarityWeights[monster.Rarity]; !ok && monster.Rarity != "" {
+			return args, fmt.Errorf("monster >%s< rarity >%s< is not one of common, uncommon, rare or legendary", monster.Name, monster.Rarity)
+		}
+	}
+	for _, object := range args.Pool.Objects {
+		if _, ok := rarityWeights[object.Rarity]; !ok && object.Rarity != "" {
+			return args, fmt.Errorf("object >%s< rarity >%s< is not one of common, uncommon, rare or legendary", object.Name, object.Rarity)
+		}
+	}
+
+	return args, nil
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestGenerate(t *testing.T) {
+
+	tests := []struct {
+		name string
+		args Args
+	}{
+		{
+			name: "Defaults",
+			args: Args{
+				Name: "Generated Depths",
+				Seed: 1,
+			},
+		},
+		{
+			name: "Single passage",
+			args: Args{
+				Name:            "Generated Passage",
+				Seed:            2,
+				Size:            12,
+				BranchingFactor: 1,
+			},
+		},
+		{
+			name: "Several bushy levels",
+			args: Args{
+				Name:            "Generated Warren",
+				Seed:            3,
+				Size:            20,
+				BranchingFactor: 4,
+				Levels:          3,
+				MonsterChance:   80,
+				ObjectChance:    80,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			d, err := Generate(tt.args)
+			require.NoError(t, err, "Generate returns without error")
+
+			size := tt.args.Size
+			if size == 0 {
+				size = DefaultSize
+			}
+			levels := tt.args.Levels
+			if levels == 0 {
+				levels = DefaultLevels
+			}
+			branchingFactor := tt.args.BranchingFactor
+			if branchingFactor == 0 {
+				branchingFactor = DefaultBranchingFactor
+			}
+			require.Len(t, d.Locations, size*levels, "Generated dungeon has size locations on each level")
+
+			issues := d.Lint()
+			require.False(t, model.DungeonIssuesHaveErrors(issues), "Generated dungeon has no lint errors >%v<", issues)
+			for _, issue := range issues {
+				require.NotEqual(t, model.DungeonIssueCodeOneWayExit, issue.Code, "Generated dungeon has no one-way exits >%v<", issue)
+			}
+
+			downs := 0
+			for _, location := range d.Locations {
+				if location.Exits.Down != "" {
+					downs++
+				}
+
+				exits := 0
+				for _, direction := range record.LocationDirections {
+					if *location.Exits.Direction(direction) != "" {
+						exits++
+					}
+				}
+				// Exits leading on, the exit back, and the up and down exits
+				require.LessOrEqual(t, exits, branchingFactor+3, "Location >%s< exits are limited by the branching factor", location.Name)
+			}
+			require.Equal(t, levels-1, downs, "Each level is joined to the next by one down exit")
+
+			again, err := Generate(tt.args)
+			require.NoError(t, err, "Generate again returns without error")
+			require.Equal(t, d, again, "Same seed generates the same dungeon")
+
+			tt.args.Seed++
+			other, err := Generate(tt.args)
+			require.NoError(t, err, "Generate with another seed returns without error")
+			require.NotEqual(t, d, other, "Another seed generates a different dungeon")
+		})
+	}
+}
+
+func TestGenerateSpawnsFromPool(t *testing.T) {
+
+	pool := Pool{
+		Monsters: []PoolMonster{
+			{
+				MonsterDefinition: model.MonsterDefinition{
+					Name:         "Mud Golem",
+					Description:  "A lumbering heap of mud.",
+					Strength:     14,
+					Dexterity:    4,
+					Intelligence: 2,
+					Equipment: []model.MonsterEquipmentDefinition{
+						{
+							Name:     "Clay Fist",
+							Equipped: true,
+						},
+					},
+				},
+				Rarity: RarityRare,
+			},
+		},
+		Objects: []PoolObject{
+			{
+				ObjectDefinition: model.ObjectDefinition{
+					Name:        "Clay Fist",
+					Description: "A fist of hardened clay.",
+				},
+			},
+		},
+	}
+
+	d, err := Generate(Args{
+		Name:          "Generated Pit",
+		Seed:          7,
+		MonsterChance: 100,
+		Pool:          pool,
+	})
+	require.NoError(t, err, "Generate returns without error")
+
+	require.Len(t, d.Monsters, 1, "Generated dungeon defines the pool monster")
+	require.Equal(t, "Mud Golem", d.Monsters[0].Name, "Generated dungeon monster is from the pool")
+	require.Len(t, d.Objects, 1, "Generated dungeon defines the monster equipment")
+	require.Equal(t, "Clay Fist", d.Objects[0].Name, "Generated dungeon object is from the pool")
+
+	for _, location := range d.Locations {
+		require.Empty(t, location.Objects, "Location >%s< has no objects when the object chance is zero", location.Name)
+		if location.Default {
+			require.Empty(t, location.Monsters, "Default location has no monsters")
+			continue
+		}
+		require.Len(t, location.Monsters, 1, "Location >%s< has a monster when the monster chance is 100", location.Name)
+	}
+}
+
+func TestGenerateInvalidArgs(t *testing.T) {
+
+	tests := []struct {
+		name string
+		args Args
+	}{
+		{
+			name: "Without a name",
+			args: Args{},
+		},
+		{
+			name: "Single location",
+			args: Args{Name: "Generated", Size: 1},
+		},
+		{
+			name: "Branching factor above the compass directions",
+			args: Args{Name: "Generated", BranchingFactor: 9},
+		},
+		{
+			name: "Negative levels",
+			args: Args{Name: "Generated", Levels: -1},
+		},
+		{
+			name: "Monster chance above 100",
+			args: Args{Name: "Generated", MonsterChance: 101},
+		},
+		{
+			name: "Unknown rarity",
+			args: Args{
+				Name: "Generated",
+				Pool: Pool{
+					Objects: []PoolObject{
+						{
+							ObjectDefinition: model.ObjectDefinition{Name: "Pebble", Description: "A pebble."},
+							Rarity:           "mythic",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.args)
+			require.Error(t, err, "Generate returns an error")
+		})
+	}
+}
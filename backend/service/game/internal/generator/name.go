@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+var dungeonAdjectives = []string{
+	"forgotten", "flooded", "crumbling", "silent", "sunken", "frozen", "smoke filled", "overgrown",
+}
+
+var dungeonNouns = []string{
+	"labyrinth of tunnels", "warren of caves", "ruin of halls", "maze of passages", "delve of shafts",
+}
+
+var locationAdjectives = []string{
+	"Damp", "Dark", "Narrow", "Wide", "Low", "Echoing", "Dusty", "Cold", "Mossy", "Collapsed", "Dripping", "Quiet",
+}
+
+var locationNouns = []string{
+	"Tunnel", "Cavern", "Passage", "Chamber", "Hall", "Grotto", "Alcove", "Crawlway", "Gallery", "Vault",
+}
+
+var locationDetails = []string{
+	"water drips from the ceiling",
+	"the walls are scratched with old marks",
+	"bones are scattered across the floor",
+	"a cold draught blows through",
+	"roots push through cracks in the stone",
+	"the air smells of smoke",
+	"the floor is slick with mud",
+	"faint echoes come from somewhere ahead",
+}
+
+// locationName returns a unique location name and a description to go with it
+func locationName(rnd *rand.Rand, level int, names map[string]int) (string, string) {
+	adjective := pick(rnd, locationAdjectives)
+	noun := pick(rnd, locationNouns)
+
+	name := fmt.Sprintf("%s %s", adjective, noun)
+	names[name]++
+	if count := names[name]; count > 1 {
+		name = fmt.Sprintf("%s %d", name, count)
+	}
+
+	description := fmt.Sprintf("%s %s on level %d, %s.", article(strings.ToLower(adjective)), strings.ToLower(noun), level, pick(rnd, locationDetails))
+
+	return name, description
+}
+
+func pick(rnd *rand.Rand, words []string) string {
+	return words[rnd.Intn(len(words))]
+}
+
+// article returns the phrase with a capitalised indefinite article
+func article(phrase string) string {
+	if strings.ContainsAny(phrase[:1], "aeiouAEIOU") {
+		return "An " + phrase
+	}
+	return "A " + phrase
+}
@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// DefaultPool returns the monsters and objects generated dungeons pick from when
+// no pool is provided
+func DefaultPool() Pool {
+	return Pool{
+		Monsters: []PoolMonster{
+			{
+				MonsterDefinition: model.MonsterDefinition{
+					Name:         "Cave Bat",
+					Description:  "A small bat with leathery wings.",
+					Strength:     4,
+					Dexterity:    16,
+					Intelligence: 4,
+				},
+				Rarity: RarityCommon,
+			},
+			{
+				MonsterDefinition: model.MonsterDefinition{
+					Name:         "Giant Spider",
+					Description:  "A giant hairy spider with too many eyes.",
+					Strength:     10,
+					Dexterity:    14,
+					Intelligence: 6,
+				},
+				Rarity: RarityCommon,
+			},
+			{
+				MonsterDefinition: model.MonsterDefinition{
+					Name:         "Skeleton Warrior",
+					Description:  "A rattling skeleton clutching a notched blade.",
+					Strength:     12,
+					Dexterity:    10,
+					Intelligence: 8,
+					Equipment: []model.MonsterEquipmentDefinition{
+						{
+							Name:     "Notched Blade",
+							Equipped: true,
+						},
+					},
+				},
+				Rarity: RarityUncommon,
+			},
+			{
+				MonsterDefinition: model.MonsterDefinition{
+					Name:         "Cave Troll",
+					Description:  "A hulking troll with a stone club.",
+					Strength:     18,
+					Dexterity:    6,
+					Intelligence: 6,
+				},
+				Rarity: RarityRare,
+			},
+			{
+				MonsterDefinition: model.MonsterDefinition{
+					Name:         "Wraith",
+					Description:  "A cold shadow with burning eyes.",
+					Strength:     14,
+					Dexterity:    14,
+					Intelligence: 16,
+				},
+				Rarity: RarityLegendary,
+			},
+		},
+		Objects: []PoolObject{
+			{
+				ObjectDefinition: model.ObjectDefinition{
+					Name:                "Torch",
+					Description:         "A torch.",
+					DescriptionDetailed: "A wooden torch wrapped in oily rags.",
+				},
+				Rarity: RarityCommon,
+			},
+			{
+				ObjectDefinition: model.ObjectDefinition{
+					Name:                "Coil Of Rope",
+					Description:         "A coil of rope.",
+					DescriptionDetailed: "A coil of frayed hemp rope.",
+				},
+				Rarity: RarityCommon,
+			},
+			{
+				ObjectDefinition: model.ObjectDefinition{
+					Name:                "Notched Blade",
+					Description:         "A notched blade.",
+					DescriptionDetailed: "A short iron blade with a badly notched edge.",
+				},
+				Rarity: RarityUncommon,
+			},
+			{
+				ObjectDefinition: model.ObjectDefinition{
+					Name:                "Healing Draught",
+					Description:         "A vial of red liquid.",
+					DescriptionDetailed: "A small glass vial of bitter smelling red liquid.",
+				},
+				Rarity: RarityRare,
+			},
+			{
+				ObjectDefinition: model.ObjectDefinition{
+					Name:                "Obsidian Crown",
+					Description:         "A black crown.",
+					DescriptionDetailed: "A crown carved from a single piece of obsidian, cold to the touch.",
+				},
+				Rarity: RarityLegendary,
+			},
+		},
+	}
+}
@@ -6,12 +6,28 @@ import (
 
 // DataConfig -
 type DataConfig struct {
+	AccountConfig   []AccountConfig
+	APIKeyConfig    []APIKeyConfig
 	ObjectConfig    []ObjectConfig
 	MonsterConfig   []MonsterConfig
 	CharacterConfig []CharacterConfig
 	DungeonConfig   []DungeonConfig
 }
 
+// AccountConfig -
+type AccountConfig struct {
+	Record record.Account
+	// Password is hashed to set the password hash of the resulting record
+	Password string
+}
+
+// APIKeyConfig -
+type APIKeyConfig struct {
+	Record record.APIKey
+	// AccountName is used to resolve the account identifier of the resulting record
+	AccountName string
+}
+
 // DungeonConfig -
 type DungeonConfig struct {
 	Record                record.Dungeon
@@ -39,7 +55,9 @@ type MonsterObjectConfig struct {
 
 // CharacterConfig -
 type CharacterConfig struct {
-	Record                record.Character
+	Record record.Character
+	// AccountName is used to resolve the owning account identifier of the resulting record
+	AccountName           string
 	CharacterObjectConfig []CharacterObjectConfig
 }
 
@@ -86,6 +104,9 @@ type LocationObjectConfig struct {
 
 // DungeonInstanceConfig -
 type DungeonInstanceConfig struct {
+	// Seed pins the dungeon instance seed so monster actions and spawns are
+	// reproducible, a random seed is used when zero
+	Seed                    int64
 	CharacterInstanceConfig []CharacterInstanceConfig
 	TurnConfig              []TurnConfig
 }
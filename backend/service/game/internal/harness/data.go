@@ -11,6 +11,14 @@ import (
 
 // Data -
 type Data struct {
+	// Account
+	AccountRecs []*record.Account
+
+	// API Key
+	APIKeyRecs []*record.APIKey
+	// APIKeys are the issued keys by API key record ID
+	APIKeys map[string]string
+
 	// Object
 	ObjectRecs []*record.Object
 
@@ -29,11 +37,12 @@ type Data struct {
 	LocationMonsterRecs []*record.LocationMonster
 
 	// Instance
-	DungeonInstanceRecs   []*record.DungeonInstance
-	LocationInstanceRecs  []*record.LocationInstance
-	CharacterInstanceRecs []*record.CharacterInstance
-	MonsterInstanceRecs   []*record.MonsterInstance
-	ObjectInstanceRecs    []*record.ObjectInstance
+	DungeonInstanceRecs      []*record.DungeonInstance
+	DungeonInstanceLeaseRecs []*record.DungeonInstanceLease
+	LocationInstanceRecs     []*record.LocationInstance
+	CharacterInstanceRecs    []*record.CharacterInstance
+	MonsterInstanceRecs      []*record.MonsterInstance
+	ObjectInstanceRecs       []*record.ObjectInstance
 
 	// Action
 	ActionRecs                []*record.Action
@@ -47,6 +56,69 @@ type Data struct {
 	TurnRecs []*record.Turn
 }
 
+// Account
+func (d *Data) AddAccountRec(rec *record.Account) {
+	for idx := range d.AccountRecs {
+		if d.AccountRecs[idx].ID == rec.ID {
+			d.AccountRecs[idx] = rec
+			return
+		}
+	}
+	d.AccountRecs = append(d.AccountRecs, rec)
+}
+
+func (d *Data) GetAccountRecByID(accountID string) (*record.Account, error) {
+	for _, rec := range d.AccountRecs {
+		if rec.ID == accountID {
+			return rec, nil
+		}
+	}
+	return nil, fmt.Errorf("failed getting account with ID >%s<", accountID)
+}
+
+func (d *Data) GetAccountRecByName(accountName string) (*record.Account, error) {
+	for idx := range d.AccountRecs {
+		if strings.EqualFold(NormalName(d.AccountRecs[idx].Name), accountName) {
+			return d.AccountRecs[idx], nil
+		}
+	}
+	return nil, fmt.Errorf("failed getting account with Name >%s<", accountName)
+}
+
+// GetAccountRecByRole returns the first account with the role
+func (d *Data) GetAccountRecByRole(role string) (*record.Account, error) {
+	for idx := range d.AccountRecs {
+		if d.AccountRecs[idx].Role == role {
+			return d.AccountRecs[idx], nil
+		}
+	}
+	return nil, fmt.Errorf("failed getting account with Role >%s<", role)
+}
+
+// API Key
+func (d *Data) AddAPIKeyRec(rec *record.APIKey, key string) {
+	if d.APIKeys == nil {
+		d.APIKeys = map[string]string{}
+	}
+	d.APIKeys[rec.ID] = key
+	for idx := range d.APIKeyRecs {
+		if d.APIKeyRecs[idx].ID == rec.ID {
+			d.APIKeyRecs[idx] = rec
+			return
+		}
+	}
+	d.APIKeyRecs = append(d.APIKeyRecs, rec)
+}
+
+func (d *Data) GetAPIKeyRecByOwner(owner string) (*record.APIKey, error) {
+	for idx := range d.APIKeyRecs {
+		if strings.EqualFold(NormalName(d.APIKeyRecs[idx].Owner), owner) {
+			return d.APIKeyRecs[idx], nil
+		}
+	}
+	return nil, fmt.Errorf("failed getting API key with Owner >%s<", owner)
+}
+
 // Object
 func (d *Data) AddObjectRec(rec *record.Object) {
 	for idx := range d.ObjectRecs {
@@ -442,6 +514,17 @@ func (d *Data) AddDungeonInstanceRec(rec *record.DungeonInstance) {
 	d.DungeonInstanceRecs = append(d.DungeonInstanceRecs, rec)
 }
 
+// DungeonInstanceLease
+func (d *Data) AddDungeonInstanceLeaseRec(rec *record.DungeonInstanceLease) {
+	for idx := range d.DungeonInstanceLeaseRecs {
+		if d.DungeonInstanceLeaseRecs[idx].ID == rec.ID {
+			d.DungeonInstanceLeaseRecs[idx] = rec
+			return
+		}
+	}
+	d.DungeonInstanceLeaseRecs = append(d.DungeonInstanceLeaseRecs, rec)
+}
+
 func (d *Data) GetDungeonInstanceRecByName(name string) (*record.DungeonInstance, error) {
 	for _, rec := range d.DungeonInstanceRecs {
 		dungeonRec, err := d.GetDungeonRecByID(rec.DungeonID)
@@ -460,6 +543,10 @@ func (d *Data) AddDungeonInstanceRecordSet(rs *model.DungeonInstanceRecordSet) {
 
 	d.AddDungeonInstanceRec(rs.DungeonInstanceRec)
 
+	if rs.DungeonInstanceLeaseRec != nil {
+		d.AddDungeonInstanceLeaseRec(rs.DungeonInstanceLeaseRec)
+	}
+
 	for idx := range rs.LocationInstanceRecs {
 		d.AddLocationInstanceRec(rs.LocationInstanceRecs[idx])
 	}
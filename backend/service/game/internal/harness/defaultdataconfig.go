@@ -1,9 +1,27 @@
 package harness
 
 import (
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
+const (
+	AccountNameDefault     string = "Default Account"
+	AccountNameOther       string = "Other Account"
+	AccountNameGameMaster  string = "Game Master Account"
+	AccountNameAdmin       string = "Admin Account"
+	AccountPasswordDefault string = "default-password"
+)
+
+const (
+	APIKeyOwnerPlayBot   string = "Play Bot"
+	APIKeyOwnerAnalytics string = "Analytics"
+)
+
+// DungeonInstanceSeedDefault pins the seed of default dungeon instances so
+// tests see the same monster actions on every run
+const DungeonInstanceSeedDefault int64 = 1
+
 const (
 	CharacterNameBarricade string = "Barricade"
 	CharacterNameLegislate string = "Legislate"
@@ -42,6 +60,51 @@ const (
 )
 
 var DefaultDataConfig = DataConfig{
+	AccountConfig: []AccountConfig{
+		{
+			Record: record.Account{
+				Name:  AccountNameDefault,
+				Email: "default@example.com",
+				Role:  record.AccountRolePlayer,
+			},
+		},
+		{
+			Record: record.Account{
+				Name:  AccountNameOther,
+				Email: "other@example.com",
+				Role:  record.AccountRolePlayer,
+			},
+		},
+		{
+			Record: record.Account{
+				Name:  AccountNameGameMaster,
+				Email: "gamemaster@example.com",
+				Role:  record.AccountRoleGameMaster,
+			},
+		},
+		{
+			Record: record.Account{
+				Name:  AccountNameAdmin,
+				Email: "admin@example.com",
+				Role:  record.AccountRoleAdmin,
+			},
+		},
+	},
+	APIKeyConfig: []APIKeyConfig{
+		{
+			Record: record.APIKey{
+				Owner:  APIKeyOwnerPlayBot,
+				Scopes: []string{model.PermissionPlay},
+			},
+			AccountName: AccountNameDefault,
+		},
+		{
+			Record: record.APIKey{
+				Owner:  APIKeyOwnerAnalytics,
+				Scopes: []string{model.PermissionDungeonInstanceManage},
+			},
+		},
+	},
 	ObjectConfig: []ObjectConfig{
 		{
 			Record: record.Object{
@@ -148,6 +211,7 @@ var DefaultDataConfig = DataConfig{
 			Record: record.Character{
 				Name: CharacterNameBarricade,
 			},
+			AccountName: AccountNameDefault,
 			CharacterObjectConfig: []CharacterObjectConfig{
 				{
 					Record: record.CharacterObject{
@@ -167,6 +231,7 @@ var DefaultDataConfig = DataConfig{
 			Record: record.Character{
 				Name: CharacterNameLegislate,
 			},
+			AccountName: AccountNameDefault,
 			CharacterObjectConfig: []CharacterObjectConfig{
 				{
 					Record: record.CharacterObject{
@@ -180,6 +245,7 @@ var DefaultDataConfig = DataConfig{
 			Record: record.Character{
 				Name: CharacterNameBolster,
 			},
+			AccountName: AccountNameDefault,
 			CharacterObjectConfig: []CharacterObjectConfig{
 				{
 					Record: record.CharacterObject{
@@ -285,6 +351,7 @@ var DefaultDataConfig = DataConfig{
 			},
 			DungeonInstanceConfig: []DungeonInstanceConfig{
 				{
+					Seed: DungeonInstanceSeedDefault,
 					CharacterInstanceConfig: []CharacterInstanceConfig{
 						{
 							Name: CharacterNameBarricade,
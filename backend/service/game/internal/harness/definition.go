@@ -0,0 +1,137 @@
+package harness
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// DungeonDefinition returns the definition of a configured dungeon including the
+// configured monsters and objects it refers to. Default values are applied the
+// same way records are created so the definition describes the data the
+// configuration produces.
+func (dc DataConfig) DungeonDefinition(dungeonName string) (*model.DungeonDefinition, error) {
+
+	var dungeonConfig *DungeonConfig
+	for idx := range dc.DungeonConfig {
+		if strings.EqualFold(dc.DungeonConfig[idx].Record.Name, dungeonName) {
+			dungeonConfig = &dc.DungeonConfig[idx]
+			break
+		}
+	}
+	if dungeonConfig == nil {
+		return nil, fmt.Errorf("dungeon >%s< is not configured", dungeonName)
+	}
+
+	d := &model.DungeonDefinition{
+		ID:          dungeonConfig.Record.ID,
+		Name:        dungeonConfig.Record.Name,
+		Description: dungeonConfig.Record.Description,
+	}
+
+	monsterNames := map[string]struct{}{}
+	objectNames := map[string]struct{}{}
+
+	for _, locationConfig := range dungeonConfig.LocationConfig {
+		ld := model.LocationDefinition{
+			ID:          locationConfig.Record.ID,
+			Name:        locationConfig.Record.Name,
+			Description: locationConfig.Record.Description,
+			Default:     locationConfig.Record.IsDefault,
+			Exits: model.LocationExitsDefinition{
+				North:     locationConfig.NorthLocationName,
+				Northeast: locationConfig.NortheastLocationName,
+				East:      locationConfig.EastLocationName,
+				Southeast: locationConfig.SoutheastLocationName,
+				South:     locationConfig.SouthLocationName,
+				Southwest: locationConfig.SouthwestLocationName,
+				West:      locationConfig.WestLocationName,
+				Northwest: locationConfig.NorthwestLocationName,
+				Up:        locationConfig.UpLocationName,
+				Down:      locationConfig.DownLocationName,
+			},
+		}
+
+		for _, locationMonsterConfig := range locationConfig.LocationMonsterConfig {
+			ld.Monsters = append(ld.Monsters, locationSpawnDefinition(locationMonsterConfig.MonsterName, locationMonsterConfig.Record.SpawnMinutes, locationMonsterConfig.Record.SpawnPercentChance))
+			monsterNames[locationMonsterConfig.MonsterName] = struct{}{}
+		}
+
+		for _, locationObjectConfig := range locationConfig.LocationObjectConfig {
+			ld.Objects = append(ld.Objects, locationSpawnDefinition(locationObjectConfig.ObjectName, locationObjectConfig.Record.SpawnMinutes, locationObjectConfig.Record.SpawnPercentChance))
+			objectNames[locationObjectConfig.ObjectName] = struct{}{}
+		}
+
+		d.Locations = append(d.Locations, ld)
+	}
+
+	for _, monsterConfig := range dc.MonsterConfig {
+		if _, ok := monsterNames[monsterConfig.Record.Name]; !ok {
+			continue
+		}
+
+		rec := monsterConfig.Record
+		md := model.MonsterDefinition{
+			ID:           rec.ID,
+			Name:         rec.Name,
+			Description:  rec.Description,
+			Strength:     defaultAttribute(rec.Strength),
+			Dexterity:    defaultAttribute(rec.Dexterity),
+			Intelligence: defaultAttribute(rec.Intelligence),
+		}
+
+		for _, monsterObjectConfig := range monsterConfig.MonsterObjectConfig {
+			md.Equipment = append(md.Equipment, model.MonsterEquipmentDefinition{
+				Name:     monsterObjectConfig.ObjectName,
+				Equipped: monsterObjectConfig.Record.IsEquipped,
+				Stashed:  monsterObjectConfig.Record.IsStashed,
+			})
+			objectNames[monsterObjectConfig.ObjectName] = struct{}{}
+		}
+
+		d.Monsters = append(d.Monsters, md)
+	}
+
+	for _, objectConfig := range dc.ObjectConfig {
+		if _, ok := objectNames[objectConfig.Record.Name]; !ok {
+			continue
+		}
+		rec := objectConfig.Record
+		d.Objects = append(d.Objects, model.ObjectDefinition{
+			ID:                  rec.ID,
+			Name:                rec.Name,
+			Description:         rec.Description,
+			DescriptionDetailed: rec.DescriptionDetailed,
+		})
+	}
+
+	d.Sort()
+
+	err := d.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func locationSpawnDefinition(name string, spawnMinutes, spawnPercentChance int) model.LocationSpawnDefinition {
+	if spawnPercentChance == 0 {
+		spawnPercentChance = 100
+	}
+	return model.LocationSpawnDefinition{
+		Name:               name,
+		SpawnMinutes:       spawnMinutes,
+		SpawnPercentChance: spawnPercentChance,
+	}
+}
+
+// defaultAttribute is the monster attribute value used when creating monster
+// records without the attribute
+func defaultAttribute(value int) int {
+	if value == 0 {
+		return 10
+	}
+	return value
+}
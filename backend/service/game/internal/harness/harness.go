@@ -80,6 +80,30 @@ func (t *Testing) CreateData() error {
 
 	l.Info("Creating test data")
 
+	// Accounts
+	for _, accountConfig := range t.DataConfig.AccountConfig {
+		accountRec, err := t.createAccountRec(accountConfig)
+		if err != nil {
+			l.Warn("failed creating account record >%v<", err)
+			return err
+		}
+		l.Debug("+ Created account record ID >%s< Name >%s<", accountRec.ID, accountRec.Name)
+		data.AddAccountRec(accountRec)
+		teardownData.AddAccountRec(accountRec)
+	}
+
+	// API Keys
+	for _, apiKeyConfig := range t.DataConfig.APIKeyConfig {
+		apiKeyRec, key, err := t.createAPIKeyRec(data, apiKeyConfig)
+		if err != nil {
+			l.Warn("failed creating API key record >%v<", err)
+			return err
+		}
+		l.Debug("+ Created API key record ID >%s< Owner >%s<", apiKeyRec.ID, apiKeyRec.Owner)
+		data.AddAPIKeyRec(apiKeyRec, key)
+		teardownData.AddAPIKeyRec(apiKeyRec)
+	}
+
 	// Objects
 	for _, objectConfig := range t.DataConfig.ObjectConfig {
 		objectRec, err := t.createObjectRec(objectConfig)
@@ -118,7 +142,7 @@ func (t *Testing) CreateData() error {
 	// Characters
 	for _, characterConfig := range t.DataConfig.CharacterConfig {
 
-		characterRec, err := t.createCharacterRec(characterConfig)
+		characterRec, err := t.createCharacterRec(data, characterConfig)
 		if err != nil {
 			l.Warn("failed creating character record >%v<", err)
 			return err
@@ -212,7 +236,7 @@ func (t *Testing) CreateData() error {
 
 		// Dungeon Instances
 		for _, dungeonInstanceConfig := range dungeonConfig.DungeonInstanceConfig {
-			dungeonInstanceRecordSet, err := t.createDungeonInstance(dungeonRec.ID)
+			dungeonInstanceRecordSet, err := t.createDungeonInstance(dungeonRec.ID, dungeonInstanceConfig.Seed)
 			if err != nil {
 				l.Warn("failed creating dungeon instance >%v<", err)
 				return err
@@ -745,6 +769,27 @@ LOCATION_INSTANCE_RECS:
 		seen[rec.ID] = true
 	}
 
+	l.Debug("Removing >%d< dungeon instance lease records", len(t.teardownData.DungeonInstanceLeaseRecs))
+
+DUNGEON_INSTANCE_LEASE_RECS:
+	for {
+		if len(t.teardownData.DungeonInstanceLeaseRecs) == 0 {
+			break DUNGEON_INSTANCE_LEASE_RECS
+		}
+		var rec *record.DungeonInstanceLease
+		rec, t.teardownData.DungeonInstanceLeaseRecs = t.teardownData.DungeonInstanceLeaseRecs[0], t.teardownData.DungeonInstanceLeaseRecs[1:]
+		if seen[rec.ID] {
+			continue
+		}
+
+		err := t.Model.(*model.Model).RemoveDungeonInstanceLeaseRec(rec.ID)
+		if err != nil {
+			l.Warn("failed removing dungeon instance lease record >%v<", err)
+			return err
+		}
+		seen[rec.ID] = true
+	}
+
 	l.Debug("Removing >%d< dungeon instance records", len(t.teardownData.DungeonInstanceRecs))
 
 DUNGEON_INSTANCE_RECS:
@@ -871,6 +916,48 @@ CHARACTER_RECS:
 		seen[rec.ID] = true
 	}
 
+	l.Debug("Removing >%d< API key records", len(t.teardownData.APIKeyRecs))
+
+API_KEY_RECS:
+	for {
+		if len(t.teardownData.APIKeyRecs) == 0 {
+			break API_KEY_RECS
+		}
+		var rec *record.APIKey
+		rec, t.teardownData.APIKeyRecs = t.teardownData.APIKeyRecs[0], t.teardownData.APIKeyRecs[1:]
+		if seen[rec.ID] {
+			continue
+		}
+
+		err := t.Model.(*model.Model).RemoveAPIKeyRec(rec.ID)
+		if err != nil {
+			l.Warn("failed removing API key record >%v<", err)
+			return err
+		}
+		seen[rec.ID] = true
+	}
+
+	l.Debug("Removing >%d< account records", len(t.teardownData.AccountRecs))
+
+ACCOUNT_RECS:
+	for {
+		if len(t.teardownData.AccountRecs) == 0 {
+			break ACCOUNT_RECS
+		}
+		var rec *record.Account
+		rec, t.teardownData.AccountRecs = t.teardownData.AccountRecs[0], t.teardownData.AccountRecs[1:]
+		if seen[rec.ID] {
+			continue
+		}
+
+		err := t.Model.(*model.Model).RemoveAccountRec(rec.ID)
+		if err != nil {
+			l.Warn("failed removing account record >%v<", err)
+			return err
+		}
+		seen[rec.ID] = true
+	}
+
 	l.Debug("Removing >%d< location monster records", len(t.teardownData.LocationMonsterRecs))
 
 LOCATION_MONSTER_RECS:
@@ -2,10 +2,12 @@ package harness
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/brianvoe/gofakeit"
 
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
 	"gitlab.com/alienspaces/go-mud/backend/core/repository"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
@@ -51,15 +53,9 @@ func (t *Testing) createMonsterRec(monsterConfig MonsterConfig) (*record.Monster
 	rec.Name = UniqueName(rec.Name)
 
 	// Default values
-	if rec.Strength == 0 {
-		rec.Strength = 10
-	}
-	if rec.Dexterity == 0 {
-		rec.Dexterity = 10
-	}
-	if rec.Intelligence == 0 {
-		rec.Intelligence = 10
-	}
+	rec.Strength = defaultAttribute(rec.Strength)
+	rec.Dexterity = defaultAttribute(rec.Dexterity)
+	rec.Intelligence = defaultAttribute(rec.Intelligence)
 
 	l.Debug("Creating monster record >%#v<", rec)
 
@@ -96,24 +92,93 @@ func (t *Testing) createMonsterObjectRec(data *Data, monsterRec *record.Monster,
 	return &rec, nil
 }
 
-func (t *Testing) createCharacterRec(characterConfig CharacterConfig) (*record.Character, error) {
-	l := t.Logger("createCharacterRec")
+func (t *Testing) createAccountRec(accountConfig AccountConfig) (*record.Account, error) {
+	l := t.Logger("createAccountRec")
 
-	rec := characterConfig.Record
+	rec := accountConfig.Record
 
 	rec.Name = UniqueName(rec.Name)
 
-	// Default values
-	if rec.Strength == 0 {
-		rec.Strength = 10
+	// Email addresses must be unique
+	if rec.Email == "" {
+		rec.Email = gofakeit.Email()
+	}
+	rec.Email = strings.ToLower(fmt.Sprintf("%s-%s", repository.NewRecordID(), rec.Email))
+
+	password := accountConfig.Password
+	if password == "" {
+		password = AccountPasswordDefault
+	}
+
+	passwordHash, err := model.HashAccountPassword(password)
+	if err != nil {
+		l.Warn("failed hashing account password >%v<", err)
+		return nil, err
+	}
+	rec.PasswordHash = passwordHash
+
+	l.Debug("Creating account record >%#v<", rec)
+
+	err = t.Model.(*model.Model).CreateAccountRec(&rec)
+	if err != nil {
+		l.Warn("failed creating account record >%v<", err)
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (t *Testing) createAPIKeyRec(data *Data, apiKeyConfig APIKeyConfig) (*record.APIKey, string, error) {
+	l := t.Logger("createAPIKeyRec")
+
+	args := &model.IssueAPIKeyArgs{
+		Owner:  UniqueName(apiKeyConfig.Record.Owner),
+		Scopes: apiKeyConfig.Record.Scopes,
 	}
-	if rec.Dexterity == 0 {
-		rec.Dexterity = 10
+
+	if apiKeyConfig.Record.ExpiresAt.Valid {
+		args.ExpiresAt = apiKeyConfig.Record.ExpiresAt.Time
 	}
-	if rec.Intelligence == 0 {
-		rec.Intelligence = 10
+
+	if apiKeyConfig.AccountName != "" {
+		accountRec, err := data.GetAccountRecByName(apiKeyConfig.AccountName)
+		if err != nil {
+			l.Warn("failed getting account record >%v<", err)
+			return nil, "", err
+		}
+		args.AccountID = accountRec.ID
 	}
 
+	l.Debug("Creating API key record >%#v<", args)
+
+	key, rec, err := t.Model.(*model.Model).IssueAPIKey(args)
+	if err != nil {
+		l.Warn("failed creating API key record >%v<", err)
+		return nil, "", err
+	}
+	return rec, key, nil
+}
+
+func (t *Testing) createCharacterRec(data *Data, characterConfig CharacterConfig) (*record.Character, error) {
+	l := t.Logger("createCharacterRec")
+
+	rec := characterConfig.Record
+
+	rec.Name = UniqueName(rec.Name)
+
+	if characterConfig.AccountName != "" {
+		accountRec, err := data.GetAccountRecByName(characterConfig.AccountName)
+		if err != nil {
+			l.Warn("failed getting account record >%v<", err)
+			return nil, err
+		}
+		rec.AccountID = null.NullStringFromString(accountRec.ID)
+	}
+
+	// Default values
+	rec.Strength = defaultAttribute(rec.Strength)
+	rec.Dexterity = defaultAttribute(rec.Dexterity)
+	rec.Intelligence = defaultAttribute(rec.Intelligence)
+
 	l.Debug("Creating dungeon character record >%#v<", rec)
 
 	err := t.Model.(*model.Model).CreateCharacterRec(&rec)
@@ -272,12 +337,20 @@ func (t *Testing) characterEnterDungeon(dungeonID, characterID string) (*model.D
 	return dungeonInstanceRecordSet, characterInstanceRecordSet, nil
 }
 
-func (t *Testing) createDungeonInstance(dungeonID string) (*model.DungeonInstanceRecordSet, error) {
+func (t *Testing) createDungeonInstance(dungeonID string, seed int64) (*model.DungeonInstanceRecordSet, error) {
 	l := t.Logger("createDungeonInstance")
 
-	l.Debug("Creating dungeon ID >%s< instance", dungeonID)
+	l.Debug("Creating dungeon ID >%s< instance seed >%d<", dungeonID, seed)
+
+	m := t.Model.(*model.Model)
+	if seed != 0 {
+		seeder := m.Seeder()
+		defer m.SetSeeder(seeder)
+
+		m.SetSeeder(func() int64 { return seed })
+	}
 
-	dungeonInstanceRecordSet, err := t.Model.(*model.Model).CreateDungeonInstance(dungeonID)
+	dungeonInstanceRecordSet, err := m.CreateDungeonInstance(dungeonID)
 	if err != nil {
 		l.Warn("failed creating dungeon instance >%v<", err)
 		return nil, err
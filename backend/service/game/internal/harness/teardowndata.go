@@ -8,6 +8,12 @@ import (
 
 // teardownData -
 type teardownData struct {
+	// Account
+	AccountRecs []*record.Account
+
+	// API Key
+	APIKeyRecs []*record.APIKey
+
 	// Object
 	ObjectRecs []*record.Object
 
@@ -26,11 +32,12 @@ type teardownData struct {
 	LocationMonsterRecs []*record.LocationMonster
 
 	// Dungeon Instance
-	DungeonInstanceRecs   []*record.DungeonInstance
-	LocationInstanceRecs  []*record.LocationInstance
-	CharacterInstanceRecs []*record.CharacterInstance
-	MonsterInstanceRecs   []*record.MonsterInstance
-	ObjectInstanceRecs    []*record.ObjectInstance
+	DungeonInstanceRecs      []*record.DungeonInstance
+	DungeonInstanceLeaseRecs []*record.DungeonInstanceLease
+	LocationInstanceRecs     []*record.LocationInstance
+	CharacterInstanceRecs    []*record.CharacterInstance
+	MonsterInstanceRecs      []*record.MonsterInstance
+	ObjectInstanceRecs       []*record.ObjectInstance
 
 	// Action
 	ActionRecs                []*record.Action
@@ -46,6 +53,9 @@ type teardownData struct {
 
 func (d *teardownData) AddDungeonInstanceRecordSet(rs *model.DungeonInstanceRecordSet) {
 	d.AddDungeonInstanceRec(rs.DungeonInstanceRec)
+	if rs.DungeonInstanceLeaseRec != nil {
+		d.AddDungeonInstanceLeaseRec(rs.DungeonInstanceLeaseRec)
+	}
 	for idx := range rs.LocationInstanceRecs {
 		d.AddLocationInstanceRec(rs.LocationInstanceRecs[idx])
 	}
@@ -94,6 +104,24 @@ func (d *teardownData) AddMonsterObjectRec(rec *record.MonsterObject) {
 	d.MonsterObjectRecs = append(d.MonsterObjectRecs, &record.MonsterObject{Record: repository.Record{ID: rec.ID}})
 }
 
+func (d *teardownData) AddAccountRec(rec *record.Account) {
+	for _, r := range d.AccountRecs {
+		if r.ID == rec.ID {
+			return
+		}
+	}
+	d.AccountRecs = append(d.AccountRecs, &record.Account{Record: repository.Record{ID: rec.ID}})
+}
+
+func (d *teardownData) AddAPIKeyRec(rec *record.APIKey) {
+	for _, r := range d.APIKeyRecs {
+		if r.ID == rec.ID {
+			return
+		}
+	}
+	d.APIKeyRecs = append(d.APIKeyRecs, &record.APIKey{Record: repository.Record{ID: rec.ID}})
+}
+
 func (d *teardownData) AddCharacterRec(rec *record.Character) {
 	for _, r := range d.CharacterRecs {
 		if r.ID == rec.ID {
@@ -157,6 +185,15 @@ func (d *teardownData) AddDungeonInstanceRec(rec *record.DungeonInstance) {
 	d.DungeonInstanceRecs = append(d.DungeonInstanceRecs, &record.DungeonInstance{Record: repository.Record{ID: rec.ID}})
 }
 
+func (d *teardownData) AddDungeonInstanceLeaseRec(rec *record.DungeonInstanceLease) {
+	for _, r := range d.DungeonInstanceLeaseRecs {
+		if r.ID == rec.ID {
+			return
+		}
+	}
+	d.DungeonInstanceLeaseRecs = append(d.DungeonInstanceLeaseRecs, &record.DungeonInstanceLease{Record: repository.Record{ID: rec.ID}})
+}
+
 func (d *teardownData) AddLocationInstanceRec(rec *record.LocationInstance) {
 	for _, r := range d.LocationInstanceRecs {
 		if r.ID == rec.ID {
@@ -0,0 +1,287 @@
+package load
+
+// Load spawns bot players against a running game server through the API. Each
+// bot creates a character, enters a dungeon and plays with a strategy until
+// the run ends, and the latency of actions, the rate actions are rejected for
+// being submitted before the next turn and errors by type are reported.
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/client"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// botAttribute is the strength, dexterity and intelligence of bot characters
+const botAttribute int = 12
+
+// Config is how a load run is made
+type Config struct {
+	// Host is the game server, for example http://localhost:8084
+	Host string
+	// Bots is the number of bot players
+	Bots int
+	// Dungeon is the name of the dungeon bots enter, the first dungeon when
+	// empty
+	Dungeon string
+	// Strategy is the name of the strategy bots play with
+	Strategy string
+	// Duration is how long bots play for
+	Duration time.Duration
+	// Actions is the most actions each bot submits, unlimited when zero
+	Actions int
+	// Think is the average pause between a bot's actions
+	Think time.Duration
+	// RampUp is the time bot starts are spread over
+	RampUp time.Duration
+	// Email and Password are of the account bot characters are created for, an
+	// account is registered when the email is empty
+	Email    string
+	Password string
+	// Seed seeds the random choices of bots
+	Seed int64
+}
+
+// Validate -
+func (c *Config) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	if c.Bots < 1 {
+		return fmt.Errorf("bots >%d< must be at least 1", c.Bots)
+	}
+	if _, ok := Strategies[c.Strategy]; !ok {
+		return fmt.Errorf("strategy >%s< is not one of >%s<", c.Strategy, strings.Join(StrategyNames(), ", "))
+	}
+	if c.Duration <= 0 && c.Actions <= 0 {
+		return fmt.Errorf("duration or actions is required")
+	}
+	return nil
+}
+
+// Run plays the bots until the duration passes, every bot has submitted its
+// actions or the context is done, then exits the bots from the dungeon and
+// returns the report
+func Run(ctx context.Context, l logger.Logger, cfg Config) (*Report, error) {
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Run identifies the account and characters of the run
+	run := fmt.Sprintf("%06x", rand.New(rand.NewSource(time.Now().UnixNano())).Intn(0xffffff))
+
+	token, err := login(l, cfg, run)
+	if err != nil {
+		return nil, err
+	}
+
+	dungeonID, err := dungeon(l, cfg, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	rec := newRecorder()
+	start := time.Now()
+
+	wg := sync.WaitGroup{}
+	for idx := 0; idx < cfg.Bots; idx++ {
+
+		c, err := client.NewClient(l, cfg.Host)
+		if err != nil {
+			return nil, err
+		}
+		c.SetToken(token)
+
+		b := &bot{
+			client:    c,
+			strategy:  Strategies[cfg.Strategy],
+			recorder:  rec,
+			config:    cfg,
+			dungeonID: dungeonID,
+			name:      fmt.Sprintf("Bot %s %d", run, idx+1),
+			rand:      rand.New(rand.NewSource(cfg.Seed + int64(idx))),
+			log:       l,
+		}
+		if cfg.Bots > 1 {
+			b.delay = cfg.RampUp * time.Duration(idx) / time.Duration(cfg.Bots)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.play(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	return rec.report(cfg.Bots, cfg.Strategy, time.Since(start)), nil
+}
+
+// login returns the token of the account bot characters are created for,
+// registering an account for the run when no account is configured
+func login(l logger.Logger, cfg Config, run string) (string, error) {
+
+	c, err := client.NewClient(l, cfg.Host)
+	if err != nil {
+		return "", err
+	}
+
+	email, password := cfg.Email, cfg.Password
+	if email == "" {
+		email = fmt.Sprintf("load-%s@example.com", run)
+		password = "load-" + run
+		if _, err := c.Register("Load "+run, email, password); err != nil {
+			return "", fmt.Errorf("failed registering account >%s<", client.ErrorMessage(err))
+		}
+	}
+
+	data, err := c.Login(email, password)
+	if err != nil {
+		return "", fmt.Errorf("failed login >%s<", client.ErrorMessage(err))
+	}
+
+	return data.Token, nil
+}
+
+// dungeon returns the ID of the dungeon bots enter
+func dungeon(l logger.Logger, cfg Config, token string) (string, error) {
+
+	c, err := client.NewClient(l, cfg.Host)
+	if err != nil {
+		return "", err
+	}
+	c.SetToken(token)
+
+	dungeons, err := c.Dungeons()
+	if err != nil {
+		return "", fmt.Errorf("failed getting dungeons >%s<", client.ErrorMessage(err))
+	}
+
+	for _, d := range dungeons {
+		if cfg.Dungeon == "" || strings.EqualFold(d.Name, cfg.Dungeon) {
+			return d.ID, nil
+		}
+	}
+
+	if cfg.Dungeon == "" {
+		return "", fmt.Errorf("there are no dungeons")
+	}
+
+	return "", fmt.Errorf("dungeon >%s< not found", cfg.Dungeon)
+}
+
+// bot is a simulated player
+type bot struct {
+	client    *client.Client
+	strategy  Strategy
+	recorder  *recorder
+	config    Config
+	dungeonID string
+	name      string
+	delay     time.Duration
+	rand      *rand.Rand
+	log       logger.Logger
+}
+
+func (b *bot) play(ctx context.Context) {
+
+	if !b.sleep(ctx, b.delay) {
+		return
+	}
+
+	c, err := b.client.CreateCharacter(b.name, botAttribute, botAttribute, botAttribute)
+	if err != nil {
+		b.recorder.error(StageCreateCharacter, err)
+		return
+	}
+
+	if _, err := b.client.EnterDungeon(b.dungeonID, c.ID); err != nil {
+		b.recorder.error(StageEnterDungeon, err)
+		return
+	}
+
+	b.recorder.play()
+
+	defer func() {
+		if _, err := b.client.ExitDungeon(b.dungeonID, c.ID); err != nil {
+			b.recorder.error(StageExitDungeon, err)
+		}
+	}()
+
+	state := &State{
+		Character: c.Name,
+		Rand:      b.rand,
+	}
+
+	// Look around before deciding anything
+	sentence := record.ActionCommandLook
+	for count := 0; b.config.Actions == 0 || count < b.config.Actions; count++ {
+
+		start := time.Now()
+		data, err := b.client.Action(b.dungeonID, c.ID, sentence)
+		b.recorder.action(time.Since(start), err)
+
+		switch {
+		case err == nil:
+			state.remember(sentence, data)
+			sentence = b.strategy.Decide(state)
+		case client.ErrorCode(err) == model.ErrorCodeActionTooEarly:
+			// Submit the same sentence next turn
+		case client.ErrorCode(err) == model.ErrorCodeActionInvalidCharacter:
+			// The character has died
+			return
+		default:
+			// Look around rather than repeat a sentence that failed
+			sentence = record.ActionCommandLook
+		}
+
+		if !b.sleep(ctx, b.think()) {
+			return
+		}
+	}
+}
+
+// think returns a pause of half to one and a half times the think time so bots
+// do not submit actions in step
+func (b *bot) think() time.Duration {
+	if b.config.Think <= 0 {
+		return 0
+	}
+	return b.config.Think/2 + time.Duration(b.rand.Int63n(int64(b.config.Think)))
+}
+
+// sleep pauses for the duration and returns false when the context is done
+func (b *bot) sleep(ctx context.Context, d time.Duration) bool {
+
+	if ctx.Err() != nil {
+		return false
+	}
+	if d <= 0 {
+		return true
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
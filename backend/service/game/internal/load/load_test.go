@@ -0,0 +1,153 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/log"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const testDungeonID string = "2d3a5c50-34a4-4b73-9d0a-7e2b7d6e3d8f"
+
+// api is a game server that rejects every other action as too early and
+// every move as an invalid direction
+func api(t *testing.T) *httptest.Server {
+
+	write := func(w http.ResponseWriter, status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		require.NoError(t, json.NewEncoder(w).Encode(v), "Encode returns without error")
+	}
+
+	mu := sync.Mutex{}
+	characters := 0
+	actions := map[string]int{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/accounts", func(w http.ResponseWriter, r *http.Request) {
+		write(w, http.StatusCreated, schema.AccountResponse{Data: []schema.AccountData{{ID: "account"}}})
+	})
+	mux.HandleFunc("/api/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		write(w, http.StatusOK, schema.LoginResponse{Data: []schema.LoginData{{Token: "token"}}})
+	})
+	mux.HandleFunc("/api/v1/dungeons", func(w http.ResponseWriter, r *http.Request) {
+		write(w, http.StatusOK, schema.DungeonResponse{Data: []schema.DungeonData{{ID: testDungeonID, Name: "Cave"}}})
+	})
+	mux.HandleFunc("/api/v1/characters", func(w http.ResponseWriter, r *http.Request) {
+		req := schema.CharacterRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req), "Decode returns without error")
+
+		mu.Lock()
+		characters++
+		id := fmt.Sprintf("character-%d", characters)
+		mu.Unlock()
+
+		write(w, http.StatusCreated, schema.CharacterResponse{Data: []schema.DungeonCharacterData{{ID: id, Name: req.Data.Name}}})
+	})
+	mux.HandleFunc("/api/v1/dungeons/"+testDungeonID+"/characters/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		id, op := parts[len(parts)-2], parts[len(parts)-1]
+
+		if op != "actions" {
+			write(w, http.StatusOK, schema.DungeonCharacterResponse{Data: []schema.DungeonCharacterData{{ID: id}}})
+			return
+		}
+
+		req := schema.ActionRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req), "Decode returns without error")
+
+		mu.Lock()
+		actions[id]++
+		count := actions[id]
+		mu.Unlock()
+
+		if count%2 == 0 {
+			write(w, http.StatusBadRequest, []coreerror.Error{model.NewActionTooEarlyError(count, count).(coreerror.Error)})
+			return
+		}
+		if strings.HasPrefix(req.Data.Sentence, record.ActionCommandMove) {
+			write(w, http.StatusBadRequest, []coreerror.Error{{ErrorCode: model.ErrorCodeActionInvalidDirection, Message: "you cannot move that direction"}})
+			return
+		}
+
+		write(w, http.StatusOK, schema.ActionResponse{Data: []schema.ActionResponseData{
+			{
+				Command:  record.ActionCommandLook,
+				Location: schema.ActionLocation{Name: "Cave", Directions: []string{"north"}},
+			},
+		}})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestRun(t *testing.T) {
+
+	server := api(t)
+	defer server.Close()
+
+	l, err := log.NewLoggerWithConfig(log.Config{Level: "error"})
+	require.NoError(t, err, "NewLoggerWithConfig returns without error")
+
+	report, err := Run(context.Background(), l, Config{
+		Host:     server.URL,
+		Bots:     4,
+		Strategy: StrategyWander,
+		Actions:  6,
+		Duration: 10 * time.Second,
+		Seed:     1,
+	})
+	require.NoError(t, err, "Run returns without error")
+
+	require.Equal(t, 4, report.Bots, "Report has bots")
+	require.Equal(t, 4, report.Playing, "Every bot played")
+	require.Equal(t, 24, report.Actions, "Every bot submitted its actions")
+	require.Equal(t, 12, report.TooEarly, "Every other action was too early")
+	require.Equal(t, 0.5, report.TooEarlyRate, "Too early rate is half")
+	require.Greater(t, int64(report.P99), int64(0), "Latency is measured")
+	require.LessOrEqual(t, report.P50, report.P95, "p50 is no more than p95")
+	require.LessOrEqual(t, report.P95, report.P99, "p95 is no more than p99")
+	for k := range report.Errors {
+		require.Equal(t, "action "+string(model.ErrorCodeActionInvalidDirection), k, "Only moves are errors")
+	}
+
+	b := &strings.Builder{}
+	require.NoError(t, report.WriteText(b), "WriteText returns without error")
+	require.Contains(t, b.String(), "Too early       12 (50.0%)", "Text report contains too early rate")
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+
+	l, err := log.NewLoggerWithConfig(log.Config{Level: "error"})
+	require.NoError(t, err, "NewLoggerWithConfig returns without error")
+
+	_, err = Run(context.Background(), l, Config{Host: "http://localhost", Bots: 1, Strategy: "dance", Actions: 1})
+	require.Error(t, err, "Run returns error for unknown strategy")
+}
+
+func TestPercentile(t *testing.T) {
+
+	latencies := []time.Duration{}
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	require.Equal(t, 50*time.Millisecond, percentile(latencies, 50), "p50 equals")
+	require.Equal(t, 95*time.Millisecond, percentile(latencies, 95), "p95 equals")
+	require.Equal(t, 99*time.Millisecond, percentile(latencies, 99), "p99 equals")
+	require.Equal(t, time.Duration(0), percentile(nil, 99), "Percentile of nothing is zero")
+	require.Equal(t, time.Millisecond, percentile(latencies[:1], 99), "Percentile of one is that one")
+}
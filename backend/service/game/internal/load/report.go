@@ -0,0 +1,206 @@
+package load
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/httpclient"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/client"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// Stages requests are made in, errors are reported by stage and type
+const (
+	StageCreateCharacter string = "create_character"
+	StageEnterDungeon    string = "enter_dungeon"
+	StageAction          string = "action"
+	StageExitDungeon     string = "exit_dungeon"
+)
+
+// recorder collects the results of every bot
+type recorder struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	actions   int
+	tooEarly  int
+	playing   int
+	errors    map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		errors: map[string]int{},
+	}
+}
+
+// action records an action request, actions rejected for being submitted
+// before the next turn are counted separately from other errors
+func (r *recorder) action(latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.actions++
+
+	// Requests that failed without a response did not measure the server
+	var clientErr httpclient.Error
+	if err == nil || (errors.As(err, &clientErr) && clientErr.Code != 0) {
+		r.latencies = append(r.latencies, latency)
+	}
+
+	if err == nil {
+		return
+	}
+	if client.ErrorCode(err) == model.ErrorCodeActionTooEarly {
+		r.tooEarly++
+		return
+	}
+	r.errors[StageAction+" "+errorType(err)]++
+}
+
+func (r *recorder) error(stage string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.errors[stage+" "+errorType(err)]++
+}
+
+func (r *recorder) play() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.playing++
+}
+
+// errorType is the API error code of a failed request, the response status
+// when the response has no API error, or how the request failed when there was
+// no response
+func errorType(err error) string {
+
+	if code := client.ErrorCode(err); code != "" {
+		return string(code)
+	}
+
+	var clientErr httpclient.Error
+	if errors.As(err, &clientErr) {
+		switch {
+		case clientErr.Code != 0:
+			return fmt.Sprintf("http_%d", clientErr.Code)
+		case clientErr.DecodeError != nil:
+			return "decode"
+		}
+	}
+
+	return "request"
+}
+
+// Report -
+type Report struct {
+	Bots     int           `json:"bots"`
+	Playing  int           `json:"playing"`
+	Strategy string        `json:"strategy"`
+	Duration time.Duration `json:"duration_ns"`
+	// Actions is the number of action requests made
+	Actions          int     `json:"actions"`
+	ActionsPerSecond float64 `json:"actions_per_second"`
+	// TooEarly is the number of actions rejected for being submitted before
+	// the next turn
+	TooEarly     int     `json:"too_early"`
+	TooEarlyRate float64 `json:"too_early_rate"`
+	// Latency percentiles of action requests that had a response
+	P50 time.Duration `json:"p50_ns"`
+	P95 time.Duration `json:"p95_ns"`
+	P99 time.Duration `json:"p99_ns"`
+	Max time.Duration `json:"max_ns"`
+	// Errors are counts keyed by stage and error type
+	Errors map[string]int `json:"errors"`
+}
+
+func (r *recorder) report(bots int, strategy string, duration time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	latencies := append([]time.Duration{}, r.latencies...)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	rep := &Report{
+		Bots:     bots,
+		Playing:  r.playing,
+		Strategy: strategy,
+		Duration: duration,
+		Actions:  r.actions,
+		TooEarly: r.tooEarly,
+		P50:      percentile(latencies, 50),
+		P95:      percentile(latencies, 95),
+		P99:      percentile(latencies, 99),
+		Errors:   map[string]int{},
+	}
+	if len(latencies) > 0 {
+		rep.Max = latencies[len(latencies)-1]
+	}
+	if r.actions > 0 {
+		rep.TooEarlyRate = float64(r.tooEarly) / float64(r.actions)
+	}
+	if duration > 0 {
+		rep.ActionsPerSecond = float64(r.actions) / duration.Seconds()
+	}
+	for k, v := range r.errors {
+		rep.Errors[k] = v
+	}
+
+	return rep
+}
+
+// percentile returns the nearest rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// WriteText writes the report as text
+func (r *Report) WriteText(w io.Writer) error {
+
+	lines := []string{
+		fmt.Sprintf("Bots            %d (%d playing)", r.Bots, r.Playing),
+		fmt.Sprintf("Strategy        %s", r.Strategy),
+		fmt.Sprintf("Duration        %s", r.Duration.Round(time.Millisecond)),
+		fmt.Sprintf("Actions         %d (%.1f/s)", r.Actions, r.ActionsPerSecond),
+		fmt.Sprintf("Too early       %d (%.1f%%)", r.TooEarly, r.TooEarlyRate*100),
+		fmt.Sprintf("Latency p50     %s", r.P50.Round(time.Microsecond)),
+		fmt.Sprintf("Latency p95     %s", r.P95.Round(time.Microsecond)),
+		fmt.Sprintf("Latency p99     %s", r.P99.Round(time.Microsecond)),
+		fmt.Sprintf("Latency max     %s", r.Max.Round(time.Microsecond)),
+	}
+
+	if len(r.Errors) == 0 {
+		lines = append(lines, "Errors          none")
+	} else {
+		lines = append(lines, "Errors")
+		keys := []string{}
+		for k := range r.Errors {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			lines = append(lines, fmt.Sprintf("  %-40s %d", k, r.Errors[k]))
+		}
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
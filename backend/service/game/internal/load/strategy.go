@@ -0,0 +1,166 @@
+package load
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// maxMemories is the number of actions a bot remembers
+const maxMemories int = 50
+
+// State is what a bot knows when deciding its next action
+type State struct {
+	// Character is the name of the bot character
+	Character string
+	// Location is where the most recent action left the character
+	Location schema.ActionLocation
+	// Memories are the actions the character has seen, oldest first
+	Memories []schema.ActionResponseData
+	// Moved is the direction the character most recently moved
+	Moved string
+	Rand  *rand.Rand
+}
+
+// remember adds the actions returned for a submitted sentence
+func (s *State) remember(sentence string, data []schema.ActionResponseData) {
+
+	if len(data) == 0 {
+		return
+	}
+
+	s.Memories = append(s.Memories, data...)
+	if len(s.Memories) > maxMemories {
+		s.Memories = s.Memories[len(s.Memories)-maxMemories:]
+	}
+
+	last := data[len(data)-1]
+	s.Location = last.Location
+
+	fields := strings.Fields(sentence)
+	if last.Command == record.ActionCommandMove && len(fields) > 1 {
+		s.Moved = fields[len(fields)-1]
+	}
+}
+
+// Strategy decides the sentence a bot submits next
+type Strategy interface {
+	Decide(s *State) string
+}
+
+// StrategyFunc adapts a function to a strategy
+type StrategyFunc func(s *State) string
+
+// Decide -
+func (f StrategyFunc) Decide(s *State) string {
+	return f(s)
+}
+
+// Strategy names
+const (
+	StrategyLook    string = "look"
+	StrategyWander  string = "wander"
+	StrategyMonster string = "monster"
+)
+
+// Strategies are the strategies bots may play with by name
+var Strategies = map[string]Strategy{
+	StrategyLook:    StrategyFunc(decideLook),
+	StrategyWander:  StrategyFunc(decideWander),
+	StrategyMonster: StrategyFunc(decideMonster),
+}
+
+// StrategyNames returns the names of the strategies in order
+func StrategyNames() []string {
+	names := []string{}
+	for name := range Strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// decideLook only ever looks, loading the server without changing the dungeon
+func decideLook(s *State) string {
+	return record.ActionCommandLook
+}
+
+// decideWander looks around now and then and otherwise moves a random
+// direction
+func decideWander(s *State) string {
+
+	directions := s.Location.Directions
+	if len(directions) == 0 || s.Rand.Intn(4) == 0 {
+		return record.ActionCommandLook
+	}
+
+	return fmt.Sprintf("%s %s", record.ActionCommandMove, directions[s.Rand.Intn(len(directions))])
+}
+
+// decideMonster plays like a dungeon monster by deciding with the model action
+// decider heuristics, attacking anything worth attacking, looking into
+// neighbouring locations for something interesting and moving towards it or on
+// to somewhere new
+func decideMonster(s *State) string {
+
+	sentence := model.Decide(decision(s))
+	if sentence == "" {
+		return record.ActionCommandLook
+	}
+
+	return sentence
+}
+
+// decision is what a bot knows when deciding as a monster would. Bots are
+// characters hunting the monsters at their location, prioritising monsters that
+// have attacked them.
+func decision(s *State) *model.Decision {
+
+	attackers := map[string]struct{}{}
+	for _, memory := range s.Memories {
+		if memory.Command == record.ActionCommandAttack && memory.Monster != nil &&
+			memory.TargetCharacter != nil && memory.TargetCharacter.Name == s.Character {
+			attackers[memory.Monster.Name] = struct{}{}
+		}
+	}
+
+	d := &model.Decision{
+		Directions: s.Location.Directions,
+		Rand:       s.Rand,
+	}
+
+	for _, monster := range s.Location.Monsters {
+		_, attacker := attackers[monster.Name]
+		d.Opponents = append(d.Opponents, model.DecisionOpponent{
+			Name:          monster.Name,
+			CurrentHealth: monster.CurrentHealth,
+			Attacker:      attacker,
+			Prey:          true,
+		})
+	}
+
+	// Looks are most recent first
+	for idx := len(s.Memories) - 1; idx >= 0; idx-- {
+		memory := s.Memories[idx]
+		if memory.Command == record.ActionCommandLook && memory.Character != nil &&
+			memory.Character.Name == s.Character && memory.Location.Name == s.Location.Name &&
+			memory.TargetLocation != nil && memory.TargetLocation.Direction != "" {
+			d.Looks = append(d.Looks, model.DecisionLook{
+				Direction:   memory.TargetLocation.Direction,
+				Interesting: len(memory.TargetLocation.Monsters) > 0,
+			})
+		}
+	}
+
+	// The direction back is where the bot most recently moved from
+	if back := record.LocationOppositeDirections[s.Moved]; back != "" {
+		d.PreviousDirections = []string{back}
+	}
+
+	return d
+}
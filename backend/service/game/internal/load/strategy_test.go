@@ -0,0 +1,168 @@
+package load
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestDecideMonster(t *testing.T) {
+
+	me := &schema.ActionCharacter{Name: "Bot 1"}
+
+	tests := []struct {
+		name     string
+		location schema.ActionLocation
+		memories []schema.ActionResponseData
+		moved    string
+		expect   []string
+	}{
+		{
+			name: "Attack monster that attacked",
+			location: schema.ActionLocation{
+				Name: "Cave",
+				Monsters: schema.ActionLocationMonsters{
+					{Name: "Angry Goblin", CurrentHealth: 5},
+					{Name: "Sleepy Rat", CurrentHealth: 5},
+				},
+			},
+			memories: []schema.ActionResponseData{
+				{
+					Command:         record.ActionCommandAttack,
+					Monster:         &schema.ActionMonster{Name: "Sleepy Rat"},
+					TargetCharacter: me,
+				},
+			},
+			expect: []string{"attack Sleepy Rat"},
+		},
+		{
+			name: "Attack any living monster",
+			location: schema.ActionLocation{
+				Name: "Cave",
+				Monsters: schema.ActionLocationMonsters{
+					{Name: "Dead Goblin", CurrentHealth: 0},
+					{Name: "Angry Goblin", CurrentHealth: 5},
+				},
+			},
+			expect: []string{"attack Angry Goblin"},
+		},
+		{
+			name: "Look a direction not looked",
+			location: schema.ActionLocation{
+				Name:       "Cave",
+				Directions: []string{"north", "south"},
+			},
+			memories: []schema.ActionResponseData{
+				{
+					Command:        record.ActionCommandLook,
+					Character:      me,
+					Location:       schema.ActionLocation{Name: "Cave"},
+					TargetLocation: &schema.ActionLocation{Name: "Tunnel", Direction: "north"},
+				},
+			},
+			expect: []string{"look south"},
+		},
+		{
+			name: "Move towards monsters seen",
+			location: schema.ActionLocation{
+				Name:       "Cave",
+				Directions: []string{"north", "south", "east"},
+			},
+			memories: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandLook,
+					Character: me,
+					Location:  schema.ActionLocation{Name: "Cave"},
+					TargetLocation: &schema.ActionLocation{
+						Name:      "Tunnel",
+						Direction: "east",
+						Monsters:  schema.ActionLocationMonsters{{Name: "Angry Goblin"}},
+					},
+				},
+			},
+			expect: []string{"move east"},
+		},
+		{
+			name: "Move on without going back",
+			location: schema.ActionLocation{
+				Name:       "Cave",
+				Directions: []string{"north", "south"},
+			},
+			memories: []schema.ActionResponseData{
+				{
+					Command:        record.ActionCommandLook,
+					Character:      me,
+					Location:       schema.ActionLocation{Name: "Cave"},
+					TargetLocation: &schema.ActionLocation{Name: "Tunnel", Direction: "north"},
+				},
+				{
+					Command:        record.ActionCommandLook,
+					Character:      me,
+					Location:       schema.ActionLocation{Name: "Cave"},
+					TargetLocation: &schema.ActionLocation{Name: "Entrance", Direction: "south"},
+				},
+			},
+			moved:  "north",
+			expect: []string{"move north"},
+		},
+		{
+			name:     "Nowhere to go",
+			location: schema.ActionLocation{Name: "Cell"},
+			expect:   []string{"look"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &State{
+				Character: me.Name,
+				Location:  tt.location,
+				Memories:  tt.memories,
+				Moved:     tt.moved,
+				Rand:      rand.New(rand.NewSource(1)),
+			}
+			// Random choices are only made between equally good sentences
+			for i := 0; i < 10; i++ {
+				require.Contains(t, tt.expect, Strategies[StrategyMonster].Decide(s), "Decide returns expected sentence")
+			}
+		})
+	}
+}
+
+func TestDecideWander(t *testing.T) {
+
+	s := &State{
+		Location: schema.ActionLocation{Directions: []string{"north", "south"}},
+		Rand:     rand.New(rand.NewSource(1)),
+	}
+
+	for i := 0; i < 20; i++ {
+		require.Contains(t, []string{"look", "move north", "move south"}, Strategies[StrategyWander].Decide(s), "Decide returns a look or move")
+	}
+
+	s.Location.Directions = nil
+	require.Equal(t, "look", Strategies[StrategyWander].Decide(s), "Decide looks without exits")
+}
+
+func TestStateRemember(t *testing.T) {
+
+	s := &State{Character: "Bot 1"}
+
+	s.remember("move north", []schema.ActionResponseData{
+		{Command: record.ActionCommandLook, Location: schema.ActionLocation{Name: "Cave"}},
+		{Command: record.ActionCommandMove, Location: schema.ActionLocation{Name: "Tunnel"}},
+	})
+	require.Equal(t, "Tunnel", s.Location.Name, "Location is where the last action left the character")
+	require.Equal(t, "north", s.Moved, "Moved is the direction moved")
+	require.Len(t, s.Memories, 2, "Actions are remembered")
+
+	for i := 0; i < maxMemories; i++ {
+		s.remember("look", []schema.ActionResponseData{{Command: record.ActionCommandLook}})
+	}
+	require.Len(t, s.Memories, maxMemories, "Memories are limited")
+	require.Equal(t, "north", s.Moved, "Moved is unchanged by looking")
+}
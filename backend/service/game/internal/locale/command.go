@@ -0,0 +1,61 @@
+package locale
+
+import "strings"
+
+// Command returns the command sentence with localised verbs and direction
+// names replaced by the English words the command parser understands,
+// "attaquer le gobelin" becomes "attack le gobelin". Words that are not part
+// of the language vocabulary are left as they are.
+func Command(language, sentence string) string {
+
+	lc, ok := locales[language]
+	if !ok {
+		return sentence
+	}
+
+	words := strings.Fields(sentence)
+	for idx, word := range words {
+		lower := strings.ToLower(word)
+		if idx == 0 {
+			if verb, ok := lc.verbs[lower]; ok {
+				words[idx] = verb
+				continue
+			}
+		}
+		if direction, ok := lc.directions[lower]; ok {
+			words[idx] = direction
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Direction returns the name of a direction in the language
+func Direction(language, direction string) string {
+
+	lc, ok := locales[language]
+	if !ok {
+		return direction
+	}
+
+	if names := lc.Directions[direction]; len(names) > 0 {
+		return names[0]
+	}
+
+	return direction
+}
+
+// Verb returns the name of a command verb in the language
+func Verb(language, verb string) string {
+
+	lc, ok := locales[language]
+	if !ok {
+		return verb
+	}
+
+	if names := lc.Verbs[verb]; len(names) > 0 {
+		return names[0]
+	}
+
+	return verb
+}
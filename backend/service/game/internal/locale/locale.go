@@ -0,0 +1,200 @@
+package locale
+
+// Locales hold everything needed to play in a language other than English.
+// Message catalogs translate system text such as error messages, vocabularies
+// translate the verbs and direction names players type into the English words
+// the command parser understands. Dungeon content is translated separately by
+// the translation table.
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	English string = "en"
+	French  string = "fr"
+	Spanish string = "es"
+)
+
+// Default is the language of dungeon content, messages and commands when no
+// other supported language is requested
+const Default string = English
+
+// Languages are the supported languages in order of preference
+var Languages []string = []string{
+	English,
+	French,
+	Spanish,
+}
+
+// IsLanguage returns whether the language is supported
+func IsLanguage(language string) bool {
+	for _, l := range Languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+//go:embed catalog/*.yaml
+var catalogFS embed.FS
+
+// Locale is the message catalog and vocabulary of a language
+type Locale struct {
+	// Messages are translated message formats keyed by the English format
+	Messages map[string]string `yaml:"messages"`
+	// Verbs are the localised names of command verbs keyed by the English
+	// verb, the first name is the one used when describing the verb
+	Verbs map[string][]string `yaml:"verbs"`
+	// Directions are the localised names of directions keyed by the English
+	// direction, the first name is the one used when describing the direction
+	Directions map[string][]string `yaml:"directions"`
+
+	messages   []message
+	verbs      map[string]string
+	directions map[string]string
+}
+
+// message matches a formatted English message so the message arguments can be
+// substituted into the translated format
+type message struct {
+	re     *regexp.Regexp
+	format string
+}
+
+var locales map[string]*Locale = mustLoadLocales()
+
+func mustLoadLocales() map[string]*Locale {
+	locales, err := loadLocales()
+	if err != nil {
+		panic(err)
+	}
+	return locales
+}
+
+func loadLocales() (map[string]*Locale, error) {
+
+	locales := map[string]*Locale{}
+
+	for _, language := range Languages {
+		if language == Default {
+			continue
+		}
+
+		b, err := catalogFS.ReadFile(path.Join("catalog", language+".yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("failed reading language >%s< catalog >%v<", language, err)
+		}
+
+		lc := &Locale{}
+		err = yaml.Unmarshal(b, lc)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing language >%s< catalog >%v<", language, err)
+		}
+
+		lc.verbs, err = vocabulary(lc.Verbs)
+		if err != nil {
+			return nil, fmt.Errorf("failed language >%s< verbs >%v<", language, err)
+		}
+
+		lc.directions, err = vocabulary(lc.Directions)
+		if err != nil {
+			return nil, fmt.Errorf("failed language >%s< directions >%v<", language, err)
+		}
+
+		formats := make([]string, 0, len(lc.Messages))
+		for format := range lc.Messages {
+			formats = append(formats, format)
+		}
+
+		// Longer formats are more specific and are matched first
+		sort.Slice(formats, func(i, j int) bool {
+			if len(formats[i]) != len(formats[j]) {
+				return len(formats[i]) > len(formats[j])
+			}
+			return formats[i] < formats[j]
+		})
+
+		for _, format := range formats {
+			re, err := formatRegexp(format)
+			if err != nil {
+				return nil, fmt.Errorf("failed compiling language >%s< message >%s< >%v<", language, format, err)
+			}
+			lc.messages = append(lc.messages, message{re: re, format: stringFormat(lc.Messages[format])})
+		}
+
+		locales[language] = lc
+	}
+
+	return locales, nil
+}
+
+var reVerb = regexp.MustCompile(`%(\[\d+\])?[-+# 0-9.]*[a-zA-Z%]`)
+
+// formatRegexp returns a regular expression matching messages formatted with
+// the format, each formatting verb is captured as a submatch
+func formatRegexp(format string) (*regexp.Regexp, error) {
+
+	var b strings.Builder
+	b.WriteString("^")
+
+	last := 0
+	for _, loc := range reVerb.FindAllStringIndex(format, -1) {
+		b.WriteString(regexp.QuoteMeta(format[last:loc[0]]))
+		verb := format[loc[0]:loc[1]]
+		switch verb[len(verb)-1] {
+		case '%':
+			b.WriteString("%")
+		case 'd':
+			b.WriteString(`(-?\d+)`)
+		default:
+			b.WriteString(`(.*?)`)
+		}
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(format[last:]))
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// stringFormat replaces every formatting verb in a translated format with a
+// string verb as message arguments are always substituted as matched strings
+func stringFormat(format string) string {
+	return reVerb.ReplaceAllStringFunc(format, func(verb string) string {
+		if verb == "%%" {
+			return verb
+		}
+		idx := strings.Index(verb, "]")
+		if idx == -1 {
+			return "%s"
+		}
+		return verb[:idx+1] + "s"
+	})
+}
+
+// vocabulary returns the English words keyed by their localised names
+func vocabulary(names map[string][]string) (map[string]string, error) {
+	words := map[string]string{}
+	for word, localised := range names {
+		if len(localised) == 0 {
+			return nil, fmt.Errorf("word >%s< has no localised names", word)
+		}
+		for _, name := range localised {
+			name = strings.ToLower(name)
+			if w, ok := words[name]; ok && w != word {
+				return nil, fmt.Errorf("localised name >%s< is used for both >%s< and >%s<", name, w, word)
+			}
+			words[name] = word
+		}
+	}
+	return words, nil
+}
@@ -0,0 +1,211 @@
+package locale
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLocales(t *testing.T) {
+
+	locales, err := loadLocales()
+	require.NoError(t, err, "loadLocales returns without error")
+
+	for _, language := range Languages {
+		if language == Default {
+			continue
+		}
+		lc, ok := locales[language]
+		require.True(t, ok, "Language >%s< has a locale", language)
+
+		for format, translated := range lc.Messages {
+			require.Equal(t,
+				len(reVerb.FindAllString(format, -1)),
+				len(reVerb.FindAllString(translated, -1)),
+				"Language >%s< message >%s< translation has the same number of arguments", language, format,
+			)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expect         string
+	}{
+		{
+			name:           "Empty",
+			acceptLanguage: "",
+			expect:         English,
+		},
+		{
+			name:           "Single supported language",
+			acceptLanguage: "fr",
+			expect:         French,
+		},
+		{
+			name:           "Region subtag",
+			acceptLanguage: "es-MX",
+			expect:         Spanish,
+		},
+		{
+			name:           "Quality values",
+			acceptLanguage: "en;q=0.5, fr-CA, fr;q=0.9",
+			expect:         French,
+		},
+		{
+			name:           "Unsupported languages are skipped",
+			acceptLanguage: "de-DE, de;q=0.9, es;q=0.4",
+			expect:         Spanish,
+		},
+		{
+			name:           "Only unsupported languages",
+			acceptLanguage: "de, ja;q=0.8",
+			expect:         English,
+		},
+		{
+			name:           "Zero quality is not acceptable",
+			acceptLanguage: "fr;q=0, es;q=0.1",
+			expect:         Spanish,
+		},
+		{
+			name:           "Wildcard",
+			acceptLanguage: "de, *;q=0.5",
+			expect:         English,
+		},
+		{
+			name:           "Malformed",
+			acceptLanguage: ";;q=x,,",
+			expect:         English,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, Negotiate(tt.acceptLanguage), "Negotiate returns expected language")
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		language string
+		message  string
+		expect   string
+	}{
+		{
+			name:     "English is unchanged",
+			language: English,
+			message:  "character name >Barricade< has died",
+			expect:   "character name >Barricade< has died",
+		},
+		{
+			name:     "French without arguments",
+			language: French,
+			message:  "you cannot move that direction",
+			expect:   "vous ne pouvez pas aller dans cette direction",
+		},
+		{
+			name:     "French with arguments",
+			language: French,
+			message:  "character name >Barricade< has died",
+			expect:   "le personnage >Barricade< est mort",
+		},
+		{
+			name:     "Spanish with numeric arguments",
+			language: Spanish,
+			message:  fmt.Sprintf("dungeon instance turn >%d< is less than or equal to entity instance turn >%d<", 3, 4),
+			expect:   "el turno de la mazmorra >3< es menor o igual que el turno de la entidad >4<, espera al siguiente turno",
+		},
+		{
+			name:     "Untranslated message is unchanged",
+			language: French,
+			message:  "something unexpected",
+			expect:   "something unexpected",
+		},
+		{
+			name:     "Unsupported language is unchanged",
+			language: "de",
+			message:  "you cannot move that direction",
+			expect:   "you cannot move that direction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, Message(tt.language, tt.message), "Message returns expected message")
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		language string
+		sentence string
+		expect   string
+	}{
+		{
+			name:     "English is unchanged",
+			language: English,
+			sentence: "move north",
+			expect:   "move north",
+		},
+		{
+			name:     "French verb and direction",
+			language: French,
+			sentence: "aller nord-est",
+			expect:   "move northeast",
+		},
+		{
+			name:     "French verb with accents",
+			language: French,
+			sentence: "Équiper épée",
+			expect:   "equip épée",
+		},
+		{
+			name:     "Spanish verb and direction",
+			language: Spanish,
+			sentence: "mirar  abajo",
+			expect:   "look down",
+		},
+		{
+			name:     "Verbs are only recognised first",
+			language: Spanish,
+			sentence: "usar atacar",
+			expect:   "use atacar",
+		},
+		{
+			name:     "English words are understood in every language",
+			language: French,
+			sentence: "look ouest",
+			expect:   "look west",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, Command(tt.language, tt.sentence), "Command returns expected sentence")
+		})
+	}
+}
+
+func TestDirection(t *testing.T) {
+
+	require.Equal(t, "nord-ouest", Direction(French, "northwest"), "Direction returns French direction")
+	require.Equal(t, "abajo", Direction(Spanish, "down"), "Direction returns Spanish direction")
+	require.Equal(t, "north", Direction(English, "north"), "Direction returns English direction")
+}
+
+func TestVerb(t *testing.T) {
+
+	require.Equal(t, "attaquer", Verb(French, "attack"), "Verb returns French verb")
+	require.Equal(t, "mirar", Verb(Spanish, "look"), "Verb returns Spanish verb")
+	require.Equal(t, "look", Verb(English, "look"), "Verb returns English verb")
+}
@@ -0,0 +1,29 @@
+package locale
+
+import "fmt"
+
+// Message returns the message translated into the language. Messages are
+// matched against the English formats in the language catalog and the
+// arguments of the matching message are substituted into the translated
+// format. Messages without a translation are returned unchanged.
+func Message(language, msg string) string {
+
+	lc, ok := locales[language]
+	if !ok {
+		return msg
+	}
+
+	for _, m := range lc.messages {
+		matches := m.re.FindStringSubmatch(msg)
+		if matches == nil {
+			continue
+		}
+		args := make([]any, 0, len(matches)-1)
+		for _, match := range matches[1:] {
+			args = append(args, match)
+		}
+		return fmt.Sprintf(m.format, args...)
+	}
+
+	return msg
+}
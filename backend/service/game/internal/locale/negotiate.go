@@ -0,0 +1,65 @@
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Negotiate returns the supported language that best matches an
+// Accept-Language header, "fr-CA, fr;q=0.9, en;q=0.5" returns French. The
+// default language is returned when the header is empty, malformed or only
+// requests unsupported languages.
+func Negotiate(acceptLanguage string) string {
+
+	type preference struct {
+		language string
+		quality  float64
+	}
+
+	preferences := []preference{}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			quality = q
+		}
+		if quality == 0 {
+			continue
+		}
+
+		// Region and script subtags are not distinguished, "fr-CA" is French
+		language, _, _ := strings.Cut(tag, "-")
+		if tag == "*" {
+			language = Default
+		}
+
+		preferences = append(preferences, preference{language: language, quality: quality})
+	}
+
+	// Equal preferences keep the order they were given in
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+
+	for _, p := range preferences {
+		if IsLanguage(p.language) {
+			return p.language
+		}
+	}
+
+	return Default
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// AccountRoles are the roles that may be assigned to an account
+var AccountRoles = []string{
+	record.AccountRolePlayer,
+	record.AccountRoleGameMaster,
+	record.AccountRoleAdmin,
+}
+
+// IsAccountRole returns whether the role may be assigned to an account
+func IsAccountRole(role string) bool {
+	for _, accountRole := range AccountRoles {
+		if role == accountRole {
+			return true
+		}
+	}
+	return false
+}
+
+type RegisterAccountArgs struct {
+	Name     string
+	Email    string
+	Password string
+}
+
+// RegisterAccount creates an account with a hashed password
+func (m *Model) RegisterAccount(args *RegisterAccountArgs) (*record.Account, error) {
+	l := m.loggerWithFunctionContext("RegisterAccount")
+
+	passwordHash, err := HashAccountPassword(args.Password)
+	if err != nil {
+		l.Warn("failed hashing account password >%v<", err)
+		return nil, err
+	}
+
+	rec := &record.Account{
+		Name:         strings.TrimSpace(args.Name),
+		Email:        normaliseAccountEmail(args.Email),
+		PasswordHash: passwordHash,
+		Role:         record.AccountRolePlayer,
+	}
+
+	err = m.CreateAccountRec(rec)
+	if err != nil {
+		l.Warn("failed creating account record >%v<", err)
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// LoginAccount returns the account with the email when the password matches
+func (m *Model) LoginAccount(email string, password string) (*record.Account, error) {
+	l := m.loggerWithFunctionContext("LoginAccount")
+
+	rec, err := m.GetAccountRecByEmail(email)
+	if err != nil {
+		l.Warn("failed getting account record >%v<", err)
+		return nil, err
+	}
+
+	if rec == nil {
+		l.Info("Account email >%s< not found", email)
+		// Verify against a dummy hash so unknown emails take as long as
+		// known emails
+		_, err := verifyAccountPassword(accountPasswordDummyHash, password)
+		if err != nil {
+			l.Warn("failed verifying dummy password >%v<", err)
+			return nil, err
+		}
+		return nil, NewAccountInvalidLoginError()
+	}
+
+	ok, err := verifyAccountPassword(rec.PasswordHash, password)
+	if err != nil {
+		l.Warn("failed verifying account ID >%s< password >%v<", rec.ID, err)
+		return nil, err
+	}
+
+	if !ok {
+		l.Info("Account ID >%s< password does not match", rec.ID)
+		return nil, NewAccountInvalidLoginError()
+	}
+
+	return rec, nil
+}
+
+// AssignAccountRole replaces the role of an account
+func (m *Model) AssignAccountRole(rec *record.Account, role string) error {
+	l := m.loggerWithFunctionContext("AssignAccountRole")
+
+	if rec == nil {
+		return fmt.Errorf("missing account record argument, cannot assign account role")
+	}
+
+	if !IsAccountRole(role) {
+		return NewAccountInvalidRoleError(role)
+	}
+
+	l.Info("Assigning account ID >%s< role >%s< replacing role >%s<", rec.ID, role, rec.Role)
+
+	rec.Role = role
+
+	err := m.UpdateAccountRec(rec)
+	if err != nil {
+		l.Warn("failed updating account record >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAccountRecByEmail returns the account with the email or nil when there is
+// no such account
+func (m *Model) GetAccountRecByEmail(email string) (*record.Account, error) {
+	l := m.loggerWithFunctionContext("GetAccountRecByEmail")
+
+	recs, err := m.GetAccountRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldAccountEmail,
+					Val: normaliseAccountEmail(email),
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting account records >%v<", err)
+		return nil, err
+	}
+
+	if len(recs) != 1 {
+		return nil, nil
+	}
+
+	return recs[0], nil
+}
+
+func normaliseAccountEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
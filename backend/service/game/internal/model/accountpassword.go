@@ -0,0 +1,75 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	accountPasswordHashAlgorithm  string = "pbkdf2-sha256"
+	accountPasswordHashIterations int    = 310000
+	accountPasswordSaltLength     int    = 16
+	accountPasswordKeyLength      int    = 32
+)
+
+// accountPasswordDummyHash is verified against when logging in with an email
+// that does not have an account so the response time does not reveal which
+// emails have an account
+const accountPasswordDummyHash string = "pbkdf2-sha256$310000$WfBpZpUKbDVn0CPsbIlPCw$zoxIkvB5AFINlkAXnQhRBVxUkJNOiU8K969IKxw8ZTw"
+
+// HashAccountPassword returns a salted PBKDF2 SHA-256 hash of the password in
+// the format "pbkdf2-sha256$<iterations>$<salt>$<key>".
+func HashAccountPassword(password string) (string, error) {
+	if password == "" {
+		return "", fmt.Errorf("password is empty, cannot hash password")
+	}
+
+	salt := make([]byte, accountPasswordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := pbkdf2.Key([]byte(password), salt, accountPasswordHashIterations, accountPasswordKeyLength, sha256.New)
+
+	return fmt.Sprintf(
+		"%s$%d$%s$%s",
+		accountPasswordHashAlgorithm,
+		accountPasswordHashIterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyAccountPassword returns whether the password matches the password hash
+func verifyAccountPassword(passwordHash string, password string) (bool, error) {
+	parts := strings.Split(passwordHash, "$")
+	if len(parts) != 4 || parts[0] != accountPasswordHashAlgorithm {
+		return false, fmt.Errorf("password hash has an unsupported format")
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations < 1 {
+		return false, fmt.Errorf("password hash iterations >%s< is invalid", parts[1])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, fmt.Errorf("password hash salt is invalid >%v<", err)
+	}
+
+	expectKey, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, fmt.Errorf("password hash key is invalid >%v<", err)
+	}
+
+	key := pbkdf2.Key([]byte(password), salt, iterations, len(expectKey), sha256.New)
+
+	return subtle.ConstantTimeCompare(key, expectKey) == 1, nil
+}
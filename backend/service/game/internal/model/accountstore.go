@@ -0,0 +1,141 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// GetAccountRecs -
+func (m *Model) GetAccountRecs(opts *coresql.Options) ([]*record.Account, error) {
+
+	l := m.loggerWithFunctionContext("GetAccountRecs")
+
+	l.Debug("Getting account records opts >%#v<", opts)
+
+	r := m.AccountRepository()
+
+	return r.GetMany(opts)
+}
+
+// GetAccountRec -
+func (m *Model) GetAccountRec(recID string, lock *coresql.Lock) (*record.Account, error) {
+
+	l := m.loggerWithFunctionContext("GetAccountRec")
+
+	l.Debug("Getting account rec ID >%s<", recID)
+
+	r := m.AccountRepository()
+
+	if !m.IsUUID(recID) {
+		return nil, fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	rec, err := r.GetOne(recID, lock)
+	if err == sql.ErrNoRows {
+		l.Warn("No record found ID >%s<", recID)
+		return nil, nil
+	}
+
+	return rec, err
+}
+
+// CreateAccountRec -
+func (m *Model) CreateAccountRec(rec *record.Account) error {
+	l := m.loggerWithFunctionContext("CreateAccountRec")
+
+	l.Debug("Creating account record >%#v<", rec)
+
+	r := m.AccountRepository()
+
+	if rec.Role == "" {
+		rec.Role = record.AccountRolePlayer
+	}
+
+	err := m.validateAccountRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	err = r.CreateOne(rec)
+	if err != nil {
+		if strings.Contains(err.Error(), "account_email_uq") {
+			return NewAccountEmailTakenError(rec)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdateAccountRec -
+func (m *Model) UpdateAccountRec(rec *record.Account) error {
+	l := m.loggerWithFunctionContext("UpdateAccountRec")
+
+	l.Debug("Updating account record >%#v<", rec)
+
+	r := m.AccountRepository()
+
+	err := m.validateAccountRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	err = r.UpdateOne(rec)
+	if err != nil {
+		if strings.Contains(err.Error(), "account_email_uq") {
+			return NewAccountEmailTakenError(rec)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAccountRec -
+func (m *Model) DeleteAccountRec(recID string) error {
+	l := m.loggerWithFunctionContext("DeleteAccountRec")
+
+	l.Debug("Deleting account rec ID >%s<", recID)
+
+	r := m.AccountRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteAccountRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.DeleteOne(recID)
+}
+
+// RemoveAccountRec -
+func (m *Model) RemoveAccountRec(recID string) error {
+
+	l := m.loggerWithFunctionContext("RemoveAccountRec")
+
+	l.Debug("Removing account rec ID >%s<", recID)
+
+	r := m.AccountRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteAccountRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.RemoveOne(recID)
+}
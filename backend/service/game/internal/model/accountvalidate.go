@@ -0,0 +1,36 @@
+package model
+
+import (
+	"fmt"
+	"net/mail"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// validateAccountRec - validates creating and updating an account record
+func (m *Model) validateAccountRec(rec *record.Account) error {
+
+	if rec.Name == "" {
+		return fmt.Errorf("failed validation, Name is empty")
+	}
+	if rec.Email == "" {
+		return fmt.Errorf("failed validation, Email is empty")
+	}
+	if _, err := mail.ParseAddress(rec.Email); err != nil {
+		return fmt.Errorf("failed validation, Email >%s< is not a valid email address", rec.Email)
+	}
+	if rec.PasswordHash == "" {
+		return fmt.Errorf("failed validation, PasswordHash is empty")
+	}
+	if !IsAccountRole(rec.Role) {
+		return NewAccountInvalidRoleError(rec.Role)
+	}
+
+	return nil
+}
+
+// validateDeleteAccountRec - validates it is okay to delete an account record
+func (m *Model) validateDeleteAccountRec(recID string) error {
+
+	return nil
+}
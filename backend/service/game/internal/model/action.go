@@ -58,6 +58,13 @@ func (m *Model) ProcessCharacterAction(dungeonInstanceID string, characterInstan
 		return nil, fmt.Errorf(msg)
 	}
 
+	// Commands may be given in the model language
+	sentence, err = m.localiseSentence(sentence, locationInstanceRecordSet)
+	if err != nil {
+		l.Warn("failed localising sentence >%v<", err)
+		return nil, err
+	}
+
 	resolved, err := m.resolveCommand(&ResolveCommandArgs{
 		Sentence:                  sentence,
 		EntityType:                EntityTypeCharacter,
@@ -120,7 +127,7 @@ func (m *Model) ProcessCharacterAction(dungeonInstanceID string, characterInstan
 		return nil, err
 	}
 
-	l.Info("Created action record ID >%s< SerialNumber >%d<", actionRec.ID, null.NullInt16ToInt16(actionRec.SerialNumber))
+	l.Info("Created action record ID >%s< SerialNumber >%d<", actionRec.ID, null.NullInt32ToInt32(actionRec.SerialNumber))
 
 	// TODO: (game) Maybe don't need to do this... Get the updated character record
 	civRec, err = m.GetCharacterInstanceViewRec(characterInstanceID)
@@ -235,10 +242,19 @@ func (m *Model) DecideMonsterAction(monsterInstanceID string) (*DecideMonsterAct
 		return nil, err
 	}
 
-	sentence, err := m.decideAction(&DeciderArgs{
+	// Choices are made from the dungeon instance random source so monster
+	// behaviour can be reproduced
+	r, err := m.dungeonInstanceRand(rec.DungeonInstanceID, rec.ID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance random source >%v<", err)
+		return nil, err
+	}
+
+	sentence, err := m.DecideAction(&DeciderArgs{
 		MonsterInstanceViewRec:    rec,
 		LocationInstanceRecordSet: locationInstanceRecordSet,
 		Memories:                  memories,
+		Rand:                      r,
 	})
 	if err != nil {
 		l.Warn("failed deciding action >%v<", err)
@@ -357,7 +373,7 @@ func (m *Model) ProcessMonsterAction(dungeonInstanceID string, monsterInstanceID
 		return nil, err
 	}
 
-	l.Info("Created action record ID >%s< SerialNumber >%d<", actionRec.ID, null.NullInt16ToInt16(actionRec.SerialNumber))
+	l.Info("Created action record ID >%s< SerialNumber >%d<", actionRec.ID, null.NullInt32ToInt32(actionRec.SerialNumber))
 
 	// Get the updated monster record
 	mivRec, err = m.GetMonsterInstanceViewRec(monsterInstanceID)
@@ -1088,7 +1104,7 @@ func (m *Model) GetActionRecsSincePreviousAction(rec *record.Action) ([]*record.
 	l.Info("Current action record ID >%s<", rec.ID)
 	l.Info("Current action record location instance ID >%s<", rec.LocationInstanceID)
 	l.Info("Current action record turn number >%d<", rec.TurnNumber)
-	l.Info("Current action record serial number >%d<", null.NullInt16ToInt16(rec.SerialNumber))
+	l.Info("Current action record serial number >%d<", null.NullInt32ToInt32(rec.SerialNumber))
 	l.Info("Current action record character instance ID >%s<", null.NullStringToString(rec.CharacterInstanceID))
 	l.Info("Current action record monster instance ID >%s<", null.NullStringToString(rec.MonsterInstanceID))
 
@@ -1130,13 +1146,13 @@ func (m *Model) GetActionRecsSincePreviousAction(rec *record.Action) ([]*record.
 	l.Info("Previous action record ID >%s<", prevActionRec.ID)
 	l.Info("Previous action record location instance ID >%s<", prevActionRec.LocationInstanceID)
 	l.Info("Previous action record turn number >%d<", prevActionRec.TurnNumber)
-	l.Info("Previous action record serial number >%d<", null.NullInt16ToInt16(prevActionRec.SerialNumber))
+	l.Info("Previous action record serial number >%d<", null.NullInt32ToInt32(prevActionRec.SerialNumber))
 	l.Info("Previous action record character instance ID >%s<", null.NullStringToString(prevActionRec.CharacterInstanceID))
 	l.Info("Previous action record monster instance ID >%s<", null.NullStringToString(prevActionRec.MonsterInstanceID))
 
 	// We add one to the previous action serial number and subtract one from the current action
 	// serial number so we exclude those specific records when looking between.
-	var adjustAmount int16 = 1
+	var adjustAmount int32 = 1
 	actionRecs, err = m.GetActionRecs(
 		&coresql.Options{
 			Params: []coresql.Param{
@@ -1146,8 +1162,8 @@ func (m *Model) GetActionRecsSincePreviousAction(rec *record.Action) ([]*record.
 				},
 				{
 					Col:  "serial_number",
-					Val:  fmt.Sprintf("%d", null.NullInt16ToInt16(prevActionRec.SerialNumber)+adjustAmount),
-					ValB: fmt.Sprintf("%d", null.NullInt16ToInt16(rec.SerialNumber)-adjustAmount),
+					Val:  fmt.Sprintf("%d", null.NullInt32ToInt32(prevActionRec.SerialNumber)+adjustAmount),
+					ValB: fmt.Sprintf("%d", null.NullInt32ToInt32(rec.SerialNumber)-adjustAmount),
 					Op:   coresql.OpBetween,
 				},
 			},
@@ -1164,6 +1180,77 @@ func (m *Model) GetActionRecsSincePreviousAction(rec *record.Action) ([]*record.
 	return actionRecs, nil
 }
 
+// GetCharacterInstanceActionRecsAfterSerialNumber returns actions with a serial number greater
+// than the provided serial number that either occurred at the character's current location,
+// were performed by the character, were system actions that targeted the character or were
+// system actions that moved a character to the character's current location, ordered by
+// serial number.
+func (m *Model) GetCharacterInstanceActionRecsAfterSerialNumber(rec *record.CharacterInstance, serialNumber int) ([]*record.Action, error) {
+	l := m.loggerWithFunctionContext("GetCharacterInstanceActionRecsAfterSerialNumber")
+
+	if rec == nil {
+		return nil, fmt.Errorf("missing character instance record argument, cannot get action records after serial number")
+	}
+
+	locationActionRecs, err := m.GetActionRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "location_instance_id",
+					Val: rec.LocationInstanceID,
+				},
+				{
+					Col: "serial_number",
+					Val: serialNumber,
+					Op:  coresql.OpGreaterThan,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting location instance action records >%v<", err)
+		return nil, err
+	}
+
+	characterActionRecs, err := m.GetActionRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "character_instance_id",
+					Val: rec.ID,
+				},
+				{
+					Col: "serial_number",
+					Val: serialNumber,
+					Op:  coresql.OpGreaterThan,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting character instance action records >%v<", err)
+		return nil, err
+	}
+
+	systemActionRecs, err := m.getCharacterInstanceSystemActionRecs(rec, serialNumber)
+	if err != nil {
+		l.Warn("failed getting character instance system action records >%v<", err)
+		return nil, err
+	}
+
+	// Characters arriving at the character's current location by system action,
+	// such as a teleport
+	arrivalActionRecs, err := m.getLocationInstanceArrivalSystemActionRecs([]any{rec.LocationInstanceID}, serialNumber, 0)
+	if err != nil {
+		l.Warn("failed getting location instance arrival system action records >%v<", err)
+		return nil, err
+	}
+
+	// Actions performed by or targeting the character at their current location
+	// are returned by more than one query
+	return mergeActionRecs(locationActionRecs, characterActionRecs, systemActionRecs, arrivalActionRecs), nil
+}
+
 // TODO: We need more than just the action records, we also need the characters and monsters
 // that were at the location the action occurred, as those details are valid memories that
 // need to be referenced when deciding what action to take..
@@ -1223,7 +1310,7 @@ func (m *Model) GetMonsterInstanceMemories(rec *record.MonsterInstanceView) ([]*
 
 	for len(maRecs) > 0 {
 		if len(oaRecs) > 0 {
-			if null.NullInt16ToInt16(maRecs[0].SerialNumber) > null.NullInt16ToInt16(oaRecs[0].SerialNumber) {
+			if null.NullInt32ToInt32(maRecs[0].SerialNumber) > null.NullInt32ToInt32(oaRecs[0].SerialNumber) {
 				memories = append(memories, &Memory{
 					ActionRec: maRecs[0],
 				})
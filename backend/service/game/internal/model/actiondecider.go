@@ -23,10 +23,53 @@ type DeciderArgs struct {
 	// has recently performed or action records that were performed where the
 	// character or monster was the target.
 	Memories []*Memory
+	// Rand is the random source choices between equally good actions are made
+	// from, see NewRand.
+	Rand *rand.Rand
 }
 
-func (m *Model) decideAction(args *DeciderArgs) (string, error) {
-	l := m.loggerWithFunctionContext("decideAction")
+// Decision is what a monster or character knows when deciding the sentence to
+// submit, independent of how it came to know it.
+type Decision struct {
+	// Opponents are at the current location, opponents that have attacked
+	// first in the order they are most worth attacking.
+	Opponents []DecisionOpponent
+	// Objects are the names of objects at the current location
+	Objects []string
+	// Directions are the directions out of the current location
+	Directions []string
+	// Looks are the directions recently looked from the current location, most
+	// recent first.
+	Looks []DecisionLook
+	// PreviousDirections lead back to the location most recently moved from
+	PreviousDirections []string
+	// Unlooked are the names of monsters, characters and objects at the current
+	// location not yet looked at, most interesting first.
+	Unlooked []string
+	// Rand is the random source choices between equally good actions are made
+	// from, see NewRand.
+	Rand *rand.Rand
+}
+
+// DecisionOpponent is a monster or character that may be attacked
+type DecisionOpponent struct {
+	Name          string
+	CurrentHealth int
+	// Attacker is true when the opponent has recently attacked
+	Attacker bool
+	// Prey is true when the opponent is attacked without having attacked
+	Prey bool
+}
+
+// DecisionLook is a direction looked and whether anything interesting was seen
+type DecisionLook struct {
+	Direction   string
+	Interesting bool
+}
+
+// DecideAction returns the sentence a monster or character decides to submit
+func (m *Model) DecideAction(args *DeciderArgs) (string, error) {
+	l := m.loggerWithFunctionContext("DecideAction")
 
 	l.Info("Deciding action args >%#v<", args)
 
@@ -36,37 +79,125 @@ func (m *Model) decideAction(args *DeciderArgs) (string, error) {
 		l.Info("Deciding action for character name >%s<", args.CharacterInstanceViewRec.Name)
 	}
 
+	d := newDecision(args)
+
+	l.Info("Opponents count >%d< looks count >%d< previous directions >%v<", len(d.Opponents), len(d.Looks), d.PreviousDirections)
+
+	sentence := Decide(d)
+
+	l.Info("Returning action >%s<", sentence)
+
+	return sentence, nil
+}
+
+// Decide returns the sentence decided from what a monster or character knows
+func Decide(d *Decision) string {
+
 	// Decider functions are typically prioritised as attack if anything is worth
 	// attacking, then grab anything thats worth grabbing, look into other rooms
 	// to find something interesting to move towards, and then move if there's
 	// somewhere worth moving to.
-	deciderFuncs := []func(args *DeciderArgs) (string, error){
-		m.decideActionAttack,
-		m.decideActionStash,
-		m.decideActionLook,
-		m.decideActionMove,
+	deciderFuncs := []func(d *Decision) string{
+		decideActionAttack,
+		decideActionStash,
+		decideActionLook,
+		decideActionMove,
 	}
 
-	var err error
-	var sentence string
-
 	for idx := range deciderFuncs {
-		sentence, err = deciderFuncs[idx](args)
-		if err != nil {
-			return "", err
-		}
+		sentence := deciderFuncs[idx](d)
 		if sentence != "" {
-			break
+			return sentence
 		}
 	}
 
-	return sentence, nil
+	return ""
+}
+
+// newDecision returns what a monster or character knows from its location and
+// memories
+func newDecision(args *DeciderArgs) *Decision {
+
+	lirs := args.LocationInstanceRecordSet
+
+	d := &Decision{
+		Directions: lirs.LocationDirections(),
+		Rand:       args.Rand,
+	}
+
+	// Monsters attack characters, prioritising anything that has attacked the
+	// monster
+	if args.MonsterInstanceViewRec != nil {
+		pidx := getPriorityAttackTargetIndex(args.Memories, args.MonsterInstanceViewRec.ID)
+		for _, rec := range lirs.CharacterInstanceViewRecs {
+			_, attacker := pidx[rec.ID]
+			d.Opponents = append(d.Opponents, DecisionOpponent{
+				Name:          rec.Name,
+				CurrentHealth: rec.CurrentHealth,
+				Attacker:      attacker,
+				Prey:          true,
+			})
+		}
+		for _, rec := range lirs.MonsterInstanceViewRecs {
+			_, attacker := pidx[rec.ID]
+			d.Opponents = append(d.Opponents, DecisionOpponent{
+				Name:          rec.Name,
+				CurrentHealth: rec.CurrentHealth,
+				Attacker:      attacker,
+			})
+		}
+	}
+
+	for _, rec := range lirs.ObjectInstanceViewRecs {
+		d.Objects = append(d.Objects, rec.Name)
+	}
+
+	for idx := range args.Memories {
+		memory := args.Memories[idx]
+
+		// The memory occurred at the current location, the memory was a look command and
+		// and the look command was looking a direction
+		if memory.ActionRec.LocationInstanceID == lirs.LocationInstanceViewRec.ID &&
+			memory.ActionRec.ResolvedCommand == record.ActionCommandLook &&
+			null.NullStringIsValid(memory.ActionRec.ResolvedTargetLocationDirection) {
+
+			// TODO: 15-implement-monster-goals: The concept of finding something interesting
+			// could be extended to anything that looks like it might help fulfil a goal. For
+			// now we'll simply assume characters are interesting.
+			d.Looks = append(d.Looks, DecisionLook{
+				Direction:   null.NullStringToString(memory.ActionRec.ResolvedTargetLocationDirection),
+				Interesting: len(memory.ActionCharacterRecs) > 0,
+			})
+		}
+	}
+
+	// Always prefer to not move back the direction we just came from
+	previousLocationInstanceID := getPreviousLocationInstanceID(args)
+	if previousLocationInstanceID != "" {
+		for _, direction := range d.Directions {
+			if lirs.LocationInstanceViewRec.DirectionLocationInstanceID(direction).String == previousLocationInstanceID {
+				d.PreviousDirections = append(d.PreviousDirections, direction)
+			}
+		}
+	}
+
+	for _, name := range []string{
+		getPriorityLookMonster(args),
+		getPriorityLookCharacter(args),
+		getPriorityLookObject(args.Memories, lirs),
+	} {
+		if name != "" {
+			d.Unlooked = append(d.Unlooked, name)
+		}
+	}
+
+	return d
 }
 
 // getPriorityAttackTargetIndex takes a list of action records and a monster
 // or character target instance ID and returns a list of monster or character
 // instance IDs that have attacked the provided monster or character instance ID.
-func (m *Model) getPriorityAttackTargetIndex(memories []*Memory, targetInstanceID string) (map[string]struct{}, error) {
+func getPriorityAttackTargetIndex(memories []*Memory, targetInstanceID string) map[string]struct{} {
 
 	iidx := map[string]struct{}{}
 
@@ -86,161 +217,74 @@ func (m *Model) getPriorityAttackTargetIndex(memories []*Memory, targetInstanceI
 		}
 	}
 
-	return iidx, nil
+	return iidx
 }
 
-func (m *Model) decideActionAttack(args *DeciderArgs) (string, error) {
-	l := m.loggerWithFunctionContext("decideActionAttack")
+func decideActionAttack(d *Decision) string {
 
-	lirs := args.LocationInstanceRecordSet
-
-	targetName := ""
-	if args.MonsterInstanceViewRec != nil {
-		l.Info("Memories count >%d<", len(args.Memories))
-
-		// Prioritise attacking anything that has attacked the monster
-		pidx, err := m.getPriorityAttackTargetIndex(args.Memories, args.MonsterInstanceViewRec.ID)
-		if err != nil {
-			return "", err
+	// Highest priority are opponents that have attacked, no point attacking
+	// an already dead opponent
+	for idx := range d.Opponents {
+		if d.Opponents[idx].Attacker && d.Opponents[idx].CurrentHealth > 0 {
+			return fmt.Sprintf("attack %s", d.Opponents[idx].Name)
 		}
+	}
 
-		l.Info("CharacterInstanceViewRecs count >%d<", len(lirs.CharacterInstanceViewRecs))
-		l.Info("MonsterInstanceViewRecs count >%d<", len(lirs.MonsterInstanceViewRecs))
-		l.Info("PriorityIndex count >%d<", len(pidx))
-
-		// Highest priority are characters that have attacked the monster
-		if len(pidx) != 0 && len(lirs.CharacterInstanceViewRecs) != 0 {
-			for idx := range lirs.CharacterInstanceViewRecs {
-
-				// No point attacking an already dead character
-				if lirs.CharacterInstanceViewRecs[idx].CurrentHealth <= 0 {
-					continue
-				}
-
-				if _, ok := pidx[lirs.CharacterInstanceViewRecs[idx].ID]; ok {
-					targetName = lirs.CharacterInstanceViewRecs[idx].Name
-					l.Warn("Choosing priority character instance >%s<", targetName)
-					break
-				}
-			}
-		}
-
-		// Second highest priority are other monster that have attacked the monster
-		if targetName == "" && len(pidx) != 0 && len(lirs.MonsterInstanceViewRecs) != 0 {
-			for idx := range lirs.MonsterInstanceViewRecs {
-
-				// No point attacking an already dead monster
-				if lirs.MonsterInstanceViewRecs[idx].CurrentHealth <= 0 {
-					continue
-				}
-
-				if _, ok := pidx[lirs.MonsterInstanceViewRecs[idx].ID]; ok {
-					targetName = lirs.MonsterInstanceViewRecs[idx].Name
-					l.Warn("Choosing priority monster instance >%s<", targetName)
-					break
-				}
-			}
-		}
-
-		// Third priority is simply any characters present in the room
-		if targetName == "" && len(lirs.CharacterInstanceViewRecs) != 0 {
-
-			// No point randomly attacking a dead person!
-			civRecs := []record.CharacterInstanceView{}
-			for idx := range lirs.CharacterInstanceViewRecs {
-				if lirs.CharacterInstanceViewRecs[idx].CurrentHealth > 0 {
-					civRecs = append(civRecs, *lirs.CharacterInstanceViewRecs[idx])
-				}
-			}
-
-			if len(civRecs) > 0 {
-				rIdx := rand.Intn(len(civRecs))
-				targetName = civRecs[rIdx].Name
-				l.Warn("Choosing random character instance >%s<", targetName)
-			}
+	// Then any prey present in the room, no point randomly attacking a dead
+	// person!
+	names := []string{}
+	for idx := range d.Opponents {
+		if d.Opponents[idx].Prey && d.Opponents[idx].CurrentHealth > 0 {
+			names = append(names, d.Opponents[idx].Name)
 		}
 	}
 
-	action := ""
-	if targetName != "" {
-		action = fmt.Sprintf("attack %s", targetName)
+	if len(names) > 0 {
+		return fmt.Sprintf("attack %s", names[d.Rand.Intn(len(names))])
 	}
-	l.Info("Returning action >%s<", action)
 
-	return action, nil
+	return ""
 }
 
-func (m *Model) decideActionStash(args *DeciderArgs) (string, error) {
-	l := m.loggerWithFunctionContext("decideActionStash")
-
-	lirs := args.LocationInstanceRecordSet
+func decideActionStash(d *Decision) string {
 
-	action := ""
-	if len(lirs.ObjectInstanceViewRecs) != 0 {
-		// TODO: 16-implement-intelligent-stashing
-		rIdx := rand.Intn(len(lirs.ObjectInstanceViewRecs))
-		action = fmt.Sprintf("stash %s", lirs.ObjectInstanceViewRecs[rIdx].Name)
+	// TODO: 16-implement-intelligent-stashing: An object is chosen so the random
+	// source is used as it will be once objects are stashed.
+	if len(d.Objects) != 0 {
+		d.Rand.Intn(len(d.Objects))
 	}
 
-	// TODO: 16-implement-intelligent-stashing
-	l.Info("Might have returned action >%s<", action)
-
-	return "", nil
+	return ""
 }
 
-// getPriorityLookDirection
-func (m *Model) getPriorityLookDirection(memories []*Memory, lirs *record.LocationInstanceViewRecordSet) (string, error) {
+// getPriorityLookDirection returns the first direction not recently looked
+// unless something interesting has already been seen
+func getPriorityLookDirection(d *Decision) string {
 
 	// Check if we've already seen anything interesting while collecting an
 	// index of all directions we've recently looked.
-	sawSomethingInteresting := false
 	lookedIndex := map[string]struct{}{}
-
-	for idx := range memories {
-		memory := memories[idx]
-
-		// The memory occurred at the current location, the memory was a look command and
-		// and the look command was looking a direction
-		if memory.ActionRec.LocationInstanceID == lirs.LocationInstanceViewRec.ID &&
-			memory.ActionRec.ResolvedCommand == record.ActionCommandLook &&
-			null.NullStringIsValid(memory.ActionRec.ResolvedTargetLocationDirection) {
-
-			// Register that we looked this direction
-			lookedIndex[null.NullStringToString(memory.ActionRec.ResolvedTargetLocationDirection)] = struct{}{}
-
-			// TODO: 15-implement-monster-goals: The concept of finding something interesting
-			// could be extended to anything that looks like it might help fulfil a goal. For
-			// now we'll simply assume characters are interesting.
-
-			// There were characters this direction so that will be interesting enough
-			// to influence the movement phase.
-			if len(memory.ActionCharacterRecs) > 0 {
-				sawSomethingInteresting = true
-			}
+	for _, look := range d.Looks {
+		if look.Interesting {
+			return ""
 		}
-	}
-
-	if sawSomethingInteresting {
-		return "", nil
+		lookedIndex[look.Direction] = struct{}{}
 	}
 
 	// Return the first direction they haven't looked
-	directions := lirs.LocationDirections()
-	unlookedDirection := ""
-	for _, direction := range directions {
+	for _, direction := range d.Directions {
 		if _, ok := lookedIndex[direction]; ok {
 			continue
 		}
-		unlookedDirection = direction
-		break
+		return direction
 	}
 
-	return unlookedDirection, nil
+	return ""
 }
 
 // getPriorityLookObject chooses an object in the current room that has not been
 // looked at which might influence a future decision.
-func (m *Model) getPriorityLookObject(memories []*Memory, lirs *record.LocationInstanceViewRecordSet) (string, error) {
+func getPriorityLookObject(memories []*Memory, lirs *record.LocationInstanceViewRecordSet) string {
 
 	objectName := ""
 	lookedIndex := map[string]struct{}{}
@@ -265,10 +309,10 @@ func (m *Model) getPriorityLookObject(memories []*Memory, lirs *record.LocationI
 		objectName = lirs.ObjectInstanceViewRecs[idx].Name
 	}
 
-	return objectName, nil
+	return objectName
 }
 
-func (m *Model) getPriorityLookMonster(args *DeciderArgs) (string, error) {
+func getPriorityLookMonster(args *DeciderArgs) string {
 
 	memories := args.Memories
 	lirs := args.LocationInstanceRecordSet
@@ -299,10 +343,10 @@ func (m *Model) getPriorityLookMonster(args *DeciderArgs) (string, error) {
 		monsterName = lirs.MonsterInstanceViewRecs[idx].Name
 	}
 
-	return monsterName, nil
+	return monsterName
 }
 
-func (m *Model) getPriorityLookCharacter(args *DeciderArgs) (string, error) {
+func getPriorityLookCharacter(args *DeciderArgs) string {
 
 	memories := args.Memories
 	lirs := args.LocationInstanceRecordSet
@@ -333,68 +377,25 @@ func (m *Model) getPriorityLookCharacter(args *DeciderArgs) (string, error) {
 		characterName = lirs.CharacterInstanceViewRecs[idx].Name
 	}
 
-	return characterName, nil
+	return characterName
 }
 
-func (m *Model) decideActionLook(args *DeciderArgs) (string, error) {
-	l := m.loggerWithFunctionContext("decideActionLook")
-
-	lirs := args.LocationInstanceRecordSet
-
-	action := ""
+func decideActionLook(d *Decision) string {
 
 	// Prioritise looking for interesting things in other rooms
-	direction, err := m.getPriorityLookDirection(args.Memories, lirs)
-	if err != nil {
-		l.Warn("failed getting priority look direction >%v<", err)
-		return "", err
-	}
-
+	direction := getPriorityLookDirection(d)
 	if direction != "" {
-		action = fmt.Sprintf("look %s", direction)
-		return action, nil
+		return fmt.Sprintf("look %s", direction)
 	}
 
 	// TODO: 15-implement-monster-goals: Unless a monster has a goal
 	// to achieve there is little point at looking at things to get
 	// more information.
-	if FeatureMonsterGoalsImplemented {
-
-		monsterName, err := m.getPriorityLookMonster(args)
-		if err != nil {
-			l.Warn("failed getting priority look monster >%v<", err)
-			return "", err
-		}
-
-		if monsterName != "" {
-			action = fmt.Sprintf("look %s", monsterName)
-			return action, nil
-		}
-
-		characterName, err := m.getPriorityLookCharacter(args)
-		if err != nil {
-			l.Warn("failed getting priority look character >%v<", err)
-			return "", err
-		}
-
-		if characterName != "" {
-			action = fmt.Sprintf("look %s", characterName)
-			return action, nil
-		}
-
-		objectName, err := m.getPriorityLookObject(args.Memories, lirs)
-		if err != nil {
-			l.Warn("failed getting priority look objectName >%v<", err)
-			return "", err
-		}
-
-		if objectName != "" {
-			action = fmt.Sprintf("look %s", objectName)
-			return action, nil
-		}
+	if FeatureMonsterGoalsImplemented && len(d.Unlooked) != 0 {
+		return fmt.Sprintf("look %s", d.Unlooked[0])
 	}
 
-	return "", nil
+	return ""
 }
 
 func getPreviousLocationInstanceID(args *DeciderArgs) string {
@@ -418,82 +419,53 @@ func getPreviousLocationInstanceID(args *DeciderArgs) string {
 	return previousLocationInstanceID
 }
 
-func (m *Model) getPriorityMoveDirections(memories []*Memory, lirs *record.LocationInstanceViewRecordSet) ([]string, error) {
+// getPriorityMoveDirections returns the directions something interesting was
+// seen
+func getPriorityMoveDirections(d *Decision) []string {
 
-	// Check if we've already seen anything interesting while collecting an
-	// index of all directions we've recently looked.
 	directions := []string{}
-
-	for idx := range memories {
-		memory := memories[idx]
-
-		// The memory occurred at the current location, the memory was a look command and
-		// and the look command was looking a direction
-		if memory.ActionRec.LocationInstanceID == lirs.LocationInstanceViewRec.ID &&
-			memory.ActionRec.ResolvedCommand == record.ActionCommandLook &&
-			null.NullStringIsValid(memory.ActionRec.ResolvedTargetLocationDirection) {
-
-			// TODO: 15-implement-monster-goals: The concept of finding something interesting
-			// could be extended to anything that looks like it might help fulfil a goal. For
-			// now we'll simply assume characters are interesting.
-
-			// There were characters in this direction so we'll add this direction to the list
-			// of possible directions we could move
-			if len(memory.ActionCharacterRecs) > 0 {
-				directions = append(directions, null.NullStringToString((memory.ActionRec.ResolvedTargetLocationDirection)))
-			}
+	for _, look := range d.Looks {
+		// There were characters in this direction so we'll add this direction to the list
+		// of possible directions we could move
+		if look.Interesting {
+			directions = append(directions, look.Direction)
 		}
 	}
 
-	return directions, nil
+	return directions
 }
 
-func (m *Model) decideActionMove(args *DeciderArgs) (string, error) {
-	l := m.loggerWithFunctionContext("decideActionAttack")
-
-	action := ""
-	lirs := args.LocationInstanceRecordSet
+func decideActionMove(d *Decision) string {
 
 	// Move an interesting direction if possible
-	interestingDirections, err := m.getPriorityMoveDirections(args.Memories, args.LocationInstanceRecordSet)
-	if err != nil {
-		l.Warn("failed to get priority move directions >%v<", err)
-		return "", err
-	}
-
+	interestingDirections := getPriorityMoveDirections(d)
 	if len(interestingDirections) > 0 {
-		rIdx := rand.Intn(len(interestingDirections))
-		action = fmt.Sprintf("move %s", interestingDirections[rIdx])
+		rIdx := d.Rand.Intn(len(interestingDirections))
+		return fmt.Sprintf("move %s", interestingDirections[rIdx])
 	}
 
-	// Otherwise move a direction we have not just come from
-	if action == "" {
-		directions := lirs.LocationDirections()
-
-		// Always prefer to not move back the direction we just came from by excluding that
-		// direction when there are multiple directions to choose from.
-		recentLocationInstanceID := getPreviousLocationInstanceID(args)
+	// Otherwise move a direction we have not just come from, excluding the
+	// direction back when there are multiple directions to choose from.
+	previousIndex := map[string]struct{}{}
+	for _, direction := range d.PreviousDirections {
+		previousIndex[direction] = struct{}{}
+	}
 
-		var availableDirections []string
-		if len(directions) > 1 && recentLocationInstanceID != "" {
-			for idx := range directions {
-				if directions[idx] != recentLocationInstanceID {
-					availableDirections = append(availableDirections, directions[idx])
-				}
+	var availableDirections []string
+	if len(d.Directions) > 1 && len(previousIndex) != 0 {
+		for _, direction := range d.Directions {
+			if _, ok := previousIndex[direction]; !ok {
+				availableDirections = append(availableDirections, direction)
 			}
-		} else {
-			availableDirections = directions
-		}
-
-		l.Info("Have possible directions >%v<", availableDirections)
-
-		if len(availableDirections) != 0 {
-			rIdx := rand.Intn(len(availableDirections))
-			action = fmt.Sprintf("move %s", availableDirections[rIdx])
 		}
+	} else {
+		availableDirections = d.Directions
 	}
 
-	l.Info("Returning action >%s<", action)
+	if len(availableDirections) != 0 {
+		rIdx := d.Rand.Intn(len(availableDirections))
+		return fmt.Sprintf("move %s", availableDirections[rIdx])
+	}
 
-	return action, nil
+	return ""
 }
@@ -0,0 +1,241 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// visibleActionPageSize is the number of location action records fetched at a
+// time when finding the action records visible to a character
+const visibleActionPageSize int = 100
+
+// characterLocationSpan is a range of action serial numbers during which a
+// character was at a location, from is exclusive and to is inclusive with
+// a to value of zero meaning the span has not ended.
+type characterLocationSpan struct {
+	locationInstanceID string
+	from               int
+	to                 int
+}
+
+func (s characterLocationSpan) contains(serialNumber int) bool {
+	return serialNumber > s.from && (s.to == 0 || serialNumber <= s.to)
+}
+
+// visible returns whether the action occurred at the location during the span,
+// or was a system action that moved a character to the location during the span
+func (s characterLocationSpan) visible(rec *record.Action, serialNumber int) bool {
+	if !s.contains(serialNumber) {
+		return false
+	}
+	if rec.LocationInstanceID == s.locationInstanceID {
+		return true
+	}
+	return isArrivalSystemActionRec(rec) && rec.ResolvedTargetLocationInstanceID.String == s.locationInstanceID
+}
+
+// GetCharacterInstanceVisibleActionRecs returns up to limit action records with a serial
+// number greater than the provided serial number that occurred at a location while the
+// character was there, ordered by serial number. A limit of zero returns all records.
+func (m *Model) GetCharacterInstanceVisibleActionRecs(rec *record.CharacterInstance, serialNumber int, limit int) ([]*record.Action, error) {
+	l := m.loggerWithFunctionContext("GetCharacterInstanceVisibleActionRecs")
+
+	if rec == nil {
+		return nil, fmt.Errorf("missing character instance record argument, cannot get visible action records")
+	}
+
+	// The character's own actions determine where the character was between
+	// each of their actions
+	characterActionRecs, err := m.GetActionRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "character_instance_id",
+					Val: rec.ID,
+				},
+				{
+					Col: "serial_number",
+					Val: serialNumber,
+					Op:  coresql.OpGreaterThan,
+				},
+			},
+			OrderBy: []coresql.OrderBy{
+				{
+					Col:       "serial_number",
+					Direction: coresql.OrderDirectionASC,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting character instance action records >%v<", err)
+		return nil, err
+	}
+
+	// System actions that targeted the character, such as a teleport, also
+	// change where the character was
+	systemActionRecs, err := m.getCharacterInstanceSystemActionRecs(rec, serialNumber)
+	if err != nil {
+		l.Warn("failed getting character instance system action records >%v<", err)
+		return nil, err
+	}
+
+	if len(systemActionRecs) > 0 {
+		characterActionRecs = append(characterActionRecs, systemActionRecs...)
+		sort.Slice(characterActionRecs, func(i, j int) bool {
+			return null.NullInt32ToInt32(characterActionRecs[i].SerialNumber) < null.NullInt32ToInt32(characterActionRecs[j].SerialNumber)
+		})
+	}
+
+	spans := characterLocationSpans(rec, serialNumber, characterActionRecs)
+
+	locationInstanceIDs := []any{}
+	seen := map[string]struct{}{}
+	for _, span := range spans {
+		if _, ok := seen[span.locationInstanceID]; ok {
+			continue
+		}
+		seen[span.locationInstanceID] = struct{}{}
+		locationInstanceIDs = append(locationInstanceIDs, span.locationInstanceID)
+	}
+
+	// Location and arrival actions are fetched a page at a time as actions that
+	// occurred while the character was elsewhere are filtered out
+	pageSize := visibleActionPageSize
+	if limit > pageSize {
+		pageSize = limit
+	}
+
+	actionRecs := []*record.Action{}
+	afterSerialNumber := serialNumber
+
+	for {
+		locationActionRecs, err := m.GetActionRecs(
+			&coresql.Options{
+				Params: []coresql.Param{
+					{
+						Col:   "location_instance_id",
+						Array: locationInstanceIDs,
+					},
+					{
+						Col: "serial_number",
+						Val: afterSerialNumber,
+						Op:  coresql.OpGreaterThan,
+					},
+				},
+				OrderBy: []coresql.OrderBy{
+					{
+						Col:       "serial_number",
+						Direction: coresql.OrderDirectionASC,
+					},
+				},
+				Limit: pageSize,
+			},
+		)
+		if err != nil {
+			l.Warn("failed getting location instance action records >%v<", err)
+			return nil, err
+		}
+
+		// Characters arriving by system action, such as a teleport, are seen
+		// at the location they arrived at
+		arrivalActionRecs, err := m.getLocationInstanceArrivalSystemActionRecs(locationInstanceIDs, afterSerialNumber, pageSize)
+		if err != nil {
+			l.Warn("failed getting location instance arrival system action records >%v<", err)
+			return nil, err
+		}
+
+		// Both pages are complete up to the last serial number of a full page
+		completeSerialNumber := 0
+		for _, recs := range [][]*record.Action{locationActionRecs, arrivalActionRecs} {
+			if len(recs) < pageSize {
+				continue
+			}
+			lastSerialNumber := int(null.NullInt32ToInt32(recs[len(recs)-1].SerialNumber))
+			if completeSerialNumber == 0 || lastSerialNumber < completeSerialNumber {
+				completeSerialNumber = lastSerialNumber
+			}
+		}
+
+		for _, actionRec := range mergeActionRecs(locationActionRecs, arrivalActionRecs) {
+			actionSerialNumber := int(null.NullInt32ToInt32(actionRec.SerialNumber))
+			if completeSerialNumber != 0 && actionSerialNumber > completeSerialNumber {
+				break
+			}
+			for _, span := range spans {
+				if span.visible(actionRec, actionSerialNumber) {
+					actionRecs = append(actionRecs, actionRec)
+					break
+				}
+			}
+			if limit > 0 && len(actionRecs) == limit {
+				break
+			}
+			afterSerialNumber = actionSerialNumber
+		}
+
+		if completeSerialNumber == 0 || (limit > 0 && len(actionRecs) == limit) {
+			break
+		}
+	}
+
+	l.Info("Returning >%d< visible action records after serial number >%d<", len(actionRecs), serialNumber)
+
+	return actionRecs, nil
+}
+
+// characterLocationSpans returns the locations a character was at after the provided
+// serial number based on the character's own actions, and system actions that targeted
+// the character, ordered by serial number.
+func characterLocationSpans(rec *record.CharacterInstance, serialNumber int, characterActionRecs []*record.Action) []characterLocationSpan {
+
+	spans := []characterLocationSpan{}
+	from := serialNumber
+
+	for _, actionRec := range characterActionRecs {
+		actionSerialNumber := int(null.NullInt32ToInt32(actionRec.SerialNumber))
+
+		// The character was at the location of their action up to and
+		// including the action
+		spans = append(spans, characterLocationSpan{
+			locationInstanceID: actionRec.LocationInstanceID,
+			from:               from,
+			to:                 actionSerialNumber,
+		})
+		from = actionSerialNumber
+	}
+
+	spans = append(spans, characterLocationSpan{
+		locationInstanceID: rec.LocationInstanceID,
+		from:               from,
+	})
+
+	return spans
+}
+
+// mergeActionRecs merges action records ordered by serial number into a single
+// list of action records ordered by serial number without duplicates
+func mergeActionRecs(actionRecs ...[]*record.Action) []*record.Action {
+
+	merged := []*record.Action{}
+	seen := map[string]struct{}{}
+	for _, recs := range actionRecs {
+		for _, rec := range recs {
+			if _, ok := seen[rec.ID]; ok {
+				continue
+			}
+			seen[rec.ID] = struct{}{}
+			merged = append(merged, rec)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return null.NullInt32ToInt32(merged[i].SerialNumber) < null.NullInt32ToInt32(merged[j].SerialNumber)
+	})
+
+	return merged
+}
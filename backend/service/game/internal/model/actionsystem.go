@@ -0,0 +1,173 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+type createSystemActionArgs struct {
+	SystemActor               string
+	Command                   string
+	LocationInstanceID        string
+	TargetCharacterInstanceID string
+	TargetMonsterInstanceID   string
+	TargetObjectInstanceID    string
+	TargetLocationInstanceID  string
+}
+
+// createSystemAction records an intervention made by a game master as an action
+// at a location so the intervention appears in the action feed of characters at
+// the location. System actions are recorded at the current dungeon instance turn
+// and are not subject to turn resolution.
+func (m *Model) createSystemAction(args *createSystemActionArgs) (*record.ActionRecordSet, error) {
+	l := m.loggerWithFunctionContext("createSystemAction")
+
+	systemActor := strings.TrimSpace(args.SystemActor)
+	if systemActor == "" {
+		return nil, fmt.Errorf("missing system actor, cannot create system action")
+	}
+
+	locationInstanceRec, err := m.GetLocationInstanceRec(args.LocationInstanceID, nil)
+	if err != nil {
+		l.Warn("failed getting location instance record >%v<", err)
+		return nil, err
+	}
+
+	if locationInstanceRec == nil {
+		return nil, fmt.Errorf("location instance ID >%s< does not exist, cannot create system action", args.LocationInstanceID)
+	}
+
+	turnNumber, err := m.GetDungeonInstanceTurnNumber(locationInstanceRec.DungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance turn number >%v<", err)
+		return nil, err
+	}
+
+	actionRec := &record.Action{
+		DungeonInstanceID:                 locationInstanceRec.DungeonInstanceID,
+		LocationInstanceID:                locationInstanceRec.ID,
+		SystemActor:                       null.NullStringFromString(systemActor),
+		TurnNumber:                        turnNumber,
+		ResolvedCommand:                   args.Command,
+		ResolvedTargetCharacterInstanceID: null.NullStringFromString(args.TargetCharacterInstanceID),
+		ResolvedTargetMonsterInstanceID:   null.NullStringFromString(args.TargetMonsterInstanceID),
+		ResolvedTargetObjectInstanceID:    null.NullStringFromString(args.TargetObjectInstanceID),
+		ResolvedTargetLocationInstanceID:  null.NullStringFromString(args.TargetLocationInstanceID),
+	}
+
+	err = m.CreateActionRec(actionRec)
+	if err != nil {
+		l.Warn("failed creating system action record >%v<", err)
+		return nil, err
+	}
+
+	l.Info("Created system actor >%s< action record ID >%s< command >%s< SerialNumber >%d<", systemActor, actionRec.ID, actionRec.ResolvedCommand, null.NullInt32ToInt32(actionRec.SerialNumber))
+
+	actionRecordSet, err := m.createActionRecordSetRecords(&record.ActionRecordSet{
+		ActionRec: actionRec,
+	})
+	if err != nil {
+		l.Warn("failed creating system action record set records >%v<", err)
+		return nil, err
+	}
+
+	return actionRecordSet, nil
+}
+
+// getCharacterInstanceSystemActionRecs returns system actions that targeted the character
+// with a serial number greater than the provided serial number, ordered by serial number
+func (m *Model) getCharacterInstanceSystemActionRecs(rec *record.CharacterInstance, serialNumber int) ([]*record.Action, error) {
+	l := m.loggerWithFunctionContext("getCharacterInstanceSystemActionRecs")
+
+	recs, err := m.GetActionRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldActionResolvedTargetCharacterInstanceID,
+					Val: rec.ID,
+				},
+				{
+					Col: record.FieldActionSystemActor,
+					Op:  coresql.OpIsNotNull,
+				},
+				{
+					Col: "serial_number",
+					Val: serialNumber,
+					Op:  coresql.OpGreaterThan,
+				},
+			},
+			OrderBy: []coresql.OrderBy{
+				{
+					Col:       "serial_number",
+					Direction: coresql.OrderDirectionASC,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting character instance system action records >%v<", err)
+		return nil, err
+	}
+
+	return recs, nil
+}
+
+// isArrivalSystemActionRec returns whether the action is a system action that moved a
+// character to another location
+func isArrivalSystemActionRec(rec *record.Action) bool {
+	return rec.SystemActor.Valid && rec.ResolvedCommand == record.ActionCommandTeleport && rec.ResolvedTargetLocationInstanceID.Valid
+}
+
+// getLocationInstanceArrivalSystemActionRecs returns system actions that moved a character
+// to one of the location instances, such as a teleport, with a serial number greater than
+// the provided serial number, ordered by serial number. Arrival system actions are recorded
+// at the location the character left so they are not found by location. A limit of zero
+// returns all records.
+func (m *Model) getLocationInstanceArrivalSystemActionRecs(locationInstanceIDs []any, serialNumber int, limit int) ([]*record.Action, error) {
+	l := m.loggerWithFunctionContext("getLocationInstanceArrivalSystemActionRecs")
+
+	if len(locationInstanceIDs) == 0 {
+		return []*record.Action{}, nil
+	}
+
+	recs, err := m.GetActionRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col:   record.FieldActionResolvedTargetLocationInstanceID,
+					Array: locationInstanceIDs,
+				},
+				{
+					Col: record.FieldActionResolvedCommand,
+					Val: record.ActionCommandTeleport,
+				},
+				{
+					Col: record.FieldActionSystemActor,
+					Op:  coresql.OpIsNotNull,
+				},
+				{
+					Col: "serial_number",
+					Val: serialNumber,
+					Op:  coresql.OpGreaterThan,
+				},
+			},
+			OrderBy: []coresql.OrderBy{
+				{
+					Col:       "serial_number",
+					Direction: coresql.OrderDirectionASC,
+				},
+			},
+			Limit: limit,
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting location instance arrival system action records >%v<", err)
+		return nil, err
+	}
+
+	return recs, nil
+}
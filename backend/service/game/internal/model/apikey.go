@@ -0,0 +1,161 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	// apiKeyPrefix identifies the key as a game API key
+	apiKeyPrefix string = "gmk"
+	// apiKeyIDLength is the number of random bytes that identify a key in listings and logs
+	apiKeyIDLength int = 4
+	// apiKeySecretLength is the number of random bytes of secret in a key
+	apiKeySecretLength int = 32
+)
+
+type IssueAPIKeyArgs struct {
+	Owner     string
+	Scopes    []string
+	AccountID string
+	ExpiresAt time.Time
+}
+
+// IssueAPIKey creates an API key record and returns the key, the key is not stored
+// and cannot be recovered once returned.
+func (m *Model) IssueAPIKey(args *IssueAPIKeyArgs) (string, *record.APIKey, error) {
+	l := m.loggerWithFunctionContext("IssueAPIKey")
+
+	if args.AccountID != "" {
+		accountRec, err := m.GetAccountRec(args.AccountID, nil)
+		if err != nil {
+			l.Warn("failed getting account record >%v<", err)
+			return "", nil, err
+		}
+		if accountRec == nil {
+			return "", nil, fmt.Errorf("account ID >%s< does not exist, cannot issue API key", args.AccountID)
+		}
+	}
+
+	keyID := make([]byte, apiKeyIDLength)
+	if _, err := rand.Read(keyID); err != nil {
+		return "", nil, err
+	}
+
+	secret := make([]byte, apiKeySecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, err
+	}
+
+	keyPrefix := fmt.Sprintf("%s_%s", apiKeyPrefix, hex.EncodeToString(keyID))
+	key := fmt.Sprintf("%s_%s", keyPrefix, base64.RawURLEncoding.EncodeToString(secret))
+
+	rec := &record.APIKey{
+		Owner:     strings.TrimSpace(args.Owner),
+		KeyPrefix: keyPrefix,
+		KeyHash:   hashAPIKey(key),
+		Scopes:    args.Scopes,
+		AccountID: null.NullStringFromString(args.AccountID),
+	}
+	if !args.ExpiresAt.IsZero() {
+		rec.ExpiresAt = null.NullTimeFromTime(args.ExpiresAt.UTC())
+	}
+
+	err := m.CreateAPIKeyRec(rec)
+	if err != nil {
+		l.Warn("failed creating API key record >%v<", err)
+		return "", nil, err
+	}
+
+	l.Info("Issued API key ID >%s< prefix >%s< owner >%s< scopes >%v<", rec.ID, rec.KeyPrefix, rec.Owner, rec.Scopes)
+
+	return key, rec, nil
+}
+
+// RevokeAPIKey revokes an API key so it no longer authenticates requests
+func (m *Model) RevokeAPIKey(recID string) (*record.APIKey, error) {
+	l := m.loggerWithFunctionContext("RevokeAPIKey")
+
+	rec, err := m.GetAPIKeyRec(recID, coresql.ForUpdate)
+	if err != nil {
+		l.Warn("failed getting API key record >%v<", err)
+		return nil, err
+	}
+
+	if rec == nil {
+		return nil, fmt.Errorf("API key ID >%s< does not exist, cannot revoke API key", recID)
+	}
+
+	if rec.RevokedAt.Valid {
+		l.Info("API key ID >%s< already revoked at >%s<", rec.ID, rec.RevokedAt.Time)
+		return rec, nil
+	}
+
+	rec.RevokedAt = null.NullTimeFromTime(time.Now().UTC())
+
+	err = m.UpdateAPIKeyRec(rec)
+	if err != nil {
+		l.Warn("failed updating API key record >%v<", err)
+		return nil, err
+	}
+
+	l.Info("Revoked API key ID >%s< prefix >%s< owner >%s<", rec.ID, rec.KeyPrefix, rec.Owner)
+
+	return rec, nil
+}
+
+// AuthenticateAPIKey returns the API key record for the key when the key has not
+// been revoked and has not expired, otherwise nil
+func (m *Model) AuthenticateAPIKey(key string) (*record.APIKey, error) {
+	l := m.loggerWithFunctionContext("AuthenticateAPIKey")
+
+	recs, err := m.GetAPIKeyRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldAPIKeyKeyHash,
+					Val: hashAPIKey(key),
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting API key records >%v<", err)
+		return nil, err
+	}
+
+	if len(recs) != 1 {
+		l.Info("API key not found")
+		return nil, nil
+	}
+
+	rec := recs[0]
+
+	if rec.RevokedAt.Valid {
+		l.Info("API key ID >%s< was revoked at >%s<", rec.ID, rec.RevokedAt.Time)
+		return nil, nil
+	}
+
+	if rec.ExpiresAt.Valid && !rec.ExpiresAt.Time.After(time.Now()) {
+		l.Info("API key ID >%s< expired at >%s<", rec.ID, rec.ExpiresAt.Time)
+		return nil, nil
+	}
+
+	return rec, nil
+}
+
+// hashAPIKey returns the hex encoded SHA-256 hash of a key. Keys contain enough
+// random bytes that a fast unsalted hash is sufficient and allows lookup by hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
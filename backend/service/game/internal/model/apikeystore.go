@@ -0,0 +1,120 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// GetAPIKeyRecs -
+func (m *Model) GetAPIKeyRecs(opts *coresql.Options) ([]*record.APIKey, error) {
+
+	l := m.loggerWithFunctionContext("GetAPIKeyRecs")
+
+	l.Debug("Getting API key records opts >%#v<", opts)
+
+	r := m.APIKeyRepository()
+
+	return r.GetMany(opts)
+}
+
+// GetAPIKeyRec -
+func (m *Model) GetAPIKeyRec(recID string, lock *coresql.Lock) (*record.APIKey, error) {
+
+	l := m.loggerWithFunctionContext("GetAPIKeyRec")
+
+	l.Debug("Getting API key rec ID >%s<", recID)
+
+	r := m.APIKeyRepository()
+
+	if !m.IsUUID(recID) {
+		return nil, fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	rec, err := r.GetOne(recID, lock)
+	if err == sql.ErrNoRows {
+		l.Warn("No record found ID >%s<", recID)
+		return nil, nil
+	}
+
+	return rec, err
+}
+
+// CreateAPIKeyRec -
+func (m *Model) CreateAPIKeyRec(rec *record.APIKey) error {
+	l := m.loggerWithFunctionContext("CreateAPIKeyRec")
+
+	l.Debug("Creating API key record >%#v<", rec)
+
+	r := m.APIKeyRepository()
+
+	err := m.validateAPIKeyRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.CreateOne(rec)
+}
+
+// UpdateAPIKeyRec -
+func (m *Model) UpdateAPIKeyRec(rec *record.APIKey) error {
+	l := m.loggerWithFunctionContext("UpdateAPIKeyRec")
+
+	l.Debug("Updating API key record >%#v<", rec)
+
+	r := m.APIKeyRepository()
+
+	err := m.validateAPIKeyRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.UpdateOne(rec)
+}
+
+// DeleteAPIKeyRec -
+func (m *Model) DeleteAPIKeyRec(recID string) error {
+	l := m.loggerWithFunctionContext("DeleteAPIKeyRec")
+
+	l.Debug("Deleting API key rec ID >%s<", recID)
+
+	r := m.APIKeyRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteAPIKeyRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.DeleteOne(recID)
+}
+
+// RemoveAPIKeyRec -
+func (m *Model) RemoveAPIKeyRec(recID string) error {
+
+	l := m.loggerWithFunctionContext("RemoveAPIKeyRec")
+
+	l.Debug("Removing API key rec ID >%s<", recID)
+
+	r := m.APIKeyRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteAPIKeyRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.RemoveOne(recID)
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"fmt"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// validateAPIKeyRec - validates creating and updating an API key record
+func (m *Model) validateAPIKeyRec(rec *record.APIKey) error {
+
+	if rec.Owner == "" {
+		return fmt.Errorf("failed validation, Owner is empty")
+	}
+	if rec.KeyPrefix == "" {
+		return fmt.Errorf("failed validation, KeyPrefix is empty")
+	}
+	if rec.KeyHash == "" {
+		return fmt.Errorf("failed validation, KeyHash is empty")
+	}
+	for _, scope := range rec.Scopes {
+		if !IsPermission(scope) {
+			return NewAPIKeyInvalidScopeError(scope)
+		}
+	}
+
+	return nil
+}
+
+// validateDeleteAPIKeyRec - validates it is okay to delete an API key record
+func (m *Model) validateDeleteAPIKeyRec(recID string) error {
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// SetClock replaces the function the time dungeon instance turns are measured
+// against is taken from, so turns can be simulated without waiting for the
+// turn duration to pass.
+func (m *Model) SetClock(clock func() time.Time) {
+	m.clock = clock
+}
+
+// now returns the time dungeon instance turns are measured against
+func (m *Model) now() time.Time {
+	if m.clock == nil {
+		return time.Now()
+	}
+	return m.clock()
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+)
+
+// DeleteDungeon deletes a dungeon along with its locations and the monsters and
+// objects that spawn at its locations. Dungeons with dungeon instances cannot be
+// deleted.
+func (m *Model) DeleteDungeon(dungeonID string) error {
+	l := m.loggerWithFunctionContext("DeleteDungeon")
+
+	err := m.validateDungeonHasNoInstances(dungeonID)
+	if err != nil {
+		return err
+	}
+
+	locationRecs, err := m.GetLocationRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_id",
+					Val: dungeonID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting location records >%v<", err)
+		return err
+	}
+
+	for _, locationRec := range locationRecs {
+		err := m.deleteLocationSpawnRecs(locationRec.ID)
+		if err != nil {
+			return err
+		}
+
+		err = m.deleteTranslationRecs(locationRec.ID)
+		if err != nil {
+			return err
+		}
+
+		err = m.DeleteLocationRec(locationRec.ID)
+		if err != nil {
+			l.Warn("failed deleting location record >%v<", err)
+			return err
+		}
+	}
+
+	err = m.deleteTranslationRecs(dungeonID)
+	if err != nil {
+		return err
+	}
+
+	err = m.DeleteDungeonRec(dungeonID)
+	if err != nil {
+		l.Warn("failed deleting dungeon record >%v<", err)
+		return err
+	}
+
+	l.Info("Deleted dungeon ID >%s< with >%d< locations", dungeonID, len(locationRecs))
+
+	return nil
+}
+
+// validateDungeonHasNoInstances returns an error when a dungeon has dungeon instances,
+// content that dungeon instances were created from cannot be deleted
+func (m *Model) validateDungeonHasNoInstances(dungeonID string) error {
+	l := m.loggerWithFunctionContext("validateDungeonHasNoInstances")
+
+	recs, err := m.GetDungeonInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_id",
+					Val: dungeonID,
+				},
+			},
+			Limit: 1,
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance records >%v<", err)
+		return err
+	}
+
+	if len(recs) > 0 {
+		return coreerror.NewInvalidActionError(fmt.Sprintf("dungeon ID >%s< has dungeon instances", dungeonID))
+	}
+
+	return nil
+}
+
+// validateNotReferenced returns an error when any records were found that reference
+// the resource being deleted
+func validateNotReferenced[T any](resource, id string, recs []T, referencedBy string) error {
+	if len(recs) > 0 {
+		return coreerror.NewInvalidActionError(fmt.Sprintf("%s ID >%s< is referenced by >%d< %s", resource, id, len(recs), referencedBy))
+	}
+	return nil
+}
+
+// referenceOptions returns query options for records where the column references
+// the ID
+func referenceOptions(col, id string) *coresql.Options {
+	return &coresql.Options{
+		Params: []coresql.Param{
+			{
+				Col: col,
+				Val: id,
+			},
+		},
+	}
+}
@@ -0,0 +1,866 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	DungeonDefinitionFormatYAML string = "yaml"
+	DungeonDefinitionFormatJSON string = "json"
+)
+
+// DungeonDefinition is a declarative definition of a dungeon, its locations and
+// the monsters and objects found within. Locations, monsters and objects refer
+// to each other by name so definitions may be written and reviewed by hand.
+// Identifiers are optional, when provided imported records keep them.
+type DungeonDefinition struct {
+	ID          string               `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string               `json:"name" yaml:"name"`
+	Description string               `json:"description" yaml:"description"`
+	Locations   []LocationDefinition `json:"locations" yaml:"locations"`
+	Monsters    []MonsterDefinition  `json:"monsters,omitempty" yaml:"monsters,omitempty"`
+	Objects     []ObjectDefinition   `json:"objects,omitempty" yaml:"objects,omitempty"`
+	// Translations are keyed by language
+	Translations map[string]TranslationDefinition `json:"translations,omitempty" yaml:"translations,omitempty"`
+}
+
+type LocationDefinition struct {
+	ID          string                    `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string                    `json:"name" yaml:"name"`
+	Description string                    `json:"description" yaml:"description"`
+	Default     bool                      `json:"default,omitempty" yaml:"default,omitempty"`
+	Exits       LocationExitsDefinition   `json:"exits" yaml:"exits,omitempty"`
+	Monsters    []LocationSpawnDefinition `json:"monsters,omitempty" yaml:"monsters,omitempty"`
+	Objects     []LocationSpawnDefinition `json:"objects,omitempty" yaml:"objects,omitempty"`
+	// Translations are keyed by language
+	Translations map[string]TranslationDefinition `json:"translations,omitempty" yaml:"translations,omitempty"`
+}
+
+// LocationExitsDefinition is the name of the location in each direction
+type LocationExitsDefinition struct {
+	North     string `json:"north,omitempty" yaml:"north,omitempty"`
+	Northeast string `json:"northeast,omitempty" yaml:"northeast,omitempty"`
+	East      string `json:"east,omitempty" yaml:"east,omitempty"`
+	Southeast string `json:"southeast,omitempty" yaml:"southeast,omitempty"`
+	South     string `json:"south,omitempty" yaml:"south,omitempty"`
+	Southwest string `json:"southwest,omitempty" yaml:"southwest,omitempty"`
+	West      string `json:"west,omitempty" yaml:"west,omitempty"`
+	Northwest string `json:"northwest,omitempty" yaml:"northwest,omitempty"`
+	Up        string `json:"up,omitempty" yaml:"up,omitempty"`
+	Down      string `json:"down,omitempty" yaml:"down,omitempty"`
+}
+
+// Direction returns the exit of a direction so it may be read or assigned, nil
+// when the direction is unknown
+func (e *LocationExitsDefinition) Direction(direction string) *string {
+	switch direction {
+	case "north":
+		return &e.North
+	case "northeast":
+		return &e.Northeast
+	case "east":
+		return &e.East
+	case "southeast":
+		return &e.Southeast
+	case "south":
+		return &e.South
+	case "southwest":
+		return &e.Southwest
+	case "west":
+		return &e.West
+	case "northwest":
+		return &e.Northwest
+	case "up":
+		return &e.Up
+	case "down":
+		return &e.Down
+	}
+	return nil
+}
+
+// LocationSpawnDefinition is a monster or object, by name, that spawns at a location
+type LocationSpawnDefinition struct {
+	Name               string `json:"name" yaml:"name"`
+	SpawnMinutes       int    `json:"spawn_minutes,omitempty" yaml:"spawn_minutes,omitempty"`
+	SpawnPercentChance int    `json:"spawn_percent_chance" yaml:"spawn_percent_chance"`
+}
+
+type MonsterDefinition struct {
+	ID           string                       `json:"id,omitempty" yaml:"id,omitempty"`
+	Name         string                       `json:"name" yaml:"name"`
+	Description  string                       `json:"description" yaml:"description"`
+	Strength     int                          `json:"strength" yaml:"strength"`
+	Dexterity    int                          `json:"dexterity" yaml:"dexterity"`
+	Intelligence int                          `json:"intelligence" yaml:"intelligence"`
+	Equipment    []MonsterEquipmentDefinition `json:"equipment,omitempty" yaml:"equipment,omitempty"`
+	// Translations are keyed by language
+	Translations map[string]TranslationDefinition `json:"translations,omitempty" yaml:"translations,omitempty"`
+}
+
+// MonsterEquipmentDefinition is an object, by name, a monster carries
+type MonsterEquipmentDefinition struct {
+	Name     string `json:"name" yaml:"name"`
+	Equipped bool   `json:"equipped,omitempty" yaml:"equipped,omitempty"`
+	Stashed  bool   `json:"stashed,omitempty" yaml:"stashed,omitempty"`
+}
+
+type ObjectDefinition struct {
+	ID                  string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name                string `json:"name" yaml:"name"`
+	Description         string `json:"description" yaml:"description"`
+	DescriptionDetailed string `json:"description_detailed" yaml:"description_detailed"`
+	// Translations are keyed by language
+	Translations map[string]TranslationDefinition `json:"translations,omitempty" yaml:"translations,omitempty"`
+}
+
+// TranslationDefinition is the text of a dungeon, location, monster or object in
+// another language, fields that are not translated are shown in English. Only
+// objects have detailed descriptions.
+type TranslationDefinition struct {
+	Name                string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description         string `json:"description,omitempty" yaml:"description,omitempty"`
+	DescriptionDetailed string `json:"description_detailed,omitempty" yaml:"description_detailed,omitempty"`
+}
+
+// UnmarshalDungeonDefinition reads a YAML or JSON dungeon definition, unknown
+// fields are rejected so misspelt fields are not silently ignored
+func UnmarshalDungeonDefinition(data []byte) (*DungeonDefinition, error) {
+
+	d := &DungeonDefinition{}
+
+	// JSON is a subset of YAML
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	err := dec.Decode(d)
+	if err != nil {
+		return nil, coreerror.NewInvalidDataError("failed reading dungeon definition >%v<", err)
+	}
+
+	return d, nil
+}
+
+// MarshalDungeonDefinition writes a dungeon definition in the YAML or JSON format
+func MarshalDungeonDefinition(d *DungeonDefinition, format string) ([]byte, error) {
+
+	switch format {
+	case DungeonDefinitionFormatYAML:
+		buf := bytes.Buffer{}
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		err := enc.Encode(d)
+		if err != nil {
+			return nil, err
+		}
+		err = enc.Close()
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case DungeonDefinitionFormatJSON:
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	return nil, fmt.Errorf("dungeon definition format >%s< is not supported", format)
+}
+
+// Sort puts the definition in a canonical order so the same dungeon always
+// produces the same definition. The default location comes first followed by
+// the remaining locations by name, everything else is ordered by name.
+func (d *DungeonDefinition) Sort() {
+
+	sort.SliceStable(d.Locations, func(i, j int) bool {
+		if d.Locations[i].Default != d.Locations[j].Default {
+			return d.Locations[i].Default
+		}
+		return d.Locations[i].Name < d.Locations[j].Name
+	})
+
+	for idx := range d.Locations {
+		sortLocationSpawnDefinitions(d.Locations[idx].Monsters)
+		sortLocationSpawnDefinitions(d.Locations[idx].Objects)
+	}
+
+	sort.SliceStable(d.Monsters, func(i, j int) bool {
+		return d.Monsters[i].Name < d.Monsters[j].Name
+	})
+
+	for idx := range d.Monsters {
+		equipment := d.Monsters[idx].Equipment
+		sort.SliceStable(equipment, func(i, j int) bool {
+			return equipment[i].Name < equipment[j].Name
+		})
+	}
+
+	sort.SliceStable(d.Objects, func(i, j int) bool {
+		return d.Objects[i].Name < d.Objects[j].Name
+	})
+}
+
+func sortLocationSpawnDefinitions(spawns []LocationSpawnDefinition) {
+	sort.SliceStable(spawns, func(i, j int) bool {
+		if spawns[i].Name != spawns[j].Name {
+			return spawns[i].Name < spawns[j].Name
+		}
+		if spawns[i].SpawnMinutes != spawns[j].SpawnMinutes {
+			return spawns[i].SpawnMinutes < spawns[j].SpawnMinutes
+		}
+		return spawns[i].SpawnPercentChance < spawns[j].SpawnPercentChance
+	})
+}
+
+// Validate checks a definition is complete and has no lint errors, lint
+// warnings do not prevent a definition from being imported
+func (d *DungeonDefinition) Validate() error {
+
+	if d.Name == "" {
+		return coreerror.NewInvalidDataError("dungeon name is empty")
+	}
+	if d.Description == "" {
+		return coreerror.NewInvalidDataError("dungeon >%s< description is empty", d.Name)
+	}
+	if len(d.Locations) == 0 {
+		return coreerror.NewInvalidDataError("dungeon >%s< has no locations", d.Name)
+	}
+	err := validateTranslationDefinitions("dungeon", d.Name, d.Translations, false)
+	if err != nil {
+		return err
+	}
+
+	for _, loc := range d.Locations {
+		if loc.Name == "" {
+			return coreerror.NewInvalidDataError("dungeon >%s< has a location without a name", d.Name)
+		}
+		err := validateTranslationDefinitions("location", loc.Name, loc.Translations, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, mon := range d.Monsters {
+		if mon.Name == "" {
+			return coreerror.NewInvalidDataError("dungeon >%s< has a monster without a name", d.Name)
+		}
+		if mon.Strength <= 0 || mon.Dexterity <= 0 || mon.Intelligence <= 0 {
+			return coreerror.NewInvalidDataError("monster >%s< strength, dexterity and intelligence must be greater than zero", mon.Name)
+		}
+		err := validateTranslationDefinitions("monster", mon.Name, mon.Translations, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, o := range d.Objects {
+		if o.Name == "" {
+			return coreerror.NewInvalidDataError("dungeon >%s< has an object without a name", d.Name)
+		}
+		err := validateTranslationDefinitions("object", o.Name, o.Translations, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, issue := range d.Lint() {
+		if issue.Severity == DungeonIssueSeverityError {
+			return coreerror.NewInvalidDataError("dungeon >%s< %s", d.Name, issue)
+		}
+	}
+
+	return nil
+}
+
+// validateTranslationDefinitions checks translations are into supported
+// languages other than the default language
+func validateTranslationDefinitions(resource, name string, translations map[string]TranslationDefinition, detailed bool) error {
+	for language, td := range translations {
+		if language == locale.Default || !locale.IsLanguage(language) {
+			return coreerror.NewInvalidDataError("%s >%s< translation language >%s< is not one of >%s<", resource, name, language, strings.Join(locale.Languages[1:], ", "))
+		}
+		if td.DescriptionDetailed != "" && !detailed {
+			return coreerror.NewInvalidDataError("%s >%s< translation >%s< has a detailed description, only objects have detailed descriptions", resource, name, language)
+		}
+	}
+	return nil
+}
+
+// ExportDungeonDefinition returns the definition of a dungeon by name including
+// the monsters and objects that spawn at its locations
+func (m *Model) ExportDungeonDefinition(dungeonName string) (*DungeonDefinition, error) {
+	l := m.loggerWithFunctionContext("ExportDungeonDefinition")
+
+	dungeonRec, err := m.getDungeonRecByName(dungeonName)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		return nil, err
+	}
+	if dungeonRec == nil {
+		return nil, coreerror.NewNotFoundError("dungeon", dungeonName)
+	}
+
+	return m.dungeonDefinition(dungeonRec)
+}
+
+// GetDungeonDefinition returns the definition of a dungeon by ID including the
+// monsters and objects that spawn at its locations
+func (m *Model) GetDungeonDefinition(dungeonID string) (*DungeonDefinition, error) {
+	l := m.loggerWithFunctionContext("GetDungeonDefinition")
+
+	dungeonRec, err := m.GetDungeonRec(dungeonID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		return nil, err
+	}
+	if dungeonRec == nil {
+		return nil, coreerror.NewNotFoundError("dungeon", dungeonID)
+	}
+
+	return m.dungeonDefinition(dungeonRec)
+}
+
+func (m *Model) dungeonDefinition(dungeonRec *record.Dungeon) (*DungeonDefinition, error) {
+	l := m.loggerWithFunctionContext("dungeonDefinition")
+
+	d := &DungeonDefinition{
+		ID:          dungeonRec.ID,
+		Name:        dungeonRec.Name,
+		Description: dungeonRec.Description,
+	}
+
+	locationRecs, err := m.GetLocationRecs(referenceOptions("dungeon_id", dungeonRec.ID))
+	if err != nil {
+		l.Warn("failed getting location records >%v<", err)
+		return nil, err
+	}
+
+	locationNames := map[string]string{}
+	for _, locationRec := range locationRecs {
+		locationNames[locationRec.ID] = locationRec.Name
+	}
+
+	monsterRecs := map[string]*record.Monster{}
+	objectRecs := map[string]*record.Object{}
+
+	for _, locationRec := range locationRecs {
+		ld := LocationDefinition{
+			ID:          locationRec.ID,
+			Name:        locationRec.Name,
+			Description: locationRec.Description,
+			Default:     locationRec.IsDefault,
+		}
+
+		for _, direction := range record.LocationDirections {
+			locationID := locationRec.DirectionLocationID(direction)
+			if !locationID.Valid {
+				continue
+			}
+			// Locations of other dungeons are left as identifiers which fail
+			// linting as undefined locations
+			name, ok := locationNames[locationID.String]
+			if !ok {
+				l.Warn("location >%s< %s location ID >%s< is not a location of dungeon >%s<", locationRec.Name, direction, locationID.String, dungeonRec.Name)
+				name = locationID.String
+			}
+			*ld.Exits.Direction(direction) = name
+		}
+
+		locationMonsterRecs, err := m.GetLocationMonsterRecs(referenceOptions("location_id", locationRec.ID))
+		if err != nil {
+			l.Warn("failed getting location monster records >%v<", err)
+			return nil, err
+		}
+
+		for _, locationMonsterRec := range locationMonsterRecs {
+			monsterRec, err := m.exportMonsterRec(monsterRecs, locationMonsterRec.MonsterID)
+			if err != nil {
+				return nil, err
+			}
+			ld.Monsters = append(ld.Monsters, LocationSpawnDefinition{
+				Name:               monsterRec.Name,
+				SpawnMinutes:       locationMonsterRec.SpawnMinutes,
+				SpawnPercentChance: locationMonsterRec.SpawnPercentChance,
+			})
+		}
+
+		locationObjectRecs, err := m.GetLocationObjectRecs(referenceOptions("location_id", locationRec.ID))
+		if err != nil {
+			l.Warn("failed getting location object records >%v<", err)
+			return nil, err
+		}
+
+		for _, locationObjectRec := range locationObjectRecs {
+			objectRec, err := m.exportObjectRec(objectRecs, locationObjectRec.ObjectID)
+			if err != nil {
+				return nil, err
+			}
+			ld.Objects = append(ld.Objects, LocationSpawnDefinition{
+				Name:               objectRec.Name,
+				SpawnMinutes:       locationObjectRec.SpawnMinutes,
+				SpawnPercentChance: locationObjectRec.SpawnPercentChance,
+			})
+		}
+
+		d.Locations = append(d.Locations, ld)
+	}
+
+	for _, monsterRec := range monsterRecs {
+		md := MonsterDefinition{
+			ID:           monsterRec.ID,
+			Name:         monsterRec.Name,
+			Description:  monsterRec.Description,
+			Strength:     monsterRec.Strength,
+			Dexterity:    monsterRec.Dexterity,
+			Intelligence: monsterRec.Intelligence,
+		}
+
+		monsterObjectRecs, err := m.GetMonsterObjectRecs(referenceOptions("monster_id", monsterRec.ID))
+		if err != nil {
+			l.Warn("failed getting monster object records >%v<", err)
+			return nil, err
+		}
+
+		for _, monsterObjectRec := range monsterObjectRecs {
+			objectRec, err := m.exportObjectRec(objectRecs, monsterObjectRec.ObjectID)
+			if err != nil {
+				return nil, err
+			}
+			md.Equipment = append(md.Equipment, MonsterEquipmentDefinition{
+				Name:     objectRec.Name,
+				Equipped: monsterObjectRec.IsEquipped,
+				Stashed:  monsterObjectRec.IsStashed,
+			})
+		}
+
+		d.Monsters = append(d.Monsters, md)
+	}
+
+	for _, objectRec := range objectRecs {
+		d.Objects = append(d.Objects, ObjectDefinition{
+			ID:                  objectRec.ID,
+			Name:                objectRec.Name,
+			Description:         objectRec.Description,
+			DescriptionDetailed: objectRec.DescriptionDetailed,
+		})
+	}
+
+	err = m.defineTranslations(d)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Sort()
+
+	l.Info("Defined dungeon >%s< with >%d< locations >%d< monsters >%d< objects", d.Name, len(d.Locations), len(d.Monsters), len(d.Objects))
+
+	return d, nil
+}
+
+func (m *Model) exportMonsterRec(monsterRecs map[string]*record.Monster, monsterID string) (*record.Monster, error) {
+	if rec, ok := monsterRecs[monsterID]; ok {
+		return rec, nil
+	}
+	rec, err := m.GetMonsterRec(monsterID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, coreerror.NewNotFoundError("monster", monsterID)
+	}
+	monsterRecs[monsterID] = rec
+	return rec, nil
+}
+
+func (m *Model) exportObjectRec(objectRecs map[string]*record.Object, objectID string) (*record.Object, error) {
+	if rec, ok := objectRecs[objectID]; ok {
+		return rec, nil
+	}
+	rec, err := m.GetObjectRec(objectID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, coreerror.NewNotFoundError("object", objectID)
+	}
+	objectRecs[objectID] = rec
+	return rec, nil
+}
+
+// ImportDungeonDefinition creates a dungeon from a definition. Monsters and
+// objects that already exist by name are updated to match the definition and
+// shared with other dungeons, a monster that already exists must have the same
+// attributes. A dungeon of the same name cannot already exist.
+func (m *Model) ImportDungeonDefinition(d *DungeonDefinition) (*record.Dungeon, error) {
+	l := m.loggerWithFunctionContext("ImportDungeonDefinition")
+
+	err := d.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	dungeonRec, err := m.getDungeonRecByName(d.Name)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		return nil, err
+	}
+	if dungeonRec != nil {
+		return nil, coreerror.NewInvalidActionError(fmt.Sprintf("dungeon >%s< already exists as dungeon ID >%s<", d.Name, dungeonRec.ID))
+	}
+
+	objectIDs := map[string]string{}
+	for _, od := range d.Objects {
+		objectRec, err := m.importObjectDefinition(od)
+		if err != nil {
+			return nil, err
+		}
+		objectIDs[od.Name] = objectRec.ID
+	}
+
+	monsterIDs := map[string]string{}
+	for _, md := range d.Monsters {
+		monsterRec, err := m.importMonsterDefinition(md, objectIDs)
+		if err != nil {
+			return nil, err
+		}
+		monsterIDs[md.Name] = monsterRec.ID
+	}
+
+	dungeonRec = &record.Dungeon{
+		Name:        d.Name,
+		Description: d.Description,
+	}
+	dungeonRec.ID = d.ID
+
+	err = m.CreateDungeonRec(dungeonRec)
+	if err != nil {
+		l.Warn("failed creating dungeon record >%v<", err)
+		return nil, err
+	}
+
+	err = m.importTranslationDefinitions(record.TranslationResourceTypeDungeon, dungeonRec.ID, d.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	// Locations are linked once every location exists
+	locationRecs := make([]*record.Location, len(d.Locations))
+	locationIDs := map[string]string{}
+	for idx, ld := range d.Locations {
+		locationRec := &record.Location{
+			DungeonID:   dungeonRec.ID,
+			Name:        ld.Name,
+			Description: ld.Description,
+			IsDefault:   ld.Default,
+		}
+		locationRec.ID = ld.ID
+
+		err := m.CreateLocationRec(locationRec)
+		if err != nil {
+			l.Warn("failed creating location record >%v<", err)
+			return nil, err
+		}
+
+		err = m.importTranslationDefinitions(record.TranslationResourceTypeLocation, locationRec.ID, ld.Translations)
+		if err != nil {
+			return nil, err
+		}
+		locationRecs[idx] = locationRec
+		locationIDs[ld.Name] = locationRec.ID
+	}
+
+	for idx, ld := range d.Locations {
+		locationRec := locationRecs[idx]
+
+		linked := false
+		for _, direction := range record.LocationDirections {
+			exit := *ld.Exits.Direction(direction)
+			if exit == "" {
+				continue
+			}
+			*locationRec.DirectionLocationID(direction) = null.NullStringFromString(locationIDs[exit])
+			linked = true
+		}
+
+		if linked {
+			err := m.UpdateLocationRec(locationRec)
+			if err != nil {
+				l.Warn("failed updating location record >%v<", err)
+				return nil, err
+			}
+		}
+
+		for _, s := range ld.Monsters {
+			err := m.CreateLocationMonsterRec(&record.LocationMonster{
+				LocationID:         locationRec.ID,
+				MonsterID:          monsterIDs[s.Name],
+				SpawnMinutes:       s.SpawnMinutes,
+				SpawnPercentChance: defaultSpawnPercentChance(s.SpawnPercentChance),
+			})
+			if err != nil {
+				l.Warn("failed creating location monster record >%v<", err)
+				return nil, err
+			}
+		}
+
+		for _, s := range ld.Objects {
+			err := m.CreateLocationObjectRec(&record.LocationObject{
+				LocationID:         locationRec.ID,
+				ObjectID:           objectIDs[s.Name],
+				SpawnMinutes:       s.SpawnMinutes,
+				SpawnPercentChance: defaultSpawnPercentChance(s.SpawnPercentChance),
+			})
+			if err != nil {
+				l.Warn("failed creating location object record >%v<", err)
+				return nil, err
+			}
+		}
+	}
+
+	l.Info("Imported dungeon >%s< as dungeon ID >%s<", d.Name, dungeonRec.ID)
+
+	return dungeonRec, nil
+}
+
+// defaultSpawnPercentChance returns a chance to spawn of 100 percent when the
+// chance was not defined
+func defaultSpawnPercentChance(chance int) int {
+	if chance == 0 {
+		return 100
+	}
+	return chance
+}
+
+func (m *Model) importObjectDefinition(od ObjectDefinition) (*record.Object, error) {
+	l := m.loggerWithFunctionContext("importObjectDefinition")
+
+	recs, err := m.GetObjectRecs(referenceOptions("name", od.Name))
+	if err != nil {
+		l.Warn("failed getting object records >%v<", err)
+		return nil, err
+	}
+
+	rec := &record.Object{}
+	if len(recs) > 0 {
+		rec = recs[0]
+	} else {
+		rec.ID = od.ID
+	}
+
+	rec.Name = od.Name
+	rec.Description = od.Description
+	rec.DescriptionDetailed = od.DescriptionDetailed
+
+	if len(recs) > 0 {
+		err = m.UpdateObjectRec(rec)
+	} else {
+		err = m.CreateObjectRec(rec)
+	}
+	if err != nil {
+		l.Warn("failed importing object >%s< >%v<", od.Name, err)
+		return nil, err
+	}
+
+	err = m.importTranslationDefinitions(record.TranslationResourceTypeObject, rec.ID, od.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+func (m *Model) importMonsterDefinition(md MonsterDefinition, objectIDs map[string]string) (*record.Monster, error) {
+	l := m.loggerWithFunctionContext("importMonsterDefinition")
+
+	recs, err := m.GetMonsterRecs(referenceOptions("name", md.Name))
+	if err != nil {
+		l.Warn("failed getting monster records >%v<", err)
+		return nil, err
+	}
+
+	rec := &record.Monster{}
+	if len(recs) > 0 {
+		rec = recs[0]
+	} else {
+		rec.ID = md.ID
+	}
+
+	// Monsters are shared by every dungeon they spawn in so importing a dungeon
+	// cannot change the attributes of an existing monster
+	if len(recs) > 0 && (rec.Strength != md.Strength || rec.Dexterity != md.Dexterity || rec.Intelligence != md.Intelligence) {
+		err := coreerror.NewInvalidDataError("monster >%s< already exists with strength >%d< dexterity >%d< intelligence >%d<, monsters are shared by dungeons and their attributes cannot be changed by importing a dungeon",
+			rec.Name, rec.Strength, rec.Dexterity, rec.Intelligence)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	rec.Name = md.Name
+	rec.Description = md.Description
+	rec.Strength = md.Strength
+	rec.Dexterity = md.Dexterity
+	rec.Intelligence = md.Intelligence
+
+	if len(recs) > 0 {
+		err = m.UpdateMonsterRec(rec)
+	} else {
+		err = m.CreateMonsterRec(rec)
+	}
+	if err != nil {
+		l.Warn("failed importing monster >%s< >%v<", md.Name, err)
+		return nil, err
+	}
+
+	err = m.importTranslationDefinitions(record.TranslationResourceTypeMonster, rec.ID, md.Translations)
+	if err != nil {
+		return nil, err
+	}
+
+	// Equipment is replaced with the defined equipment
+	monsterObjectRecs, err := m.GetMonsterObjectRecs(referenceOptions("monster_id", rec.ID))
+	if err != nil {
+		l.Warn("failed getting monster object records >%v<", err)
+		return nil, err
+	}
+
+	for _, monsterObjectRec := range monsterObjectRecs {
+		err := m.DeleteMonsterObjectRec(monsterObjectRec.ID)
+		if err != nil {
+			l.Warn("failed deleting monster object record >%v<", err)
+			return nil, err
+		}
+	}
+
+	for _, e := range md.Equipment {
+		err := m.CreateMonsterObjectRec(&record.MonsterObject{
+			MonsterID:  rec.ID,
+			ObjectID:   objectIDs[e.Name],
+			IsEquipped: e.Equipped,
+			IsStashed:  e.Stashed,
+		})
+		if err != nil {
+			l.Warn("failed creating monster object record >%v<", err)
+			return nil, err
+		}
+	}
+
+	return rec, nil
+}
+
+func (m *Model) getDungeonRecByName(name string) (*record.Dungeon, error) {
+
+	recs, err := m.GetDungeonRecs(referenceOptions("name", name))
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, nil
+	}
+
+	return recs[0], nil
+}
+
+// defineTranslations adds the translations of the dungeon, its locations and its
+// monsters and objects to a definition
+func (m *Model) defineTranslations(d *DungeonDefinition) error {
+	l := m.loggerWithFunctionContext("defineTranslations")
+
+	resourceIDs := []string{d.ID}
+	for _, ld := range d.Locations {
+		resourceIDs = append(resourceIDs, ld.ID)
+	}
+	for _, md := range d.Monsters {
+		resourceIDs = append(resourceIDs, md.ID)
+	}
+	for _, od := range d.Objects {
+		resourceIDs = append(resourceIDs, od.ID)
+	}
+
+	recs, err := m.GetTranslationRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldTranslationResourceID,
+					Val: resourceIDs,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting translation records >%v<", err)
+		return err
+	}
+
+	translations := map[string]map[string]TranslationDefinition{}
+	for _, rec := range recs {
+		if translations[rec.ResourceID] == nil {
+			translations[rec.ResourceID] = map[string]TranslationDefinition{}
+		}
+		td := translations[rec.ResourceID][rec.Language]
+		switch rec.Field {
+		case record.TranslationFieldName:
+			td.Name = rec.Value
+		case record.TranslationFieldDescription:
+			td.Description = rec.Value
+		case record.TranslationFieldDescriptionDetailed:
+			td.DescriptionDetailed = rec.Value
+		}
+		translations[rec.ResourceID][rec.Language] = td
+	}
+
+	d.Translations = translations[d.ID]
+	for idx := range d.Locations {
+		d.Locations[idx].Translations = translations[d.Locations[idx].ID]
+	}
+	for idx := range d.Monsters {
+		d.Monsters[idx].Translations = translations[d.Monsters[idx].ID]
+	}
+	for idx := range d.Objects {
+		d.Objects[idx].Translations = translations[d.Objects[idx].ID]
+	}
+
+	return nil
+}
+
+// importTranslationDefinitions creates or updates the translations of a
+// resource, translations that are not defined are left as they are
+func (m *Model) importTranslationDefinitions(resourceType, resourceID string, translations map[string]TranslationDefinition) error {
+	l := m.loggerWithFunctionContext("importTranslationDefinitions")
+
+	for language, td := range translations {
+		for field, value := range map[string]string{
+			record.TranslationFieldName:                td.Name,
+			record.TranslationFieldDescription:         td.Description,
+			record.TranslationFieldDescriptionDetailed: td.DescriptionDetailed,
+		} {
+			if value == "" {
+				continue
+			}
+			err := m.SetTranslation(&record.Translation{
+				ResourceType: resourceType,
+				ResourceID:   resourceID,
+				Language:     language,
+				Field:        field,
+				Value:        value,
+			})
+			if err != nil {
+				l.Warn("failed setting %s ID >%s< language >%s< field >%s< translation >%v<", resourceType, resourceID, language, field, err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
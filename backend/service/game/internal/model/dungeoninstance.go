@@ -9,11 +9,12 @@ import (
 )
 
 type DungeonInstanceRecordSet struct {
-	DungeonInstanceRec    *record.DungeonInstance
-	LocationInstanceRecs  []*record.LocationInstance
-	ObjectInstanceRecs    []*record.ObjectInstance
-	MonsterInstanceRecs   []*record.MonsterInstance
-	CharacterInstanceRecs []*record.CharacterInstance
+	DungeonInstanceRec      *record.DungeonInstance
+	DungeonInstanceLeaseRec *record.DungeonInstanceLease
+	LocationInstanceRecs    []*record.LocationInstance
+	ObjectInstanceRecs      []*record.ObjectInstance
+	MonsterInstanceRecs     []*record.MonsterInstance
+	CharacterInstanceRecs   []*record.CharacterInstance
 }
 
 type DungeonInstanceViewRecordSet struct {
@@ -186,6 +187,24 @@ func (m *Model) GetDungeonInstanceRecordSet(dungeonInstanceID string) (*DungeonI
 	}
 	recordSet.DungeonInstanceRec = dungeonInstanceRec
 
+	dungeonInstanceLeaseRecs, err := m.GetDungeonInstanceLeaseRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldDungeonInstanceLeaseDungeonInstanceID,
+					Val: dungeonInstanceID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance lease records >%v<", err)
+		return nil, err
+	}
+	if len(dungeonInstanceLeaseRecs) > 0 {
+		recordSet.DungeonInstanceLeaseRec = dungeonInstanceLeaseRecs[0]
+	}
+
 	locationInstanceRecs, err := m.GetLocationInstanceRecs(
 		&coresql.Options{
 			Params: []coresql.Param{
@@ -259,67 +278,22 @@ func (m *Model) CreateDungeonInstance(dungeonID string) (*DungeonInstanceRecordS
 
 	l.Debug("Creating dungeon instance from dungeon ID >%s<", dungeonID)
 
-	r := m.DungeonInstanceRepository()
-
-	locationInstanceRecs := []*record.LocationInstance{}
 	monsterInstanceRecs := []*record.MonsterInstance{}
 	objectInstanceRecs := []*record.ObjectInstance{}
 
-	dungeonInstanceRec := &record.DungeonInstance{
-		DungeonID: dungeonID,
-	}
-
-	err := r.CreateOne(dungeonInstanceRec)
+	dungeonInstanceRec, dungeonInstanceLeaseRec, err := m.createDungeonInstanceRecs(dungeonID, m.newSeed())
 	if err != nil {
-		l.Warn("failed creating dungeon instance record >%v<", err)
+		l.Warn("failed creating dungeon instance records >%v<", err)
 		return nil, err
 	}
 
-	// Create location instance records
-	locationRecs, err := m.GetLocationRecs(
-		&coresql.Options{
-			Params: []coresql.Param{
-				{
-					Col: "dungeon_id",
-					Val: dungeonID,
-				},
-			},
-		},
-	)
-	if err != nil {
-		l.Warn("failed getting locations records >%v<", err)
-		return nil, err
-	}
-
-	for _, locationRec := range locationRecs {
-		locationInstanceRec := &record.LocationInstance{
-			DungeonInstanceID: dungeonInstanceRec.ID,
-			LocationID:        locationRec.ID,
-		}
-		err := m.CreateLocationInstanceRec(locationInstanceRec)
-		if err != nil {
-			l.Warn("failed creating location instance record >%v<", err)
-			return nil, err
-		}
-		locationInstanceRecs = append(locationInstanceRecs, locationInstanceRec)
-	}
-
-	locationMap := makeLocationMap(locationRecs, locationInstanceRecs)
-
-	// Resolve location instance direction IDs
-	locationInstanceRecs, err = m.resolveLocationInstanceDirectionIdentifiers(locationMap, locationInstanceRecs)
+	locationInstanceRecs, err := m.createDungeonInstanceLocationInstanceRecs(dungeonInstanceRec)
 	if err != nil {
-		l.Warn("failed resolving location instance direction identifiers >%v<", err)
+		l.Warn("failed creating location instance records >%v<", err)
 		return nil, err
 	}
 
-	// Update location instance records
 	for _, locationInstanceRec := range locationInstanceRecs {
-		err := m.UpdateLocationInstanceRec(locationInstanceRec)
-		if err != nil {
-			l.Warn("failed updating location instance record >%v<", err)
-			return nil, err
-		}
 
 		// Create location object instance records
 		locationObjectRecs, err := m.GetLocationObjectRecs(
@@ -339,6 +313,11 @@ func (m *Model) CreateDungeonInstance(dungeonID string) (*DungeonInstanceRecordS
 
 		objectInstanceMap := map[string]*record.ObjectInstance{}
 		for _, locationObjectRec := range locationObjectRecs {
+			if !spawns(dungeonInstanceRec.Seed, locationObjectRec.ID, locationObjectRec.SpawnPercentChance) {
+				l.Debug("Location object ID >%s< did not spawn", locationObjectRec.ID)
+				continue
+			}
+
 			objectRec, err := m.GetObjectRec(locationObjectRec.ObjectID, nil)
 			if err != nil {
 				l.Warn("failed getting object record >%v<", err)
@@ -379,80 +358,200 @@ func (m *Model) CreateDungeonInstance(dungeonID string) (*DungeonInstanceRecordS
 
 		monsterInstanceMap := map[string]*record.MonsterInstance{}
 		for _, monsterLocationRec := range locationMonsterRecs {
+			if !spawns(dungeonInstanceRec.Seed, monsterLocationRec.ID, monsterLocationRec.SpawnPercentChance) {
+				l.Debug("Location monster ID >%s< did not spawn", monsterLocationRec.ID)
+				continue
+			}
+
 			monsterRec, err := m.GetMonsterRec(monsterLocationRec.MonsterID, nil)
 			if err != nil {
 				l.Warn("failed getting monster record >%v<", err)
 				return nil, err
 			}
 
-			monsterInstanceRec := &record.MonsterInstance{
-				MonsterID:          monsterRec.ID,
-				DungeonInstanceID:  dungeonInstanceRec.ID,
-				LocationInstanceID: locationInstanceRec.ID,
-				Strength:           monsterRec.Strength,
-				Dexterity:          monsterRec.Dexterity,
-				Intelligence:       monsterRec.Intelligence,
-				Health:             monsterRec.Health,
-				Fatigue:            monsterRec.Fatigue,
-				Coins:              monsterRec.Coins,
-				ExperiencePoints:   monsterRec.ExperiencePoints,
-				AttributePoints:    monsterRec.AttributePoints,
-			}
-
-			err = m.CreateMonsterInstanceRec(monsterInstanceRec)
+			monsterInstanceRec, monsterObjectInstanceRecs, err := m.createMonsterInstanceRecs(dungeonInstanceRec.ID, locationInstanceRec.ID, monsterRec)
 			if err != nil {
-				l.Warn("failed creating monster instance record >%v<", err)
+				l.Warn("failed creating monster instance records >%v<", err)
 				return nil, err
 			}
 
 			monsterInstanceMap[monsterRec.ID] = monsterInstanceRec
 			monsterInstanceRecs = append(monsterInstanceRecs, monsterInstanceRec)
 
-			monsterObjectRecs, err := m.GetMonsterObjectRecs(
-				&coresql.Options{
-					Params: []coresql.Param{
-						{
-							Col: "monster_id",
-							Val: monsterRec.ID,
-						},
-					},
-				},
-			)
-			if err != nil {
-				l.Warn("failed getting monster object records >%v<", err)
-				return nil, err
+			for _, objectInstanceRec := range monsterObjectInstanceRecs {
+				objectInstanceMap[objectInstanceRec.ObjectID] = objectInstanceRec
+				objectInstanceRecs = append(objectInstanceRecs, objectInstanceRec)
 			}
+		}
+	}
+
+	dungeonInstanceRecordSet := DungeonInstanceRecordSet{
+		DungeonInstanceRec:      dungeonInstanceRec,
+		DungeonInstanceLeaseRec: dungeonInstanceLeaseRec,
+		LocationInstanceRecs:    locationInstanceRecs,
+		MonsterInstanceRecs:     monsterInstanceRecs,
+		ObjectInstanceRecs:      objectInstanceRecs,
+	}
 
-			for _, monsterObjectRec := range monsterObjectRecs {
+	return &dungeonInstanceRecordSet, nil
+}
 
-				objectInstanceRec := &record.ObjectInstance{
-					ObjectID:          monsterObjectRec.ObjectID,
-					DungeonInstanceID: dungeonInstanceRec.ID,
-					MonsterInstanceID: null.NullStringFromString(monsterInstanceRec.ID),
-					IsEquipped:        monsterObjectRec.IsEquipped,
-					IsStashed:         monsterObjectRec.IsStashed,
-				}
+// spawns rolls whether a location monster or object spawns when a dungeon
+// instance is created, the roll is made at turn zero of the dungeon instance.
+func spawns(seed int64, locationSpawnID string, spawnPercentChance int) bool {
+	return NewRand(seed, 0, locationSpawnID).Intn(100) < spawnPercentChance
+}
 
-				err := m.CreateObjectInstanceRec(objectInstanceRec)
-				if err != nil {
-					l.Warn("failed creating monster object instance record >%v<", err)
-					return nil, err
-				}
+// createDungeonInstanceRecs creates a dungeon instance record with the random
+// seed along with an unclaimed dungeon instance lease record.
+func (m *Model) createDungeonInstanceRecs(dungeonID string, seed int64) (*record.DungeonInstance, *record.DungeonInstanceLease, error) {
+	l := m.loggerWithFunctionContext("createDungeonInstanceRecs")
 
-				objectInstanceMap[monsterObjectRec.ObjectID] = objectInstanceRec
-				objectInstanceRecs = append(objectInstanceRecs, objectInstanceRec)
-			}
+	r := m.DungeonInstanceRepository()
+
+	dungeonInstanceRec := &record.DungeonInstance{
+		DungeonID: dungeonID,
+		Seed:      seed,
+	}
+
+	err := r.CreateOne(dungeonInstanceRec)
+	if err != nil {
+		l.Warn("failed creating dungeon instance record >%v<", err)
+		return nil, nil, err
+	}
+
+	// Create an unclaimed dungeon instance lease record, the dungeon instance
+	// will be claimed by a server daemon for processing.
+	dungeonInstanceLeaseRec := &record.DungeonInstanceLease{
+		DungeonInstanceID: dungeonInstanceRec.ID,
+	}
+
+	err = m.CreateDungeonInstanceLeaseRec(dungeonInstanceLeaseRec)
+	if err != nil {
+		l.Warn("failed creating dungeon instance lease record >%v<", err)
+		return nil, nil, err
+	}
+
+	return dungeonInstanceRec, dungeonInstanceLeaseRec, nil
+}
+
+// createMonsterInstanceRecs creates a monster instance record at a location instance
+// along with an object instance record for every object the monster has.
+func (m *Model) createMonsterInstanceRecs(dungeonInstanceID, locationInstanceID string, monsterRec *record.Monster) (*record.MonsterInstance, []*record.ObjectInstance, error) {
+	l := m.loggerWithFunctionContext("createMonsterInstanceRecs")
+
+	monsterInstanceRec := &record.MonsterInstance{
+		MonsterID:          monsterRec.ID,
+		DungeonInstanceID:  dungeonInstanceID,
+		LocationInstanceID: locationInstanceID,
+		Strength:           monsterRec.Strength,
+		Dexterity:          monsterRec.Dexterity,
+		Intelligence:       monsterRec.Intelligence,
+		Health:             monsterRec.Health,
+		Fatigue:            monsterRec.Fatigue,
+		Coins:              monsterRec.Coins,
+		ExperiencePoints:   monsterRec.ExperiencePoints,
+		AttributePoints:    monsterRec.AttributePoints,
+	}
+
+	err := m.CreateMonsterInstanceRec(monsterInstanceRec)
+	if err != nil {
+		l.Warn("failed creating monster instance record >%v<", err)
+		return nil, nil, err
+	}
+
+	monsterObjectRecs, err := m.GetMonsterObjectRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "monster_id",
+					Val: monsterRec.ID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting monster object records >%v<", err)
+		return nil, nil, err
+	}
+
+	objectInstanceRecs := []*record.ObjectInstance{}
+	for _, monsterObjectRec := range monsterObjectRecs {
+
+		objectInstanceRec := &record.ObjectInstance{
+			ObjectID:          monsterObjectRec.ObjectID,
+			DungeonInstanceID: dungeonInstanceID,
+			MonsterInstanceID: null.NullStringFromString(monsterInstanceRec.ID),
+			IsEquipped:        monsterObjectRec.IsEquipped,
+			IsStashed:         monsterObjectRec.IsStashed,
+		}
+
+		err := m.CreateObjectInstanceRec(objectInstanceRec)
+		if err != nil {
+			l.Warn("failed creating monster object instance record >%v<", err)
+			return nil, nil, err
 		}
+
+		objectInstanceRecs = append(objectInstanceRecs, objectInstanceRec)
 	}
 
-	dungeonInstanceRecordSet := DungeonInstanceRecordSet{
-		DungeonInstanceRec:   dungeonInstanceRec,
-		LocationInstanceRecs: locationInstanceRecs,
-		MonsterInstanceRecs:  monsterInstanceRecs,
-		ObjectInstanceRecs:   objectInstanceRecs,
+	return monsterInstanceRec, objectInstanceRecs, nil
+}
+
+// createDungeonInstanceLocationInstanceRecs creates a location instance record
+// for every dungeon location with location instance directions resolved.
+func (m *Model) createDungeonInstanceLocationInstanceRecs(dungeonInstanceRec *record.DungeonInstance) ([]*record.LocationInstance, error) {
+	l := m.loggerWithFunctionContext("createDungeonInstanceLocationInstanceRecs")
+
+	locationInstanceRecs := []*record.LocationInstance{}
+
+	locationRecs, err := m.GetLocationRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_id",
+					Val: dungeonInstanceRec.DungeonID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting locations records >%v<", err)
+		return nil, err
 	}
 
-	return &dungeonInstanceRecordSet, nil
+	for _, locationRec := range locationRecs {
+		locationInstanceRec := &record.LocationInstance{
+			DungeonInstanceID: dungeonInstanceRec.ID,
+			LocationID:        locationRec.ID,
+		}
+		err := m.CreateLocationInstanceRec(locationInstanceRec)
+		if err != nil {
+			l.Warn("failed creating location instance record >%v<", err)
+			return nil, err
+		}
+		locationInstanceRecs = append(locationInstanceRecs, locationInstanceRec)
+	}
+
+	locationMap := makeLocationMap(locationRecs, locationInstanceRecs)
+
+	// Resolve location instance direction IDs
+	locationInstanceRecs, err = m.resolveLocationInstanceDirectionIdentifiers(locationMap, locationInstanceRecs)
+	if err != nil {
+		l.Warn("failed resolving location instance direction identifiers >%v<", err)
+		return nil, err
+	}
+
+	// Update location instance records
+	for _, locationInstanceRec := range locationInstanceRecs {
+		err := m.UpdateLocationInstanceRec(locationInstanceRec)
+		if err != nil {
+			l.Warn("failed updating location instance record >%v<", err)
+			return nil, err
+		}
+	}
+
+	return locationInstanceRecs, nil
 }
 
 // DeleteDungeonInstance -
@@ -555,6 +654,30 @@ func (m *Model) DeleteDungeonInstance(dungeonInstanceID string) (err error) {
 		}
 	}
 
+	dilRecs, err := m.GetDungeonInstanceLeaseRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldDungeonInstanceLeaseDungeonInstanceID,
+					Val: dungeonInstanceID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed to get dungeon instance lease records >%v<", err)
+		return err
+	}
+
+	for idx := range dilRecs {
+		l.Info("Deleting dungeon instance lease record ID >%s<", dilRecs[idx].ID)
+		err := m.DeleteDungeonInstanceLeaseRec(dilRecs[idx].ID)
+		if err != nil {
+			l.Warn("failed to delete dungeon instance lease record >%v<", err)
+			return err
+		}
+	}
+
 	l.Info("Deleting dungeon instance record ID >%s<", dungeonInstanceID)
 	err = m.DeleteDungeonInstanceRec(dungeonInstanceID)
 	if err != nil {
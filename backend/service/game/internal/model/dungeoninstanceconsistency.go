@@ -0,0 +1,497 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// Dungeon instance invariants
+const (
+	// Every location instance direction leads to a location instance in the
+	// same dungeon instance
+	InvariantLocationDirection string = "location_direction"
+	// An object is equipped or stashed, never both
+	InvariantObjectEquippedStashed string = "object_equipped_stashed"
+	// Equipped objects are held by living characters and monsters
+	InvariantEquippedHolderLiving string = "equipped_holder_living"
+	// A character has at most one character instance
+	InvariantCharacterInstanceUnique string = "character_instance_unique"
+	// Living characters and monsters have not decayed and dead characters and
+	// monsters have not decayed beyond removal
+	InvariantDecay string = "decay"
+	// A dungeon instance has at most one turn record
+	InvariantTurnUnique string = "turn_unique"
+)
+
+type CheckDungeonInstanceConsistencyArgs struct {
+	DungeonInstanceID string
+	// Repair repairs violations as they are found
+	Repair bool
+}
+
+// DungeonInstanceConsistency is every invariant violation found in a dungeon
+// instance
+type DungeonInstanceConsistency struct {
+	DungeonInstanceID string                 `json:"dungeon_instance_id"`
+	Violations        []ConsistencyViolation `json:"violations"`
+}
+
+// ConsistencyViolation is a record that violates a dungeon instance invariant
+type ConsistencyViolation struct {
+	Invariant  string `json:"invariant"`
+	RecordType string `json:"record_type"`
+	RecordID   string `json:"record_id"`
+	Message    string `json:"message"`
+	Repaired   bool   `json:"repaired"`
+}
+
+// CheckDungeonInstanceConsistency checks the invariants of a running dungeon
+// instance. When repairing, violations are repaired as follows:
+//
+//   - Directions leading to another dungeon instance lead to the same location
+//     in this dungeon instance, or nowhere when the location is not found
+//   - Objects both equipped and stashed remain stashed
+//   - Objects equipped by dead characters and monsters are dropped where the
+//     character or monster died
+//   - Older instances of a character are removed and their objects are moved
+//     to the most recent instance, violations are reported in the dungeon
+//     instance of the older instance
+//   - Living characters and monsters have their decay cleared and dead
+//     characters and monsters that have decayed beyond removal are removed the
+//     next turn
+//   - Turn records other than the most recent are removed
+//
+// When repairing, records are locked for update as they are checked so repairs
+// do not overwrite changes made concurrently by turn processing.
+func (m *Model) CheckDungeonInstanceConsistency(args *CheckDungeonInstanceConsistencyArgs) (*DungeonInstanceConsistency, error) {
+	l := m.loggerWithFunctionContext("CheckDungeonInstanceConsistency")
+
+	rec, err := m.GetDungeonInstanceRec(args.DungeonInstanceID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	if rec == nil {
+		err := fmt.Errorf("dungeon instance ID >%s< does not exist", args.DungeonInstanceID)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	c := &DungeonInstanceConsistency{
+		DungeonInstanceID: args.DungeonInstanceID,
+		Violations:        []ConsistencyViolation{},
+	}
+
+	checks := []func(*DungeonInstanceConsistency, bool) error{
+		m.checkLocationInstanceDirections,
+		m.checkObjectInstances,
+		m.checkCharacterInstancesUnique,
+		m.checkDecay,
+		m.checkTurnsUnique,
+	}
+
+	for _, check := range checks {
+		err := check(c, args.Repair)
+		if err != nil {
+			l.Warn("failed checking dungeon instance ID >%s< consistency >%v<", args.DungeonInstanceID, err)
+			return nil, err
+		}
+	}
+
+	for _, v := range c.Violations {
+		l.Warn("Dungeon instance ID >%s< invariant >%s< violated by %s ID >%s< repaired >%t< %s", c.DungeonInstanceID, v.Invariant, v.RecordType, v.RecordID, v.Repaired, v.Message)
+	}
+
+	return c, nil
+}
+
+func (c *DungeonInstanceConsistency) violate(invariant, recordType, recordID string, repaired bool, format string, args ...any) {
+	c.Violations = append(c.Violations, ConsistencyViolation{
+		Invariant:  invariant,
+		RecordType: recordType,
+		RecordID:   recordID,
+		Message:    fmt.Sprintf(format, args...),
+		Repaired:   repaired,
+	})
+}
+
+// options returns options for getting the dungeon instance records with the
+// parameter, locked for update when repairing
+func (c *DungeonInstanceConsistency) options(col string, val any, repair bool) *coresql.Options {
+	opts := &coresql.Options{
+		Params: []coresql.Param{
+			{
+				Col: col,
+				Val: val,
+			},
+		},
+	}
+	if repair {
+		opts.Lock = coresql.ForUpdate
+	}
+	return opts
+}
+
+// WriteText writes the violations as text
+func (c *DungeonInstanceConsistency) WriteText(w io.Writer) error {
+
+	lines := []string{
+		fmt.Sprintf("Instance        %s", c.DungeonInstanceID),
+		fmt.Sprintf("Violations      %d", len(c.Violations)),
+	}
+	for _, v := range c.Violations {
+		line := fmt.Sprintf("  %s %s %s %s", v.Invariant, v.RecordType, v.RecordID, v.Message)
+		if v.Repaired {
+			line += " (repaired)"
+		}
+		lines = append(lines, line)
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLocationInstanceDirections checks every location instance direction leads
+// to a location instance in the same dungeon instance
+func (m *Model) checkLocationInstanceDirections(c *DungeonInstanceConsistency, repair bool) error {
+	l := m.loggerWithFunctionContext("checkLocationInstanceDirections")
+
+	recs, err := m.GetLocationInstanceRecs(c.options("dungeon_instance_id", c.DungeonInstanceID, repair))
+	if err != nil {
+		l.Warn("failed getting location instance records >%v<", err)
+		return err
+	}
+
+	locationInstanceIDs := map[string]bool{}
+	// Location instance IDs indexed by location ID
+	locationLocationInstanceIDs := map[string]string{}
+	for _, rec := range recs {
+		locationInstanceIDs[rec.ID] = true
+		locationLocationInstanceIDs[rec.LocationID] = rec.ID
+	}
+
+	for _, rec := range recs {
+		updated := false
+		for _, d := range []struct {
+			direction string
+			id        *sql.NullString
+		}{
+			{"north", &rec.NorthLocationInstanceID},
+			{"northeast", &rec.NortheastLocationInstanceID},
+			{"east", &rec.EastLocationInstanceID},
+			{"southeast", &rec.SoutheastLocationInstanceID},
+			{"south", &rec.SouthLocationInstanceID},
+			{"southwest", &rec.SouthwestLocationInstanceID},
+			{"west", &rec.WestLocationInstanceID},
+			{"northwest", &rec.NorthwestLocationInstanceID},
+			{"up", &rec.UpLocationInstanceID},
+			{"down", &rec.DownLocationInstanceID},
+		} {
+			if !d.id.Valid || locationInstanceIDs[d.id.String] {
+				continue
+			}
+
+			toID := d.id.String
+			if repair {
+				toRec, err := m.GetLocationInstanceRec(toID, nil)
+				if err != nil {
+					l.Warn("failed getting location instance record >%v<", err)
+					return err
+				}
+				*d.id = sql.NullString{}
+				if toRec != nil {
+					*d.id = null.NullStringFromString(locationLocationInstanceIDs[toRec.LocationID])
+				}
+				updated = true
+			}
+
+			c.violate(InvariantLocationDirection, "location_instance", rec.ID, repair,
+				"direction >%s< leads to location instance ID >%s< outside the dungeon instance", d.direction, toID)
+		}
+
+		if updated {
+			err := m.UpdateLocationInstanceRec(rec)
+			if err != nil {
+				l.Warn("failed updating location instance record >%v<", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkObjectInstances checks objects are not both equipped and stashed and
+// equipped objects are held by living characters and monsters
+func (m *Model) checkObjectInstances(c *DungeonInstanceConsistency, repair bool) error {
+	l := m.loggerWithFunctionContext("checkObjectInstances")
+
+	opts := c.options("dungeon_instance_id", c.DungeonInstanceID, repair)
+
+	recs, err := m.GetObjectInstanceRecs(opts)
+	if err != nil {
+		l.Warn("failed getting object instance records >%v<", err)
+		return err
+	}
+
+	characterInstanceRecs, err := m.GetCharacterInstanceRecs(opts)
+	if err != nil {
+		l.Warn("failed getting character instance records >%v<", err)
+		return err
+	}
+
+	monsterInstanceRecs, err := m.GetMonsterInstanceRecs(opts)
+	if err != nil {
+		l.Warn("failed getting monster instance records >%v<", err)
+		return err
+	}
+
+	type holder struct {
+		entityType         EntityType
+		health             int
+		locationInstanceID string
+	}
+
+	holders := map[string]holder{}
+	for _, rec := range characterInstanceRecs {
+		holders[rec.ID] = holder{EntityTypeCharacter, rec.Health, rec.LocationInstanceID}
+	}
+	for _, rec := range monsterInstanceRecs {
+		holders[rec.ID] = holder{EntityTypeMonster, rec.Health, rec.LocationInstanceID}
+	}
+
+	for _, rec := range recs {
+		updated := false
+
+		if rec.IsEquipped && rec.IsStashed {
+			if repair {
+				rec.IsEquipped = false
+				updated = true
+			}
+			c.violate(InvariantObjectEquippedStashed, "object_instance", rec.ID, repair,
+				"object is both equipped and stashed")
+		}
+
+		holderID := null.NullStringToString(rec.CharacterInstanceID)
+		if holderID == "" {
+			holderID = null.NullStringToString(rec.MonsterInstanceID)
+		}
+
+		if h, ok := holders[holderID]; ok && rec.IsEquipped && h.health <= 0 {
+			if repair {
+				rec.LocationInstanceID = null.NullStringFromString(h.locationInstanceID)
+				rec.CharacterInstanceID = sql.NullString{}
+				rec.MonsterInstanceID = sql.NullString{}
+				rec.IsEquipped = false
+				rec.IsStashed = false
+				updated = true
+			}
+			c.violate(InvariantEquippedHolderLiving, "object_instance", rec.ID, repair,
+				"object is equipped by dead %s instance ID >%s<", h.entityType, holderID)
+		}
+
+		if updated {
+			err := m.UpdateObjectInstanceRec(rec)
+			if err != nil {
+				l.Warn("failed updating object instance record >%v<", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkCharacterInstancesUnique checks the characters in a dungeon instance do
+// not have an instance more recent than the instance in this dungeon instance
+func (m *Model) checkCharacterInstancesUnique(c *DungeonInstanceConsistency, repair bool) error {
+	l := m.loggerWithFunctionContext("checkCharacterInstancesUnique")
+
+	recs, err := m.GetCharacterInstanceRecs(c.options("dungeon_instance_id", c.DungeonInstanceID, repair))
+	if err != nil {
+		l.Warn("failed getting character instance records >%v<", err)
+		return err
+	}
+
+	for _, rec := range recs {
+		characterInstanceRecs, err := m.GetCharacterInstanceRecs(c.options("character_id", rec.CharacterID, repair))
+		if err != nil {
+			l.Warn("failed getting character ID >%s< instance records >%v<", rec.CharacterID, err)
+			return err
+		}
+
+		var newest *record.CharacterInstance
+		for _, ciRec := range characterInstanceRecs {
+			if newest == nil || ciRec.CreatedAt.After(newest.CreatedAt) ||
+				(ciRec.CreatedAt.Equal(newest.CreatedAt) && ciRec.ID > newest.ID) {
+				newest = ciRec
+			}
+		}
+
+		if newest == nil || newest.ID == rec.ID {
+			continue
+		}
+
+		if repair {
+			objectInstanceRecs, err := m.GetObjectInstanceRecs(c.options("character_instance_id", rec.ID, repair))
+			if err != nil {
+				l.Warn("failed getting character instance object instance records >%v<", err)
+				return err
+			}
+
+			// Objects are moved to the most recent instance stashed so they do
+			// not replace the objects that instance has equipped
+			for _, oiRec := range objectInstanceRecs {
+				oiRec.DungeonInstanceID = newest.DungeonInstanceID
+				oiRec.CharacterInstanceID = null.NullStringFromString(newest.ID)
+				oiRec.IsEquipped = false
+				oiRec.IsStashed = true
+				err := m.UpdateObjectInstanceRec(oiRec)
+				if err != nil {
+					l.Warn("failed updating character instance object instance record >%v<", err)
+					return err
+				}
+			}
+
+			err = m.DeleteCharacterInstanceRec(rec.ID)
+			if err != nil {
+				l.Warn("failed deleting character instance record >%v<", err)
+				return err
+			}
+		}
+
+		c.violate(InvariantCharacterInstanceUnique, "character_instance", rec.ID, repair,
+			"character ID >%s< has a more recent character instance ID >%s< in dungeon instance ID >%s<", rec.CharacterID, newest.ID, newest.DungeonInstanceID)
+	}
+
+	return nil
+}
+
+// checkDecay checks living characters and monsters have not decayed and dead
+// characters and monsters have not decayed beyond removal
+func (m *Model) checkDecay(c *DungeonInstanceConsistency, repair bool) error {
+	l := m.loggerWithFunctionContext("checkDecay")
+
+	opts := c.options("dungeon_instance_id", c.DungeonInstanceID, repair)
+
+	// decay returns the decay a character or monster should have, and whether
+	// that differs from the decay they have
+	decay := func(health, decay int) (int, bool) {
+		switch {
+		case health > 0 && decay != 0:
+			return 0, true
+		case health <= 0 && decay < 0:
+			return 0, true
+		case health <= 0 && decay >= DecayTurns:
+			// Removed by the next turn
+			return DecayTurns - 1, true
+		}
+		return decay, false
+	}
+
+	characterInstanceRecs, err := m.GetCharacterInstanceRecs(opts)
+	if err != nil {
+		l.Warn("failed getting character instance records >%v<", err)
+		return err
+	}
+
+	for _, rec := range characterInstanceRecs {
+		d, violated := decay(rec.Health, rec.Decay)
+		if !violated {
+			continue
+		}
+
+		c.violate(InvariantDecay, "character_instance", rec.ID, repair,
+			"character instance with health >%d< has decay >%d<", rec.Health, rec.Decay)
+
+		if repair {
+			rec.Decay = d
+			err := m.UpdateCharacterInstanceRec(rec)
+			if err != nil {
+				l.Warn("failed updating character instance record >%v<", err)
+				return err
+			}
+		}
+	}
+
+	monsterInstanceRecs, err := m.GetMonsterInstanceRecs(opts)
+	if err != nil {
+		l.Warn("failed getting monster instance records >%v<", err)
+		return err
+	}
+
+	for _, rec := range monsterInstanceRecs {
+		d, violated := decay(rec.Health, rec.Decay)
+		if !violated {
+			continue
+		}
+
+		c.violate(InvariantDecay, "monster_instance", rec.ID, repair,
+			"monster instance with health >%d< has decay >%d<", rec.Health, rec.Decay)
+
+		if repair {
+			rec.Decay = d
+			err := m.UpdateMonsterInstanceRec(rec)
+			if err != nil {
+				l.Warn("failed updating monster instance record >%v<", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkTurnsUnique checks a dungeon instance has at most one turn record
+func (m *Model) checkTurnsUnique(c *DungeonInstanceConsistency, repair bool) error {
+	l := m.loggerWithFunctionContext("checkTurnsUnique")
+
+	recs, err := m.GetTurnRecs(c.options("dungeon_instance_id", c.DungeonInstanceID, repair))
+	if err != nil {
+		l.Warn("failed getting turn records >%v<", err)
+		return err
+	}
+
+	if len(recs) < 2 {
+		return nil
+	}
+
+	// The most recent turn is kept
+	var newest *record.Turn
+	for _, rec := range recs {
+		if newest == nil || rec.TurnNumber > newest.TurnNumber ||
+			(rec.TurnNumber == newest.TurnNumber && rec.IncrementedAt.Time.After(newest.IncrementedAt.Time)) {
+			newest = rec
+		}
+	}
+
+	for _, rec := range recs {
+		if rec.ID == newest.ID {
+			continue
+		}
+
+		if repair {
+			err := m.DeleteTurnRec(rec.ID)
+			if err != nil {
+				l.Warn("failed deleting turn record >%v<", err)
+				return err
+			}
+		}
+
+		c.violate(InvariantTurnUnique, "turn", rec.ID, repair,
+			"turn >%d< is not the most recent turn record ID >%s< turn >%d<", rec.TurnNumber, newest.ID, newest.TurnNumber)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+type RecordDungeonInstanceFailureArgs struct {
+	DungeonInstanceID string
+	Error             error
+	// MaxFailures is the number of consecutive failures after which the
+	// dungeon instance is quarantined, a value of zero never quarantines.
+	MaxFailures int
+}
+
+// RecordDungeonInstanceFailure increments the consecutive failure count of a
+// dungeon instance and records the last error. When the failure count reaches
+// the maximum number of failures the dungeon instance is quarantined.
+func (m *Model) RecordDungeonInstanceFailure(args *RecordDungeonInstanceFailureArgs) (*record.DungeonInstance, error) {
+	l := m.loggerWithFunctionContext("RecordDungeonInstanceFailure")
+
+	rec, err := m.getDungeonInstanceRecForFailure(args.DungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	rec.FailureCount++
+	if args.Error != nil {
+		rec.LastError = null.NullStringFromString(args.Error.Error())
+	}
+	rec.LastErrorAt = null.NullTimeFromTime(now)
+
+	if args.MaxFailures > 0 && rec.FailureCount >= args.MaxFailures && !rec.QuarantinedAt.Valid {
+		l.Warn("Quarantining dungeon instance ID >%s< after >%d< consecutive failures", rec.ID, rec.FailureCount)
+		rec.QuarantinedAt = null.NullTimeFromTime(now)
+	}
+
+	err = m.UpdateDungeonInstanceRec(rec)
+	if err != nil {
+		l.Warn("failed updating dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// ClearDungeonInstanceFailures resets the consecutive failure count of a dungeon
+// instance after it has been successfully processed. The last error is kept
+// for reference.
+func (m *Model) ClearDungeonInstanceFailures(dungeonInstanceID string) (*record.DungeonInstance, error) {
+	l := m.loggerWithFunctionContext("ClearDungeonInstanceFailures")
+
+	rec, err := m.getDungeonInstanceRecForFailure(dungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	if rec.FailureCount == 0 {
+		return rec, nil
+	}
+
+	rec.FailureCount = 0
+
+	err = m.UpdateDungeonInstanceRec(rec)
+	if err != nil {
+		l.Warn("failed updating dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// UnquarantineDungeonInstance removes a dungeon instance from quarantine and
+// resets its consecutive failure count so it will be processed again.
+func (m *Model) UnquarantineDungeonInstance(dungeonInstanceID string) (*record.DungeonInstance, error) {
+	l := m.loggerWithFunctionContext("UnquarantineDungeonInstance")
+
+	rec, err := m.getDungeonInstanceRecForFailure(dungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	if !rec.QuarantinedAt.Valid {
+		err := fmt.Errorf("dungeon instance ID >%s< is not quarantined", dungeonInstanceID)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	l.Info("Unquarantining dungeon instance ID >%s<", rec.ID)
+
+	rec.FailureCount = 0
+	rec.QuarantinedAt = sql.NullTime{}
+
+	err = m.UpdateDungeonInstanceRec(rec)
+	if err != nil {
+		l.Warn("failed updating dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// GetFailingDungeonInstanceRecs returns dungeon instances that have failed
+// processing at least once since their last successful turn, or when
+// quarantined is true, only dungeon instances that are quarantined.
+func (m *Model) GetFailingDungeonInstanceRecs(quarantined bool) ([]*record.DungeonInstance, error) {
+	l := m.loggerWithFunctionContext("GetFailingDungeonInstanceRecs")
+
+	param := coresql.Param{
+		Col: record.FieldDungeonInstanceFailureCount,
+		Val: 0,
+		Op:  coresql.OpGreaterThan,
+	}
+	if quarantined {
+		param = coresql.Param{
+			Col: record.FieldDungeonInstanceQuarantinedAt,
+			Op:  coresql.OpIsNotNull,
+		}
+	}
+
+	recs, err := m.GetDungeonInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{param},
+			OrderBy: []coresql.OrderBy{
+				{
+					Col:       "created_at",
+					Direction: coresql.OrderDirectionASC,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance records >%v<", err)
+		return nil, err
+	}
+
+	return recs, nil
+}
+
+func (m *Model) getDungeonInstanceRecForFailure(dungeonInstanceID string) (*record.DungeonInstance, error) {
+	rec, err := m.GetDungeonInstanceRec(dungeonInstanceID, coresql.ForUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("dungeon instance ID >%s< does not exist", dungeonInstanceID)
+	}
+	return rec, nil
+}
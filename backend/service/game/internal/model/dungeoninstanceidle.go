@@ -0,0 +1,108 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// UpdateDungeonInstanceEmptySince records when a dungeon instance first became
+// empty of characters, or clears it when the dungeon instance is no longer
+// empty. Returns how long the dungeon instance has been empty.
+func (m *Model) UpdateDungeonInstanceEmptySince(rec *record.DungeonInstance, empty bool) (time.Duration, error) {
+	l := m.loggerWithFunctionContext("UpdateDungeonInstanceEmptySince")
+
+	now := time.Now().UTC()
+
+	if !empty {
+		if !rec.EmptySince.Valid {
+			return 0, nil
+		}
+		rec.EmptySince = sql.NullTime{}
+	} else {
+		if rec.EmptySince.Valid {
+			return now.Sub(rec.EmptySince.Time), nil
+		}
+		rec.EmptySince = null.NullTimeFromTime(now)
+	}
+
+	err := m.UpdateDungeonInstanceRec(rec)
+	if err != nil {
+		l.Warn("failed updating dungeon instance record >%v<", err)
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// GetIdleCharacterInstanceRecs returns character instances within a dungeon
+// instance that have not performed an action since the provided time. Characters
+// that have never performed an action are idle from when they entered.
+func (m *Model) GetIdleCharacterInstanceRecs(dungeonInstanceID string, idleSince time.Time) ([]*record.CharacterInstance, error) {
+	l := m.loggerWithFunctionContext("GetIdleCharacterInstanceRecs")
+
+	ciRecs, err := m.GetCharacterInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_instance_id",
+					Val: dungeonInstanceID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting character instance records >%v<", err)
+		return nil, err
+	}
+
+	idleRecs := []*record.CharacterInstance{}
+	for _, ciRec := range ciRecs {
+		lastActiveAt, err := m.getCharacterInstanceLastActiveAt(ciRec)
+		if err != nil {
+			l.Warn("failed getting character instance ID >%s< last active >%v<", ciRec.ID, err)
+			return nil, err
+		}
+		if lastActiveAt.Before(idleSince) {
+			l.Debug("Character instance ID >%s< idle since >%s<", ciRec.ID, lastActiveAt)
+			idleRecs = append(idleRecs, ciRec)
+		}
+	}
+
+	return idleRecs, nil
+}
+
+// getCharacterInstanceLastActiveAt returns when the character last performed an
+// action, or when the character entered the dungeon instance.
+func (m *Model) getCharacterInstanceLastActiveAt(ciRec *record.CharacterInstance) (time.Time, error) {
+
+	actionRecs, err := m.GetActionRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "character_instance_id",
+					Val: ciRec.ID,
+				},
+			},
+			OrderBy: []coresql.OrderBy{
+				{
+					Col:       "created_at",
+					Direction: coresql.OrderDirectionDESC,
+				},
+			},
+			Limit: 1,
+		},
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(actionRecs) == 0 || actionRecs[0].CreatedAt.Before(ciRec.CreatedAt) {
+		return ciRec.CreatedAt, nil
+	}
+
+	return actionRecs[0].CreatedAt, nil
+}
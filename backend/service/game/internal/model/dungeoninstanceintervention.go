@@ -0,0 +1,428 @@
+package model
+
+import (
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// Game master interventions change a running dungeon instance outside of the
+// normal turn based actions of characters and monsters. Every intervention is
+// recorded as a system action so characters see it in their action feed.
+
+type TeleportCharacterInstanceArgs struct {
+	SystemActor         string
+	DungeonInstanceID   string
+	CharacterInstanceID string
+	LocationInstanceID  string
+}
+
+// TeleportCharacterInstance moves a character instance to another location in
+// the same dungeon instance
+func (m *Model) TeleportCharacterInstance(args *TeleportCharacterInstanceArgs) (*record.ActionRecordSet, error) {
+	l := m.loggerWithFunctionContext("TeleportCharacterInstance")
+
+	rec, err := m.getInterventionCharacterInstanceRec(args.DungeonInstanceID, args.CharacterInstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	locationInstanceRec, err := m.getInterventionLocationInstanceRec(args.DungeonInstanceID, args.LocationInstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.LocationInstanceID == locationInstanceRec.ID {
+		return nil, NewInvalidActionError("character instance ID >%s< is already at location instance ID >%s<", rec.ID, locationInstanceRec.ID)
+	}
+
+	fromLocationInstanceID := rec.LocationInstanceID
+	rec.LocationInstanceID = locationInstanceRec.ID
+
+	err = m.UpdateCharacterInstanceRec(rec)
+	if err != nil {
+		l.Warn("failed updating character instance record >%v<", err)
+		return nil, err
+	}
+
+	l.Info("Teleported character instance ID >%s< from location instance ID >%s< to >%s<", rec.ID, fromLocationInstanceID, rec.LocationInstanceID)
+
+	return m.createSystemAction(&createSystemActionArgs{
+		SystemActor:               args.SystemActor,
+		Command:                   record.ActionCommandTeleport,
+		LocationInstanceID:        fromLocationInstanceID,
+		TargetCharacterInstanceID: rec.ID,
+		TargetLocationInstanceID:  rec.LocationInstanceID,
+	})
+}
+
+type SpawnMonsterInstanceArgs struct {
+	SystemActor        string
+	DungeonInstanceID  string
+	LocationInstanceID string
+	MonsterID          string
+}
+
+// SpawnMonsterInstance creates a monster instance, along with the monster's
+// objects, at a location
+func (m *Model) SpawnMonsterInstance(args *SpawnMonsterInstanceArgs) (*record.ActionRecordSet, error) {
+	l := m.loggerWithFunctionContext("SpawnMonsterInstance")
+
+	locationInstanceRec, err := m.getInterventionLocationInstanceRec(args.DungeonInstanceID, args.LocationInstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	monsterRec, err := m.GetMonsterRec(args.MonsterID, nil)
+	if err != nil {
+		l.Warn("failed getting monster record >%v<", err)
+		return nil, err
+	}
+
+	if monsterRec == nil {
+		return nil, coreerror.NewNotFoundError("monster", args.MonsterID)
+	}
+
+	monsterInstanceRec, _, err := m.createMonsterInstanceRecs(locationInstanceRec.DungeonInstanceID, locationInstanceRec.ID, monsterRec)
+	if err != nil {
+		l.Warn("failed creating monster instance records >%v<", err)
+		return nil, err
+	}
+
+	l.Info("Spawned monster ID >%s< instance ID >%s< at location instance ID >%s<", monsterRec.ID, monsterInstanceRec.ID, locationInstanceRec.ID)
+
+	return m.createSystemAction(&createSystemActionArgs{
+		SystemActor:             args.SystemActor,
+		Command:                 record.ActionCommandSpawn,
+		LocationInstanceID:      locationInstanceRec.ID,
+		TargetMonsterInstanceID: monsterInstanceRec.ID,
+	})
+}
+
+type SpawnObjectInstanceArgs struct {
+	SystemActor        string
+	DungeonInstanceID  string
+	LocationInstanceID string
+	ObjectID           string
+}
+
+// SpawnObjectInstance creates an object instance at a location
+func (m *Model) SpawnObjectInstance(args *SpawnObjectInstanceArgs) (*record.ActionRecordSet, error) {
+	l := m.loggerWithFunctionContext("SpawnObjectInstance")
+
+	locationInstanceRec, err := m.getInterventionLocationInstanceRec(args.DungeonInstanceID, args.LocationInstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	objectRec, err := m.GetObjectRec(args.ObjectID, nil)
+	if err != nil {
+		l.Warn("failed getting object record >%v<", err)
+		return nil, err
+	}
+
+	if objectRec == nil {
+		return nil, coreerror.NewNotFoundError("object", args.ObjectID)
+	}
+
+	objectInstanceRec := &record.ObjectInstance{
+		ObjectID:           objectRec.ID,
+		DungeonInstanceID:  locationInstanceRec.DungeonInstanceID,
+		LocationInstanceID: null.NullStringFromString(locationInstanceRec.ID),
+	}
+
+	err = m.CreateObjectInstanceRec(objectInstanceRec)
+	if err != nil {
+		l.Warn("failed creating object instance record >%v<", err)
+		return nil, err
+	}
+
+	l.Info("Spawned object ID >%s< instance ID >%s< at location instance ID >%s<", objectRec.ID, objectInstanceRec.ID, locationInstanceRec.ID)
+
+	return m.createSystemAction(&createSystemActionArgs{
+		SystemActor:            args.SystemActor,
+		Command:                record.ActionCommandSpawn,
+		LocationInstanceID:     locationInstanceRec.ID,
+		TargetObjectInstanceID: objectInstanceRec.ID,
+	})
+}
+
+type EntityInstanceInterventionArgs struct {
+	SystemActor       string
+	DungeonInstanceID string
+	EntityType        EntityType
+	EntityInstanceID  string
+}
+
+// HealEntityInstance restores a character or monster instance to full health and
+// fatigue, a dead entity that has not yet decayed is revived
+func (m *Model) HealEntityInstance(args *EntityInstanceInterventionArgs) (*record.ActionRecordSet, error) {
+	l := m.loggerWithFunctionContext("HealEntityInstance")
+
+	switch args.EntityType {
+	case EntityTypeCharacter:
+		rec, err := m.getInterventionCharacterInstanceRec(args.DungeonInstanceID, args.EntityInstanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		characterRec, err := m.GetCharacterRec(rec.CharacterID, nil)
+		if err != nil {
+			l.Warn("failed getting character record >%v<", err)
+			return nil, err
+		}
+
+		rec.Health = characterRec.Health
+		rec.Fatigue = characterRec.Fatigue
+		rec.Decay = 0
+
+		err = m.UpdateCharacterInstanceRec(rec)
+		if err != nil {
+			l.Warn("failed updating character instance record >%v<", err)
+			return nil, err
+		}
+
+		return m.createSystemAction(&createSystemActionArgs{
+			SystemActor:               args.SystemActor,
+			Command:                   record.ActionCommandHeal,
+			LocationInstanceID:        rec.LocationInstanceID,
+			TargetCharacterInstanceID: rec.ID,
+		})
+	case EntityTypeMonster:
+		rec, err := m.getInterventionMonsterInstanceRec(args.DungeonInstanceID, args.EntityInstanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		monsterRec, err := m.GetMonsterRec(rec.MonsterID, nil)
+		if err != nil {
+			l.Warn("failed getting monster record >%v<", err)
+			return nil, err
+		}
+
+		rec.Health = monsterRec.Health
+		rec.Fatigue = monsterRec.Fatigue
+		rec.Decay = 0
+
+		err = m.UpdateMonsterInstanceRec(rec)
+		if err != nil {
+			l.Warn("failed updating monster instance record >%v<", err)
+			return nil, err
+		}
+
+		return m.createSystemAction(&createSystemActionArgs{
+			SystemActor:             args.SystemActor,
+			Command:                 record.ActionCommandHeal,
+			LocationInstanceID:      rec.LocationInstanceID,
+			TargetMonsterInstanceID: rec.ID,
+		})
+	}
+
+	return nil, NewInvalidActionError("entity type >%s< cannot be healed", args.EntityType)
+}
+
+// KillEntityInstance reduces the health of a character or monster instance to zero,
+// the dead entity decays over the following turns
+func (m *Model) KillEntityInstance(args *EntityInstanceInterventionArgs) (*record.ActionRecordSet, error) {
+	l := m.loggerWithFunctionContext("KillEntityInstance")
+
+	switch args.EntityType {
+	case EntityTypeCharacter:
+		rec, err := m.getInterventionCharacterInstanceRec(args.DungeonInstanceID, args.EntityInstanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if rec.Health <= 0 {
+			return nil, NewInvalidActionError("character instance ID >%s< is already dead", rec.ID)
+		}
+
+		rec.Health = 0
+
+		err = m.UpdateCharacterInstanceRec(rec)
+		if err != nil {
+			l.Warn("failed updating character instance record >%v<", err)
+			return nil, err
+		}
+
+		return m.createSystemAction(&createSystemActionArgs{
+			SystemActor:               args.SystemActor,
+			Command:                   record.ActionCommandKill,
+			LocationInstanceID:        rec.LocationInstanceID,
+			TargetCharacterInstanceID: rec.ID,
+		})
+	case EntityTypeMonster:
+		rec, err := m.getInterventionMonsterInstanceRec(args.DungeonInstanceID, args.EntityInstanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if rec.Health <= 0 {
+			return nil, NewInvalidActionError("monster instance ID >%s< is already dead", rec.ID)
+		}
+
+		rec.Health = 0
+
+		err = m.UpdateMonsterInstanceRec(rec)
+		if err != nil {
+			l.Warn("failed updating monster instance record >%v<", err)
+			return nil, err
+		}
+
+		return m.createSystemAction(&createSystemActionArgs{
+			SystemActor:             args.SystemActor,
+			Command:                 record.ActionCommandKill,
+			LocationInstanceID:      rec.LocationInstanceID,
+			TargetMonsterInstanceID: rec.ID,
+		})
+	}
+
+	return nil, NewInvalidActionError("entity type >%s< cannot be killed", args.EntityType)
+}
+
+type KickCharacterInstanceArgs struct {
+	SystemActor         string
+	DungeonInstanceID   string
+	CharacterInstanceID string
+}
+
+// KickCharacterInstance removes a character from a dungeon instance, the character
+// keeps their experience, coins and objects as though they had exited the dungeon
+func (m *Model) KickCharacterInstance(args *KickCharacterInstanceArgs) (*record.ActionRecordSet, error) {
+	l := m.loggerWithFunctionContext("KickCharacterInstance")
+
+	rec, err := m.getInterventionCharacterInstanceRec(args.DungeonInstanceID, args.CharacterInstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The kick is recorded before the character exits so the action captures
+	// the character while they are still at the location
+	actionRecordSet, err := m.createSystemAction(&createSystemActionArgs{
+		SystemActor:               args.SystemActor,
+		Command:                   record.ActionCommandKick,
+		LocationInstanceID:        rec.LocationInstanceID,
+		TargetCharacterInstanceID: rec.ID,
+	})
+	if err != nil {
+		l.Warn("failed creating kick system action >%v<", err)
+		return nil, err
+	}
+
+	err = m.CharacterExitDungeon(rec.CharacterID)
+	if err != nil {
+		l.Warn("failed exiting character ID >%s< from dungeon >%v<", rec.CharacterID, err)
+		return nil, err
+	}
+
+	l.Info("Kicked character ID >%s< instance ID >%s< from dungeon instance ID >%s<", rec.CharacterID, rec.ID, rec.DungeonInstanceID)
+
+	return actionRecordSet, nil
+}
+
+type ForceDeleteDungeonInstanceArgs struct {
+	SystemActor       string
+	DungeonInstanceID string
+}
+
+// ForceDeleteDungeonInstance kicks every character from a dungeon instance and then
+// deletes the dungeon instance regardless of whether it is being processed
+func (m *Model) ForceDeleteDungeonInstance(args *ForceDeleteDungeonInstanceArgs) error {
+	l := m.loggerWithFunctionContext("ForceDeleteDungeonInstance")
+
+	rec, err := m.GetDungeonInstanceRec(args.DungeonInstanceID, coresql.ForUpdate)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record >%v<", err)
+		return err
+	}
+
+	if rec == nil {
+		return coreerror.NewNotFoundError("dungeon instance", args.DungeonInstanceID)
+	}
+
+	characterInstanceRecs, err := m.GetCharacterInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldCharacterInstanceDungeonInstanceID,
+					Val: rec.ID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting character instance records >%v<", err)
+		return err
+	}
+
+	for _, characterInstanceRec := range characterInstanceRecs {
+		_, err := m.KickCharacterInstance(&KickCharacterInstanceArgs{
+			SystemActor:         args.SystemActor,
+			DungeonInstanceID:   rec.ID,
+			CharacterInstanceID: characterInstanceRec.ID,
+		})
+		if err != nil {
+			l.Warn("failed kicking character instance ID >%s< >%v<", characterInstanceRec.ID, err)
+			return err
+		}
+	}
+
+	err = m.DeleteDungeonInstance(rec.ID)
+	if err != nil {
+		l.Warn("failed deleting dungeon instance >%v<", err)
+		return err
+	}
+
+	l.Info("System actor >%s< force deleted dungeon instance ID >%s<", args.SystemActor, rec.ID)
+
+	return nil
+}
+
+func (m *Model) getInterventionCharacterInstanceRec(dungeonInstanceID, characterInstanceID string) (*record.CharacterInstance, error) {
+	l := m.loggerWithFunctionContext("getInterventionCharacterInstanceRec")
+
+	rec, err := m.GetCharacterInstanceRec(characterInstanceID, coresql.ForUpdate)
+	if err != nil {
+		l.Warn("failed getting character instance record >%v<", err)
+		return nil, err
+	}
+
+	if rec == nil || rec.DungeonInstanceID != dungeonInstanceID {
+		return nil, coreerror.NewNotFoundError("character instance", characterInstanceID)
+	}
+
+	return rec, nil
+}
+
+func (m *Model) getInterventionMonsterInstanceRec(dungeonInstanceID, monsterInstanceID string) (*record.MonsterInstance, error) {
+	l := m.loggerWithFunctionContext("getInterventionMonsterInstanceRec")
+
+	rec, err := m.GetMonsterInstanceRec(monsterInstanceID, coresql.ForUpdate)
+	if err != nil {
+		l.Warn("failed getting monster instance record >%v<", err)
+		return nil, err
+	}
+
+	if rec == nil || rec.DungeonInstanceID != dungeonInstanceID {
+		return nil, coreerror.NewNotFoundError("monster instance", monsterInstanceID)
+	}
+
+	return rec, nil
+}
+
+func (m *Model) getInterventionLocationInstanceRec(dungeonInstanceID, locationInstanceID string) (*record.LocationInstance, error) {
+	l := m.loggerWithFunctionContext("getInterventionLocationInstanceRec")
+
+	rec, err := m.GetLocationInstanceRec(locationInstanceID, nil)
+	if err != nil {
+		l.Warn("failed getting location instance record >%v<", err)
+		return nil, err
+	}
+
+	if rec == nil || rec.DungeonInstanceID != dungeonInstanceID {
+		return nil, coreerror.NewNotFoundError("location instance", locationInstanceID)
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,197 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+type ClaimDungeonInstanceLeasesArgs struct {
+	DaemonID      string
+	LeaseDuration time.Duration
+	// MaxLeases is the maximum number of dungeon instance leases a daemon
+	// may hold, a value of zero is unlimited.
+	MaxLeases int
+}
+
+type ClaimDungeonInstanceLeasesResult struct {
+	// Records are all dungeon instance leases currently held by the daemon
+	Records []*record.DungeonInstanceLease
+	// ClaimedCount is the number of leases newly claimed from other daemons
+	// or that were previously unclaimed.
+	ClaimedCount int
+}
+
+// ClaimDungeonInstanceLeases renews all dungeon instance leases already held by
+// the daemon and claims any unclaimed or expired dungeon instance leases up to
+// the maximum number of leases the daemon may hold. Held leases are renewed by
+// daemon ID so they are always renewed, unclaimed and expired lease records
+// that are currently locked by another daemon are skipped.
+func (m *Model) ClaimDungeonInstanceLeases(args *ClaimDungeonInstanceLeasesArgs) (*ClaimDungeonInstanceLeasesResult, error) {
+	l := m.loggerWithFunctionContext("ClaimDungeonInstanceLeases")
+
+	if args.DaemonID == "" {
+		err := fmt.Errorf("missing daemon ID, cannot claim dungeon instance leases")
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	if args.LeaseDuration <= 0 {
+		err := fmt.Errorf("lease duration >%d< is invalid, cannot claim dungeon instance leases", args.LeaseDuration)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(args.LeaseDuration)
+
+	// Leases already held are always renewed before any new leases are claimed
+	held, err := m.DungeonInstanceLeaseRepository().RenewDaemonLeases(args.DaemonID, expiresAt)
+	if err != nil {
+		l.Warn("failed renewing dungeon instance leases >%v<", err)
+		return nil, err
+	}
+
+	result := ClaimDungeonInstanceLeasesResult{
+		Records: held,
+	}
+
+	// Expired leases are claimed before unclaimed leases as their dungeon
+	// instances have stalled
+	for _, params := range [][]coresql.Param{
+		{
+			{
+				Col: record.FieldDungeonInstanceLeaseExpiresAt,
+				Op:  coresql.OpLessThan,
+				Val: now,
+			},
+		},
+		{
+			{
+				Col: record.FieldDungeonInstanceLeaseDaemonID,
+				Op:  coresql.OpIsNull,
+			},
+		},
+	} {
+		limit := 0
+		if args.MaxLeases > 0 {
+			limit = args.MaxLeases - len(result.Records)
+			if limit <= 0 {
+				break
+			}
+		}
+
+		recs, err := m.GetDungeonInstanceLeaseRecs(
+			&coresql.Options{
+				Params: params,
+				OrderBy: []coresql.OrderBy{
+					{
+						Col:       "created_at",
+						Direction: coresql.OrderDirectionASC,
+					},
+				},
+				Limit: limit,
+				Lock:  coresql.ForUpdateSkipLocked,
+			},
+		)
+		if err != nil {
+			l.Warn("failed getting dungeon instance lease records >%v<", err)
+			return nil, err
+		}
+
+		for _, rec := range recs {
+			if rec.DaemonID.Valid {
+				l.Info("Claiming expired dungeon instance ID >%s< lease from daemon ID >%s<", rec.DungeonInstanceID, rec.DaemonID.String)
+			} else {
+				l.Info("Claiming dungeon instance ID >%s< lease", rec.DungeonInstanceID)
+			}
+
+			rec.DaemonID = null.NullStringFromString(args.DaemonID)
+			rec.ExpiresAt = null.NullTimeFromTime(expiresAt)
+			err := m.UpdateDungeonInstanceLeaseRec(rec)
+			if err != nil {
+				l.Warn("failed claiming dungeon instance lease ID >%s< >%v<", rec.ID, err)
+				return nil, err
+			}
+			result.Records = append(result.Records, rec)
+			result.ClaimedCount++
+		}
+	}
+
+	l.Debug("Daemon ID >%s< holds >%d< dungeon instance leases, claimed >%d<", args.DaemonID, len(result.Records), result.ClaimedCount)
+
+	return &result, nil
+}
+
+// ReleaseDungeonInstanceLeases releases all dungeon instance leases held by the
+// daemon so they can be immediately claimed by another daemon.
+func (m *Model) ReleaseDungeonInstanceLeases(daemonID string) error {
+	l := m.loggerWithFunctionContext("ReleaseDungeonInstanceLeases")
+
+	recs, err := m.GetDungeonInstanceLeaseRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldDungeonInstanceLeaseDaemonID,
+					Val: daemonID,
+				},
+			},
+			Lock: coresql.ForUpdate,
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance lease records >%v<", err)
+		return err
+	}
+
+	for _, rec := range recs {
+		l.Info("Releasing dungeon instance ID >%s< lease", rec.DungeonInstanceID)
+
+		rec.DaemonID = sql.NullString{}
+		rec.ExpiresAt = sql.NullTime{}
+		err := m.UpdateDungeonInstanceLeaseRec(rec)
+		if err != nil {
+			l.Warn("failed releasing dungeon instance lease ID >%s< >%v<", rec.ID, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HoldsDungeonInstanceLease returns whether the daemon currently holds an
+// unexpired lease on the dungeon instance.
+func (m *Model) HoldsDungeonInstanceLease(dungeonInstanceID, daemonID string) (bool, error) {
+	l := m.loggerWithFunctionContext("HoldsDungeonInstanceLease")
+
+	recs, err := m.GetDungeonInstanceLeaseRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldDungeonInstanceLeaseDungeonInstanceID,
+					Val: dungeonInstanceID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance lease records >%v<", err)
+		return false, err
+	}
+
+	if len(recs) != 1 {
+		l.Warn("Unexpected number of dungeon instance lease records >%d< for dungeon instance ID >%s<", len(recs), dungeonInstanceID)
+		return false, nil
+	}
+
+	rec := recs[0]
+
+	return rec.DaemonID.Valid &&
+		rec.DaemonID.String == daemonID &&
+		rec.ExpiresAt.Valid &&
+		rec.ExpiresAt.Time.After(time.Now().UTC()), nil
+}
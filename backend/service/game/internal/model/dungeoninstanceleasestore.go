@@ -0,0 +1,120 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// GetDungeonInstanceLeaseRecs -
+func (m *Model) GetDungeonInstanceLeaseRecs(opts *coresql.Options) ([]*record.DungeonInstanceLease, error) {
+
+	l := m.loggerWithFunctionContext("GetDungeonInstanceLeaseRecs")
+
+	l.Debug("Getting dungeon instance lease records opts >%#v<", opts)
+
+	r := m.DungeonInstanceLeaseRepository()
+
+	return r.GetMany(opts)
+}
+
+// GetDungeonInstanceLeaseRec -
+func (m *Model) GetDungeonInstanceLeaseRec(recID string, lock *coresql.Lock) (*record.DungeonInstanceLease, error) {
+
+	l := m.loggerWithFunctionContext("GetDungeonInstanceLeaseRec")
+
+	l.Debug("Getting dungeon instance lease rec ID >%s<", recID)
+
+	r := m.DungeonInstanceLeaseRepository()
+
+	if !m.IsUUID(recID) {
+		return nil, fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	rec, err := r.GetOne(recID, lock)
+	if err == sql.ErrNoRows {
+		l.Warn("No record found ID >%s<", recID)
+		return nil, nil
+	}
+
+	return rec, err
+}
+
+// CreateDungeonInstanceLeaseRec -
+func (m *Model) CreateDungeonInstanceLeaseRec(rec *record.DungeonInstanceLease) error {
+	l := m.loggerWithFunctionContext("CreateDungeonInstanceLeaseRec")
+
+	l.Debug("Creating dungeon instance lease record >%#v<", rec)
+
+	r := m.DungeonInstanceLeaseRepository()
+
+	err := m.validateDungeonInstanceLeaseRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.CreateOne(rec)
+}
+
+// UpdateDungeonInstanceLeaseRec -
+func (m *Model) UpdateDungeonInstanceLeaseRec(rec *record.DungeonInstanceLease) error {
+	l := m.loggerWithFunctionContext("UpdateDungeonInstanceLeaseRec")
+
+	l.Debug("Updating dungeon instance lease record >%#v<", rec)
+
+	r := m.DungeonInstanceLeaseRepository()
+
+	err := m.validateDungeonInstanceLeaseRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.UpdateOne(rec)
+}
+
+// DeleteDungeonInstanceLeaseRec -
+func (m *Model) DeleteDungeonInstanceLeaseRec(recID string) error {
+	l := m.loggerWithFunctionContext("DeleteDungeonInstanceLeaseRec")
+
+	l.Debug("Deleting dungeon instance lease rec ID >%s<", recID)
+
+	r := m.DungeonInstanceLeaseRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteDungeonInstanceLeaseRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.DeleteOne(recID)
+}
+
+// RemoveDungeonInstanceLeaseRec -
+func (m *Model) RemoveDungeonInstanceLeaseRec(recID string) error {
+
+	l := m.loggerWithFunctionContext("RemoveDungeonInstanceLeaseRec")
+
+	l.Debug("Removing dungeon instance lease rec ID >%s<", recID)
+
+	r := m.DungeonInstanceLeaseRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteDungeonInstanceLeaseRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.RemoveOne(recID)
+}
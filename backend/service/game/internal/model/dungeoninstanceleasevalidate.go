@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// validateDungeonInstanceLeaseRec - validates creating and updating a dungeon instance lease record
+func (m *Model) validateDungeonInstanceLeaseRec(rec *record.DungeonInstanceLease) error {
+	l := m.loggerWithFunctionContext("validateDungeonInstanceLeaseRec")
+
+	if rec.DungeonInstanceID == "" {
+		return fmt.Errorf("failed validation, DungeonInstanceID is empty")
+	}
+
+	// New dungeon instance lease
+	if rec.ID == "" {
+		// Can only have a single lease record per dungeon instance
+		recs, err := m.GetDungeonInstanceLeaseRecs(
+			&coresql.Options{
+				Params: []coresql.Param{
+					{
+						Col: record.FieldDungeonInstanceLeaseDungeonInstanceID,
+						Val: rec.DungeonInstanceID,
+					},
+				},
+			},
+		)
+		if err != nil {
+			l.Warn("failed to get many dungeon instance lease records >%v<", err)
+			return err
+		}
+
+		if len(recs) != 0 {
+			err := fmt.Errorf("dungeon instance lease record for dungeon instance ID >%s< already exists, cannot create dungeon instance lease record", rec.DungeonInstanceID)
+			l.Warn(err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDeleteDungeonInstanceLeaseRec - validates it is okay to delete a dungeon instance lease record
+func (m *Model) validateDeleteDungeonInstanceLeaseRec(recID string) error {
+
+	return nil
+}
@@ -0,0 +1,742 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/calculator"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// Replay divergence fields
+const (
+	ReplayFieldLocation string = "location"
+	ReplayFieldHealth   string = "health"
+	ReplayFieldObject   string = "object"
+)
+
+// replayAbsent is the recorded value of a character, monster or object the
+// replay expected to find in an action record but did not
+const replayAbsent string = "absent"
+
+// DungeonInstanceReplay is the turn by turn timeline of a dungeon instance
+// rebuilt from its action log
+type DungeonInstanceReplay struct {
+	DungeonInstanceID string             `json:"dungeon_instance_id"`
+	Actions           int                `json:"actions"`
+	Turns             []ReplayTurn       `json:"turns"`
+	Divergences       []ReplayDivergence `json:"divergences"`
+}
+
+// ReplayTurn is the actions performed during a turn and every character and
+// monster once the actions were replayed
+type ReplayTurn struct {
+	Turn     int            `json:"turn"`
+	Actions  []ReplayAction `json:"actions"`
+	Entities []ReplayEntity `json:"entities"`
+}
+
+// ReplayAction is an action from the action log
+type ReplayAction struct {
+	SerialNumber int    `json:"serial_number"`
+	ActionID     string `json:"action_id"`
+	Actor        string `json:"actor"`
+	Command      string `json:"command"`
+	Target       string `json:"target,omitempty"`
+	Location     string `json:"location"`
+}
+
+// ReplayEntity is a character or monster as rebuilt by the replay
+type ReplayEntity struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Location string   `json:"location"`
+	Health   int      `json:"health"`
+	Equipped []string `json:"equipped"`
+	Stashed  []string `json:"stashed"`
+}
+
+// ReplayDivergence is a difference between the replayed state of a character,
+// monster or object and the state recorded with an action. The replay
+// continues from the recorded state so a single divergence is reported once.
+type ReplayDivergence struct {
+	SerialNumber int    `json:"serial_number"`
+	Turn         int    `json:"turn"`
+	ActionID     string `json:"action_id"`
+	// RecordID is the action character, monster or object record the state
+	// was recorded in, empty when the state was expected but not recorded
+	RecordID   string `json:"record_id,omitempty"`
+	InstanceID string `json:"instance_id"`
+	Name       string `json:"name"`
+	Field      string `json:"field"`
+	Replayed   string `json:"replayed"`
+	Recorded   string `json:"recorded"`
+}
+
+// ReplayDungeonInstance replays the action log of a dungeon instance
+func (m *Model) ReplayDungeonInstance(dungeonInstanceID string) (*DungeonInstanceReplay, error) {
+	l := m.loggerWithFunctionContext("ReplayDungeonInstance")
+
+	rec, err := m.GetDungeonInstanceRec(dungeonInstanceID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record >%v<", err)
+		return nil, err
+	}
+
+	if rec == nil {
+		err := fmt.Errorf("dungeon instance ID >%s< does not exist", dungeonInstanceID)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	actionRecs, err := m.GetActionRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_instance_id",
+					Val: dungeonInstanceID,
+				},
+			},
+			OrderBy: []coresql.OrderBy{
+				{
+					Col:       "serial_number",
+					Direction: coresql.OrderDirectionASC,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance action records >%v<", err)
+		return nil, err
+	}
+
+	actionRecordSets := []*record.ActionRecordSet{}
+	for _, actionRec := range actionRecs {
+		actionRecordSet, err := m.GetActionRecordSet(actionRec.ID)
+		if err != nil {
+			l.Warn("failed getting action ID >%s< record set >%v<", actionRec.ID, err)
+			return nil, err
+		}
+		actionRecordSets = append(actionRecordSets, actionRecordSet)
+	}
+
+	l.Info("Replaying dungeon instance ID >%s< actions >%d<", dungeonInstanceID, len(actionRecordSets))
+
+	return ReplayActionRecordSets(dungeonInstanceID, actionRecordSets), nil
+}
+
+// ReplayActionRecordSets rebuilds the position, health and inventory of every
+// character and monster by applying each action in serial number order, then
+// compares the rebuilt state with the state recorded after the action.
+//
+// The action log does not record the starting state of a dungeon instance so
+// characters, monsters and objects are taken as recorded the first time they
+// appear. Characters that exit the dungeon and dead characters and monsters
+// that decay leave no action, they are removed when a location they were at is
+// recorded without them.
+func ReplayActionRecordSets(dungeonInstanceID string, actionRecordSets []*record.ActionRecordSet) *DungeonInstanceReplay {
+
+	s := newReplayState()
+
+	replay := DungeonInstanceReplay{
+		DungeonInstanceID: dungeonInstanceID,
+		Actions:           len(actionRecordSets),
+		Turns:             []ReplayTurn{},
+		Divergences:       []ReplayDivergence{},
+	}
+
+	var turn *ReplayTurn
+	for _, ars := range actionRecordSets {
+		actionRec := ars.ActionRec
+
+		if turn == nil || turn.Turn != actionRec.TurnNumber {
+			if turn != nil {
+				turn.Entities = s.replayEntities()
+				replay.Turns = append(replay.Turns, *turn)
+			}
+			turn = &ReplayTurn{
+				Turn:    actionRec.TurnNumber,
+				Actions: []ReplayAction{},
+			}
+		}
+
+		s.locate(ars)
+
+		// Only what was known before the action was performed is replayed,
+		// anything appearing for the first time is taken as recorded
+		known := s.known()
+
+		s.apply(ars, known)
+
+		replay.Divergences = append(replay.Divergences, s.compare(ars, known)...)
+
+		turn.Actions = append(turn.Actions, s.replayAction(ars))
+	}
+
+	if turn != nil {
+		turn.Entities = s.replayEntities()
+		replay.Turns = append(replay.Turns, *turn)
+	}
+
+	return &replay
+}
+
+// WriteText writes the replay timeline as text
+func (r *DungeonInstanceReplay) WriteText(w io.Writer) error {
+
+	lines := []string{
+		fmt.Sprintf("Instance        %s", r.DungeonInstanceID),
+		fmt.Sprintf("Actions         %d", r.Actions),
+		fmt.Sprintf("Turns           %d", len(r.Turns)),
+		fmt.Sprintf("Divergences     %d", len(r.Divergences)),
+	}
+
+	divergences := map[int][]ReplayDivergence{}
+	for _, d := range r.Divergences {
+		divergences[d.SerialNumber] = append(divergences[d.SerialNumber], d)
+	}
+
+	for _, t := range r.Turns {
+		lines = append(lines, "", fmt.Sprintf("Turn %d", t.Turn))
+		for _, a := range t.Actions {
+			line := fmt.Sprintf("  #%-6d %s %s", a.SerialNumber, a.Actor, a.Command)
+			if a.Target != "" {
+				line += fmt.Sprintf(" %s", a.Target)
+			}
+			line += fmt.Sprintf(" at %s", a.Location)
+			lines = append(lines, line)
+
+			for _, d := range divergences[a.SerialNumber] {
+				lines = append(lines, fmt.Sprintf("    ! %s %s replayed >%s< recorded >%s<", d.Name, d.Field, d.Replayed, d.Recorded))
+			}
+		}
+		for _, e := range t.Entities {
+			line := fmt.Sprintf("  %s %s at %s with %d health", e.Type, e.Name, e.Location, e.Health)
+			if len(e.Equipped) > 0 {
+				line += fmt.Sprintf(", equipped %s", strings.Join(e.Equipped, ", "))
+			}
+			if len(e.Stashed) > 0 {
+				line += fmt.Sprintf(", stashed %s", strings.Join(e.Stashed, ", "))
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replayState is the replayed state of a dungeon instance
+type replayState struct {
+	locations map[string]string
+	entities  map[string]*replayEntityState
+	objects   map[string]*replayObjectState
+	// removed are characters and monsters that left the dungeon instance
+	removed map[string]*replayEntityState
+	// order is the order characters and monsters first appeared in
+	order []string
+}
+
+type replayEntityState struct {
+	id                 string
+	name               string
+	entityType         EntityType
+	locationInstanceID string
+	health             int
+	maxHealth          int
+	strength           int
+}
+
+type replayObjectState struct {
+	id                 string
+	name               string
+	locationInstanceID string
+	holderID           string
+	isStashed          bool
+	isEquipped         bool
+}
+
+// replayKnown is what was known before an action was replayed
+type replayKnown struct {
+	entities map[string]bool
+	objects  map[string]bool
+}
+
+func newReplayState() *replayState {
+	return &replayState{
+		locations: map[string]string{},
+		entities:  map[string]*replayEntityState{},
+		objects:   map[string]*replayObjectState{},
+		removed:   map[string]*replayEntityState{},
+	}
+}
+
+func (s *replayState) known() *replayKnown {
+	k := &replayKnown{
+		entities: map[string]bool{},
+		objects:  map[string]bool{},
+	}
+	for id := range s.entities {
+		k.entities[id] = true
+	}
+	for id := range s.objects {
+		k.objects[id] = true
+	}
+	return k
+}
+
+// locate records the names of the locations in an action record set
+func (s *replayState) locate(ars *record.ActionRecordSet) {
+	for _, als := range []*record.ActionLocationRecordSet{ars.CurrentLocation, ars.TargetLocation} {
+		if als != nil && als.LocationInstanceViewRec != nil {
+			s.locations[als.LocationInstanceViewRec.ID] = als.LocationInstanceViewRec.Name
+		}
+	}
+}
+
+func (s *replayState) locationName(locationInstanceID string) string {
+	if name, ok := s.locations[locationInstanceID]; ok {
+		return name
+	}
+	return locationInstanceID
+}
+
+func (s *replayState) entityName(id string) string {
+	if e, ok := s.entities[id]; ok {
+		return e.name
+	}
+	if e, ok := s.removed[id]; ok {
+		return e.name
+	}
+	return id
+}
+
+func (s *replayState) objectName(id string) string {
+	if o, ok := s.objects[id]; ok {
+		return o.name
+	}
+	return id
+}
+
+// objectWhere describes where an object is
+func (s *replayState) objectWhere(o *replayObjectState) string {
+	if o.holderID == "" {
+		if o.locationInstanceID == "" {
+			return replayAbsent
+		}
+		return s.locationName(o.locationInstanceID)
+	}
+
+	holder := s.entityName(o.holderID)
+
+	switch {
+	case o.isEquipped:
+		return fmt.Sprintf("%s (equipped)", holder)
+	case o.isStashed:
+		return fmt.Sprintf("%s (stashed)", holder)
+	}
+
+	return holder
+}
+
+// actorID returns the character or monster that performed an action, empty
+// for system actions
+func actorID(actionRec *record.Action) string {
+	switch {
+	case actionRec.CharacterInstanceID.Valid:
+		return actionRec.CharacterInstanceID.String
+	case actionRec.MonsterInstanceID.Valid:
+		return actionRec.MonsterInstanceID.String
+	}
+	return ""
+}
+
+// targetEntityID returns the character or monster an action targeted
+func targetEntityID(actionRec *record.Action) string {
+	switch {
+	case actionRec.ResolvedTargetCharacterInstanceID.Valid:
+		return actionRec.ResolvedTargetCharacterInstanceID.String
+	case actionRec.ResolvedTargetMonsterInstanceID.Valid:
+		return actionRec.ResolvedTargetMonsterInstanceID.String
+	}
+	return ""
+}
+
+// apply performs an action against the replayed state the same way the
+// action was performed against the dungeon instance
+func (s *replayState) apply(ars *record.ActionRecordSet, known *replayKnown) {
+	actionRec := ars.ActionRec
+
+	actor := s.entities[actorID(actionRec)]
+	if actor != nil && !known.entities[actor.id] {
+		actor = nil
+	}
+
+	target := s.entities[targetEntityID(actionRec)]
+	if target != nil && !known.entities[target.id] {
+		target = nil
+	}
+
+	hold := func(objectInstanceID sql.NullString, equipped bool) {
+		o := s.objects[null.NullStringToString(objectInstanceID)]
+		if o == nil || actor == nil {
+			return
+		}
+		o.locationInstanceID = ""
+		o.holderID = actor.id
+		o.isEquipped = equipped
+		o.isStashed = !equipped
+	}
+
+	switch actionRec.ResolvedCommand {
+	case record.ActionCommandMove:
+		if actor != nil {
+			actor.locationInstanceID = actionRec.LocationInstanceID
+		}
+	case record.ActionCommandStash:
+		hold(actionRec.ResolvedStashedObjectInstanceID, false)
+	case record.ActionCommandEquip:
+		hold(actionRec.ResolvedEquippedObjectInstanceID, true)
+	case record.ActionCommandDrop:
+		o := s.objects[null.NullStringToString(actionRec.ResolvedDroppedObjectInstanceID)]
+		if o != nil && actor != nil {
+			o.locationInstanceID = actionRec.LocationInstanceID
+			o.holderID = ""
+			o.isEquipped = false
+			o.isStashed = false
+		}
+	case record.ActionCommandAttack:
+		if actor == nil || target == nil {
+			return
+		}
+		dmg := 0
+		switch actor.entityType {
+		case EntityTypeCharacter:
+			dmg, _ = calculator.CalculateCharacterDamage(&record.CharacterInstanceView{Strength: actor.strength}, nil)
+		case EntityTypeMonster:
+			dmg, _ = calculator.CalculateMonsterDamage(&record.MonsterInstanceView{Strength: actor.strength}, nil)
+		}
+		target.health -= dmg
+	case record.ActionCommandTeleport:
+		if target != nil {
+			target.locationInstanceID = null.NullStringToString(actionRec.ResolvedTargetLocationInstanceID)
+		}
+	case record.ActionCommandHeal:
+		if target != nil {
+			target.health = target.maxHealth
+		}
+	case record.ActionCommandKill:
+		if target != nil {
+			target.health = 0
+		}
+	}
+}
+
+// compare compares the replayed state with the state recorded after an action
+// and continues from the recorded state
+func (s *replayState) compare(ars *record.ActionRecordSet, known *replayKnown) []ReplayDivergence {
+	actionRec := ars.ActionRec
+
+	divergences := []ReplayDivergence{}
+
+	diverge := func(recordID, instanceID, name, field, replayed, recorded string) {
+		divergences = append(divergences, ReplayDivergence{
+			SerialNumber: int(null.NullInt32ToInt32(actionRec.SerialNumber)),
+			Turn:         actionRec.TurnNumber,
+			ActionID:     actionRec.ID,
+			RecordID:     recordID,
+			InstanceID:   instanceID,
+			Name:         name,
+			Field:        field,
+			Replayed:     replayed,
+			Recorded:     recorded,
+		})
+	}
+
+	entity := func(recordID, id string, entityType EntityType, name, locationInstanceID string, health, maxHealth, strength int) {
+		e, ok := s.entities[id]
+		if !ok || !known.entities[id] {
+			if r, ok := s.removed[id]; ok {
+				diverge(recordID, id, name, ReplayFieldLocation, replayAbsent, s.locationName(locationInstanceID))
+				delete(s.removed, id)
+				e = r
+			}
+			if e == nil {
+				e = &replayEntityState{id: id}
+				s.order = append(s.order, id)
+			}
+			e.name = name
+			e.entityType = entityType
+			e.locationInstanceID = locationInstanceID
+			e.health = health
+			e.maxHealth = maxHealth
+			e.strength = strength
+			s.entities[id] = e
+			return
+		}
+		if e.locationInstanceID != locationInstanceID {
+			diverge(recordID, id, name, ReplayFieldLocation, s.locationName(e.locationInstanceID), s.locationName(locationInstanceID))
+			e.locationInstanceID = locationInstanceID
+		}
+		if e.health != health {
+			diverge(recordID, id, name, ReplayFieldHealth, strconv.Itoa(e.health), strconv.Itoa(health))
+			e.health = health
+		}
+		e.maxHealth = maxHealth
+		e.strength = strength
+	}
+
+	object := func(recordID, id, name string, where *replayObjectState) {
+		o, ok := s.objects[id]
+		if !ok || !known.objects[id] {
+			o := *where
+			o.id = id
+			o.name = name
+			s.objects[id] = &o
+			return
+		}
+		if o.holderID != where.holderID || o.locationInstanceID != where.locationInstanceID ||
+			o.isEquipped != where.isEquipped || o.isStashed != where.isStashed {
+			diverge(recordID, id, name, ReplayFieldObject, s.objectWhere(o), s.objectWhere(where))
+			o.holderID = where.holderID
+			o.locationInstanceID = where.locationInstanceID
+			o.isEquipped = where.isEquipped
+			o.isStashed = where.isStashed
+		}
+	}
+
+	// inventory compares every object a character or monster holds, or only
+	// their equipped objects when only equipped objects were recorded
+	inventory := func(holderID string, equippedOnly bool, recorded map[string]bool) {
+		ids := []string{}
+		for id, o := range s.objects {
+			if o.holderID == holderID && (!equippedOnly || o.isEquipped) && !recorded[id] && known.objects[id] {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			o := s.objects[id]
+			diverge("", id, o.name, ReplayFieldObject, s.objectWhere(o), replayAbsent)
+			o.holderID = ""
+			o.isEquipped = false
+			o.isStashed = false
+		}
+	}
+
+	characterObjects := func(holderID string, recs []*record.ActionCharacterObject, equippedOnly bool) {
+		recorded := map[string]bool{}
+		for _, rec := range recs {
+			recorded[rec.ObjectInstanceID] = true
+			object(rec.ID, rec.ObjectInstanceID, rec.Name, &replayObjectState{
+				holderID:   holderID,
+				isEquipped: rec.IsEquipped,
+				isStashed:  rec.IsStashed,
+			})
+		}
+		inventory(holderID, equippedOnly, recorded)
+	}
+
+	monsterObjects := func(holderID string, recs []*record.ActionMonsterObject, equippedOnly bool) {
+		recorded := map[string]bool{}
+		for _, rec := range recs {
+			recorded[rec.ObjectInstanceID] = true
+			object(rec.ID, rec.ObjectInstanceID, rec.Name, &replayObjectState{
+				holderID:   holderID,
+				isEquipped: rec.IsEquipped,
+				isStashed:  rec.IsStashed,
+			})
+		}
+		inventory(holderID, equippedOnly, recorded)
+	}
+
+	character := func(rec *record.ActionCharacter) {
+		entity(rec.ID, rec.CharacterInstanceID, EntityTypeCharacter, rec.Name, rec.LocationInstanceID, rec.CurrentHealth, rec.Health, rec.Strength)
+	}
+
+	monster := func(rec *record.ActionMonster) {
+		entity(rec.ID, rec.MonsterInstanceID, EntityTypeMonster, rec.Name, rec.LocationInstanceID, rec.CurrentHealth, rec.Health, rec.Strength)
+	}
+
+	// The character or monster performing the action and everything they hold
+	if ars.ActionCharacterRec != nil {
+		character(ars.ActionCharacterRec)
+		characterObjects(ars.ActionCharacterRec.CharacterInstanceID, ars.ActionCharacterObjectRecs, false)
+	}
+	if ars.ActionMonsterRec != nil {
+		monster(ars.ActionMonsterRec)
+		monsterObjects(ars.ActionMonsterRec.MonsterInstanceID, ars.ActionMonsterObjectRecs, false)
+	}
+
+	// The character or monster targeted and their equipped objects
+	if ars.TargetActionCharacterRec != nil {
+		character(ars.TargetActionCharacterRec)
+		characterObjects(ars.TargetActionCharacterRec.CharacterInstanceID, ars.TargetActionCharacterObjectRecs, true)
+	}
+	if ars.TargetActionMonsterRec != nil {
+		monster(ars.TargetActionMonsterRec)
+		monsterObjects(ars.TargetActionMonsterRec.MonsterInstanceID, ars.TargetActionMonsterObjectRecs, true)
+	}
+
+	// Every character, monster and object at the locations
+	for _, als := range []*record.ActionLocationRecordSet{ars.CurrentLocation, ars.TargetLocation} {
+		if als == nil || als.LocationInstanceViewRec == nil {
+			continue
+		}
+		locationInstanceID := als.LocationInstanceViewRec.ID
+
+		recorded := map[string]bool{}
+		for _, rec := range als.ActionCharacterRecs {
+			recorded[rec.CharacterInstanceID] = true
+			character(rec)
+		}
+		for _, rec := range als.ActionMonsterRecs {
+			recorded[rec.MonsterInstanceID] = true
+			monster(rec)
+		}
+
+		ids := []string{}
+		for id, e := range s.entities {
+			if e.locationInstanceID == locationInstanceID && !recorded[id] && known.entities[id] {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			e := s.entities[id]
+			// Dead characters and monsters decay and characters exit the
+			// dungeon without an action, only living monsters are missing
+			if e.entityType == EntityTypeMonster && e.health > 0 {
+				diverge("", id, e.name, ReplayFieldLocation, s.locationName(e.locationInstanceID), replayAbsent)
+			}
+			s.remove(id)
+		}
+
+		recorded = map[string]bool{}
+		for _, rec := range als.ActionObjectRecs {
+			recorded[rec.ObjectInstanceID] = true
+			object(rec.ID, rec.ObjectInstanceID, rec.Name, &replayObjectState{
+				locationInstanceID: locationInstanceID,
+			})
+		}
+
+		ids = []string{}
+		for id, o := range s.objects {
+			if o.holderID == "" && o.locationInstanceID == locationInstanceID && !recorded[id] && known.objects[id] {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			o := s.objects[id]
+			diverge("", id, o.name, ReplayFieldObject, s.objectWhere(o), replayAbsent)
+			o.locationInstanceID = ""
+		}
+	}
+
+	// A kicked character is recorded before they exit the dungeon
+	if actionRec.ResolvedCommand == record.ActionCommandKick {
+		s.remove(null.NullStringToString(actionRec.ResolvedTargetCharacterInstanceID))
+	}
+
+	return divergences
+}
+
+// remove removes a character or monster, along with the objects they hold,
+// from the dungeon instance
+func (s *replayState) remove(id string) {
+	e, ok := s.entities[id]
+	if !ok {
+		return
+	}
+	delete(s.entities, id)
+	s.removed[id] = e
+
+	for objectID, o := range s.objects {
+		if o.holderID == id {
+			delete(s.objects, objectID)
+		}
+	}
+}
+
+// replayAction describes an action from the action log
+func (s *replayState) replayAction(ars *record.ActionRecordSet) ReplayAction {
+	actionRec := ars.ActionRec
+
+	actor := null.NullStringToString(actionRec.SystemActor)
+	if id := actorID(actionRec); id != "" {
+		actor = s.entityName(id)
+	}
+
+	target := ""
+	switch {
+	case targetEntityID(actionRec) != "":
+		target = s.entityName(targetEntityID(actionRec))
+	case actionRec.ResolvedTargetObjectInstanceID.Valid:
+		target = s.objectName(actionRec.ResolvedTargetObjectInstanceID.String)
+	case actionRec.ResolvedTargetLocationDirection.Valid:
+		target = actionRec.ResolvedTargetLocationDirection.String
+	}
+
+	return ReplayAction{
+		SerialNumber: int(null.NullInt32ToInt32(actionRec.SerialNumber)),
+		ActionID:     actionRec.ID,
+		Actor:        actor,
+		Command:      actionRec.ResolvedCommand,
+		Target:       target,
+		Location:     s.locationName(actionRec.LocationInstanceID),
+	}
+}
+
+// replayEntities returns every character and monster in the order they first
+// appeared
+func (s *replayState) replayEntities() []ReplayEntity {
+
+	entities := []ReplayEntity{}
+	for _, id := range s.order {
+		e, ok := s.entities[id]
+		if !ok {
+			continue
+		}
+
+		re := ReplayEntity{
+			ID:       e.id,
+			Name:     e.name,
+			Type:     string(e.entityType),
+			Location: s.locationName(e.locationInstanceID),
+			Health:   e.health,
+			Equipped: []string{},
+			Stashed:  []string{},
+		}
+
+		for _, o := range s.objects {
+			if o.holderID != e.id {
+				continue
+			}
+			if o.isEquipped {
+				re.Equipped = append(re.Equipped, o.name)
+			}
+			if o.isStashed {
+				re.Stashed = append(re.Stashed, o.name)
+			}
+		}
+		sort.Strings(re.Equipped)
+		sort.Strings(re.Stashed)
+
+		entities = append(entities, re)
+	}
+
+	return entities
+}
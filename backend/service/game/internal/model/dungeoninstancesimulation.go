@@ -0,0 +1,648 @@
+package model
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// SimulationScript places scripted characters in a simulated dungeon instance
+type SimulationScript struct {
+	Characters []SimulationCharacter `json:"characters" yaml:"characters"`
+}
+
+// SimulationCharacter is a character that submits the same sentences every
+// time a simulation is run
+type SimulationCharacter struct {
+	Name string `json:"name" yaml:"name"`
+	// Attributes default to an even share of the attribute points new
+	// characters have
+	Strength     int `json:"strength,omitempty" yaml:"strength,omitempty"`
+	Dexterity    int `json:"dexterity,omitempty" yaml:"dexterity,omitempty"`
+	Intelligence int `json:"intelligence,omitempty" yaml:"intelligence,omitempty"`
+	// Location is the name of the location the character enters the dungeon
+	// instance at, the default location when empty
+	Location string `json:"location,omitempty" yaml:"location,omitempty"`
+	// Actions are the sentences submitted, one each turn
+	Actions []string `json:"actions" yaml:"actions"`
+	// Repeat submits the actions again from the first once every action has
+	// been submitted, otherwise the character does nothing more
+	Repeat bool `json:"repeat,omitempty" yaml:"repeat,omitempty"`
+}
+
+// UnmarshalSimulationScript reads a YAML or JSON simulation script, unknown
+// fields are rejected so misspelt fields are not silently ignored
+func UnmarshalSimulationScript(data []byte) (*SimulationScript, error) {
+
+	s := &SimulationScript{}
+
+	// JSON is a subset of YAML
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	err := dec.Decode(s)
+	if err != nil {
+		return nil, coreerror.NewInvalidDataError("failed reading simulation script >%v<", err)
+	}
+
+	for _, c := range s.Characters {
+		if c.Name == "" {
+			return nil, coreerror.NewInvalidDataError("simulation script character name is required")
+		}
+	}
+
+	return s, nil
+}
+
+// sentence returns the sentence a scripted character submits at a turn, empty
+// when the character has nothing more to do
+func (c *SimulationCharacter) sentence(turn int) string {
+	if len(c.Actions) == 0 {
+		return ""
+	}
+	idx := turn - 1
+	if idx >= len(c.Actions) {
+		if !c.Repeat {
+			return ""
+		}
+		idx = idx % len(c.Actions)
+	}
+	return c.Actions[idx]
+}
+
+type SimulateDungeonInstanceArgs struct {
+	DungeonName string
+	// Turns is the number of turns to run
+	Turns int
+	// Seed pins the dungeon instance seed, a random seed is used when zero
+	Seed   int64
+	Script *SimulationScript
+}
+
+// DungeonInstanceSimulation summarises what happened in a simulated dungeon
+// instance
+type DungeonInstanceSimulation struct {
+	DungeonInstanceID string                     `json:"dungeon_instance_id"`
+	DungeonName       string                     `json:"dungeon_name"`
+	Seed              int64                      `json:"seed"`
+	Turns             int                        `json:"turns"`
+	Deaths            []SimulationDeath          `json:"deaths"`
+	Movements         []SimulationMovement       `json:"movements"`
+	ObjectChanges     []SimulationObjectChange   `json:"object_changes"`
+	Survivors         []SimulationEntity         `json:"survivors"`
+	Errors            []SimulationCharacterError `json:"errors,omitempty"`
+}
+
+// SimulationEntity is a character or monster
+type SimulationEntity struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Location string `json:"location"`
+	Health   int    `json:"health"`
+}
+
+// SimulationDeath is a character or monster that died
+type SimulationDeath struct {
+	Turn     int    `json:"turn"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Location string `json:"location"`
+	// KilledBy is the name of the last character or monster to attack during
+	// the turn, empty when nothing attacked
+	KilledBy string `json:"killed_by,omitempty"`
+}
+
+// SimulationMovement is every location a character or monster moved to
+type SimulationMovement struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Moves     int      `json:"moves"`
+	Locations []string `json:"locations"`
+}
+
+// SimulationObjectChange is an object that changed hands or places
+type SimulationObjectChange struct {
+	Turn   int    `json:"turn"`
+	Object string `json:"object"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// SimulationCharacterError is a scripted character sentence that failed
+type SimulationCharacterError struct {
+	Turn     int    `json:"turn"`
+	Name     string `json:"name"`
+	Sentence string `json:"sentence"`
+	Error    string `json:"error"`
+}
+
+// SimulateDungeonInstance creates a dungeon instance, places any scripted
+// characters and runs turns as fast as they can be processed by advancing the
+// model clock a turn duration each turn. Monsters act as they would in the
+// daemon and scripted characters submit their sentences after the monsters.
+// Records are created in the model transaction, roll back to discard them.
+func (m *Model) SimulateDungeonInstance(args *SimulateDungeonInstanceArgs) (*DungeonInstanceSimulation, error) {
+	l := m.loggerWithFunctionContext("SimulateDungeonInstance")
+
+	if args.Turns < 1 {
+		return nil, coreerror.NewInvalidDataError("turns >%d< must be at least 1", args.Turns)
+	}
+
+	dungeonRec, err := m.getDungeonRecByName(args.DungeonName)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		return nil, err
+	}
+	if dungeonRec == nil {
+		return nil, coreerror.NewNotFoundError("dungeon", args.DungeonName)
+	}
+
+	seeder, clock := m.seeder, m.clock
+	defer func() {
+		m.seeder, m.clock = seeder, clock
+	}()
+
+	if args.Seed != 0 {
+		m.SetSeeder(func() int64 { return args.Seed })
+	}
+
+	now := m.now()
+	m.SetClock(func() time.Time { return now })
+
+	dungeonInstanceRecordSet, err := m.CreateDungeonInstance(dungeonRec.ID)
+	if err != nil {
+		l.Warn("failed creating dungeon instance >%v<", err)
+		return nil, err
+	}
+	dungeonInstanceID := dungeonInstanceRecordSet.DungeonInstanceRec.ID
+
+	l.Info("Simulating dungeon instance ID >%s< seed >%d< turns >%d<", dungeonInstanceID, dungeonInstanceRecordSet.DungeonInstanceRec.Seed, args.Turns)
+
+	characters := []SimulationCharacter{}
+	if args.Script != nil {
+		characters = args.Script.Characters
+	}
+
+	characterInstanceIDs, err := m.placeSimulationCharacters(dungeonInstanceID, characters)
+	if err != nil {
+		l.Warn("failed placing simulation characters >%v<", err)
+		return nil, err
+	}
+
+	before, err := m.simulationState(dungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting simulation state >%v<", err)
+		return nil, err
+	}
+
+	s := newSimulationSummary(before)
+
+	// The first turn is measured from when the turn record is created
+	_, err = m.IncrementDungeonInstanceTurn(&IncrementDungeonInstanceTurnArgs{
+		DungeonInstanceID: dungeonInstanceID,
+	})
+	if err != nil {
+		l.Warn("failed creating dungeon instance turn >%v<", err)
+		return nil, err
+	}
+
+	for turn := 1; turn <= args.Turns; turn++ {
+
+		now = now.Add(m.turnDuration)
+
+		result, err := m.ProcessDungeonInstanceTurn(&ProcessDungeonInstanceTurnArgs{
+			DungeonInstanceID: dungeonInstanceID,
+		})
+		if err != nil {
+			l.Warn("failed processing dungeon instance turn >%v<", err)
+			return nil, err
+		}
+		if !result.IncrementTurnResult.Incremented {
+			err := fmt.Errorf("dungeon instance ID >%s< turn >%d< was not incremented", dungeonInstanceID, turn)
+			l.Warn(err.Error())
+			return nil, err
+		}
+
+		actionRecordSets := result.MonsterInstanceActionRecordSets
+
+		for idx := range characters {
+			sentence := characters[idx].sentence(turn)
+			if sentence == "" {
+				continue
+			}
+
+			// Dead and decayed characters no longer act
+			entity, ok := before.entities[characterInstanceIDs[idx]]
+			if !ok || entity.health <= 0 {
+				continue
+			}
+
+			ars, err := m.ProcessCharacterAction(dungeonInstanceID, characterInstanceIDs[idx], sentence)
+			if err != nil {
+				l.Info("Character >%s< sentence >%s< failed >%v<", characters[idx].Name, sentence, err)
+				s.Errors = append(s.Errors, SimulationCharacterError{
+					Turn:     turn,
+					Name:     characters[idx].Name,
+					Sentence: sentence,
+					Error:    err.Error(),
+				})
+				continue
+			}
+			if ars != nil {
+				actionRecordSets = append(actionRecordSets, ars)
+			}
+		}
+
+		after, err := m.simulationState(dungeonInstanceID)
+		if err != nil {
+			l.Warn("failed getting simulation state >%v<", err)
+			return nil, err
+		}
+
+		s.turn(turn, before, after, actionRecordSets)
+		before = after
+	}
+
+	sim := s.simulation(before)
+	sim.DungeonInstanceID = dungeonInstanceID
+	sim.DungeonName = dungeonRec.Name
+	sim.Seed = dungeonInstanceRecordSet.DungeonInstanceRec.Seed
+	sim.Turns = args.Turns
+
+	return sim, nil
+}
+
+// placeSimulationCharacters creates scripted characters and enters them into a
+// dungeon instance, returning their character instance IDs in script order
+func (m *Model) placeSimulationCharacters(dungeonInstanceID string, characters []SimulationCharacter) ([]string, error) {
+	l := m.loggerWithFunctionContext("placeSimulationCharacters")
+
+	locationInstanceViewRecs, err := m.GetLocationInstanceViewRecs(referenceOptions("dungeon_instance_id", dungeonInstanceID))
+	if err != nil {
+		l.Warn("failed getting location instance view records >%v<", err)
+		return nil, err
+	}
+
+	characterInstanceIDs := []string{}
+	for _, c := range characters {
+
+		locationInstanceID := ""
+		for _, rec := range locationInstanceViewRecs {
+			if (c.Location == "" && rec.IsDefault) || (c.Location != "" && strings.EqualFold(rec.Name, c.Location)) {
+				locationInstanceID = rec.ID
+				break
+			}
+		}
+		if locationInstanceID == "" {
+			return nil, coreerror.NewInvalidDataError("simulation character >%s< location >%s< not found", c.Name, c.Location)
+		}
+
+		characterRec := &record.Character{
+			Name:         c.Name,
+			Strength:     c.Strength,
+			Dexterity:    c.Dexterity,
+			Intelligence: c.Intelligence,
+		}
+		for _, attribute := range []*int{&characterRec.Strength, &characterRec.Dexterity, &characterRec.Intelligence} {
+			if *attribute == 0 {
+				*attribute = defaultAttributePoints / 3
+			}
+		}
+
+		err := m.CreateCharacterRec(characterRec)
+		if err != nil {
+			l.Warn("failed creating simulation character >%s< record >%v<", c.Name, err)
+			return nil, err
+		}
+
+		characterInstanceRecordSet, err := m.CreateCharacterInstance(locationInstanceID, characterRec.ID)
+		if err != nil {
+			l.Warn("failed creating simulation character >%s< instance >%v<", c.Name, err)
+			return nil, err
+		}
+
+		characterInstanceIDs = append(characterInstanceIDs, characterInstanceRecordSet.CharacterInstanceRec.ID)
+	}
+
+	return characterInstanceIDs, nil
+}
+
+// simulationState is what a simulation summary is built from, entities and
+// objects are keyed by instance ID
+type simulationState struct {
+	locations map[string]string
+	entities  map[string]simulationEntityState
+	objects   map[string]simulationObjectState
+}
+
+type simulationEntityState struct {
+	name               string
+	entityType         string
+	locationInstanceID string
+	health             int
+}
+
+type simulationObjectState struct {
+	name string
+	// where describes who has the object or where it lies
+	where string
+}
+
+func (m *Model) simulationState(dungeonInstanceID string) (*simulationState, error) {
+	l := m.loggerWithFunctionContext("simulationState")
+
+	rs, err := m.GetDungeonInstanceViewRecordSet(dungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance view record set >%v<", err)
+		return nil, err
+	}
+
+	s := &simulationState{
+		locations: map[string]string{},
+		entities:  map[string]simulationEntityState{},
+		objects:   map[string]simulationObjectState{},
+	}
+
+	for _, rec := range rs.LocationInstanceViewRecs {
+		s.locations[rec.ID] = rec.Name
+	}
+	for _, rec := range rs.CharacterInstanceViewRecs {
+		s.entities[rec.ID] = simulationEntityState{
+			name:               rec.Name,
+			entityType:         string(EntityTypeCharacter),
+			locationInstanceID: rec.LocationInstanceID,
+			health:             rec.CurrentHealth,
+		}
+	}
+	for _, rec := range rs.MonsterInstanceViewRecs {
+		s.entities[rec.ID] = simulationEntityState{
+			name:               rec.Name,
+			entityType:         string(EntityTypeMonster),
+			locationInstanceID: rec.LocationInstanceID,
+			health:             rec.CurrentHealth,
+		}
+	}
+	for _, rec := range rs.ObjectInstanceViewRecs {
+		s.objects[rec.ID] = simulationObjectState{
+			name:  rec.Name,
+			where: s.objectWhere(rec),
+		}
+	}
+
+	return s, nil
+}
+
+func (s *simulationState) objectWhere(rec *record.ObjectInstanceView) string {
+
+	holder := ""
+	switch {
+	case rec.CharacterInstanceID.Valid:
+		holder = s.entities[rec.CharacterInstanceID.String].name
+	case rec.MonsterInstanceID.Valid:
+		holder = s.entities[rec.MonsterInstanceID.String].name
+	default:
+		return s.locations[null.NullStringToString(rec.LocationInstanceID)]
+	}
+
+	switch {
+	case rec.IsEquipped:
+		return fmt.Sprintf("%s (equipped)", holder)
+	case rec.IsStashed:
+		return fmt.Sprintf("%s (stashed)", holder)
+	}
+
+	return holder
+}
+
+// simulationSummary collects what happened each turn
+type simulationSummary struct {
+	DungeonInstanceSimulation
+	movements map[string]*SimulationMovement
+	moved     []string
+}
+
+func newSimulationSummary(start *simulationState) *simulationSummary {
+
+	s := &simulationSummary{
+		movements: map[string]*SimulationMovement{},
+	}
+
+	// Movements start where entities start, in a stable order
+	ids := []string{}
+	for id := range start.entities {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return start.entities[ids[i]].name < start.entities[ids[j]].name
+	})
+	for _, id := range ids {
+		s.track(start, id)
+	}
+
+	return s
+}
+
+// track starts tracking the movements of an entity
+func (s *simulationSummary) track(state *simulationState, id string) {
+	entity := state.entities[id]
+	s.movements[id] = &SimulationMovement{
+		Name:      entity.name,
+		Type:      entity.entityType,
+		Locations: []string{state.locations[entity.locationInstanceID]},
+	}
+	s.moved = append(s.moved, id)
+}
+
+// turn adds the deaths, movements and object changes between the state before
+// and after a turn
+func (s *simulationSummary) turn(turn int, before, after *simulationState, actionRecordSets []*record.ActionRecordSet) {
+
+	// The last character or monster to attack each target
+	attackers := map[string]string{}
+	for _, ars := range actionRecordSets {
+		if ars == nil || ars.ActionRec == nil || ars.ActionRec.ResolvedCommand != record.ActionCommandAttack {
+			continue
+		}
+		attacker := ""
+		switch {
+		case ars.ActionCharacterRec != nil:
+			attacker = ars.ActionCharacterRec.Name
+		case ars.ActionMonsterRec != nil:
+			attacker = ars.ActionMonsterRec.Name
+		}
+		for _, target := range []sql.NullString{ars.ActionRec.ResolvedTargetCharacterInstanceID, ars.ActionRec.ResolvedTargetMonsterInstanceID} {
+			if target.Valid {
+				attackers[target.String] = attacker
+			}
+		}
+	}
+
+	ids := []string{}
+	for id := range after.entities {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return after.entities[ids[i]].name < after.entities[ids[j]].name
+	})
+
+	for _, id := range ids {
+		a := after.entities[id]
+		b, ok := before.entities[id]
+		if !ok {
+			// Spawned during the turn
+			s.track(after, id)
+			continue
+		}
+
+		if b.health > 0 && a.health <= 0 {
+			s.Deaths = append(s.Deaths, SimulationDeath{
+				Turn:     turn,
+				Name:     a.name,
+				Type:     a.entityType,
+				Location: after.locations[a.locationInstanceID],
+				KilledBy: attackers[id],
+			})
+		}
+
+		if b.locationInstanceID != a.locationInstanceID {
+			mv := s.movements[id]
+			mv.Moves++
+			mv.Locations = append(mv.Locations, after.locations[a.locationInstanceID])
+		}
+	}
+
+	objectIDs := []string{}
+	for id := range before.objects {
+		objectIDs = append(objectIDs, id)
+	}
+	for id := range after.objects {
+		if _, ok := before.objects[id]; !ok {
+			objectIDs = append(objectIDs, id)
+		}
+	}
+	sort.Strings(objectIDs)
+
+	changes := []SimulationObjectChange{}
+	for _, id := range objectIDs {
+		b, bok := before.objects[id]
+		a, aok := after.objects[id]
+		if bok && aok && b.where == a.where {
+			continue
+		}
+		change := SimulationObjectChange{
+			Turn:   turn,
+			Object: a.name,
+			From:   b.where,
+			To:     a.where,
+		}
+		if !aok {
+			change.Object = b.name
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Object < changes[j].Object
+	})
+	s.ObjectChanges = append(s.ObjectChanges, changes...)
+}
+
+// simulation returns the summary with the survivors of the final state
+func (s *simulationSummary) simulation(final *simulationState) *DungeonInstanceSimulation {
+
+	sim := s.DungeonInstanceSimulation
+
+	sim.Movements = []SimulationMovement{}
+	for _, id := range s.moved {
+		sim.Movements = append(sim.Movements, *s.movements[id])
+	}
+
+	sim.Survivors = []SimulationEntity{}
+	for _, mv := range s.moved {
+		entity, ok := final.entities[mv]
+		if !ok || entity.health <= 0 {
+			continue
+		}
+		sim.Survivors = append(sim.Survivors, SimulationEntity{
+			Name:     entity.name,
+			Type:     entity.entityType,
+			Location: final.locations[entity.locationInstanceID],
+			Health:   entity.health,
+		})
+	}
+
+	if sim.Deaths == nil {
+		sim.Deaths = []SimulationDeath{}
+	}
+	if sim.ObjectChanges == nil {
+		sim.ObjectChanges = []SimulationObjectChange{}
+	}
+
+	return &sim
+}
+
+// WriteText writes the simulation summary as text
+func (sim *DungeonInstanceSimulation) WriteText(w io.Writer) error {
+
+	lines := []string{
+		fmt.Sprintf("Dungeon         %s", sim.DungeonName),
+		fmt.Sprintf("Instance        %s", sim.DungeonInstanceID),
+		fmt.Sprintf("Seed            %d", sim.Seed),
+		fmt.Sprintf("Turns           %d", sim.Turns),
+		"",
+		fmt.Sprintf("Deaths          %d", len(sim.Deaths)),
+	}
+	for _, d := range sim.Deaths {
+		line := fmt.Sprintf("  turn %-6d %s %s died at %s", d.Turn, d.Type, d.Name, d.Location)
+		if d.KilledBy != "" {
+			line += fmt.Sprintf(", killed by %s", d.KilledBy)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", "Movements")
+	for _, mv := range sim.Movements {
+		lines = append(lines, fmt.Sprintf("  %s %s moved %d times: %s", mv.Type, mv.Name, mv.Moves, strings.Join(mv.Locations, " > ")))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Object changes  %d", len(sim.ObjectChanges)))
+	for _, c := range sim.ObjectChanges {
+		from, to := c.From, c.To
+		if from == "" {
+			from = "nowhere"
+		}
+		if to == "" {
+			to = "nowhere"
+		}
+		lines = append(lines, fmt.Sprintf("  turn %-6d %s from %s to %s", c.Turn, c.Object, from, to))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Survivors       %d", len(sim.Survivors)))
+	for _, e := range sim.Survivors {
+		lines = append(lines, fmt.Sprintf("  %s %s at %s with %d health", e.Type, e.Name, e.Location, e.Health))
+	}
+
+	if len(sim.Errors) > 0 {
+		lines = append(lines, "", fmt.Sprintf("Errors          %d", len(sim.Errors)))
+		for _, e := range sim.Errors {
+			lines = append(lines, fmt.Sprintf("  turn %-6d %s >%s< %s", e.Turn, e.Name, e.Sentence, e.Error))
+		}
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
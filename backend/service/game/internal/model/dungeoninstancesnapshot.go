@@ -0,0 +1,422 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// DungeonInstanceSnapshotVersion is the current dungeon instance snapshot format
+// version. Increment when the snapshot format changes in a way older snapshots
+// cannot be restored from.
+const DungeonInstanceSnapshotVersion int = 2
+
+// DungeonInstanceSnapshot is a serialisable snapshot of the state of a dungeon
+// instance. Location, monster, object and character instances are identified
+// by their instance ID at the time the snapshot was taken, locations are
+// referenced by location ID so the snapshot can be restored into a new dungeon
+// instance.
+type DungeonInstanceSnapshot struct {
+	Version            int                         `json:"version"`
+	CreatedAt          time.Time                   `json:"created_at"`
+	DungeonID          string                      `json:"dungeon_id"`
+	DungeonInstanceID  string                      `json:"dungeon_instance_id"`
+	Seed               int64                       `json:"seed"`
+	TurnNumber         int                         `json:"turn_number"`
+	LocationInstances  []LocationInstanceSnapshot  `json:"location_instances"`
+	MonsterInstances   []MonsterInstanceSnapshot   `json:"monster_instances"`
+	CharacterInstances []CharacterInstanceSnapshot `json:"character_instances"`
+	ObjectInstances    []ObjectInstanceSnapshot    `json:"object_instances"`
+}
+
+type LocationInstanceSnapshot struct {
+	ID         string `json:"id"`
+	LocationID string `json:"location_id"`
+}
+
+type MonsterInstanceSnapshot struct {
+	ID               string `json:"id"`
+	MonsterID        string `json:"monster_id"`
+	LocationID       string `json:"location_id"`
+	Strength         int    `json:"strength"`
+	Dexterity        int    `json:"dexterity"`
+	Intelligence     int    `json:"intelligence"`
+	Health           int    `json:"health"`
+	Fatigue          int    `json:"fatigue"`
+	Decay            int    `json:"decay"`
+	Coins            int    `json:"coins"`
+	ExperiencePoints int    `json:"experience_points"`
+	AttributePoints  int    `json:"attribute_points"`
+}
+
+type CharacterInstanceSnapshot struct {
+	ID               string `json:"id"`
+	CharacterID      string `json:"character_id"`
+	LocationID       string `json:"location_id"`
+	Strength         int    `json:"strength"`
+	Dexterity        int    `json:"dexterity"`
+	Intelligence     int    `json:"intelligence"`
+	Health           int    `json:"health"`
+	Fatigue          int    `json:"fatigue"`
+	Decay            int    `json:"decay"`
+	Coins            int    `json:"coins"`
+	ExperiencePoints int    `json:"experience_points"`
+	AttributePoints  int    `json:"attribute_points"`
+}
+
+type ObjectInstanceSnapshot struct {
+	ID                  string `json:"id"`
+	ObjectID            string `json:"object_id"`
+	LocationID          string `json:"location_id,omitempty"`
+	CharacterInstanceID string `json:"character_instance_id,omitempty"`
+	MonsterInstanceID   string `json:"monster_instance_id,omitempty"`
+	IsStashed           bool   `json:"is_stashed"`
+	IsEquipped          bool   `json:"is_equipped"`
+}
+
+// SnapshotDungeonInstance returns a snapshot of the current state of a dungeon
+// instance including the current turn number and character positions.
+func (m *Model) SnapshotDungeonInstance(dungeonInstanceID string) (*DungeonInstanceSnapshot, error) {
+	l := m.loggerWithFunctionContext("SnapshotDungeonInstance")
+
+	rs, err := m.GetDungeonInstanceRecordSet(dungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record set >%v<", err)
+		return nil, err
+	}
+
+	if rs.DungeonInstanceRec == nil {
+		err := fmt.Errorf("dungeon instance ID >%s< does not exist", dungeonInstanceID)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	turnRecs, err := m.GetTurnRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_instance_id",
+					Val: dungeonInstanceID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting turn records >%v<", err)
+		return nil, err
+	}
+
+	s := &DungeonInstanceSnapshot{
+		Version:            DungeonInstanceSnapshotVersion,
+		CreatedAt:          time.Now().UTC(),
+		DungeonID:          rs.DungeonInstanceRec.DungeonID,
+		DungeonInstanceID:  rs.DungeonInstanceRec.ID,
+		Seed:               rs.DungeonInstanceRec.Seed,
+		LocationInstances:  []LocationInstanceSnapshot{},
+		MonsterInstances:   []MonsterInstanceSnapshot{},
+		CharacterInstances: []CharacterInstanceSnapshot{},
+		ObjectInstances:    []ObjectInstanceSnapshot{},
+	}
+
+	if len(turnRecs) > 0 {
+		s.TurnNumber = turnRecs[0].TurnNumber
+	}
+
+	locationIDs := map[string]string{}
+	for _, rec := range rs.LocationInstanceRecs {
+		locationIDs[rec.ID] = rec.LocationID
+		s.LocationInstances = append(s.LocationInstances, LocationInstanceSnapshot{
+			ID:         rec.ID,
+			LocationID: rec.LocationID,
+		})
+	}
+
+	for _, rec := range rs.MonsterInstanceRecs {
+		s.MonsterInstances = append(s.MonsterInstances, MonsterInstanceSnapshot{
+			ID:               rec.ID,
+			MonsterID:        rec.MonsterID,
+			LocationID:       locationIDs[rec.LocationInstanceID],
+			Strength:         rec.Strength,
+			Dexterity:        rec.Dexterity,
+			Intelligence:     rec.Intelligence,
+			Health:           rec.Health,
+			Fatigue:          rec.Fatigue,
+			Decay:            rec.Decay,
+			Coins:            rec.Coins,
+			ExperiencePoints: rec.ExperiencePoints,
+			AttributePoints:  rec.AttributePoints,
+		})
+	}
+
+	for _, rec := range rs.CharacterInstanceRecs {
+		s.CharacterInstances = append(s.CharacterInstances, CharacterInstanceSnapshot{
+			ID:               rec.ID,
+			CharacterID:      rec.CharacterID,
+			LocationID:       locationIDs[rec.LocationInstanceID],
+			Strength:         rec.Strength,
+			Dexterity:        rec.Dexterity,
+			Intelligence:     rec.Intelligence,
+			Health:           rec.Health,
+			Fatigue:          rec.Fatigue,
+			Decay:            rec.Decay,
+			Coins:            rec.Coins,
+			ExperiencePoints: rec.ExperiencePoints,
+			AttributePoints:  rec.AttributePoints,
+		})
+	}
+
+	for _, rec := range rs.ObjectInstanceRecs {
+		s.ObjectInstances = append(s.ObjectInstances, ObjectInstanceSnapshot{
+			ID:                  rec.ID,
+			ObjectID:            rec.ObjectID,
+			LocationID:          locationIDs[rec.LocationInstanceID.String],
+			CharacterInstanceID: rec.CharacterInstanceID.String,
+			MonsterInstanceID:   rec.MonsterInstanceID.String,
+			IsStashed:           rec.IsStashed,
+			IsEquipped:          rec.IsEquipped,
+		})
+	}
+
+	return s, nil
+}
+
+// RestoreDungeonInstanceSnapshot creates a new dungeon instance from a dungeon
+// instance snapshot. Characters that have since entered another dungeon instance
+// are not restored and any objects they were carrying are left at the location
+// they were in.
+func (m *Model) RestoreDungeonInstanceSnapshot(s *DungeonInstanceSnapshot) (*DungeonInstanceRecordSet, error) {
+	l := m.loggerWithFunctionContext("RestoreDungeonInstanceSnapshot")
+
+	if s == nil {
+		err := fmt.Errorf("missing dungeon instance snapshot, cannot restore dungeon instance")
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	if s.Version != DungeonInstanceSnapshotVersion {
+		err := fmt.Errorf("dungeon instance snapshot version >%d< is not supported, expected version >%d<", s.Version, DungeonInstanceSnapshotVersion)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	dungeonRec, err := m.GetDungeonRec(s.DungeonID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		return nil, err
+	}
+
+	if dungeonRec == nil {
+		err := fmt.Errorf("dungeon ID >%s< does not exist, cannot restore dungeon instance", s.DungeonID)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	l.Info("Restoring dungeon instance ID >%s< snapshot into a new dungeon instance", s.DungeonInstanceID)
+
+	// The restored dungeon instance continues with the random seed of the
+	// snapshot dungeon instance so restored runs can be reproduced
+	dungeonInstanceRec, dungeonInstanceLeaseRec, err := m.createDungeonInstanceRecs(s.DungeonID, s.Seed)
+	if err != nil {
+		l.Warn("failed creating dungeon instance records >%v<", err)
+		return nil, err
+	}
+
+	locationInstanceRecs, err := m.createDungeonInstanceLocationInstanceRecs(dungeonInstanceRec)
+	if err != nil {
+		l.Warn("failed creating location instance records >%v<", err)
+		return nil, err
+	}
+
+	rs := &DungeonInstanceRecordSet{
+		DungeonInstanceRec:      dungeonInstanceRec,
+		DungeonInstanceLeaseRec: dungeonInstanceLeaseRec,
+		LocationInstanceRecs:    locationInstanceRecs,
+	}
+
+	// Location instance IDs indexed by location ID
+	locationInstanceIDs := map[string]string{}
+	for _, rec := range locationInstanceRecs {
+		locationInstanceIDs[rec.LocationID] = rec.ID
+	}
+
+	locationInstanceID := func(locationID string) (string, error) {
+		id, ok := locationInstanceIDs[locationID]
+		if !ok {
+			return "", fmt.Errorf("location ID >%s< does not exist in dungeon ID >%s<", locationID, s.DungeonID)
+		}
+		return id, nil
+	}
+
+	turnRec := &record.Turn{
+		DungeonInstanceID: dungeonInstanceRec.ID,
+		TurnNumber:        s.TurnNumber,
+		IncrementedAt:     null.NullTimeFromTime(m.now().UTC()),
+	}
+
+	// The turn record is created without the new turn record defaults so the
+	// restored dungeon instance continues from the snapshot turn
+	err = m.validateTurnRec(turnRec)
+	if err != nil {
+		l.Warn("failed validating turn record >%v<", err)
+		return nil, err
+	}
+
+	err = m.TurnRepository().CreateOne(turnRec)
+	if err != nil {
+		l.Warn("failed creating turn record >%v<", err)
+		return nil, err
+	}
+
+	// Restored instances are assigned identifiers before creation as they are
+	// not new monsters and characters and may have attributes new monsters and
+	// characters cannot have, such as no health.
+	monsterInstanceIDs := map[string]string{}
+	for _, ms := range s.MonsterInstances {
+		liID, err := locationInstanceID(ms.LocationID)
+		if err != nil {
+			l.Warn("failed resolving monster instance ID >%s< location >%v<", ms.ID, err)
+			return nil, err
+		}
+
+		rec := &record.MonsterInstance{
+			MonsterID:          ms.MonsterID,
+			DungeonInstanceID:  dungeonInstanceRec.ID,
+			LocationInstanceID: liID,
+			Strength:           ms.Strength,
+			Dexterity:          ms.Dexterity,
+			Intelligence:       ms.Intelligence,
+			Health:             ms.Health,
+			Fatigue:            ms.Fatigue,
+			Decay:              ms.Decay,
+			Coins:              ms.Coins,
+			ExperiencePoints:   ms.ExperiencePoints,
+			AttributePoints:    ms.AttributePoints,
+			Record: repository.Record{
+				ID: repository.NewRecordID(),
+			},
+		}
+
+		err = m.CreateMonsterInstanceRec(rec)
+		if err != nil {
+			l.Warn("failed creating monster instance record >%v<", err)
+			return nil, err
+		}
+
+		monsterInstanceIDs[ms.ID] = rec.ID
+		rs.MonsterInstanceRecs = append(rs.MonsterInstanceRecs, rec)
+	}
+
+	characterInstanceIDs := map[string]string{}
+	characterLocationIDs := map[string]string{}
+	for _, cs := range s.CharacterInstances {
+		characterLocationIDs[cs.ID] = cs.LocationID
+
+		ciRec, err := m.GetCharacterInstanceRecByCharacterID(cs.CharacterID)
+		if err != nil {
+			l.Warn("failed getting character ID >%s< instance record >%v<", cs.CharacterID, err)
+			return nil, err
+		}
+
+		if ciRec != nil {
+			l.Warn("Character ID >%s< is already inside dungeon instance ID >%s<, not restoring character", cs.CharacterID, ciRec.DungeonInstanceID)
+			continue
+		}
+
+		liID, err := locationInstanceID(cs.LocationID)
+		if err != nil {
+			l.Warn("failed resolving character instance ID >%s< location >%v<", cs.ID, err)
+			return nil, err
+		}
+
+		rec := &record.CharacterInstance{
+			CharacterID:        cs.CharacterID,
+			DungeonInstanceID:  dungeonInstanceRec.ID,
+			LocationInstanceID: liID,
+			Strength:           cs.Strength,
+			Dexterity:          cs.Dexterity,
+			Intelligence:       cs.Intelligence,
+			Health:             cs.Health,
+			Fatigue:            cs.Fatigue,
+			Decay:              cs.Decay,
+			Coins:              cs.Coins,
+			ExperiencePoints:   cs.ExperiencePoints,
+			AttributePoints:    cs.AttributePoints,
+			Record: repository.Record{
+				ID: repository.NewRecordID(),
+			},
+		}
+
+		err = m.CreateCharacterInstanceRec(rec)
+		if err != nil {
+			l.Warn("failed creating character instance record >%v<", err)
+			return nil, err
+		}
+
+		characterInstanceIDs[cs.ID] = rec.ID
+		rs.CharacterInstanceRecs = append(rs.CharacterInstanceRecs, rec)
+	}
+
+	for _, ois := range s.ObjectInstances {
+		rec := &record.ObjectInstance{
+			ObjectID:          ois.ObjectID,
+			DungeonInstanceID: dungeonInstanceRec.ID,
+			IsStashed:         ois.IsStashed,
+			IsEquipped:        ois.IsEquipped,
+			Record: repository.Record{
+				ID: repository.NewRecordID(),
+			},
+		}
+
+		switch {
+		case ois.MonsterInstanceID != "":
+			miID, ok := monsterInstanceIDs[ois.MonsterInstanceID]
+			if !ok {
+				err := fmt.Errorf("object instance ID >%s< monster instance ID >%s< does not exist in snapshot", ois.ID, ois.MonsterInstanceID)
+				l.Warn(err.Error())
+				return nil, err
+			}
+			rec.MonsterInstanceID = null.NullStringFromString(miID)
+		case ois.CharacterInstanceID != "":
+			if ciID, ok := characterInstanceIDs[ois.CharacterInstanceID]; ok {
+				rec.CharacterInstanceID = null.NullStringFromString(ciID)
+				break
+			}
+			locationID, ok := characterLocationIDs[ois.CharacterInstanceID]
+			if !ok {
+				err := fmt.Errorf("object instance ID >%s< character instance ID >%s< does not exist in snapshot", ois.ID, ois.CharacterInstanceID)
+				l.Warn(err.Error())
+				return nil, err
+			}
+			// The character was not restored so leave the object where they were
+			liID, err := locationInstanceID(locationID)
+			if err != nil {
+				l.Warn("failed resolving object instance ID >%s< location >%v<", ois.ID, err)
+				return nil, err
+			}
+			rec.LocationInstanceID = null.NullStringFromString(liID)
+			rec.IsStashed = false
+			rec.IsEquipped = false
+		default:
+			liID, err := locationInstanceID(ois.LocationID)
+			if err != nil {
+				l.Warn("failed resolving object instance ID >%s< location >%v<", ois.ID, err)
+				return nil, err
+			}
+			rec.LocationInstanceID = null.NullStringFromString(liID)
+		}
+
+		err = m.CreateObjectInstanceRec(rec)
+		if err != nil {
+			l.Warn("failed creating object instance record >%v<", err)
+			return nil, err
+		}
+
+		rs.ObjectInstanceRecs = append(rs.ObjectInstanceRecs, rec)
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,210 @@
+package model
+
+import (
+	"time"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// DecayTurns is the number of turns dead characters and monsters remain in a
+// dungeon instance before they are removed
+const DecayTurns int = 10
+
+type ProcessDungeonInstanceTurnArgs struct {
+	DungeonInstanceID string
+	TurnDuration      *time.Duration
+}
+
+type ProcessDungeonInstanceTurnResult struct {
+	IncrementTurnResult             *IncrementDungeonInstanceTurnResult
+	MonsterInstanceActionRecordSets []*record.ActionRecordSet
+}
+
+// ProcessDungeonInstanceTurn attempts to increment a dungeon instance turn and
+// when incremented processes the turn. When it is too early to increment the
+// turn the result is not incremented and includes how long to wait.
+func (m *Model) ProcessDungeonInstanceTurn(args *ProcessDungeonInstanceTurnArgs) (*ProcessDungeonInstanceTurnResult, error) {
+	l := m.loggerWithFunctionContext("ProcessDungeonInstanceTurn")
+
+	// TODO: 10-implement-effects:
+	// Process any active effects that are still applied to the character.
+
+	result := ProcessDungeonInstanceTurnResult{}
+
+	// Increment turn
+	incrementTurnResult, err := m.IncrementDungeonInstanceTurn(&IncrementDungeonInstanceTurnArgs{
+		DungeonInstanceID: args.DungeonInstanceID,
+		TurnDuration:      args.TurnDuration,
+	})
+	if err != nil {
+		l.Warn("failed incrementing dungeon instance ID >%s< turn >%v<", args.DungeonInstanceID, err)
+		return nil, err
+	}
+
+	result.IncrementTurnResult = incrementTurnResult
+
+	if !incrementTurnResult.Incremented {
+		l.Debug("Too early to process, wait >%d< milliseconds", incrementTurnResult.WaitMilliseconds)
+		return &result, nil
+	}
+
+	// Decay dead characters and remove completely decayed characters
+	err = m.decayCharacterInstances(args.DungeonInstanceID)
+	if err != nil {
+		l.Warn("failed decaying characters >%v<", err)
+		return nil, err
+	}
+
+	// Decay dead monsters and remove completely decayed monsters
+	err = m.decayMonsterInstances(args.DungeonInstanceID)
+	if err != nil {
+		l.Warn("failed decaying monsters >%v<", err)
+		return nil, err
+	}
+
+	// Process monster instances
+	mrecs, err := m.GetMonsterInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_instance_id",
+					Val: args.DungeonInstanceID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance ID >%s< monster instance records >%v<", args.DungeonInstanceID, err)
+		return nil, err
+	}
+
+	l.Debug("Processing turn >%d< with >%d< monster instance records", incrementTurnResult.Record.TurnNumber, len(mrecs))
+
+	for idx := range mrecs {
+		l.Info("Processing monster instance ID >%s< monster ID >%s<", mrecs[idx].ID, mrecs[idx].MonsterID)
+		dmar, err := m.DecideMonsterAction(mrecs[idx].ID)
+		if err != nil {
+			l.Warn("failed deciding monster instance ID >%s< action >%v<", mrecs[idx].ID, err)
+			return nil, err
+		}
+
+		l.Info("Monster instance ID >%s< Sentence >%s<", dmar.MonsterInstanceID, dmar.Sentence)
+
+		if dmar.Sentence == "" {
+			l.Info("Monster instance ID >%s< not doing anything this turn", dmar.MonsterInstanceID)
+			continue
+		}
+
+		ars, err := m.ProcessMonsterAction(dmar.DungeonInstanceID, dmar.MonsterInstanceID, dmar.Sentence)
+		if err != nil {
+			l.Warn("failed processing monster action >%s< action >%v<", dmar.Sentence, err)
+			return nil, err
+		}
+
+		l.Debug("Processed monster instance ID >%s< action >%#v<", dmar.MonsterInstanceID, ars.ActionRec)
+		result.MonsterInstanceActionRecordSets = append(result.MonsterInstanceActionRecordSets, ars)
+	}
+
+	l.Debug("Processed dungeon instance ID >%s< turn >%d<", args.DungeonInstanceID, incrementTurnResult.Record.TurnNumber)
+
+	return &result, nil
+}
+
+// decayCharacterInstances decays dead character instances, removing character
+// instances that have completely decayed
+func (m *Model) decayCharacterInstances(dungeonInstanceID string) error {
+	l := m.loggerWithFunctionContext("decayCharacterInstances")
+
+	crecs, err := m.GetCharacterInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldCharacterInstanceDungeonInstanceID,
+					Val: dungeonInstanceID,
+				},
+				{
+					Col: record.FieldCharacterInstanceHealth,
+					Val: 0,
+					Op:  coresql.OpLessThanEqual,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance ID >%s< character instance records >%v<", dungeonInstanceID, err)
+		return err
+	}
+
+	for idx := range crecs {
+		l.Info("Decaying character instance ID >%s< character ID >%s<", crecs[idx].ID, crecs[idx].CharacterID)
+
+		crec := crecs[idx]
+		crec.Decay += 1
+
+		err := m.UpdateCharacterInstanceRec(crec)
+		if err != nil {
+			l.Warn("failed updating character instance ID >%s< decay >%v<", crec.ID, err)
+			return err
+		}
+
+		if crec.Decay >= DecayTurns {
+			err := m.DeleteCharacterInstance(crec.CharacterID)
+			if err != nil {
+				l.Warn("failed exiting decayed character instance ID >%s< from dungeon >%v<", crec.ID, err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// decayMonsterInstances decays dead monster instances, removing monster
+// instances that have completely decayed
+func (m *Model) decayMonsterInstances(dungeonInstanceID string) error {
+	l := m.loggerWithFunctionContext("decayMonsterInstances")
+
+	mrecs, err := m.GetMonsterInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldMonsterInstanceDungeonInstanceID,
+					Val: dungeonInstanceID,
+				},
+				{
+					Col: record.FieldMonsterInstanceHealth,
+					Val: 0,
+					Op:  coresql.OpLessThanEqual,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting dungeon instance ID >%s< monster instance records >%v<", dungeonInstanceID, err)
+		return err
+	}
+
+	for idx := range mrecs {
+		l.Info("Decaying monster instance ID >%s< monster ID >%s<", mrecs[idx].ID, mrecs[idx].MonsterID)
+
+		mrec := mrecs[idx]
+		mrec.Decay += 1
+
+		err := m.UpdateMonsterInstanceRec(mrec)
+		if err != nil {
+			l.Warn("failed updating monster instance ID >%s< decay >%v<", mrec.ID, err)
+			return err
+		}
+
+		if mrec.Decay >= DecayTurns {
+			err := m.DeleteMonsterInstance(mrec.MonsterID)
+			if err != nil {
+				l.Warn("failed exiting decayed monster instance ID >%s< from dungeon >%v<", mrec.ID, err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package model
+
+import (
+	"fmt"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// LocationRecommendedMaxEntities is the most monsters and objects a location
+// should spawn. Locations are not limited to this number of entities, though
+// crowded locations are hard to play.
+const LocationRecommendedMaxEntities int = 15
+
+const (
+	// DungeonIssueSeverityError issues leave a dungeon unplayable and prevent
+	// a dungeon definition from being imported
+	DungeonIssueSeverityError string = "error"
+	// DungeonIssueSeverityWarning issues are probably mistakes
+	DungeonIssueSeverityWarning string = "warning"
+	// DungeonIssueSeverityInfo issues are worth knowing about but are often
+	// intended
+	DungeonIssueSeverityInfo string = "info"
+)
+
+const (
+	DungeonIssueCodeNoDefaultLocation        string = "no_default_location"
+	DungeonIssueCodeMultipleDefaultLocations string = "multiple_default_locations"
+	DungeonIssueCodeDuplicateName            string = "duplicate_name"
+	DungeonIssueCodeUndefinedLocation        string = "undefined_location"
+	DungeonIssueCodeUndefinedMonster         string = "undefined_monster"
+	DungeonIssueCodeUndefinedObject          string = "undefined_object"
+	DungeonIssueCodeSelfExit                 string = "self_exit"
+	DungeonIssueCodeUnreachableLocation      string = "unreachable_location"
+	DungeonIssueCodeNoExits                  string = "no_exits"
+	DungeonIssueCodeDeadEnd                  string = "dead_end"
+	DungeonIssueCodeOneWayExit               string = "one_way_exit"
+	DungeonIssueCodeSpawnsExceedCapacity     string = "spawns_exceed_capacity"
+)
+
+// DungeonIssue is a problem found when linting a dungeon definition
+type DungeonIssue struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Location string `json:"location,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (i DungeonIssue) String() string {
+	if i.Location != "" {
+		return fmt.Sprintf("%s %s location >%s< %s", i.Severity, i.Code, i.Location, i.Message)
+	}
+	return fmt.Sprintf("%s %s %s", i.Severity, i.Code, i.Message)
+}
+
+// DungeonIssuesHaveErrors returns true when any issue is an error
+func DungeonIssuesHaveErrors(issues []DungeonIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == DungeonIssueSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint returns every issue found with a dungeon definition. Locations are
+// checked for reachability from the default location, exits without a matching
+// exit back, dead ends and spawns exceeding the recommended number of entities.
+// Monsters, objects and locations referred to by name must be defined.
+func (d *DungeonDefinition) Lint() []DungeonIssue {
+
+	issues := []DungeonIssue{}
+
+	addIssue := func(severity, code, location, format string, args ...any) {
+		issues = append(issues, DungeonIssue{
+			Severity: severity,
+			Code:     code,
+			Location: location,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	objectNames := map[string]struct{}{}
+	for _, o := range d.Objects {
+		if _, ok := objectNames[o.Name]; ok {
+			addIssue(DungeonIssueSeverityError, DungeonIssueCodeDuplicateName, "", "object >%s< is defined more than once", o.Name)
+		}
+		objectNames[o.Name] = struct{}{}
+	}
+
+	monsterNames := map[string]struct{}{}
+	for _, mon := range d.Monsters {
+		if _, ok := monsterNames[mon.Name]; ok {
+			addIssue(DungeonIssueSeverityError, DungeonIssueCodeDuplicateName, "", "monster >%s< is defined more than once", mon.Name)
+		}
+		monsterNames[mon.Name] = struct{}{}
+
+		for _, e := range mon.Equipment {
+			if _, ok := objectNames[e.Name]; !ok {
+				addIssue(DungeonIssueSeverityError, DungeonIssueCodeUndefinedObject, "", "monster >%s< equipment object >%s< is not defined", mon.Name, e.Name)
+			}
+		}
+	}
+
+	locations := map[string]*LocationDefinition{}
+	defaultLocations := []string{}
+	for idx := range d.Locations {
+		loc := &d.Locations[idx]
+		if _, ok := locations[loc.Name]; ok {
+			addIssue(DungeonIssueSeverityError, DungeonIssueCodeDuplicateName, loc.Name, "is defined more than once")
+		}
+		locations[loc.Name] = loc
+		if loc.Default {
+			defaultLocations = append(defaultLocations, loc.Name)
+		}
+	}
+
+	switch len(defaultLocations) {
+	case 0:
+		addIssue(DungeonIssueSeverityError, DungeonIssueCodeNoDefaultLocation, "", "no location is the default location characters enter the dungeon at")
+	case 1:
+	default:
+		addIssue(DungeonIssueSeverityError, DungeonIssueCodeMultipleDefaultLocations, "", "locations %q are all the default location", defaultLocations)
+	}
+
+	for idx := range d.Locations {
+		loc := &d.Locations[idx]
+
+		exitCount := 0
+		for _, direction := range record.LocationDirections {
+			exit := *loc.Exits.Direction(direction)
+			if exit == "" {
+				continue
+			}
+			exitCount++
+
+			if exit == loc.Name {
+				addIssue(DungeonIssueSeverityError, DungeonIssueCodeSelfExit, loc.Name, "%s exit leads back to itself", direction)
+				continue
+			}
+
+			target, ok := locations[exit]
+			if !ok {
+				addIssue(DungeonIssueSeverityError, DungeonIssueCodeUndefinedLocation, loc.Name, "%s exit location >%s< is not defined", direction, exit)
+				continue
+			}
+
+			opposite := record.LocationOppositeDirections[direction]
+			back := *target.Exits.Direction(opposite)
+			if back == "" {
+				addIssue(DungeonIssueSeverityWarning, DungeonIssueCodeOneWayExit, loc.Name, "%s exit to >%s< has no %s exit back", direction, exit, opposite)
+			} else if back != loc.Name {
+				addIssue(DungeonIssueSeverityWarning, DungeonIssueCodeOneWayExit, loc.Name, "%s exit to >%s< has a %s exit back to >%s<", direction, exit, opposite, back)
+			}
+		}
+
+		// Characters can always exit the dungeon so a location without exits
+		// is only a problem when the dungeon has other locations
+		switch {
+		case exitCount == 0 && len(d.Locations) == 1:
+			addIssue(DungeonIssueSeverityInfo, DungeonIssueCodeNoExits, loc.Name, "has no exits, characters can only exit the dungeon")
+		case exitCount == 0:
+			addIssue(DungeonIssueSeverityWarning, DungeonIssueCodeNoExits, loc.Name, "has no exits, characters can only exit the dungeon")
+		case exitCount == 1:
+			addIssue(DungeonIssueSeverityInfo, DungeonIssueCodeDeadEnd, loc.Name, "is a dead end")
+		}
+
+		for _, s := range loc.Monsters {
+			if _, ok := monsterNames[s.Name]; !ok {
+				addIssue(DungeonIssueSeverityError, DungeonIssueCodeUndefinedMonster, loc.Name, "monster >%s< is not defined", s.Name)
+			}
+		}
+		for _, s := range loc.Objects {
+			if _, ok := objectNames[s.Name]; !ok {
+				addIssue(DungeonIssueSeverityError, DungeonIssueCodeUndefinedObject, loc.Name, "object >%s< is not defined", s.Name)
+			}
+		}
+
+		spawnCount := len(loc.Monsters) + len(loc.Objects)
+		if spawnCount > LocationRecommendedMaxEntities {
+			addIssue(DungeonIssueSeverityWarning, DungeonIssueCodeSpawnsExceedCapacity, loc.Name, "spawns >%d< monsters and objects which exceeds the recommended >%d< entities", spawnCount, LocationRecommendedMaxEntities)
+		}
+	}
+
+	// Every location should be reachable from the default location
+	if len(defaultLocations) > 0 {
+		reachable := map[string]struct{}{
+			defaultLocations[0]: {},
+		}
+		queue := []string{defaultLocations[0]}
+		for len(queue) > 0 {
+			loc := locations[queue[0]]
+			queue = queue[1:]
+			for _, direction := range record.LocationDirections {
+				exit := *loc.Exits.Direction(direction)
+				if _, ok := locations[exit]; !ok {
+					continue
+				}
+				if _, ok := reachable[exit]; ok {
+					continue
+				}
+				reachable[exit] = struct{}{}
+				queue = append(queue, exit)
+			}
+		}
+
+		for _, loc := range d.Locations {
+			if _, ok := reachable[loc.Name]; !ok {
+				addIssue(DungeonIssueSeverityError, DungeonIssueCodeUnreachableLocation, loc.Name, "cannot be reached from the default location >%s<", defaultLocations[0])
+			}
+		}
+	}
+
+	return issues
+}
+
+// LintDungeon returns every issue found with a dungeon
+func (m *Model) LintDungeon(dungeonID string) ([]DungeonIssue, error) {
+	l := m.loggerWithFunctionContext("LintDungeon")
+
+	d, err := m.GetDungeonDefinition(dungeonID)
+	if err != nil {
+		l.Warn("failed getting dungeon definition >%v<", err)
+		return nil, err
+	}
+
+	issues := d.Lint()
+
+	l.Info("Linted dungeon ID >%s< with >%d< issues", dungeonID, len(issues))
+
+	return issues, nil
+}
@@ -1,12 +1,20 @@
 package model
 
 import (
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 // validateDungeonRec - validates creating and updating a dungeon record
 func (m *Model) validateDungeonRec(rec *record.Dungeon) error {
 
+	if rec.Name == "" {
+		return coreerror.NewInvalidDataError("failed validation, Name is empty")
+	}
+	if rec.Description == "" {
+		return coreerror.NewInvalidDataError("failed validation, Description is empty")
+	}
+
 	return nil
 }
 
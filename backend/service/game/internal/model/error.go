@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
@@ -16,6 +17,10 @@ const (
 	ErrorCodeActionInvalidCharacter coreerror.ErrorCode = "action.invalid_character"
 	ErrorCodeActionInvalidDungeon   coreerror.ErrorCode = "action.invalid_dungeon"
 	ErrorCodeCharacterNameTaken     coreerror.ErrorCode = "character.name_taken"
+	ErrorCodeAccountEmailTaken      coreerror.ErrorCode = "account.email_taken"
+	ErrorCodeAccountInvalidLogin    coreerror.ErrorCode = "account.invalid_login"
+	ErrorCodeAccountInvalidRole     coreerror.ErrorCode = "account.invalid_role"
+	ErrorCodeAPIKeyInvalidScope     coreerror.ErrorCode = "api_key.invalid_scope"
 )
 
 func NewInternalError(message string, args ...any) error {
@@ -31,6 +36,41 @@ func NewCharacterNameTakenError(rec *record.Character) error {
 	}
 }
 
+func NewAccountEmailTakenError(rec *record.Account) error {
+	msg := fmt.Sprintf("account email >%s< has been taken", rec.Email)
+	return coreerror.Error{
+		HttpStatusCode: http.StatusBadRequest,
+		ErrorCode:      ErrorCodeAccountEmailTaken,
+		Message:        msg,
+	}
+}
+
+func NewAccountInvalidLoginError() error {
+	return coreerror.Error{
+		HttpStatusCode: http.StatusUnauthorized,
+		ErrorCode:      ErrorCodeAccountInvalidLogin,
+		Message:        "account email or password is incorrect",
+	}
+}
+
+func NewAccountInvalidRoleError(role string) error {
+	msg := fmt.Sprintf("account role >%s< is not one of >%s<", role, strings.Join(AccountRoles, ", "))
+	return coreerror.Error{
+		HttpStatusCode: http.StatusBadRequest,
+		ErrorCode:      ErrorCodeAccountInvalidRole,
+		Message:        msg,
+	}
+}
+
+func NewAPIKeyInvalidScopeError(scope string) error {
+	msg := fmt.Sprintf("API key scope >%s< is not one of >%s<", scope, strings.Join(Permissions, ", "))
+	return coreerror.Error{
+		HttpStatusCode: http.StatusBadRequest,
+		ErrorCode:      ErrorCodeAPIKeyInvalidScope,
+		Message:        msg,
+	}
+}
+
 func NewInvalidActionError(message string, args ...any) error {
 	if len(args) > 0 {
 		message = fmt.Sprintf(message, args...)
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"database/sql"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// DeleteLocation deletes a location along with the monsters and objects that spawn
+// at the location. Links to the location from other locations are removed. Locations
+// of dungeons with dungeon instances cannot be deleted.
+func (m *Model) DeleteLocation(locationID string) error {
+	l := m.loggerWithFunctionContext("DeleteLocation")
+
+	rec, err := m.GetLocationRec(locationID, nil)
+	if err != nil {
+		l.Warn("failed getting location record >%v<", err)
+		return err
+	}
+
+	if rec == nil {
+		return nil
+	}
+
+	err = m.validateDungeonHasNoInstances(rec.DungeonID)
+	if err != nil {
+		return err
+	}
+
+	locationRecs, err := m.GetLocationRecs(referenceOptions("dungeon_id", rec.DungeonID))
+	if err != nil {
+		l.Warn("failed getting location records >%v<", err)
+		return err
+	}
+
+	for _, locationRec := range locationRecs {
+		if locationRec.ID == rec.ID {
+			continue
+		}
+		linked := false
+		for _, direction := range record.LocationDirections {
+			linkedLocationID := locationRec.DirectionLocationID(direction)
+			if linkedLocationID.Valid && linkedLocationID.String == rec.ID {
+				*linkedLocationID = sql.NullString{}
+				linked = true
+			}
+		}
+		if !linked {
+			continue
+		}
+		l.Info("Removing links from location ID >%s< to location ID >%s<", locationRec.ID, rec.ID)
+		err := m.UpdateLocationRec(locationRec)
+		if err != nil {
+			l.Warn("failed updating location record >%v<", err)
+			return err
+		}
+	}
+
+	err = m.deleteLocationSpawnRecs(rec.ID)
+	if err != nil {
+		return err
+	}
+
+	err = m.deleteTranslationRecs(rec.ID)
+	if err != nil {
+		return err
+	}
+
+	err = m.DeleteLocationRec(rec.ID)
+	if err != nil {
+		l.Warn("failed deleting location record >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// deleteLocationSpawnRecs deletes the monsters and objects that spawn at a location
+func (m *Model) deleteLocationSpawnRecs(locationID string) error {
+	l := m.loggerWithFunctionContext("deleteLocationSpawnRecs")
+
+	locationMonsterRecs, err := m.GetLocationMonsterRecs(referenceOptions("location_id", locationID))
+	if err != nil {
+		l.Warn("failed getting location monster records >%v<", err)
+		return err
+	}
+
+	for _, locationMonsterRec := range locationMonsterRecs {
+		err := m.DeleteLocationMonsterRec(locationMonsterRec.ID)
+		if err != nil {
+			l.Warn("failed deleting location monster record >%v<", err)
+			return err
+		}
+	}
+
+	locationObjectRecs, err := m.GetLocationObjectRecs(referenceOptions("location_id", locationID))
+	if err != nil {
+		l.Warn("failed getting location object records >%v<", err)
+		return err
+	}
+
+	for _, locationObjectRec := range locationObjectRecs {
+		err := m.DeleteLocationObjectRec(locationObjectRec.ID)
+		if err != nil {
+			l.Warn("failed deleting location object record >%v<", err)
+			return err
+		}
+	}
+
+	return nil
+}
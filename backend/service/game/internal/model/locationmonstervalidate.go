@@ -1,8 +1,7 @@
 package model
 
 import (
-	"fmt"
-
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
@@ -12,19 +11,35 @@ func (m *Model) validateLocationMonsterRec(rec *record.LocationMonster) error {
 	// New monster
 	if rec.ID == "" {
 		if rec.LocationID == "" {
-			return fmt.Errorf("failed validation, LocationID is empty")
+			return coreerror.NewInvalidDataError("failed validation, LocationID is empty")
 		}
 		if rec.MonsterID == "" {
-			return fmt.Errorf("failed validation, MonsterID is empty")
+			return coreerror.NewInvalidDataError("failed validation, MonsterID is empty")
 		}
 		if rec.SpawnMinutes < 0 || rec.SpawnMinutes > 60 {
-			return fmt.Errorf("failed validation, SpawnMinutes must be greater than or equal to 0 and less than or equal to 60")
+			return coreerror.NewInvalidDataError("failed validation, SpawnMinutes must be greater than or equal to 0 and less than or equal to 60")
 		}
 		if rec.SpawnPercentChance < 1 || rec.SpawnPercentChance > 100 {
-			return fmt.Errorf("failed validation, SpawnPercentChance must be greater than 0 and less than or equal to 100")
+			return coreerror.NewInvalidDataError("failed validation, SpawnPercentChance must be greater than 0 and less than or equal to 100")
 		}
 	}
 
+	locationRec, err := m.GetLocationRec(rec.LocationID, nil)
+	if err != nil {
+		return err
+	}
+	if locationRec == nil {
+		return coreerror.NewInvalidDataError("failed validation, location ID >%s< does not exist", rec.LocationID)
+	}
+
+	monsterRec, err := m.GetMonsterRec(rec.MonsterID, nil)
+	if err != nil {
+		return err
+	}
+	if monsterRec == nil {
+		return coreerror.NewInvalidDataError("failed validation, monster ID >%s< does not exist", rec.MonsterID)
+	}
+
 	return nil
 }
 
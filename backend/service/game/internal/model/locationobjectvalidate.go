@@ -1,12 +1,42 @@
 package model
 
 import (
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 // validateLocationObjectRec - validates creating and updating a location object record
 func (m *Model) validateLocationObjectRec(rec *record.LocationObject) error {
 
+	if rec.LocationID == "" {
+		return coreerror.NewInvalidDataError("failed validation, LocationID is empty")
+	}
+	if rec.ObjectID == "" {
+		return coreerror.NewInvalidDataError("failed validation, ObjectID is empty")
+	}
+	if rec.SpawnMinutes < 0 || rec.SpawnMinutes > 60 {
+		return coreerror.NewInvalidDataError("failed validation, SpawnMinutes must be greater than or equal to 0 and less than or equal to 60")
+	}
+	if rec.SpawnPercentChance < 1 || rec.SpawnPercentChance > 100 {
+		return coreerror.NewInvalidDataError("failed validation, SpawnPercentChance must be greater than 0 and less than or equal to 100")
+	}
+
+	locationRec, err := m.GetLocationRec(rec.LocationID, nil)
+	if err != nil {
+		return err
+	}
+	if locationRec == nil {
+		return coreerror.NewInvalidDataError("failed validation, location ID >%s< does not exist", rec.LocationID)
+	}
+
+	objectRec, err := m.GetObjectRec(rec.ObjectID, nil)
+	if err != nil {
+		return err
+	}
+	if objectRec == nil {
+		return coreerror.NewInvalidDataError("failed validation, object ID >%s< does not exist", rec.ObjectID)
+	}
+
 	return nil
 }
 
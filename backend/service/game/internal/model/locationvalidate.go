@@ -1,19 +1,42 @@
 package model
 
 import (
-	"fmt"
-
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 // validateLocationRec - validates creating and updating a location record
 func (m *Model) validateLocationRec(rec *record.Location) error {
 
+	if rec.DungeonID == "" {
+		return coreerror.NewInvalidDataError("failed validation, DungeonID is empty")
+	}
 	if rec.Name == "" {
-		return fmt.Errorf("failed validation, Name is empty")
+		return coreerror.NewInvalidDataError("failed validation, Name is empty")
 	}
 	if rec.Description == "" {
-		return fmt.Errorf("failed validation, Description is empty")
+		return coreerror.NewInvalidDataError("failed validation, Description is empty")
+	}
+
+	// Locations may only link to other locations in the same dungeon
+	for _, direction := range record.LocationDirections {
+		locationID := rec.DirectionLocationID(direction)
+		if !locationID.Valid {
+			continue
+		}
+		if rec.ID != "" && locationID.String == rec.ID {
+			return coreerror.NewInvalidDataError("failed validation, %s location ID >%s< is the location", direction, locationID.String)
+		}
+		if !m.IsUUID(locationID.String) {
+			return coreerror.NewInvalidDataError("failed validation, %s location ID >%s< is not a valid UUID", direction, locationID.String)
+		}
+		linkedRec, err := m.GetLocationRec(locationID.String, nil)
+		if err != nil {
+			return err
+		}
+		if linkedRec == nil || linkedRec.DungeonID != rec.DungeonID {
+			return coreerror.NewInvalidDataError("failed validation, %s location ID >%s< is not a location in dungeon ID >%s<", direction, locationID.String, rec.DungeonID)
+		}
 	}
 
 	return nil
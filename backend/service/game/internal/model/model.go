@@ -19,18 +19,21 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/config"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/query/dungeonentityinstanceturn"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/query/dungeoninstancecapacity"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/account"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/action"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/actioncharacter"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/actioncharacterobject"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/actionmonster"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/actionmonsterobject"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/actionobject"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/apikey"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/character"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/characterinstance"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/characterinstanceview"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/characterobject"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/dungeon"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/dungeoninstance"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/dungeoninstancelease"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/dungeoninstanceview"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/location"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/locationinstance"
@@ -44,12 +47,19 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/object"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/objectinstance"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/objectinstanceview"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/translation"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/repository/turn"
 )
 
 // Model -
 type Model struct {
 	turnDuration time.Duration
+	// language commands are parsed in and dungeon content is translated into
+	language string
+	// seeder returns the seed of new dungeon instances
+	seeder func() int64
+	// clock returns the time dungeon instance turns are measured against
+	clock func() time.Time
 	model.Model
 }
 
@@ -109,6 +119,20 @@ func (m *Model) NewRepositories(p preparer.Repository, tx *sqlx.Tx) ([]repositor
 
 	repositoryList := []repositor.Repositor{}
 
+	accountRepo, err := account.NewRepository(m.Log, p, tx)
+	if err != nil {
+		m.Log.Warn("Failed new account repository >%v<", err)
+		return nil, err
+	}
+	repositoryList = append(repositoryList, accountRepo)
+
+	apiKeyRepo, err := apikey.NewRepository(m.Log, p, tx)
+	if err != nil {
+		m.Log.Warn("Failed new API key repository >%v<", err)
+		return nil, err
+	}
+	repositoryList = append(repositoryList, apiKeyRepo)
+
 	dungeonRepo, err := dungeon.NewRepository(m.Log, p, tx)
 	if err != nil {
 		m.Log.Warn("Failed new dungeon repository >%v<", err)
@@ -130,6 +154,13 @@ func (m *Model) NewRepositories(p preparer.Repository, tx *sqlx.Tx) ([]repositor
 	}
 	repositoryList = append(repositoryList, dungeonInstanceViewRepo)
 
+	dungeonInstanceLeaseRepo, err := dungeoninstancelease.NewRepository(m.Log, p, tx)
+	if err != nil {
+		m.Log.Warn("Failed new dungeon instance lease repository >%v<", err)
+		return nil, err
+	}
+	repositoryList = append(repositoryList, dungeonInstanceLeaseRepo)
+
 	locationRepo, err := location.NewRepository(m.Log, p, tx)
 	if err != nil {
 		m.Log.Warn("Failed new location repository >%v<", err)
@@ -291,9 +322,40 @@ func (m *Model) NewRepositories(p preparer.Repository, tx *sqlx.Tx) ([]repositor
 	}
 	repositoryList = append(repositoryList, turnRepo)
 
+	translationRepo, err := translation.NewRepository(m.Log, p, tx)
+	if err != nil {
+		m.Log.Warn("Failed new translation repository >%v<", err)
+		return nil, err
+	}
+	repositoryList = append(repositoryList, translationRepo)
+
 	return repositoryList, nil
 }
 
+// AccountRepository -
+func (m *Model) AccountRepository() *account.Repository {
+
+	r := m.Repositories[account.TableName]
+	if r == nil {
+		m.Log.Warn("Repository >%s< is nil", account.TableName)
+		return nil
+	}
+
+	return r.(*account.Repository)
+}
+
+// APIKeyRepository -
+func (m *Model) APIKeyRepository() *apikey.Repository {
+
+	r := m.Repositories[apikey.TableName]
+	if r == nil {
+		m.Log.Warn("Repository >%s< is nil", apikey.TableName)
+		return nil
+	}
+
+	return r.(*apikey.Repository)
+}
+
 // DungeonRepository -
 func (m *Model) DungeonRepository() *dungeon.Repository {
 
@@ -330,6 +392,18 @@ func (m *Model) DungeonInstanceViewRepository() *dungeoninstanceview.Repository
 	return r.(*dungeoninstanceview.Repository)
 }
 
+// DungeonInstanceLeaseRepository -
+func (m *Model) DungeonInstanceLeaseRepository() *dungeoninstancelease.Repository {
+
+	r := m.Repositories[dungeoninstancelease.TableName]
+	if r == nil {
+		m.Log.Warn("Repository >%s< is nil", dungeoninstancelease.TableName)
+		return nil
+	}
+
+	return r.(*dungeoninstancelease.Repository)
+}
+
 // LocationRepository -
 func (m *Model) LocationRepository() *location.Repository {
 
@@ -606,6 +680,18 @@ func (m *Model) TurnRepository() *turn.Repository {
 	return r.(*turn.Repository)
 }
 
+// TranslationRepository -
+func (m *Model) TranslationRepository() *translation.Repository {
+
+	r := m.Repositories[translation.TableName]
+	if r == nil {
+		m.Log.Warn("Repository >%s< is nil", translation.TableName)
+		return nil
+	}
+
+	return r.(*translation.Repository)
+}
+
 // DungeonInstanceCapacityQuery -
 func (m *Model) DungeonInstanceCapacityQuery() *dungeoninstancecapacity.Query {
 
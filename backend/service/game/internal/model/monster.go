@@ -84,3 +84,52 @@ func (m *Model) GetMonsterStashedObjectRecs(monsterID string) ([]*record.Object,
 
 	return objectRecs, nil
 }
+
+// DeleteMonster deletes a monster along with the objects the monster carries. Monsters
+// that spawn at a location or have monster instances cannot be deleted.
+func (m *Model) DeleteMonster(monsterID string) error {
+	l := m.loggerWithFunctionContext("DeleteMonster")
+
+	locationMonsterRecs, err := m.GetLocationMonsterRecs(referenceOptions("monster_id", monsterID))
+	if err != nil {
+		l.Warn("failed getting location monster records >%v<", err)
+		return err
+	}
+
+	err = validateNotReferenced("monster", monsterID, locationMonsterRecs, "location monsters")
+	if err != nil {
+		return err
+	}
+
+	monsterInstanceRecs, err := m.GetMonsterInstanceRecs(referenceOptions("monster_id", monsterID))
+	if err != nil {
+		l.Warn("failed getting monster instance records >%v<", err)
+		return err
+	}
+
+	err = validateNotReferenced("monster", monsterID, monsterInstanceRecs, "monster instances")
+	if err != nil {
+		return err
+	}
+
+	monsterObjectRecs, err := m.GetMonsterObjectRecs(referenceOptions("monster_id", monsterID))
+	if err != nil {
+		l.Warn("failed getting monster object records >%v<", err)
+		return err
+	}
+
+	for _, monsterObjectRec := range monsterObjectRecs {
+		err := m.DeleteMonsterObjectRec(monsterObjectRec.ID)
+		if err != nil {
+			l.Warn("failed deleting monster object record >%v<", err)
+			return err
+		}
+	}
+
+	err = m.deleteTranslationRecs(monsterID)
+	if err != nil {
+		return err
+	}
+
+	return m.DeleteMonsterRec(monsterID)
+}
@@ -86,7 +86,20 @@ func (m *Model) UpdateMonsterRec(rec *record.Monster) error {
 
 	r := m.MonsterRepository()
 
-	err := m.validateMonsterRec(rec)
+	// Health and fatigue are recalculated as attributes may have changed
+	rec, err := calculator.CalculateMonsterHealth(rec)
+	if err != nil {
+		l.Debug("Failed calculating monster health >%v<", err)
+		return err
+	}
+
+	rec, err = calculator.CalculateMonsterFatigue(rec)
+	if err != nil {
+		l.Debug("Failed calculating monster fatigue >%v<", err)
+		return err
+	}
+
+	err = m.validateMonsterRec(rec)
 	if err != nil {
 		l.Debug("Failed model validation >%v<", err)
 		return err
@@ -1,8 +1,8 @@
 package model
 
 import (
-	"fmt"
-
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
@@ -12,24 +12,47 @@ func (m *Model) validateMonsterRec(rec *record.Monster) error {
 	// New monster
 	if rec.ID == "" {
 		if rec.Strength == 0 {
-			return fmt.Errorf("failed validation, Strength is empty")
+			return coreerror.NewInvalidDataError("failed validation, Strength is empty")
 		}
 		if rec.Dexterity == 0 {
-			return fmt.Errorf("failed validation, Dexterity is empty")
+			return coreerror.NewInvalidDataError("failed validation, Dexterity is empty")
 		}
 		if rec.Intelligence == 0 {
-			return fmt.Errorf("failed validation, Intelligence is empty")
+			return coreerror.NewInvalidDataError("failed validation, Intelligence is empty")
 		}
 		if rec.Health == 0 {
-			return fmt.Errorf("failed validation, Health is empty")
+			return coreerror.NewInvalidDataError("failed validation, Health is empty")
 		}
 		if rec.Fatigue == 0 {
-			return fmt.Errorf("failed validation, Fatigue is empty")
+			return coreerror.NewInvalidDataError("failed validation, Fatigue is empty")
 		}
 	}
 
 	if rec.Name == "" {
-		return fmt.Errorf("failed validation, Name is empty")
+		return coreerror.NewInvalidDataError("failed validation, Name is empty")
+	}
+	if rec.Description == "" {
+		return coreerror.NewInvalidDataError("failed validation, Description is empty")
+	}
+
+	// Monster names are unique
+	recs, err := m.GetMonsterRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "name",
+					Val: rec.Name,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	for _, r := range recs {
+		if r.ID != rec.ID {
+			return coreerror.NewInvalidDataError("failed validation, monster name >%s< has been taken", rec.Name)
+		}
 	}
 
 	return nil
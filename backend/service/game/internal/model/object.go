@@ -0,0 +1,58 @@
+package model
+
+// DeleteObject deletes an object that is not carried by a character or monster, does
+// not spawn at a location and has no object instances
+func (m *Model) DeleteObject(objectID string) error {
+	l := m.loggerWithFunctionContext("DeleteObject")
+
+	locationObjectRecs, err := m.GetLocationObjectRecs(referenceOptions("object_id", objectID))
+	if err != nil {
+		l.Warn("failed getting location object records >%v<", err)
+		return err
+	}
+
+	err = validateNotReferenced("object", objectID, locationObjectRecs, "location objects")
+	if err != nil {
+		return err
+	}
+
+	monsterObjectRecs, err := m.GetMonsterObjectRecs(referenceOptions("object_id", objectID))
+	if err != nil {
+		l.Warn("failed getting monster object records >%v<", err)
+		return err
+	}
+
+	err = validateNotReferenced("object", objectID, monsterObjectRecs, "monster objects")
+	if err != nil {
+		return err
+	}
+
+	characterObjectRecs, err := m.GetCharacterObjectRecs(referenceOptions("object_id", objectID))
+	if err != nil {
+		l.Warn("failed getting character object records >%v<", err)
+		return err
+	}
+
+	err = validateNotReferenced("object", objectID, characterObjectRecs, "character objects")
+	if err != nil {
+		return err
+	}
+
+	objectInstanceRecs, err := m.GetObjectInstanceRecs(referenceOptions("object_id", objectID))
+	if err != nil {
+		l.Warn("failed getting object instance records >%v<", err)
+		return err
+	}
+
+	err = validateNotReferenced("object", objectID, objectInstanceRecs, "object instances")
+	if err != nil {
+		return err
+	}
+
+	err = m.deleteTranslationRecs(objectID)
+	if err != nil {
+		return err
+	}
+
+	return m.DeleteObjectRec(objectID)
+}
@@ -1,8 +1,7 @@
 package model
 
 import (
-	"fmt"
-
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
@@ -10,7 +9,13 @@ import (
 func (m *Model) validateObjectRec(rec *record.Object) error {
 
 	if rec.Name == "" {
-		return fmt.Errorf("failed validation, Name is empty")
+		return coreerror.NewInvalidDataError("failed validation, Name is empty")
+	}
+	if rec.Description == "" {
+		return coreerror.NewInvalidDataError("failed validation, Description is empty")
+	}
+	if rec.DescriptionDetailed == "" {
+		return coreerror.NewInvalidDataError("failed validation, DescriptionDetailed is empty")
 	}
 
 	return nil
@@ -0,0 +1,51 @@
+package model
+
+import "gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+
+const (
+	// PermissionPlay allows playing characters owned by the authenticated account
+	PermissionPlay string = "play"
+	// PermissionDungeonInstanceManage allows inspecting and managing any dungeon instance
+	PermissionDungeonInstanceManage string = "dungeon_instance:manage"
+	// PermissionDungeonAuthor allows creating, updating and deleting dungeons, locations,
+	// monsters, objects and where monsters and objects spawn
+	PermissionDungeonAuthor string = "dungeon:author"
+	// PermissionAccountManage allows managing any account including assigning roles
+	PermissionAccountManage string = "account:manage"
+)
+
+// Permissions are all permissions that may be granted by an account role or an API key scope
+var Permissions = []string{
+	PermissionPlay,
+	PermissionDungeonInstanceManage,
+	PermissionDungeonAuthor,
+	PermissionAccountManage,
+}
+
+// AccountRolePermissions are the permissions granted by each account role
+var AccountRolePermissions = map[string][]string{
+	record.AccountRolePlayer: {
+		PermissionPlay,
+	},
+	record.AccountRoleGameMaster: {
+		PermissionPlay,
+		PermissionDungeonInstanceManage,
+		PermissionDungeonAuthor,
+	},
+	record.AccountRoleAdmin: {
+		PermissionPlay,
+		PermissionDungeonInstanceManage,
+		PermissionDungeonAuthor,
+		PermissionAccountManage,
+	},
+}
+
+// IsPermission returns whether the permission may be granted
+func IsPermission(permission string) bool {
+	for _, p := range Permissions {
+		if permission == p {
+			return true
+		}
+	}
+	return false
+}
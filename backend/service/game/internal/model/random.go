@@ -0,0 +1,71 @@
+package model
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+)
+
+// SetSeeder replaces the function new dungeon instance seeds are taken from,
+// pinning seeds so dungeon instances can be reproduced in tests.
+func (m *Model) SetSeeder(seeder func() int64) {
+	m.seeder = seeder
+}
+
+// Seeder returns the function new dungeon instance seeds are taken from so a
+// replaced seeder may be restored, nil when seeds are random.
+func (m *Model) Seeder() func() int64 {
+	return m.seeder
+}
+
+// newSeed returns a seed for a new dungeon instance
+func (m *Model) newSeed() int64 {
+	if m.seeder == nil {
+		return rand.Int63()
+	}
+	return m.seeder()
+}
+
+// NewRand returns the random source an entity makes its choices from during a
+// dungeon instance turn. The source is derived from the dungeon instance seed,
+// the turn number and the entity identifier so the same choices are made no
+// matter the order entities are processed in.
+func NewRand(seed int64, turnNumber int, entityID string) *rand.Rand {
+
+	h := fnv.New64a()
+
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(seed))
+	h.Write(b)
+	binary.BigEndian.PutUint64(b, uint64(turnNumber))
+	h.Write(b)
+	h.Write([]byte(entityID))
+
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// dungeonInstanceRand returns the random source an entity makes its choices
+// from during the current turn of a dungeon instance.
+func (m *Model) dungeonInstanceRand(dungeonInstanceID, entityID string) (*rand.Rand, error) {
+	l := m.loggerWithFunctionContext("dungeonInstanceRand")
+
+	dungeonInstanceRec, err := m.GetDungeonInstanceRec(dungeonInstanceID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon instance record >%v<", err)
+		return nil, err
+	}
+	if dungeonInstanceRec == nil {
+		msg := fmt.Sprintf("failed getting dungeon instance ID >%s<", dungeonInstanceID)
+		l.Warn(msg)
+		return nil, fmt.Errorf(msg)
+	}
+
+	turnNumber, err := m.GetDungeonInstanceTurnNumber(dungeonInstanceID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance turn number >%v<", err)
+		return nil, err
+	}
+
+	return NewRand(dungeonInstanceRec.Seed, turnNumber, entityID), nil
+}
@@ -0,0 +1,129 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/stretchr/testify/require"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+func TestRegisterAndLoginAccount(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	tests := []struct {
+		name             string
+		registerArgs     func(data harness.Data) *model.RegisterAccountArgs
+		expectRegisterOK bool
+		loginEmail       func(args *model.RegisterAccountArgs) string
+		loginPassword    func(args *model.RegisterAccountArgs) string
+		expectLoginOK    bool
+	}{
+		{
+			name: "Registers and logs in with matching credentials",
+			registerArgs: func(data harness.Data) *model.RegisterAccountArgs {
+				return &model.RegisterAccountArgs{
+					Name:     gofakeit.Name(),
+					Email:    gofakeit.Email(),
+					Password: "a-valid-password",
+				}
+			},
+			expectRegisterOK: true,
+			loginEmail: func(args *model.RegisterAccountArgs) string {
+				return strings.ToUpper(args.Email)
+			},
+			loginPassword: func(args *model.RegisterAccountArgs) string {
+				return args.Password
+			},
+			expectLoginOK: true,
+		},
+		{
+			name: "Registers and fails to log in with an invalid password",
+			registerArgs: func(data harness.Data) *model.RegisterAccountArgs {
+				return &model.RegisterAccountArgs{
+					Name:     gofakeit.Name(),
+					Email:    gofakeit.Email(),
+					Password: "a-valid-password",
+				}
+			},
+			expectRegisterOK: true,
+			loginEmail: func(args *model.RegisterAccountArgs) string {
+				return args.Email
+			},
+			loginPassword: func(args *model.RegisterAccountArgs) string {
+				return "an-invalid-password"
+			},
+			expectLoginOK: false,
+		},
+		{
+			name: "Fails to register with an email that is taken",
+			registerArgs: func(data harness.Data) *model.RegisterAccountArgs {
+				return &model.RegisterAccountArgs{
+					Name:     gofakeit.Name(),
+					Email:    data.AccountRecs[0].Email,
+					Password: "a-valid-password",
+				}
+			},
+			expectRegisterOK: false,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Run test >%s<", tc.name)
+
+			// Test harness
+			_, err = th.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = th.RollbackTx()
+				require.NoError(t, err, "RollbackTx returns without error")
+				err = th.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// init tx
+			_, err = th.InitTx()
+			require.NoError(t, err, "InitTx returns without error")
+
+			args := tc.registerArgs(th.Data)
+
+			rec, err := th.Model.(*model.Model).RegisterAccount(args)
+			if !tc.expectRegisterOK {
+				require.Error(t, err, "RegisterAccount returns error")
+				return
+			}
+			require.NoError(t, err, "RegisterAccount returns without error")
+			require.NotEmpty(t, rec.ID, "Registered account ID is not empty")
+			require.NotEqual(t, args.Password, rec.PasswordHash, "Registered account password is hashed")
+
+			loginRec, err := th.Model.(*model.Model).LoginAccount(tc.loginEmail(args), tc.loginPassword(args))
+			if !tc.expectLoginOK {
+				require.Error(t, err, "LoginAccount returns error")
+				require.True(t, coreerror.HasErrorCode(err, model.ErrorCodeAccountInvalidLogin), "LoginAccount error code equals expected")
+				return
+			}
+			require.NoError(t, err, "LoginAccount returns without error")
+			require.Equal(t, rec.ID, loginRec.ID, "Logged in account ID equals registered account ID")
+		})
+	}
+}
@@ -1542,3 +1542,146 @@ func TestProcessCharacterAction(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCharacterInstanceVisibleActionRecs(t *testing.T) {
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	config := harness.DefaultDataConfig
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	// init tx
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+
+	m := th.Model.(*model.Model)
+
+	diRec, _ := th.Data.GetDungeonInstanceRecByName(harness.DungeonNameCave)
+	ciRec, _ := th.Data.GetCharacterInstanceRecByName(harness.CharacterNameBarricade)
+
+	actionIDs := []string{}
+	for idx := 0; idx < 2; idx++ {
+		if idx > 0 {
+			turnDuration := time.Duration(0)
+			_, err := m.IncrementDungeonInstanceTurn(&model.IncrementDungeonInstanceTurnArgs{
+				DungeonInstanceID: diRec.ID,
+				TurnDuration:      &turnDuration,
+			})
+			require.NoError(t, err, "IncrementDungeonInstanceTurn returns without error")
+		}
+		rslt, err := m.ProcessCharacterAction(diRec.ID, ciRec.ID, "look")
+		require.NoError(t, err, "ProcessCharacterAction returns without error")
+		actionIDs = append(actionIDs, rslt.ActionRec.ID)
+	}
+
+	ciRec, err = m.GetCharacterInstanceRec(ciRec.ID, nil)
+	require.NoError(t, err, "GetCharacterInstanceRec returns without error")
+
+	firstActionRec, err := m.GetActionRec(actionIDs[0], nil)
+	require.NoError(t, err, "GetActionRec returns without error")
+	firstSerialNumber := int(null.NullInt32ToInt32(firstActionRec.SerialNumber))
+
+	recs, err := m.GetCharacterInstanceVisibleActionRecs(ciRec, firstSerialNumber-1, 0)
+	require.NoError(t, err, "GetCharacterInstanceVisibleActionRecs returns without error")
+
+	recIDs := []string{}
+	for idx := range recs {
+		if idx > 0 {
+			require.Less(t, null.NullInt32ToInt32(recs[idx-1].SerialNumber), null.NullInt32ToInt32(recs[idx].SerialNumber), "Action records are ordered by serial number")
+		}
+		recIDs = append(recIDs, recs[idx].ID)
+	}
+	for _, actionID := range actionIDs {
+		require.Contains(t, recIDs, actionID, "Visible action records contain character action")
+	}
+
+	recs, err = m.GetCharacterInstanceVisibleActionRecs(ciRec, firstSerialNumber-1, 1)
+	require.NoError(t, err, "GetCharacterInstanceVisibleActionRecs returns without error")
+	require.Len(t, recs, 1, "Visible action records are limited")
+	require.Equal(t, actionIDs[0], recs[0].ID, "First visible action record is the first character action")
+
+	recs, err = m.GetCharacterInstanceVisibleActionRecs(ciRec, firstSerialNumber, 0)
+	require.NoError(t, err, "GetCharacterInstanceVisibleActionRecs returns without error")
+	for _, rec := range recs {
+		require.NotEqual(t, actionIDs[0], rec.ID, "Visible action records exclude actions at or before serial number")
+	}
+}
+
+func TestGetCharacterInstanceVisibleActionRecsTeleport(t *testing.T) {
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	config := harness.DefaultDataConfig
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	// init tx
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+
+	m := th.Model.(*model.Model)
+
+	diRec, _ := th.Data.GetDungeonInstanceRecByName(harness.DungeonNameCave)
+	observerRec, _ := th.Data.GetCharacterInstanceRecByName(harness.CharacterNameBarricade)
+	teleportedRec, _ := th.Data.GetCharacterInstanceRecByName(harness.CharacterNameLegislate)
+	liRec, _ := th.Data.GetLocationInstanceRecByName(harness.LocationNameCaveTunnel)
+
+	// The observer is moved to the destination before the character is teleported
+	rs, err := m.TeleportCharacterInstance(&model.TeleportCharacterInstanceArgs{
+		SystemActor:         "Game Master",
+		DungeonInstanceID:   diRec.ID,
+		CharacterInstanceID: observerRec.ID,
+		LocationInstanceID:  liRec.ID,
+	})
+	require.NoError(t, err, "TeleportCharacterInstance returns without error")
+	serialNumber := int(null.NullInt32ToInt32(rs.ActionRec.SerialNumber))
+
+	rs, err = m.TeleportCharacterInstance(&model.TeleportCharacterInstanceArgs{
+		SystemActor:         "Game Master",
+		DungeonInstanceID:   diRec.ID,
+		CharacterInstanceID: teleportedRec.ID,
+		LocationInstanceID:  liRec.ID,
+	})
+	require.NoError(t, err, "TeleportCharacterInstance returns without error")
+	require.NotEqual(t, liRec.ID, rs.ActionRec.LocationInstanceID, "Teleport is recorded at the location the character left")
+
+	observerRec, err = m.GetCharacterInstanceRec(observerRec.ID, nil)
+	require.NoError(t, err, "GetCharacterInstanceRec returns without error")
+
+	recs, err := m.GetCharacterInstanceVisibleActionRecs(observerRec, serialNumber, 0)
+	require.NoError(t, err, "GetCharacterInstanceVisibleActionRecs returns without error")
+	require.Len(t, recs, 1, "Visible action records contain the arrival")
+	require.Equal(t, rs.ActionRec.ID, recs[0].ID, "Character at the destination sees the teleported character arrive")
+
+	recs, err = m.GetCharacterInstanceActionRecsAfterSerialNumber(observerRec, serialNumber)
+	require.NoError(t, err, "GetCharacterInstanceActionRecsAfterSerialNumber returns without error")
+	require.Len(t, recs, 1, "Action records contain the arrival")
+	require.Equal(t, rs.ActionRec.ID, recs[0].ID, "Character at the destination is sent the teleported character arriving")
+}
@@ -0,0 +1,167 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/log"
+	coremodel "gitlab.com/alienspaces/go-mud/backend/core/model"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestNewRand(t *testing.T) {
+
+	sequence := func(seed int64, turnNumber int, entityID string) []int {
+		r := model.NewRand(seed, turnNumber, entityID)
+		s := []int{}
+		for i := 0; i < 10; i++ {
+			s = append(s, r.Intn(100))
+		}
+		return s
+	}
+
+	require.Equal(t, sequence(1, 1, "goblin"), sequence(1, 1, "goblin"), "Same seed, turn and entity returns the same sequence")
+	require.NotEqual(t, sequence(1, 1, "goblin"), sequence(2, 1, "goblin"), "Different seed returns a different sequence")
+	require.NotEqual(t, sequence(1, 1, "goblin"), sequence(1, 2, "goblin"), "Different turn returns a different sequence")
+	require.NotEqual(t, sequence(1, 1, "goblin"), sequence(1, 1, "dwarf"), "Different entity returns a different sequence")
+}
+
+func TestDecideAction(t *testing.T) {
+
+	l, err := log.NewLoggerWithConfig(log.Config{Level: "error"})
+	require.NoError(t, err, "NewLoggerWithConfig returns without error")
+
+	m := &model.Model{
+		Model: coremodel.Model{
+			Log: l,
+		},
+	}
+
+	const seed int64 = 1
+	const monsterInstanceID string = "monster-instance"
+
+	look := func(direction string) *model.Memory {
+		return &model.Memory{
+			ActionRec: &record.Action{
+				ResolvedCommand:                 record.ActionCommandLook,
+				ResolvedTargetLocationDirection: null.NullStringFromString(direction),
+			},
+		}
+	}
+
+	tests := []struct {
+		name                      string
+		locationInstanceRecordSet *record.LocationInstanceViewRecordSet
+		memories                  []*model.Memory
+		expect                    []string
+	}{
+		{
+			name: "Attack random living character",
+			locationInstanceRecordSet: &record.LocationInstanceViewRecordSet{
+				LocationInstanceViewRec: &record.LocationInstanceView{
+					Name: "Cave",
+				},
+				CharacterInstanceViewRecs: []*record.CharacterInstanceView{
+					{Name: "Barricade", CurrentHealth: 10},
+					{Name: "Legislate", CurrentHealth: 0},
+					{Name: "Bolster", CurrentHealth: 10},
+					{Name: "Stalwart", CurrentHealth: 10},
+				},
+			},
+			expect: []string{
+				"attack Stalwart",
+				"attack Barricade",
+				"attack Bolster",
+				"attack Stalwart",
+				"attack Barricade",
+				"attack Bolster",
+				"attack Bolster",
+				"attack Bolster",
+			},
+		},
+		{
+			name: "Move random direction",
+			locationInstanceRecordSet: &record.LocationInstanceViewRecordSet{
+				LocationInstanceViewRec: &record.LocationInstanceView{
+					Name:                    "Cave",
+					NorthLocationInstanceID: null.NullStringFromString("tunnel"),
+					EastLocationInstanceID:  null.NullStringFromString("pool"),
+					SouthLocationInstanceID: null.NullStringFromString("entrance"),
+				},
+			},
+			// Having looked every direction and seen nothing interesting
+			memories: []*model.Memory{
+				look("north"),
+				look("east"),
+				look("south"),
+			},
+			expect: []string{
+				"move south",
+				"move north",
+				"move east",
+				"move south",
+				"move north",
+				"move east",
+				"move east",
+				"move east",
+			},
+		},
+		{
+			name: "Move without going back",
+			locationInstanceRecordSet: &record.LocationInstanceViewRecordSet{
+				LocationInstanceViewRec: &record.LocationInstanceView{
+					Name:                    "Cave",
+					NorthLocationInstanceID: null.NullStringFromString("tunnel"),
+					EastLocationInstanceID:  null.NullStringFromString("pool"),
+					SouthLocationInstanceID: null.NullStringFromString("entrance"),
+				},
+			},
+			// Having come from the entrance and looked every direction
+			memories: []*model.Memory{
+				{
+					ActionRec: &record.Action{
+						LocationInstanceID: "entrance",
+						ResolvedCommand:    record.ActionCommandMove,
+					},
+				},
+				look("north"),
+				look("east"),
+				look("south"),
+			},
+			expect: []string{
+				"move north",
+				"move north",
+				"move east",
+				"move north",
+				"move east",
+				"move north",
+				"move east",
+				"move east",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// Decisions for a pinned seed are the same every run
+			sentences := []string{}
+			for turnNumber := 1; turnNumber <= len(tt.expect); turnNumber++ {
+				sentence, err := m.DecideAction(&model.DeciderArgs{
+					MonsterInstanceViewRec:    &record.MonsterInstanceView{Name: "Angry Goblin"},
+					LocationInstanceRecordSet: tt.locationInstanceRecordSet,
+					Memories:                  tt.memories,
+					Rand:                      model.NewRand(seed, turnNumber, monsterInstanceID),
+				})
+				require.NoError(t, err, "DecideAction returns without error")
+				sentences = append(sentences, sentence)
+			}
+			require.Equal(t, tt.expect, sentences, "DecideAction returns expected sentences")
+		})
+	}
+}
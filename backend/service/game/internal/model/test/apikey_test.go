@@ -0,0 +1,130 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+func TestIssueAuthenticateAndRevokeAPIKey(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	tests := []struct {
+		name                 string
+		issueArgs            func(data harness.Data) *model.IssueAPIKeyArgs
+		expectIssueErrorCode coreerror.ErrorCode
+		expectAuthenticateOK bool
+	}{
+		{
+			name: "Issues and authenticates a key for an account",
+			issueArgs: func(data harness.Data) *model.IssueAPIKeyArgs {
+				return &model.IssueAPIKeyArgs{
+					Owner:     "Test Bot",
+					Scopes:    []string{model.PermissionPlay},
+					AccountID: data.AccountRecs[0].ID,
+				}
+			},
+			expectAuthenticateOK: true,
+		},
+		{
+			name: "Issues and authenticates a key without an account",
+			issueArgs: func(data harness.Data) *model.IssueAPIKeyArgs {
+				return &model.IssueAPIKeyArgs{
+					Owner:     "Test Tool",
+					Scopes:    []string{model.PermissionDungeonInstanceManage},
+					ExpiresAt: time.Now().Add(time.Hour),
+				}
+			},
+			expectAuthenticateOK: true,
+		},
+		{
+			name: "Issues and fails to authenticate an expired key",
+			issueArgs: func(data harness.Data) *model.IssueAPIKeyArgs {
+				return &model.IssueAPIKeyArgs{
+					Owner:     "Test Tool",
+					Scopes:    []string{model.PermissionDungeonInstanceManage},
+					ExpiresAt: time.Now().Add(-time.Hour),
+				}
+			},
+			expectAuthenticateOK: false,
+		},
+		{
+			name: "Fails to issue a key with an invalid scope",
+			issueArgs: func(data harness.Data) *model.IssueAPIKeyArgs {
+				return &model.IssueAPIKeyArgs{
+					Owner:  "Test Tool",
+					Scopes: []string{"everything"},
+				}
+			},
+			expectIssueErrorCode: model.ErrorCodeAPIKeyInvalidScope,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Run test >%s<", tc.name)
+
+			// Test harness
+			_, err = th.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = th.RollbackTx()
+				require.NoError(t, err, "RollbackTx returns without error")
+				err = th.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// init tx
+			_, err = th.InitTx()
+			require.NoError(t, err, "InitTx returns without error")
+
+			m := th.Model.(*model.Model)
+
+			key, rec, err := m.IssueAPIKey(tc.issueArgs(th.Data))
+			if tc.expectIssueErrorCode != "" {
+				require.Error(t, err, "IssueAPIKey returns error")
+				require.True(t, coreerror.HasErrorCode(err, tc.expectIssueErrorCode), "IssueAPIKey error code equals expected")
+				return
+			}
+			require.NoError(t, err, "IssueAPIKey returns without error")
+			require.NotEmpty(t, key, "Issued key is not empty")
+			require.NotEqual(t, key, rec.KeyHash, "Issued key is hashed")
+
+			authRec, err := m.AuthenticateAPIKey(key)
+			require.NoError(t, err, "AuthenticateAPIKey returns without error")
+			if !tc.expectAuthenticateOK {
+				require.Nil(t, authRec, "AuthenticateAPIKey returns nil")
+				return
+			}
+			require.NotNil(t, authRec, "AuthenticateAPIKey returns a record")
+			require.Equal(t, rec.ID, authRec.ID, "Authenticated key ID equals issued key ID")
+
+			_, err = m.RevokeAPIKey(rec.ID)
+			require.NoError(t, err, "RevokeAPIKey returns without error")
+
+			authRec, err = m.AuthenticateAPIKey(key)
+			require.NoError(t, err, "AuthenticateAPIKey returns without error")
+			require.Nil(t, authRec, "AuthenticateAPIKey returns nil after revoking")
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+func TestImportExportDungeonDefinition(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	tests := []struct {
+		name         string
+		filename     string
+		definition   func(data harness.Data, d *model.DungeonDefinition) *model.DungeonDefinition
+		importTwice  bool
+		expectImport bool
+	}{
+		{
+			name:         "Imports and exports the cave",
+			filename:     "../../data/cave/cave.yaml",
+			expectImport: true,
+		},
+		{
+			name:         "Imports and exports the cabin",
+			filename:     "../../data/cabin/cabin.yaml",
+			expectImport: true,
+		},
+		{
+			name:     "Imports and exports the cave without identifiers",
+			filename: "../../data/cave/cave.yaml",
+			definition: func(data harness.Data, d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.ID = ""
+				for idx := range d.Locations {
+					d.Locations[idx].ID = ""
+				}
+				for idx := range d.Monsters {
+					d.Monsters[idx].ID = ""
+				}
+				for idx := range d.Objects {
+					d.Objects[idx].ID = ""
+				}
+				return d
+			},
+			expectImport: true,
+		},
+		{
+			name:         "Fails to import a dungeon that already exists",
+			filename:     "../../data/cabin/cabin.yaml",
+			importTwice:  true,
+			expectImport: false,
+		},
+		{
+			name:     "Fails to import an exit to an undefined location",
+			filename: "../../data/cabin/cabin.yaml",
+			definition: func(data harness.Data, d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.Locations[0].Exits.East = "Cabin Attic"
+				return d
+			},
+			expectImport: false,
+		},
+		{
+			name:     "Imports a dungeon sharing an existing monster",
+			filename: "../../data/cabin/cabin.yaml",
+			definition: func(data harness.Data, d *model.DungeonDefinition) *model.DungeonDefinition {
+				return withExistingMonster(data, d, 0)
+			},
+			expectImport: true,
+		},
+		{
+			name:     "Fails to import a dungeon changing the attributes of an existing monster",
+			filename: "../../data/cabin/cabin.yaml",
+			definition: func(data harness.Data, d *model.DungeonDefinition) *model.DungeonDefinition {
+				return withExistingMonster(data, d, 1)
+			},
+			expectImport: false,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Run test >%s<", tc.name)
+
+			// Test harness
+			_, err = th.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = th.RollbackTx()
+				require.NoError(t, err, "RollbackTx returns without error")
+				err = th.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// init tx
+			_, err = th.InitTx()
+			require.NoError(t, err, "InitTx returns without error")
+
+			data, err := os.ReadFile(tc.filename)
+			require.NoError(t, err, "ReadFile returns without error")
+
+			d, err := model.UnmarshalDungeonDefinition(data)
+			require.NoError(t, err, "UnmarshalDungeonDefinition returns without error")
+
+			if tc.definition != nil {
+				d = tc.definition(th.Data, d)
+			}
+
+			if tc.importTwice {
+				_, err := th.Model.(*model.Model).ImportDungeonDefinition(d)
+				require.NoError(t, err, "ImportDungeonDefinition returns without error")
+			}
+
+			rec, err := th.Model.(*model.Model).ImportDungeonDefinition(d)
+			if !tc.expectImport {
+				require.Error(t, err, "ImportDungeonDefinition returns error")
+				return
+			}
+			require.NoError(t, err, "ImportDungeonDefinition returns without error")
+			require.Equal(t, d.Name, rec.Name, "Imported dungeon name equals expected")
+
+			exported, err := th.Model.(*model.Model).ExportDungeonDefinition(d.Name)
+			require.NoError(t, err, "ExportDungeonDefinition returns without error")
+
+			if tc.definition != nil {
+				// Identifiers are assigned on import
+				require.NotEmpty(t, exported.ID, "Exported dungeon ID is not empty")
+				d.ID = exported.ID
+				for idx := range d.Locations {
+					d.Locations[idx].ID = exported.Locations[idx].ID
+				}
+				for idx := range d.Monsters {
+					d.Monsters[idx].ID = exported.Monsters[idx].ID
+				}
+				for idx := range d.Objects {
+					d.Objects[idx].ID = exported.Objects[idx].ID
+				}
+			}
+
+			require.Equal(t, d, exported, "Exported dungeon definition equals imported definition")
+		})
+	}
+}
+
+// withExistingMonster adds an existing harness monster to the first location of
+// a dungeon definition with the strength adjusted
+func withExistingMonster(data harness.Data, d *model.DungeonDefinition, adjustStrength int) *model.DungeonDefinition {
+	mRec := data.MonsterRecs[0]
+
+	d.Monsters = append(d.Monsters, model.MonsterDefinition{
+		Name:         mRec.Name,
+		Description:  mRec.Description,
+		Strength:     mRec.Strength + adjustStrength,
+		Dexterity:    mRec.Dexterity,
+		Intelligence: mRec.Intelligence,
+	})
+	d.Locations[0].Monsters = append(d.Locations[0].Monsters, model.LocationSpawnDefinition{
+		Name:               mRec.Name,
+		SpawnPercentChance: 100,
+	})
+
+	return d
+}
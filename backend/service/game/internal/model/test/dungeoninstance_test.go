@@ -0,0 +1,222 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+func TestGetIdleCharacterInstanceRecs(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	tests := []struct {
+		name        string
+		idleSince   func() time.Time
+		expectCount func(data harness.Data, dungeonInstanceID string) int
+	}{
+		{
+			name: "Returns no characters when all have been active",
+			idleSince: func() time.Time {
+				return time.Now().Add(-time.Hour)
+			},
+			expectCount: func(data harness.Data, dungeonInstanceID string) int {
+				return 0
+			},
+		},
+		{
+			name: "Returns all characters when none have been active",
+			idleSince: func() time.Time {
+				return time.Now().Add(time.Hour)
+			},
+			expectCount: func(data harness.Data, dungeonInstanceID string) int {
+				count := 0
+				for _, ciRec := range data.CharacterInstanceRecs {
+					if ciRec.DungeonInstanceID == dungeonInstanceID {
+						count++
+					}
+				}
+				return count
+			},
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Run test >%s<", tc.name)
+
+			// Test harness
+			_, err = th.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = th.RollbackTx()
+				require.NoError(t, err, "RollbackTx returns without error")
+				err = th.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// init tx
+			_, err = th.InitTx()
+			require.NoError(t, err, "InitTx returns without error")
+
+			dungeonInstanceID := th.Data.DungeonInstanceRecs[0].ID
+
+			recs, err := th.Model.(*model.Model).GetIdleCharacterInstanceRecs(dungeonInstanceID, tc.idleSince())
+			require.NoError(t, err, "GetIdleCharacterInstanceRecs returns without error")
+			require.Equal(t, tc.expectCount(th.Data, dungeonInstanceID), len(recs), "Idle character instance count equals expected")
+		})
+	}
+}
+
+func TestUpdateDungeonInstanceEmptySince(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	// init tx
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+
+	m := th.Model.(*model.Model)
+
+	rec, err := m.GetDungeonInstanceRec(th.Data.DungeonInstanceRecs[0].ID, nil)
+	require.NoError(t, err, "GetDungeonInstanceRec returns without error")
+
+	emptyFor, err := m.UpdateDungeonInstanceEmptySince(rec, true)
+	require.NoError(t, err, "UpdateDungeonInstanceEmptySince returns without error")
+	require.Equal(t, time.Duration(0), emptyFor, "Newly empty dungeon instance has been empty for no time")
+	require.True(t, rec.EmptySince.Valid, "Empty since is set")
+
+	time.Sleep(10 * time.Millisecond)
+
+	emptyFor, err = m.UpdateDungeonInstanceEmptySince(rec, true)
+	require.NoError(t, err, "UpdateDungeonInstanceEmptySince returns without error")
+	require.Greater(t, emptyFor, time.Duration(0), "Empty dungeon instance has been empty for some time")
+
+	emptyFor, err = m.UpdateDungeonInstanceEmptySince(rec, false)
+	require.NoError(t, err, "UpdateDungeonInstanceEmptySince returns without error")
+	require.Equal(t, time.Duration(0), emptyFor, "Occupied dungeon instance is not empty")
+	require.False(t, rec.EmptySince.Valid, "Empty since is cleared")
+}
+
+func TestSnapshotRestoreDungeonInstance(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	// init tx
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+
+	m := th.Model.(*model.Model)
+
+	dungeonInstanceID := th.Data.DungeonInstanceRecs[0].ID
+
+	// Advance several turns so the snapshot is not taken at the first turn
+	turnDuration := time.Duration(0)
+	turnNumber := 0
+	for i := 0; i < 3; i++ {
+		result, err := m.IncrementDungeonInstanceTurn(&model.IncrementDungeonInstanceTurnArgs{
+			DungeonInstanceID: dungeonInstanceID,
+			TurnDuration:      &turnDuration,
+		})
+		require.NoError(t, err, "IncrementDungeonInstanceTurn returns without error")
+		require.True(t, result.Incremented, "IncrementDungeonInstanceTurn increments the turn")
+		turnNumber = result.Record.TurnNumber
+	}
+	require.Greater(t, turnNumber, 1, "Dungeon instance has advanced beyond the first turn")
+
+	snapshot, err := m.SnapshotDungeonInstance(dungeonInstanceID)
+	require.NoError(t, err, "SnapshotDungeonInstance returns without error")
+	require.Equal(t, model.DungeonInstanceSnapshotVersion, snapshot.Version, "Snapshot version equals expected")
+	require.Equal(t, turnNumber, snapshot.TurnNumber, "Snapshot turn number equals expected")
+
+	// Characters can only be restored once they are no longer in the original dungeon instance
+	err = m.DeleteDungeonInstance(dungeonInstanceID)
+	require.NoError(t, err, "DeleteDungeonInstance returns without error")
+
+	rs, err := m.RestoreDungeonInstanceSnapshot(snapshot)
+	require.NoError(t, err, "RestoreDungeonInstanceSnapshot returns without error")
+	require.NotEqual(t, dungeonInstanceID, rs.DungeonInstanceRec.ID, "Restored dungeon instance is a new dungeon instance")
+
+	restored, err := m.SnapshotDungeonInstance(rs.DungeonInstanceRec.ID)
+	require.NoError(t, err, "SnapshotDungeonInstance returns without error")
+
+	require.Equal(t, snapshot.DungeonID, restored.DungeonID, "Restored dungeon ID equals expected")
+	require.Equal(t, th.Data.DungeonInstanceRecs[0].Seed, snapshot.Seed, "Snapshot seed equals the dungeon instance seed")
+	require.Equal(t, snapshot.Seed, rs.DungeonInstanceRec.Seed, "Restored dungeon instance seed equals the snapshot seed")
+	require.Equal(t, snapshot.Seed, restored.Seed, "Restored snapshot seed equals expected")
+	require.Equal(t, turnNumber, restored.TurnNumber, "Restored turn number equals the snapshot turn number")
+	require.Equal(t, len(snapshot.LocationInstances), len(restored.LocationInstances), "Restored location instance count equals expected")
+	require.Equal(t, len(snapshot.MonsterInstances), len(restored.MonsterInstances), "Restored monster instance count equals expected")
+	require.Equal(t, len(snapshot.ObjectInstances), len(restored.ObjectInstances), "Restored object instance count equals expected")
+	require.Equal(t, len(snapshot.CharacterInstances), len(restored.CharacterInstances), "Restored character instance count equals expected")
+
+	for _, expect := range snapshot.CharacterInstances {
+		found := false
+		for _, got := range restored.CharacterInstances {
+			if got.CharacterID != expect.CharacterID {
+				continue
+			}
+			found = true
+			require.Equal(t, expect.LocationID, got.LocationID, "Restored character location equals expected")
+			require.Equal(t, expect.Health, got.Health, "Restored character health equals expected")
+			require.Equal(t, expect.Coins, got.Coins, "Restored character coins equals expected")
+		}
+		require.True(t, found, "Restored character ID >%s< exists", expect.CharacterID)
+	}
+}
@@ -0,0 +1,221 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestCheckDungeonInstanceConsistency(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+	}()
+
+	m := th.Model.(*model.Model)
+
+	dungeonInstanceRec := th.Data.DungeonInstanceRecs[0]
+
+	locationInstanceRecs := []*record.LocationInstance{}
+	for _, rec := range th.Data.LocationInstanceRecs {
+		if rec.DungeonInstanceID == dungeonInstanceRec.ID {
+			locationInstanceRecs = append(locationInstanceRecs, rec)
+		}
+	}
+	require.NotEmpty(t, locationInstanceRecs, "Dungeon instance has location instances")
+
+	characterInstanceRecs := []*record.CharacterInstance{}
+	for _, rec := range th.Data.CharacterInstanceRecs {
+		if rec.DungeonInstanceID == dungeonInstanceRec.ID {
+			characterInstanceRecs = append(characterInstanceRecs, rec)
+		}
+	}
+	require.NotEmpty(t, characterInstanceRecs, "Dungeon instance has character instances")
+
+	monsterInstanceRecs := []*record.MonsterInstance{}
+	for _, rec := range th.Data.MonsterInstanceRecs {
+		if rec.DungeonInstanceID == dungeonInstanceRec.ID {
+			monsterInstanceRecs = append(monsterInstanceRecs, rec)
+		}
+	}
+	require.NotEmpty(t, monsterInstanceRecs, "Dungeon instance has monster instances")
+
+	objectInstanceRecs := []*record.ObjectInstance{}
+	for _, rec := range th.Data.ObjectInstanceRecs {
+		if rec.DungeonInstanceID == dungeonInstanceRec.ID {
+			objectInstanceRecs = append(objectInstanceRecs, rec)
+		}
+	}
+	require.GreaterOrEqual(t, len(objectInstanceRecs), 2, "Dungeon instance has object instances")
+
+	// Another instance of the same dungeon
+	otherRecordSet, err := m.CreateDungeonInstance(dungeonInstanceRec.DungeonID)
+	require.NoError(t, err, "CreateDungeonInstance returns without error")
+
+	// A direction leading into the other dungeon instance
+	otherLocationInstanceRec := otherRecordSet.LocationInstanceRecs[0]
+	locationInstanceRec, err := m.GetLocationInstanceRec(locationInstanceRecs[0].ID, nil)
+	require.NoError(t, err, "GetLocationInstanceRec returns without error")
+	locationInstanceRec.NorthLocationInstanceID = null.NullStringFromString(otherLocationInstanceRec.ID)
+	require.NoError(t, m.UpdateLocationInstanceRec(locationInstanceRec), "UpdateLocationInstanceRec returns without error")
+
+	expectNorthLocationInstanceID := ""
+	for _, rec := range locationInstanceRecs {
+		if rec.LocationID == otherLocationInstanceRec.LocationID {
+			expectNorthLocationInstanceID = rec.ID
+		}
+	}
+
+	// An object both equipped and stashed
+	characterInstanceRec := characterInstanceRecs[0]
+	equippedStashedRec, err := m.GetObjectInstanceRec(objectInstanceRecs[0].ID, nil)
+	require.NoError(t, err, "GetObjectInstanceRec returns without error")
+	equippedStashedRec.LocationInstanceID = sql.NullString{}
+	equippedStashedRec.MonsterInstanceID = sql.NullString{}
+	equippedStashedRec.CharacterInstanceID = null.NullStringFromString(characterInstanceRec.ID)
+	equippedStashedRec.IsEquipped = true
+	equippedStashedRec.IsStashed = true
+	require.NoError(t, m.UpdateObjectInstanceRec(equippedStashedRec), "UpdateObjectInstanceRec returns without error")
+
+	// A dead monster that has decayed beyond removal
+	monsterInstanceRec, err := m.GetMonsterInstanceRec(monsterInstanceRecs[0].ID, nil)
+	require.NoError(t, err, "GetMonsterInstanceRec returns without error")
+	monsterInstanceRec.Health = 0
+	monsterInstanceRec.Decay = model.DecayTurns + 2
+	require.NoError(t, m.UpdateMonsterInstanceRec(monsterInstanceRec), "UpdateMonsterInstanceRec returns without error")
+
+	// An object equipped by the dead monster
+	deadHolderRec, err := m.GetObjectInstanceRec(objectInstanceRecs[1].ID, nil)
+	require.NoError(t, err, "GetObjectInstanceRec returns without error")
+	deadHolderRec.LocationInstanceID = sql.NullString{}
+	deadHolderRec.CharacterInstanceID = sql.NullString{}
+	deadHolderRec.MonsterInstanceID = null.NullStringFromString(monsterInstanceRec.ID)
+	deadHolderRec.IsEquipped = true
+	deadHolderRec.IsStashed = false
+	require.NoError(t, m.UpdateObjectInstanceRec(deadHolderRec), "UpdateObjectInstanceRec returns without error")
+
+	// A more recent instance of the character in the other dungeon instance,
+	// created without validation as validation does not allow it
+	duplicateRec := &record.CharacterInstance{
+		CharacterID:        characterInstanceRec.CharacterID,
+		DungeonInstanceID:  otherRecordSet.DungeonInstanceRec.ID,
+		LocationInstanceID: otherLocationInstanceRec.ID,
+		Strength:           characterInstanceRec.Strength,
+		Dexterity:          characterInstanceRec.Dexterity,
+		Intelligence:       characterInstanceRec.Intelligence,
+		Health:             characterInstanceRec.Health,
+		Fatigue:            characterInstanceRec.Fatigue,
+	}
+	require.NoError(t, m.CharacterInstanceRepository().CreateOne(duplicateRec), "CreateOne returns without error")
+
+	// An older turn record, created without validation as validation does not
+	// allow it
+	turnRec := &record.Turn{
+		DungeonInstanceID: dungeonInstanceRec.ID,
+		TurnNumber:        0,
+	}
+	require.NoError(t, m.TurnRepository().CreateOne(turnRec), "CreateOne returns without error")
+
+	type violation struct {
+		invariant string
+		recordID  string
+	}
+
+	expect := []violation{
+		{model.InvariantLocationDirection, locationInstanceRec.ID},
+		{model.InvariantObjectEquippedStashed, equippedStashedRec.ID},
+		{model.InvariantEquippedHolderLiving, deadHolderRec.ID},
+		{model.InvariantCharacterInstanceUnique, characterInstanceRec.ID},
+		{model.InvariantDecay, monsterInstanceRec.ID},
+		{model.InvariantTurnUnique, turnRec.ID},
+	}
+
+	check := func(repair bool) map[violation]bool {
+		result, err := m.CheckDungeonInstanceConsistency(&model.CheckDungeonInstanceConsistencyArgs{
+			DungeonInstanceID: dungeonInstanceRec.ID,
+			Repair:            repair,
+		})
+		require.NoError(t, err, "CheckDungeonInstanceConsistency returns without error")
+		require.Equal(t, dungeonInstanceRec.ID, result.DungeonInstanceID, "Result is for the dungeon instance")
+
+		found := map[violation]bool{}
+		for _, v := range result.Violations {
+			require.Equal(t, repair, v.Repaired, "Violation is repaired when repairing")
+			found[violation{v.Invariant, v.RecordID}] = true
+		}
+		return found
+	}
+
+	// Violations are reported
+	found := check(false)
+	for _, v := range expect {
+		require.True(t, found[v], "Violation >%s< record ID >%s< is reported", v.invariant, v.recordID)
+	}
+
+	// Violations are reported again and repaired
+	found = check(true)
+	for _, v := range expect {
+		require.True(t, found[v], "Violation >%s< record ID >%s< is repaired", v.invariant, v.recordID)
+	}
+
+	// Repaired violations are no longer reported
+	found = check(false)
+	for _, v := range expect {
+		require.False(t, found[v], "Violation >%s< record ID >%s< is not reported", v.invariant, v.recordID)
+	}
+
+	locationInstanceRec, err = m.GetLocationInstanceRec(locationInstanceRec.ID, nil)
+	require.NoError(t, err, "GetLocationInstanceRec returns without error")
+	require.Equal(t, expectNorthLocationInstanceID, null.NullStringToString(locationInstanceRec.NorthLocationInstanceID), "Direction leads to the same location in the dungeon instance")
+
+	// Objects of the older character instance are moved to the more recent
+	// character instance
+	equippedStashedRec, err = m.GetObjectInstanceRec(equippedStashedRec.ID, nil)
+	require.NoError(t, err, "GetObjectInstanceRec returns without error")
+	require.NotNil(t, equippedStashedRec, "Object of the older character instance is not removed")
+	require.Equal(t, duplicateRec.ID, null.NullStringToString(equippedStashedRec.CharacterInstanceID), "Object is held by the more recent character instance")
+	require.Equal(t, duplicateRec.DungeonInstanceID, equippedStashedRec.DungeonInstanceID, "Object is in the dungeon instance of the more recent character instance")
+	require.False(t, equippedStashedRec.IsEquipped, "Object is not equipped")
+	require.True(t, equippedStashedRec.IsStashed, "Object is stashed")
+
+	deadHolderRec, err = m.GetObjectInstanceRec(deadHolderRec.ID, nil)
+	require.NoError(t, err, "GetObjectInstanceRec returns without error")
+	require.Equal(t, monsterInstanceRec.LocationInstanceID, null.NullStringToString(deadHolderRec.LocationInstanceID), "Object is dropped where the monster died")
+
+	// Unknown dungeon instances
+	_, err = m.CheckDungeonInstanceConsistency(&model.CheckDungeonInstanceConsistencyArgs{
+		DungeonInstanceID: "ae6b4f9c-6a2b-4d5f-9c1e-2d6c3f1b8a70",
+	})
+	require.Error(t, err, "CheckDungeonInstanceConsistency returns error for unknown dungeon instance")
+}
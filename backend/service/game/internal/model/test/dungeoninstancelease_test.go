@@ -0,0 +1,138 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestClaimDungeonInstanceLeases(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+	}()
+
+	m := th.Model.(*model.Model)
+
+	// Another unclaimed dungeon instance lease so more than one lease may be
+	// claimed
+	_, err = m.CreateDungeonInstance(th.Data.DungeonInstanceRecs[0].DungeonID)
+	require.NoError(t, err, "CreateDungeonInstance returns without error")
+
+	daemonID := "test-claim-daemon-a"
+	otherDaemonID := "test-claim-daemon-b"
+
+	claim := func(daemonID string, maxLeases int, leaseDuration time.Duration) *model.ClaimDungeonInstanceLeasesResult {
+		result, err := m.ClaimDungeonInstanceLeases(&model.ClaimDungeonInstanceLeasesArgs{
+			DaemonID:      daemonID,
+			LeaseDuration: leaseDuration,
+			MaxLeases:     maxLeases,
+		})
+		require.NoError(t, err, "ClaimDungeonInstanceLeases returns without error")
+		for _, rec := range result.Records {
+			require.Equal(t, daemonID, null.NullStringToString(rec.DaemonID), "Lease is held by the daemon")
+		}
+		return result
+	}
+
+	recordIDs := func(recs []*record.DungeonInstanceLease) []string {
+		ids := []string{}
+		for _, rec := range recs {
+			ids = append(ids, rec.ID)
+		}
+		return ids
+	}
+
+	// Claim an unclaimed lease
+	result := claim(daemonID, 1, time.Minute)
+	require.Len(t, result.Records, 1, "Daemon holds the maximum number of leases")
+	require.Equal(t, 1, result.ClaimedCount, "Daemon claimed a lease")
+
+	claimedRec := result.Records[0]
+	require.True(t, claimedRec.ExpiresAt.Valid, "Claimed lease expires")
+
+	held, err := m.HoldsDungeonInstanceLease(claimedRec.DungeonInstanceID, daemonID)
+	require.NoError(t, err, "HoldsDungeonInstanceLease returns without error")
+	require.True(t, held, "Daemon holds the claimed lease")
+
+	// Renew the held lease
+	result = claim(daemonID, 1, time.Hour)
+	require.Equal(t, []string{claimedRec.ID}, recordIDs(result.Records), "Daemon holds the same lease")
+	require.Equal(t, 0, result.ClaimedCount, "Daemon claimed no leases")
+	require.True(t, result.Records[0].ExpiresAt.Time.After(claimedRec.ExpiresAt.Time), "Renewed lease expires later")
+
+	// Maximum leases limits claimed leases
+	result = claim(daemonID, 2, time.Hour)
+	require.Len(t, result.Records, 2, "Daemon holds the maximum number of leases")
+	require.Equal(t, 1, result.ClaimedCount, "Daemon claimed a lease")
+	require.Contains(t, recordIDs(result.Records), claimedRec.ID, "Daemon still holds the first lease")
+
+	// Maximum leases does not release held leases
+	result = claim(daemonID, 1, time.Hour)
+	require.Len(t, result.Records, 2, "Daemon holds all previously held leases")
+	require.Equal(t, 0, result.ClaimedCount, "Daemon claimed no leases")
+
+	// Expired leases are claimed by another daemon
+	expiredRec, err := m.GetDungeonInstanceLeaseRec(claimedRec.ID, nil)
+	require.NoError(t, err, "GetDungeonInstanceLeaseRec returns without error")
+	expiredRec.ExpiresAt = null.NullTimeFromTime(time.Now().UTC().Add(-time.Minute))
+	require.NoError(t, m.UpdateDungeonInstanceLeaseRec(expiredRec), "UpdateDungeonInstanceLeaseRec returns without error")
+
+	held, err = m.HoldsDungeonInstanceLease(claimedRec.DungeonInstanceID, daemonID)
+	require.NoError(t, err, "HoldsDungeonInstanceLease returns without error")
+	require.False(t, held, "Daemon does not hold the expired lease")
+
+	result = claim(otherDaemonID, 0, time.Hour)
+	require.Contains(t, recordIDs(result.Records), claimedRec.ID, "Other daemon holds the expired lease")
+	require.GreaterOrEqual(t, result.ClaimedCount, 1, "Other daemon claimed the expired lease")
+
+	held, err = m.HoldsDungeonInstanceLease(claimedRec.DungeonInstanceID, otherDaemonID)
+	require.NoError(t, err, "HoldsDungeonInstanceLease returns without error")
+	require.True(t, held, "Other daemon holds the expired lease")
+
+	// The daemon no longer renews a lease taken over by another daemon
+	result = claim(daemonID, 0, time.Hour)
+	require.NotContains(t, recordIDs(result.Records), claimedRec.ID, "Daemon does not hold the lease taken over")
+
+	// Invalid arguments
+	_, err = m.ClaimDungeonInstanceLeases(&model.ClaimDungeonInstanceLeasesArgs{
+		LeaseDuration: time.Minute,
+	})
+	require.Error(t, err, "ClaimDungeonInstanceLeases returns error without daemon ID")
+
+	_, err = m.ClaimDungeonInstanceLeases(&model.ClaimDungeonInstanceLeasesArgs{
+		DaemonID: daemonID,
+	})
+	require.Error(t, err, "ClaimDungeonInstanceLeases returns error without lease duration")
+}
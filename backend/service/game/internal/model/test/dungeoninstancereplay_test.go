@@ -0,0 +1,241 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestReplayActionRecordSets(t *testing.T) {
+
+	const (
+		entranceID = "location-entrance"
+		tunnelID   = "location-tunnel"
+		bolsterID  = "character-bolster"
+		goblinID   = "monster-goblin"
+		daggerID   = "object-dagger"
+		boneID     = "object-bone"
+	)
+
+	location := func(id, name string, characterHealth, monsterHealth int, objects ...string) *record.ActionLocationRecordSet {
+		als := &record.ActionLocationRecordSet{
+			LocationInstanceViewRec: &record.LocationInstanceView{
+				Name:   name,
+				Record: repository.Record{ID: id},
+			},
+			ActionCharacterRecs: []*record.ActionCharacter{},
+			ActionMonsterRecs:   []*record.ActionMonster{},
+			ActionObjectRecs:    []*record.ActionObject{},
+		}
+		if id != tunnelID {
+			return als
+		}
+		als.ActionCharacterRecs = append(als.ActionCharacterRecs, &record.ActionCharacter{
+			LocationInstanceID:  id,
+			CharacterInstanceID: bolsterID,
+			Name:                "Bolster",
+			Strength:            10,
+			Health:              20,
+			CurrentHealth:       characterHealth,
+		})
+		als.ActionMonsterRecs = append(als.ActionMonsterRecs, &record.ActionMonster{
+			LocationInstanceID: id,
+			MonsterInstanceID:  goblinID,
+			Name:               "Goblin",
+			Strength:           8,
+			Health:             10,
+			CurrentHealth:      monsterHealth,
+		})
+		for _, objectID := range objects {
+			als.ActionObjectRecs = append(als.ActionObjectRecs, &record.ActionObject{
+				LocationInstanceID: id,
+				ObjectInstanceID:   objectID,
+				Name:               "Bone",
+			})
+		}
+		return als
+	}
+
+	bolster := func(locationInstanceID string, health int, boneStashed bool) (*record.ActionCharacter, []*record.ActionCharacterObject) {
+		objects := []*record.ActionCharacterObject{
+			{ObjectInstanceID: daggerID, Name: "Dagger", IsEquipped: true},
+		}
+		if boneStashed {
+			objects = append(objects, &record.ActionCharacterObject{ObjectInstanceID: boneID, Name: "Bone", IsStashed: true})
+		}
+		return &record.ActionCharacter{
+			LocationInstanceID:  locationInstanceID,
+			CharacterInstanceID: bolsterID,
+			Name:                "Bolster",
+			Strength:            10,
+			Health:              20,
+			CurrentHealth:       health,
+		}, objects
+	}
+
+	action := func(serialNumber, turnNumber int, command string) *record.Action {
+		return &record.Action{
+			DungeonInstanceID:   "dungeon-instance",
+			LocationInstanceID:  tunnelID,
+			CharacterInstanceID: null.NullStringFromString(bolsterID),
+			SerialNumber:        sql.NullInt32{Int32: int32(serialNumber), Valid: true},
+			TurnNumber:          turnNumber,
+			ResolvedCommand:     command,
+		}
+	}
+
+	// Bolster moves into the tunnel
+	move := action(1, 1, record.ActionCommandMove)
+	move.ResolvedTargetLocationDirection = null.NullStringFromString("north")
+	move.ResolvedTargetLocationInstanceID = null.NullStringFromString(tunnelID)
+	moveCharacterRec, moveObjectRecs := bolster(tunnelID, 20, false)
+
+	// Bolster attacks the goblin for (10 / 2) + 5 damage
+	attack := action(2, 1, record.ActionCommandAttack)
+	attack.ResolvedTargetMonsterInstanceID = null.NullStringFromString(goblinID)
+	attackCharacterRec, attackObjectRecs := bolster(tunnelID, 20, false)
+
+	// Bolster stashes the bone
+	stash := action(3, 2, record.ActionCommandStash)
+	stash.ResolvedStashedObjectInstanceID = null.NullStringFromString(boneID)
+	stashCharacterRec, stashObjectRecs := bolster(tunnelID, 20, true)
+
+	// Bolster drops the dagger though the dagger was recorded as still equipped,
+	// and Bolster was recorded as having lost health nothing replayed explains
+	drop := action(4, 2, record.ActionCommandDrop)
+	drop.ResolvedDroppedObjectInstanceID = null.NullStringFromString(daggerID)
+	dropCharacterRec, dropObjectRecs := bolster(tunnelID, 15, true)
+
+	actionRecordSets := []*record.ActionRecordSet{
+		{
+			ActionRec:                 move,
+			ActionCharacterRec:        moveCharacterRec,
+			ActionCharacterObjectRecs: moveObjectRecs,
+			CurrentLocation:           location(tunnelID, "Tunnel", 20, 10, boneID),
+			TargetLocation:            location(tunnelID, "Tunnel", 20, 10, boneID),
+		},
+		{
+			ActionRec:                 attack,
+			ActionCharacterRec:        attackCharacterRec,
+			ActionCharacterObjectRecs: attackObjectRecs,
+			CurrentLocation:           location(tunnelID, "Tunnel", 20, 0, boneID),
+			TargetActionMonsterRec: &record.ActionMonster{
+				LocationInstanceID: tunnelID,
+				MonsterInstanceID:  goblinID,
+				Name:               "Goblin",
+				Strength:           8,
+				Health:             10,
+				CurrentHealth:      0,
+			},
+		},
+		{
+			ActionRec:                 stash,
+			ActionCharacterRec:        stashCharacterRec,
+			ActionCharacterObjectRecs: stashObjectRecs,
+			CurrentLocation:           location(tunnelID, "Tunnel", 20, 0),
+		},
+		{
+			ActionRec:                 drop,
+			ActionCharacterRec:        dropCharacterRec,
+			ActionCharacterObjectRecs: dropObjectRecs,
+			CurrentLocation:           location(tunnelID, "Tunnel", 15, 0),
+		},
+	}
+
+	replay := model.ReplayActionRecordSets("dungeon-instance", actionRecordSets)
+
+	require.Equal(t, 4, replay.Actions, "Replay counts actions")
+	require.Len(t, replay.Turns, 2, "Replay has a timeline entry for each turn")
+
+	require.Equal(t, []model.ReplayAction{
+		{SerialNumber: 1, Actor: "Bolster", Command: "move", Target: "north", Location: "Tunnel"},
+		{SerialNumber: 2, Actor: "Bolster", Command: "attack", Target: "Goblin", Location: "Tunnel"},
+	}, replay.Turns[0].Actions, "Turn one actions are described")
+
+	require.Equal(t, []model.ReplayEntity{
+		{ID: bolsterID, Name: "Bolster", Type: "character", Location: "Tunnel", Health: 20, Equipped: []string{"Dagger"}, Stashed: []string{}},
+		{ID: goblinID, Name: "Goblin", Type: "monster", Location: "Tunnel", Health: 0, Equipped: []string{}, Stashed: []string{}},
+	}, replay.Turns[0].Entities, "Turn one ends with the goblin dead")
+
+	require.Equal(t, []model.ReplayEntity{
+		{ID: bolsterID, Name: "Bolster", Type: "character", Location: "Tunnel", Health: 15, Equipped: []string{"Dagger"}, Stashed: []string{"Bone"}},
+		{ID: goblinID, Name: "Goblin", Type: "monster", Location: "Tunnel", Health: 0, Equipped: []string{}, Stashed: []string{}},
+	}, replay.Turns[1].Entities, "Turn two ends with the recorded state")
+
+	require.Equal(t, []model.ReplayDivergence{
+		{SerialNumber: 4, Turn: 2, InstanceID: bolsterID, Name: "Bolster", Field: model.ReplayFieldHealth, Replayed: "20", Recorded: "15"},
+		{SerialNumber: 4, Turn: 2, InstanceID: daggerID, Name: "Dagger", Field: model.ReplayFieldObject, Replayed: "Tunnel", Recorded: "Bolster (equipped)"},
+	}, replay.Divergences, "Replay flags divergences from the recorded state")
+
+	b := &strings.Builder{}
+	require.NoError(t, replay.WriteText(b), "WriteText returns without error")
+	require.Contains(t, b.String(), "#2      Bolster attack Goblin at Tunnel", "Text timeline contains actions")
+	require.Contains(t, b.String(), "! Dagger object replayed >Tunnel< recorded >Bolster (equipped)<", "Text timeline contains divergences")
+}
+
+func TestReplayDungeonInstance(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+	}()
+
+	m := th.Model.(*model.Model)
+
+	dungeonInstanceID := th.Data.DungeonInstanceRecs[0].ID
+
+	actionCount := 0
+	for _, rec := range th.Data.ActionRecs {
+		if rec.DungeonInstanceID == dungeonInstanceID {
+			actionCount++
+		}
+	}
+
+	replay, err := m.ReplayDungeonInstance(dungeonInstanceID)
+	require.NoError(t, err, "ReplayDungeonInstance returns without error")
+	require.Equal(t, dungeonInstanceID, replay.DungeonInstanceID, "Replay is of the dungeon instance")
+	require.Equal(t, actionCount, replay.Actions, "Replay includes every dungeon instance action")
+
+	serialNumber := 0
+	for _, turn := range replay.Turns {
+		for _, a := range turn.Actions {
+			require.Greater(t, a.SerialNumber, serialNumber, "Actions are replayed in serial number order")
+			serialNumber = a.SerialNumber
+		}
+	}
+
+	_, err = m.ReplayDungeonInstance("ae6b4f9c-6a2b-4d5f-9c1e-2d6c3f1b8a70")
+	require.Error(t, err, "ReplayDungeonInstance returns error for unknown dungeon instance")
+}
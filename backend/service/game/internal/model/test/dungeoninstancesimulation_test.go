@@ -0,0 +1,168 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+func TestUnmarshalSimulationScript(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		data        string
+		expect      *model.SimulationScript
+		expectError bool
+	}{
+		{
+			name: "YAML script",
+			data: `
+characters:
+  - name: Scripted Fighter
+    strength: 14
+    location: Cave Tunnel
+    actions:
+      - look
+      - move north
+    repeat: true
+`,
+			expect: &model.SimulationScript{
+				Characters: []model.SimulationCharacter{
+					{
+						Name:     "Scripted Fighter",
+						Strength: 14,
+						Location: "Cave Tunnel",
+						Actions:  []string{"look", "move north"},
+						Repeat:   true,
+					},
+				},
+			},
+		},
+		{
+			name: "JSON script",
+			data: `{"characters": [{"name": "Scripted Fighter", "actions": ["look"]}]}`,
+			expect: &model.SimulationScript{
+				Characters: []model.SimulationCharacter{
+					{
+						Name:    "Scripted Fighter",
+						Actions: []string{"look"},
+					},
+				},
+			},
+		},
+		{
+			name:        "Unknown field",
+			data:        `{"characters": [{"name": "Scripted Fighter", "sentences": ["look"]}]}`,
+			expectError: true,
+		},
+		{
+			name:        "Missing name",
+			data:        `{"characters": [{"actions": ["look"]}]}`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := model.UnmarshalSimulationScript([]byte(tc.data))
+			if tc.expectError {
+				require.Error(t, err, "UnmarshalSimulationScript returns error")
+				return
+			}
+			require.NoError(t, err, "UnmarshalSimulationScript returns without error")
+			require.Equal(t, tc.expect, s, "UnmarshalSimulationScript returns expected script")
+		})
+	}
+}
+
+func TestSimulateDungeonInstance(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Teardown returns without error")
+	}()
+
+	args := &model.SimulateDungeonInstanceArgs{
+		DungeonName: harness.DungeonNameCave,
+		Turns:       20,
+		Seed:        harness.DungeonInstanceSeedDefault,
+		Script: &model.SimulationScript{
+			Characters: []model.SimulationCharacter{
+				{
+					Name:    "Scripted Fighter",
+					Actions: []string{"look", "move north", "look", "move south"},
+					Repeat:  true,
+				},
+			},
+		},
+	}
+
+	// Simulations with the same seed and script are the same
+	sims := []*model.DungeonInstanceSimulation{}
+	for i := 0; i < 2; i++ {
+		_, err = th.InitTx()
+		require.NoError(t, err, "InitTx returns without error")
+
+		sim, err := th.Model.(*model.Model).SimulateDungeonInstance(args)
+		require.NoError(t, err, "SimulateDungeonInstance returns without error")
+		sims = append(sims, sim)
+
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+	}
+
+	require.Equal(t, args.Turns, sims[0].Turns, "Simulation ran every turn")
+	require.Equal(t, args.Seed, sims[0].Seed, "Simulation used the seed")
+	require.Equal(t, sims[0].Deaths, sims[1].Deaths, "Deaths are the same")
+	require.Equal(t, sims[0].Movements, sims[1].Movements, "Movements are the same")
+	require.Equal(t, sims[0].ObjectChanges, sims[1].ObjectChanges, "Object changes are the same")
+	require.Equal(t, sims[0].Errors, sims[1].Errors, "Errors are the same")
+
+	found := false
+	for _, mv := range sims[0].Movements {
+		if mv.Name == "Scripted Fighter" {
+			found = true
+			require.NotEmpty(t, mv.Locations, "Scripted character starts at a location")
+		}
+	}
+	require.True(t, found, "Scripted character movements are summarised")
+
+	b := &strings.Builder{}
+	require.NoError(t, sims[0].WriteText(b), "WriteText returns without error")
+	require.Contains(t, b.String(), "Turns           20", "Text summary contains turns")
+
+	// Invalid simulations
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+	defer func() {
+		err = th.RollbackTx()
+		require.NoError(t, err, "RollbackTx returns without error")
+	}()
+
+	_, err = th.Model.(*model.Model).SimulateDungeonInstance(&model.SimulateDungeonInstanceArgs{
+		DungeonName: "Nowhere",
+		Turns:       1,
+	})
+	require.Error(t, err, "SimulateDungeonInstance returns error for unknown dungeon")
+}
@@ -0,0 +1,267 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+func TestLintDungeonDefinition(t *testing.T) {
+
+	// A small dungeon with no issues other than its dead ends
+	newDefinition := func() *model.DungeonDefinition {
+		return &model.DungeonDefinition{
+			Name:        "Crypt",
+			Description: "A cold stone crypt.",
+			Locations: []model.LocationDefinition{
+				{
+					Name:        "Crypt Stairs",
+					Description: "Worn stone stairs.",
+					Default:     true,
+					Exits: model.LocationExitsDefinition{
+						Down: "Crypt Hall",
+					},
+				},
+				{
+					Name:        "Crypt Hall",
+					Description: "A long hall lined with tombs.",
+					Exits: model.LocationExitsDefinition{
+						Up:   "Crypt Stairs",
+						East: "Crypt Tomb",
+					},
+					Monsters: []model.LocationSpawnDefinition{
+						{
+							Name:               "Skeleton",
+							SpawnPercentChance: 100,
+						},
+					},
+				},
+				{
+					Name:        "Crypt Tomb",
+					Description: "A tomb with an open sarcophagus.",
+					Exits: model.LocationExitsDefinition{
+						West: "Crypt Hall",
+					},
+					Objects: []model.LocationSpawnDefinition{
+						{
+							Name:               "Bone Dagger",
+							SpawnPercentChance: 100,
+						},
+					},
+				},
+			},
+			Monsters: []model.MonsterDefinition{
+				{
+					Name:         "Skeleton",
+					Description:  "A rattling skeleton.",
+					Strength:     10,
+					Dexterity:    10,
+					Intelligence: 10,
+					Equipment: []model.MonsterEquipmentDefinition{
+						{
+							Name:     "Bone Dagger",
+							Equipped: true,
+						},
+					},
+				},
+			},
+			Objects: []model.ObjectDefinition{
+				{
+					Name:                "Bone Dagger",
+					Description:         "A bone dagger.",
+					DescriptionDetailed: "A dagger carved from a thigh bone.",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		definition   func(d *model.DungeonDefinition) *model.DungeonDefinition
+		expectIssues []model.DungeonIssue
+	}{
+		{
+			name: "Reports only dead ends",
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityInfo,
+					Code:     model.DungeonIssueCodeDeadEnd,
+					Location: "Crypt Stairs",
+				},
+				{
+					Severity: model.DungeonIssueSeverityInfo,
+					Code:     model.DungeonIssueCodeDeadEnd,
+					Location: "Crypt Tomb",
+				},
+			},
+		},
+		{
+			name: "Reports a missing default location",
+			definition: func(d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.Locations[0].Default = false
+				return d
+			},
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityError,
+					Code:     model.DungeonIssueCodeNoDefaultLocation,
+				},
+			},
+		},
+		{
+			name: "Reports multiple default locations",
+			definition: func(d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.Locations[2].Default = true
+				return d
+			},
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityError,
+					Code:     model.DungeonIssueCodeMultipleDefaultLocations,
+				},
+			},
+		},
+		{
+			name: "Reports unreachable locations and one-way exits",
+			definition: func(d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.Locations[1].Exits.East = ""
+				return d
+			},
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityWarning,
+					Code:     model.DungeonIssueCodeOneWayExit,
+					Location: "Crypt Tomb",
+				},
+				{
+					Severity: model.DungeonIssueSeverityError,
+					Code:     model.DungeonIssueCodeUnreachableLocation,
+					Location: "Crypt Tomb",
+				},
+			},
+		},
+		{
+			name: "Reports a location with no exits",
+			definition: func(d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.Locations[2].Exits.West = ""
+				return d
+			},
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityWarning,
+					Code:     model.DungeonIssueCodeOneWayExit,
+					Location: "Crypt Hall",
+				},
+				{
+					Severity: model.DungeonIssueSeverityWarning,
+					Code:     model.DungeonIssueCodeNoExits,
+					Location: "Crypt Tomb",
+				},
+			},
+		},
+		{
+			name: "Reports a single location dungeon with no exits",
+			definition: func(d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.Locations = d.Locations[:1]
+				d.Locations[0].Exits.Down = ""
+				return d
+			},
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityInfo,
+					Code:     model.DungeonIssueCodeNoExits,
+					Location: "Crypt Stairs",
+				},
+			},
+		},
+		{
+			name: "Reports undefined locations, monsters and objects",
+			definition: func(d *model.DungeonDefinition) *model.DungeonDefinition {
+				d.Locations[1].Exits.North = "Crypt Vault"
+				d.Locations[1].Monsters[0].Name = "Zombie"
+				d.Monsters[0].Equipment[0].Name = "Bone Sword"
+				d.Locations[2].Objects[0].Name = "Bone Sword"
+				return d
+			},
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityError,
+					Code:     model.DungeonIssueCodeUndefinedObject,
+				},
+				{
+					Severity: model.DungeonIssueSeverityError,
+					Code:     model.DungeonIssueCodeUndefinedLocation,
+					Location: "Crypt Hall",
+				},
+				{
+					Severity: model.DungeonIssueSeverityError,
+					Code:     model.DungeonIssueCodeUndefinedMonster,
+					Location: "Crypt Hall",
+				},
+				{
+					Severity: model.DungeonIssueSeverityError,
+					Code:     model.DungeonIssueCodeUndefinedObject,
+					Location: "Crypt Tomb",
+				},
+			},
+		},
+		{
+			name: "Reports spawns exceeding the recommended location entities",
+			definition: func(d *model.DungeonDefinition) *model.DungeonDefinition {
+				for len(d.Locations[2].Objects) <= model.LocationRecommendedMaxEntities {
+					d.Locations[2].Objects = append(d.Locations[2].Objects, d.Locations[2].Objects[0])
+				}
+				return d
+			},
+			expectIssues: []model.DungeonIssue{
+				{
+					Severity: model.DungeonIssueSeverityWarning,
+					Code:     model.DungeonIssueCodeSpawnsExceedCapacity,
+					Location: "Crypt Tomb",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Run test >%s<", tc.name)
+
+			d := newDefinition()
+			if tc.definition != nil {
+				d = tc.definition(d)
+			}
+
+			issues := d.Lint()
+
+			// Dead ends are expected unless a test case is about them
+			filtered := []model.DungeonIssue{}
+			for _, issue := range issues {
+				if issue.Code == model.DungeonIssueCodeDeadEnd && tc.definition != nil {
+					continue
+				}
+				filtered = append(filtered, issue)
+			}
+
+			require.Len(t, filtered, len(tc.expectIssues), "Issues length equals expected >%v<", filtered)
+			for idx, expect := range tc.expectIssues {
+				require.Equal(t, expect.Severity, filtered[idx].Severity, "Issue severity equals expected")
+				require.Equal(t, expect.Code, filtered[idx].Code, "Issue code equals expected")
+				require.Equal(t, expect.Location, filtered[idx].Location, "Issue location equals expected")
+				require.NotEmpty(t, filtered[idx].Message, "Issue message is not empty")
+			}
+
+			err := d.Validate()
+			if model.DungeonIssuesHaveErrors(issues) {
+				require.Error(t, err, "Validate returns error")
+				return
+			}
+			require.NoError(t, err, "Validate returns without error")
+		})
+	}
+}
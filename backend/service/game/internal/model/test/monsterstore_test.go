@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/calculator"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
@@ -193,6 +194,17 @@ func TestUpdateMonsterRec(t *testing.T) {
 			},
 			err: false,
 		},
+		{
+			name: "With changed attributes",
+			rec: func() *record.Monster {
+				rec := *h.Data.MonsterRecs[0]
+				rec.Strength += 2
+				rec.Dexterity += 1
+				rec.Intelligence += 3
+				return &rec
+			},
+			err: false,
+		},
 		{
 			name: "Without ID",
 			rec: func() *record.Monster {
@@ -233,6 +245,14 @@ func TestUpdateMonsterRec(t *testing.T) {
 			}
 			require.NoError(t, err, "UpdateMonsterRec returns without error")
 			require.NotEmpty(t, rec.UpdatedAt, "UpdateMonsterRec returns record with UpdatedAt")
+
+			expectRec := *rec
+			_, err = calculator.CalculateMonsterHealth(&expectRec)
+			require.NoError(t, err, "CalculateMonsterHealth returns without error")
+			_, err = calculator.CalculateMonsterFatigue(&expectRec)
+			require.NoError(t, err, "CalculateMonsterFatigue returns without error")
+			require.Equal(t, expectRec.Health, rec.Health, "UpdateMonsterRec recalculates health")
+			require.Equal(t, expectRec.Fatigue, rec.Fatigue, "UpdateMonsterRec recalculates fatigue")
 		}()
 	}
 }
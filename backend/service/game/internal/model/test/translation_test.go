@@ -0,0 +1,143 @@
+package test
+
+// NOTE: model tests are run is the public space to avoid cyclic dependencies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestSetTranslationAndTranslateDungeonRecs(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "NewTesting returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	// harness commit data
+	th.ShouldCommitData = true
+
+	tests := []struct {
+		name            string
+		rec             func(data harness.Data) *record.Translation
+		language        string
+		expectErrorCode coreerror.ErrorCode
+		expectName      func(data harness.Data) string
+	}{
+		{
+			name: "Translates a dungeon name into French",
+			rec: func(data harness.Data) *record.Translation {
+				return &record.Translation{
+					ResourceType: record.TranslationResourceTypeDungeon,
+					ResourceID:   data.DungeonRecs[0].ID,
+					Language:     locale.French,
+					Field:        record.TranslationFieldName,
+					Value:        "Le Donjon",
+				}
+			},
+			language: locale.French,
+			expectName: func(data harness.Data) string {
+				return "Le Donjon"
+			},
+		},
+		{
+			name: "Does not translate a dungeon name into another language",
+			rec: func(data harness.Data) *record.Translation {
+				return &record.Translation{
+					ResourceType: record.TranslationResourceTypeDungeon,
+					ResourceID:   data.DungeonRecs[0].ID,
+					Language:     locale.French,
+					Field:        record.TranslationFieldName,
+					Value:        "Le Donjon",
+				}
+			},
+			language: locale.Spanish,
+			expectName: func(data harness.Data) string {
+				return data.DungeonRecs[0].Name
+			},
+		},
+		{
+			name: "Fails to translate into the default language",
+			rec: func(data harness.Data) *record.Translation {
+				return &record.Translation{
+					ResourceType: record.TranslationResourceTypeDungeon,
+					ResourceID:   data.DungeonRecs[0].ID,
+					Language:     locale.Default,
+					Field:        record.TranslationFieldName,
+					Value:        "The Dungeon",
+				}
+			},
+			expectErrorCode: coreerror.InvalidData,
+		},
+		{
+			name: "Fails to translate a dungeon detailed description",
+			rec: func(data harness.Data) *record.Translation {
+				return &record.Translation{
+					ResourceType: record.TranslationResourceTypeDungeon,
+					ResourceID:   data.DungeonRecs[0].ID,
+					Language:     locale.French,
+					Field:        record.TranslationFieldDescriptionDetailed,
+					Value:        "Un donjon sombre.",
+				}
+			},
+			expectErrorCode: coreerror.InvalidData,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Logf("Run test >%s<", tc.name)
+
+			// Test harness
+			_, err = th.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = th.RollbackTx()
+				require.NoError(t, err, "RollbackTx returns without error")
+				err = th.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// init tx
+			_, err = th.InitTx()
+			require.NoError(t, err, "InitTx returns without error")
+
+			m := th.Model.(*model.Model)
+
+			err = m.SetTranslation(tc.rec(th.Data))
+			if tc.expectErrorCode != "" {
+				require.Error(t, err, "SetTranslation returns error")
+				require.True(t, coreerror.HasErrorCode(err, tc.expectErrorCode), "SetTranslation error code equals expected")
+				return
+			}
+			require.NoError(t, err, "SetTranslation returns without error")
+
+			// Setting the same field again updates the existing translation
+			err = m.SetTranslation(tc.rec(th.Data))
+			require.NoError(t, err, "SetTranslation again returns without error")
+
+			m.SetLanguage(tc.language)
+
+			rec, err := m.GetDungeonRec(th.Data.DungeonRecs[0].ID, nil)
+			require.NoError(t, err, "GetDungeonRec returns without error")
+
+			err = m.TranslateDungeonRecs([]*record.Dungeon{rec})
+			require.NoError(t, err, "TranslateDungeonRecs returns without error")
+			require.Equal(t, tc.expectName(th.Data), rec.Name, "Translated dungeon name equals expected")
+			require.Equal(t, th.Data.DungeonRecs[0].Description, rec.Description, "Untranslated dungeon description is unchanged")
+		})
+	}
+}
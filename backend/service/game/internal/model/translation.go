@@ -0,0 +1,469 @@
+package model
+
+import (
+	"sort"
+	"strings"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// SetLanguage sets the language commands are parsed in and dungeon content is
+// translated into, unsupported languages are ignored
+func (m *Model) SetLanguage(language string) {
+	if !locale.IsLanguage(language) {
+		return
+	}
+	m.language = language
+}
+
+// Language returns the language commands are parsed in and dungeon content is
+// translated into
+func (m *Model) Language() string {
+	if m.language == "" {
+		return locale.Default
+	}
+	return m.language
+}
+
+// Translations are translated field values keyed by resource ID and field
+type Translations map[string]map[string]string
+
+// Value returns the translated value of a resource field, or the value when the
+// field has not been translated
+func (t Translations) Value(resourceID, field, value string) string {
+	if translated, ok := t[resourceID][field]; ok {
+		return translated
+	}
+	return value
+}
+
+// GetTranslations returns the translations of resources into a language, there
+// are no translations into the default language
+func (m *Model) GetTranslations(language string, resourceIDs []string) (Translations, error) {
+	l := m.loggerWithFunctionContext("GetTranslations")
+
+	t := Translations{}
+
+	if language == locale.Default || len(resourceIDs) == 0 {
+		return t, nil
+	}
+
+	recs, err := m.GetTranslationRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldTranslationLanguage,
+					Val: language,
+				},
+				{
+					Col: record.FieldTranslationResourceID,
+					Val: resourceIDs,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting translation records >%v<", err)
+		return nil, err
+	}
+
+	for _, rec := range recs {
+		if t[rec.ResourceID] == nil {
+			t[rec.ResourceID] = map[string]string{}
+		}
+		t[rec.ResourceID][rec.Field] = rec.Value
+	}
+
+	return t, nil
+}
+
+// SetTranslation creates the translation of a resource field or updates the
+// existing translation
+func (m *Model) SetTranslation(rec *record.Translation) error {
+	l := m.loggerWithFunctionContext("SetTranslation")
+
+	recs, err := m.GetTranslationRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: record.FieldTranslationResourceID,
+					Val: rec.ResourceID,
+				},
+				{
+					Col: record.FieldTranslationLanguage,
+					Val: rec.Language,
+				},
+				{
+					Col: record.FieldTranslationField,
+					Val: rec.Field,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting translation records >%v<", err)
+		return err
+	}
+
+	if len(recs) == 0 {
+		return m.CreateTranslationRec(rec)
+	}
+
+	rec.ID = recs[0].ID
+	rec.CreatedAt = recs[0].CreatedAt
+
+	return m.UpdateTranslationRec(rec)
+}
+
+// deleteTranslationRecs deletes every translation of a resource
+func (m *Model) deleteTranslationRecs(resourceID string) error {
+	l := m.loggerWithFunctionContext("deleteTranslationRecs")
+
+	recs, err := m.GetTranslationRecs(referenceOptions(record.FieldTranslationResourceID, resourceID))
+	if err != nil {
+		l.Warn("failed getting translation records >%v<", err)
+		return err
+	}
+
+	for _, rec := range recs {
+		err := m.DeleteTranslationRec(rec.ID)
+		if err != nil {
+			l.Warn("failed deleting translation record >%v<", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TranslateDungeonRecs translates dungeon names and descriptions in place into
+// the model language
+func (m *Model) TranslateDungeonRecs(recs []*record.Dungeon) error {
+
+	ids := []string{}
+	for _, rec := range recs {
+		ids = append(ids, rec.ID)
+	}
+
+	t, err := m.GetTranslations(m.Language(), ids)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range recs {
+		rec.Name = t.Value(rec.ID, record.TranslationFieldName, rec.Name)
+		rec.Description = t.Value(rec.ID, record.TranslationFieldDescription, rec.Description)
+	}
+
+	return nil
+}
+
+// TranslateLocationRecs translates location names and descriptions in place
+// into the model language
+func (m *Model) TranslateLocationRecs(recs []*record.Location) error {
+
+	ids := []string{}
+	for _, rec := range recs {
+		ids = append(ids, rec.ID)
+	}
+
+	t, err := m.GetTranslations(m.Language(), ids)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range recs {
+		rec.Name = t.Value(rec.ID, record.TranslationFieldName, rec.Name)
+		rec.Description = t.Value(rec.ID, record.TranslationFieldDescription, rec.Description)
+	}
+
+	return nil
+}
+
+// TranslateMonsterRecs translates monster names and descriptions in place into
+// the model language
+func (m *Model) TranslateMonsterRecs(recs []*record.Monster) error {
+
+	ids := []string{}
+	for _, rec := range recs {
+		ids = append(ids, rec.ID)
+	}
+
+	t, err := m.GetTranslations(m.Language(), ids)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range recs {
+		rec.Name = t.Value(rec.ID, record.TranslationFieldName, rec.Name)
+		rec.Description = t.Value(rec.ID, record.TranslationFieldDescription, rec.Description)
+	}
+
+	return nil
+}
+
+// TranslateObjectRecs translates object names and descriptions in place into
+// the model language
+func (m *Model) TranslateObjectRecs(recs []*record.Object) error {
+
+	ids := []string{}
+	for _, rec := range recs {
+		ids = append(ids, rec.ID)
+	}
+
+	t, err := m.GetTranslations(m.Language(), ids)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range recs {
+		rec.Name = t.Value(rec.ID, record.TranslationFieldName, rec.Name)
+		rec.Description = t.Value(rec.ID, record.TranslationFieldDescription, rec.Description)
+		rec.DescriptionDetailed = t.Value(rec.ID, record.TranslationFieldDescriptionDetailed, rec.DescriptionDetailed)
+	}
+
+	return nil
+}
+
+// TranslateActionRecordSet translates the location, monster and object names and
+// descriptions of an action record set in place into the model language.
+// Character names are chosen by players and are not translated.
+func (m *Model) TranslateActionRecordSet(rs *record.ActionRecordSet) error {
+	l := m.loggerWithFunctionContext("TranslateActionRecordSet")
+
+	language := m.Language()
+	if language == locale.Default {
+		return nil
+	}
+
+	locationSets := []*record.ActionLocationRecordSet{}
+	for _, set := range []*record.ActionLocationRecordSet{rs.CurrentLocation, rs.TargetLocation} {
+		if set != nil && set.LocationInstanceViewRec != nil {
+			locationSets = append(locationSets, set)
+		}
+	}
+
+	monsterRecs := []*record.ActionMonster{}
+	for _, rec := range []*record.ActionMonster{rs.ActionMonsterRec, rs.TargetActionMonsterRec} {
+		if rec != nil {
+			monsterRecs = append(monsterRecs, rec)
+		}
+	}
+
+	objectRecs := []*record.ActionObject{}
+	for _, rec := range []*record.ActionObject{
+		rs.EquippedActionObjectRec,
+		rs.StashedActionObjectRec,
+		rs.DroppedActionObjectRec,
+		rs.TargetActionObjectRec,
+	} {
+		if rec != nil {
+			objectRecs = append(objectRecs, rec)
+		}
+	}
+
+	for _, set := range locationSets {
+		monsterRecs = append(monsterRecs, set.ActionMonsterRecs...)
+		objectRecs = append(objectRecs, set.ActionObjectRecs...)
+	}
+
+	// Objects carried by characters and monsters only have names
+	carriedObjectRecs := map[string][]*string{}
+	for _, recs := range [][]*record.ActionCharacterObject{rs.ActionCharacterObjectRecs, rs.TargetActionCharacterObjectRecs} {
+		for _, rec := range recs {
+			carriedObjectRecs[rec.ObjectInstanceID] = append(carriedObjectRecs[rec.ObjectInstanceID], &rec.Name)
+		}
+	}
+	for _, recs := range [][]*record.ActionMonsterObject{rs.ActionMonsterObjectRecs, rs.TargetActionMonsterObjectRecs} {
+		for _, rec := range recs {
+			carriedObjectRecs[rec.ObjectInstanceID] = append(carriedObjectRecs[rec.ObjectInstanceID], &rec.Name)
+		}
+	}
+
+	monsterInstanceIDs := []string{}
+	for _, rec := range monsterRecs {
+		monsterInstanceIDs = append(monsterInstanceIDs, rec.MonsterInstanceID)
+	}
+
+	objectInstanceIDs := []string{}
+	for _, rec := range objectRecs {
+		objectInstanceIDs = append(objectInstanceIDs, rec.ObjectInstanceID)
+	}
+	for id := range carriedObjectRecs {
+		objectInstanceIDs = append(objectInstanceIDs, id)
+	}
+
+	resourceIDs := []string{}
+	for _, set := range locationSets {
+		resourceIDs = append(resourceIDs, set.LocationInstanceViewRec.LocationID)
+	}
+
+	monsterIDs := map[string]string{}
+	if len(monsterInstanceIDs) > 0 {
+		recs, err := m.GetMonsterInstanceRecs(
+			&coresql.Options{
+				Params: []coresql.Param{
+					{
+						Col: "id",
+						Val: monsterInstanceIDs,
+					},
+				},
+			},
+		)
+		if err != nil {
+			l.Warn("failed getting monster instance records >%v<", err)
+			return err
+		}
+		for _, rec := range recs {
+			monsterIDs[rec.ID] = rec.MonsterID
+			resourceIDs = append(resourceIDs, rec.MonsterID)
+		}
+	}
+
+	objectViewRecs := map[string]*record.ObjectInstanceView{}
+	if len(objectInstanceIDs) > 0 {
+		recs, err := m.GetObjectInstanceViewRecs(
+			&coresql.Options{
+				Params: []coresql.Param{
+					{
+						Col: "id",
+						Val: objectInstanceIDs,
+					},
+				},
+			},
+		)
+		if err != nil {
+			l.Warn("failed getting object instance view records >%v<", err)
+			return err
+		}
+		for _, rec := range recs {
+			objectViewRecs[rec.ID] = rec
+			resourceIDs = append(resourceIDs, rec.ObjectID)
+		}
+	}
+
+	t, err := m.GetTranslations(language, resourceIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, set := range locationSets {
+		rec := set.LocationInstanceViewRec
+		rec.Name = t.Value(rec.LocationID, record.TranslationFieldName, rec.Name)
+		rec.Description = t.Value(rec.LocationID, record.TranslationFieldDescription, rec.Description)
+	}
+
+	for _, rec := range monsterRecs {
+		monsterID, ok := monsterIDs[rec.MonsterInstanceID]
+		if !ok {
+			continue
+		}
+		rec.Name = t.Value(monsterID, record.TranslationFieldName, rec.Name)
+	}
+
+	for _, rec := range objectRecs {
+		viewRec, ok := objectViewRecs[rec.ObjectInstanceID]
+		if !ok {
+			continue
+		}
+		rec.Name = t.Value(viewRec.ObjectID, record.TranslationFieldName, rec.Name)
+		// The description is either the description or the detailed description
+		// depending on how closely the object was looked at
+		switch rec.Description {
+		case viewRec.DescriptionDetailed:
+			rec.Description = t.Value(viewRec.ObjectID, record.TranslationFieldDescriptionDetailed, rec.Description)
+		case viewRec.Description:
+			rec.Description = t.Value(viewRec.ObjectID, record.TranslationFieldDescription, rec.Description)
+		}
+	}
+
+	for objectInstanceID, names := range carriedObjectRecs {
+		viewRec, ok := objectViewRecs[objectInstanceID]
+		if !ok {
+			continue
+		}
+		for _, name := range names {
+			*name = t.Value(viewRec.ObjectID, record.TranslationFieldName, *name)
+		}
+	}
+
+	return nil
+}
+
+// localiseSentence returns a command sentence in the model language with the
+// names of monsters and objects in the dungeon instance, verbs and direction
+// names replaced by the English words the command parser understands
+func (m *Model) localiseSentence(sentence string, set *record.LocationInstanceViewRecordSet) (string, error) {
+	l := m.loggerWithFunctionContext("localiseSentence")
+
+	language := m.Language()
+	if language == locale.Default {
+		return sentence, nil
+	}
+
+	objectRecs, err := m.GetObjectInstanceViewRecs(
+		referenceOptions("dungeon_instance_id", set.LocationInstanceViewRec.DungeonInstanceID),
+	)
+	if err != nil {
+		l.Warn("failed getting object instance view records >%v<", err)
+		return "", err
+	}
+
+	// English names keyed by resource ID
+	names := map[string]string{}
+	for _, rec := range set.MonsterInstanceViewRecs {
+		names[rec.MonsterID] = rec.Name
+	}
+	for _, rec := range objectRecs {
+		names[rec.ObjectID] = rec.Name
+	}
+
+	resourceIDs := []string{}
+	for id := range names {
+		resourceIDs = append(resourceIDs, id)
+	}
+
+	t, err := m.GetTranslations(language, resourceIDs)
+	if err != nil {
+		return "", err
+	}
+
+	type replacement struct {
+		localised string
+		english   string
+	}
+
+	replacements := []replacement{}
+	for id, name := range names {
+		localised := t.Value(id, record.TranslationFieldName, name)
+		if localised == name {
+			continue
+		}
+		replacements = append(replacements, replacement{
+			localised: strings.ToLower(localised),
+			english:   strings.ToLower(name),
+		})
+	}
+
+	// Longer names are replaced first so names containing other names are
+	// not partially replaced
+	sort.Slice(replacements, func(i, j int) bool {
+		if len(replacements[i].localised) != len(replacements[j].localised) {
+			return len(replacements[i].localised) > len(replacements[j].localised)
+		}
+		return replacements[i].localised < replacements[j].localised
+	})
+
+	localised := strings.ToLower(sentence)
+	for _, r := range replacements {
+		localised = strings.ReplaceAll(localised, r.localised, r.english)
+	}
+
+	localised = locale.Command(language, localised)
+
+	l.Debug("Localised sentence >%s< as >%s<", sentence, localised)
+
+	return localised, nil
+}
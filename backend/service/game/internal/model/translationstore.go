@@ -0,0 +1,120 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// GetTranslationRecs -
+func (m *Model) GetTranslationRecs(opts *coresql.Options) ([]*record.Translation, error) {
+
+	l := m.loggerWithFunctionContext("GetTranslationRecs")
+
+	l.Debug("Getting translation records opts >%#v<", opts)
+
+	r := m.TranslationRepository()
+
+	return r.GetMany(opts)
+}
+
+// GetTranslationRec -
+func (m *Model) GetTranslationRec(recID string, lock *coresql.Lock) (*record.Translation, error) {
+
+	l := m.loggerWithFunctionContext("GetTranslationRec")
+
+	l.Debug("Getting translation rec ID >%s<", recID)
+
+	r := m.TranslationRepository()
+
+	if !m.IsUUID(recID) {
+		return nil, fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	rec, err := r.GetOne(recID, lock)
+	if err == sql.ErrNoRows {
+		l.Warn("No record found ID >%s<", recID)
+		return nil, nil
+	}
+
+	return rec, err
+}
+
+// CreateTranslationRec -
+func (m *Model) CreateTranslationRec(rec *record.Translation) error {
+	l := m.loggerWithFunctionContext("CreateTranslationRec")
+
+	l.Debug("Creating translation record >%#v<", rec)
+
+	r := m.TranslationRepository()
+
+	err := m.validateTranslationRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.CreateOne(rec)
+}
+
+// UpdateTranslationRec -
+func (m *Model) UpdateTranslationRec(rec *record.Translation) error {
+	l := m.loggerWithFunctionContext("UpdateTranslationRec")
+
+	l.Debug("Updating translation record >%#v<", rec)
+
+	r := m.TranslationRepository()
+
+	err := m.validateTranslationRec(rec)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.UpdateOne(rec)
+}
+
+// DeleteTranslationRec -
+func (m *Model) DeleteTranslationRec(recID string) error {
+	l := m.loggerWithFunctionContext("DeleteTranslationRec")
+
+	l.Debug("Deleting translation rec ID >%s<", recID)
+
+	r := m.TranslationRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteTranslationRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.DeleteOne(recID)
+}
+
+// RemoveTranslationRec -
+func (m *Model) RemoveTranslationRec(recID string) error {
+
+	l := m.loggerWithFunctionContext("RemoveTranslationRec")
+
+	l.Debug("Removing translation rec ID >%s<", recID)
+
+	r := m.TranslationRepository()
+
+	if !m.IsUUID(recID) {
+		return fmt.Errorf("ID >%s< is not a valid UUID", recID)
+	}
+
+	err := m.validateDeleteTranslationRec(recID)
+	if err != nil {
+		l.Debug("Failed model validation >%v<", err)
+		return err
+	}
+
+	return r.RemoveOne(recID)
+}
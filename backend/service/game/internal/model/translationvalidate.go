@@ -0,0 +1,96 @@
+package model
+
+import (
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// translationFields are the fields of each resource type that may be translated
+var translationFields = map[string][]string{
+	record.TranslationResourceTypeDungeon: {
+		record.TranslationFieldName,
+		record.TranslationFieldDescription,
+	},
+	record.TranslationResourceTypeLocation: {
+		record.TranslationFieldName,
+		record.TranslationFieldDescription,
+	},
+	record.TranslationResourceTypeMonster: {
+		record.TranslationFieldName,
+		record.TranslationFieldDescription,
+	},
+	record.TranslationResourceTypeObject: {
+		record.TranslationFieldName,
+		record.TranslationFieldDescription,
+		record.TranslationFieldDescriptionDetailed,
+	},
+}
+
+// validateTranslationRec - validates creating and updating a translation record
+func (m *Model) validateTranslationRec(rec *record.Translation) error {
+
+	fields, ok := translationFields[rec.ResourceType]
+	if !ok {
+		return coreerror.NewInvalidDataError("failed validation, ResourceType >%s< is not a translatable resource type", rec.ResourceType)
+	}
+
+	validField := false
+	for _, field := range fields {
+		if rec.Field == field {
+			validField = true
+			break
+		}
+	}
+	if !validField {
+		return coreerror.NewInvalidDataError("failed validation, Field >%s< is not a translatable %s field", rec.Field, rec.ResourceType)
+	}
+
+	if !m.IsUUID(rec.ResourceID) {
+		return coreerror.NewInvalidDataError("failed validation, ResourceID >%s< is not a valid UUID", rec.ResourceID)
+	}
+
+	// Dungeon content is authored in the default language
+	if rec.Language == locale.Default || !locale.IsLanguage(rec.Language) {
+		return coreerror.NewInvalidDataError("failed validation, Language >%s< is not a supported translation language", rec.Language)
+	}
+
+	if rec.Value == "" {
+		return coreerror.NewInvalidDataError("failed validation, Value is empty")
+	}
+
+	var exists bool
+	var err error
+	switch rec.ResourceType {
+	case record.TranslationResourceTypeDungeon:
+		var r *record.Dungeon
+		r, err = m.GetDungeonRec(rec.ResourceID, nil)
+		exists = r != nil
+	case record.TranslationResourceTypeLocation:
+		var r *record.Location
+		r, err = m.GetLocationRec(rec.ResourceID, nil)
+		exists = r != nil
+	case record.TranslationResourceTypeMonster:
+		var r *record.Monster
+		r, err = m.GetMonsterRec(rec.ResourceID, nil)
+		exists = r != nil
+	case record.TranslationResourceTypeObject:
+		var r *record.Object
+		r, err = m.GetObjectRec(rec.ResourceID, nil)
+		exists = r != nil
+	}
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return coreerror.NewInvalidDataError("failed validation, %s ID >%s< does not exist", rec.ResourceType, rec.ResourceID)
+	}
+
+	return nil
+}
+
+// validateDeleteTranslationRec - validates it is okay to delete a translation record
+func (m *Model) validateDeleteTranslationRec(recID string) error {
+
+	return nil
+}
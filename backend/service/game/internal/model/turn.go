@@ -20,6 +20,11 @@ type IncrementDungeonInstanceTurnResult struct {
 	Incremented      bool
 }
 
+// TurnDuration returns the configured minimum duration between dungeon instance turns
+func (m *Model) TurnDuration() time.Duration {
+	return m.turnDuration
+}
+
 // IncrementDungeonInstanceTurnRec -
 func (m *Model) IncrementDungeonInstanceTurn(args *IncrementDungeonInstanceTurnArgs) (*IncrementDungeonInstanceTurnResult, error) {
 	l := m.loggerWithFunctionContext("IncrementDungeonInstanceTurn")
@@ -68,7 +73,7 @@ func (m *Model) IncrementDungeonInstanceTurn(args *IncrementDungeonInstanceTurnA
 		turnDuration = *args.TurnDuration
 	}
 
-	sinceLastIncremented := time.Since(null.NullTimeToTime(rec.IncrementedAt))
+	sinceLastIncremented := m.now().Sub(null.NullTimeToTime(rec.IncrementedAt))
 	l.Debug("Last incremented duration >%d<", sinceLastIncremented.Milliseconds())
 	l.Debug("Turn duration             >%d<", turnDuration.Milliseconds())
 
@@ -84,7 +89,7 @@ func (m *Model) IncrementDungeonInstanceTurn(args *IncrementDungeonInstanceTurnA
 	l.Debug("Can increment, since last incremented %d > duration %d", sinceLastIncremented.Milliseconds(), turnDuration.Milliseconds())
 
 	rec.TurnNumber++
-	rec.IncrementedAt = null.NullTimeFromTime(time.Now().UTC())
+	rec.IncrementedAt = null.NullTimeFromTime(m.now().UTC())
 
 	err = m.UpdateTurnRec(rec)
 	if err != nil {
@@ -97,3 +102,30 @@ func (m *Model) IncrementDungeonInstanceTurn(args *IncrementDungeonInstanceTurnA
 		Incremented: true,
 	}, nil
 }
+
+// GetDungeonInstanceTurnNumber returns the current turn number of a dungeon instance,
+// a dungeon instance that has yet to complete a turn is at turn zero
+func (m *Model) GetDungeonInstanceTurnNumber(dungeonInstanceID string) (int, error) {
+	l := m.loggerWithFunctionContext("GetDungeonInstanceTurnNumber")
+
+	recs, err := m.GetTurnRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "dungeon_instance_id",
+					Val: dungeonInstanceID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting turn records >%v<", err)
+		return 0, err
+	}
+
+	if len(recs) == 0 {
+		return 0, nil
+	}
+
+	return recs[0].TurnNumber, nil
+}
@@ -3,7 +3,6 @@ package model
 import (
 	"database/sql"
 	"fmt"
-	"time"
 
 	"gitlab.com/alienspaces/go-mud/backend/core/null"
 	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
@@ -50,7 +49,7 @@ func (m *Model) CreateTurnRec(rec *record.Turn) error {
 
 	// Initial defaults
 	rec.TurnNumber = 1
-	rec.IncrementedAt = null.NullTimeFromTime(time.Now().UTC())
+	rec.IncrementedAt = null.NullTimeFromTime(m.now().UTC())
 
 	l.Debug("Creating turn record >%#v<", rec)
 
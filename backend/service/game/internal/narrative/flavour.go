@@ -0,0 +1,51 @@
+package narrative
+
+import (
+	_ "embed"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Flavour is text that overrides the default templates for particular monsters
+// and objects. Template keys, for example "attack.hit" or "attack.hit.target",
+// map to the template used instead of the default template. Object templates
+// are used when an action is performed with or on the object.
+type Flavour struct {
+	Monsters map[string]MonsterFlavour    `yaml:"monsters"`
+	Objects  map[string]map[string]string `yaml:"objects"`
+}
+
+// MonsterFlavour templates are split by whether the monster performs the
+// action or the action is performed on the monster
+type MonsterFlavour struct {
+	Acting   map[string]string `yaml:"acting"`
+	Targeted map[string]string `yaml:"targeted"`
+}
+
+//go:embed flavour.yaml
+var defaultFlavour []byte
+
+// DefaultFlavour returns the flavour text for the seed dungeon monsters and
+// objects
+func DefaultFlavour() (Flavour, error) {
+	return unmarshalFlavour(defaultFlavour)
+}
+
+// LoadFlavour reads flavour text from a YAML file
+func LoadFlavour(path string) (Flavour, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Flavour{}, err
+	}
+	return unmarshalFlavour(data)
+}
+
+func unmarshalFlavour(data []byte) (Flavour, error) {
+	f := Flavour{}
+	err := yaml.Unmarshal(data, &f)
+	if err != nil {
+		return Flavour{}, err
+	}
+	return f, nil
+}
@@ -0,0 +1,364 @@
+package narrative
+
+// The narrator describes what happened in an action from the point of view of
+// the character reading it. Templates are chosen by command, by the outcome of
+// the action and by the perspective of the reader, so the character attacking
+// reads "You attack Grumpy Dwarf." while the dwarf's victims read "Barricade
+// attacks Grumpy Dwarf." Monsters and objects may override any template with
+// their own flavour text. Narratives may be read in any supported language,
+// flavour text is written in English and is only used for English narratives.
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	// PerspectiveActor is the character performing the action
+	PerspectiveActor string = "actor"
+	// PerspectiveTarget is the character the action is performed on
+	PerspectiveTarget string = "target"
+	// PerspectiveObserver is anyone else who can see the action
+	PerspectiveObserver string = "observer"
+	// PerspectiveArrival is an observer at the location a character or
+	// monster arrived at
+	PerspectiveArrival string = "arrival"
+)
+
+const (
+	OutcomeSuccess   string = "success"
+	OutcomeHit       string = "hit"
+	OutcomeKill      string = "kill"
+	OutcomeLocation  string = "location"
+	OutcomeDirection string = "direction"
+	OutcomeObject    string = "object"
+	OutcomeCharacter string = "character"
+	OutcomeMonster   string = "monster"
+)
+
+// Data is provided to templates when rendering a narrative. Actor and Target
+// are "You" and "you", in the language of the narrative, when the reader is
+// the actor or the target.
+type Data struct {
+	Perspective    string
+	Actor          string
+	Target         string
+	Object         string
+	Weapon         string
+	Direction      string
+	Location       string
+	TargetLocation string
+
+	// direction is the English direction Direction is the name of
+	direction string
+	// words are the words of the language of the narrative, nil for English
+	words *words
+}
+
+// Verb returns the first form when the reader is the actor and the second
+// form otherwise, "{{.Actor}} {{.Verb "attack" "attacks"}}"
+func (d Data) Verb(you, other string) string {
+	if d.Perspective == PerspectiveActor {
+		return you
+	}
+	return other
+}
+
+// Heading returns the direction as the way something is heading
+func (d Data) Heading() string {
+	if d.words != nil {
+		if heading, ok := d.words.headings[d.direction]; ok {
+			return heading
+		}
+		return d.Direction
+	}
+	switch d.direction {
+	case "up":
+		return "upwards"
+	case "down":
+		return "downwards"
+	}
+	return d.direction
+}
+
+// From returns where something arrives from when it moved in the direction
+func (d Data) From() string {
+	if d.words != nil {
+		return d.words.froms[d.direction]
+	}
+	switch d.direction {
+	case "up":
+		return "below"
+	case "down":
+		return "above"
+	case "":
+		return "nowhere"
+	}
+	return "the " + record.LocationOppositeDirections[d.direction]
+}
+
+var funcs = template.FuncMap{
+	// the prefixes object names with the definite article
+	"the": func(name string) string {
+		if name == "" {
+			return ""
+		}
+		return "the " + name
+	},
+}
+
+// Narrator renders action narratives
+type Narrator struct {
+	templates map[string]*template.Template
+	// languages are the templates of languages other than English
+	languages        map[string]map[string]*template.Template
+	monstersActing   map[string]map[string]*template.Template
+	monstersTargeted map[string]map[string]*template.Template
+	objects          map[string]map[string]*template.Template
+}
+
+// NewNarrator returns a narrator using the default templates and the flavour
+// text overrides
+func NewNarrator(flavour Flavour) (*Narrator, error) {
+
+	templates, err := parseTemplates("default", defaultTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Narrator{
+		templates:        templates,
+		languages:        map[string]map[string]*template.Template{},
+		monstersActing:   map[string]map[string]*template.Template{},
+		monstersTargeted: map[string]map[string]*template.Template{},
+		objects:          map[string]map[string]*template.Template{},
+	}
+
+	for language, texts := range languageTemplates {
+		n.languages[language], err = parseTemplates(language, texts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for name, mf := range flavour.Monsters {
+		n.monstersActing[strings.ToLower(name)], err = parseTemplates("monster "+name+" acting", mf.Acting)
+		if err != nil {
+			return nil, err
+		}
+		n.monstersTargeted[strings.ToLower(name)], err = parseTemplates("monster "+name+" targeted", mf.Targeted)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for name, texts := range flavour.Objects {
+		n.objects[strings.ToLower(name)], err = parseTemplates("object "+name, texts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+func parseTemplates(source string, texts map[string]string) (map[string]*template.Template, error) {
+	templates := map[string]*template.Template{}
+	for key, text := range texts {
+		t, err := template.New(key).Funcs(funcs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %s template >%s< >%v<", source, key, err)
+		}
+		templates[key] = t
+	}
+	return templates, nil
+}
+
+// Narrate returns the narrative of an action as read by the character instance
+// in the language, an empty character instance ID reads the action as an
+// observer
+func (n *Narrator) Narrate(set record.ActionRecordSet, characterInstanceID string, language string) (string, error) {
+
+	if set.ActionRec == nil {
+		return "", fmt.Errorf("action record set is missing the action record, cannot narrate")
+	}
+
+	command := set.ActionRec.ResolvedCommand
+	outcome := Outcome(set)
+	perspective := Perspective(set, characterInstanceID)
+
+	data := newData(set, perspective, language)
+
+	keys := []string{
+		command + "." + outcome + "." + perspective,
+	}
+	if perspective == PerspectiveArrival {
+		keys = append(keys, command+"."+outcome+"."+PerspectiveObserver)
+	}
+	keys = append(keys, command+"."+outcome)
+
+	// Flavour text takes precedence over the default templates
+	sources := []map[string]*template.Template{}
+	if templates, ok := n.languages[language]; ok {
+		sources = append(sources, templates)
+	} else {
+		if data.Weapon != "" {
+			sources = append(sources, n.objects[strings.ToLower(data.Weapon)])
+		}
+		if set.ActionMonsterRec != nil {
+			sources = append(sources, n.monstersActing[strings.ToLower(set.ActionMonsterRec.Name)])
+		}
+		if set.TargetActionMonsterRec != nil {
+			sources = append(sources, n.monstersTargeted[strings.ToLower(set.TargetActionMonsterRec.Name)])
+		}
+		if data.Object != "" {
+			sources = append(sources, n.objects[strings.ToLower(data.Object)])
+		}
+	}
+	sources = append(sources, n.templates)
+
+	for _, source := range sources {
+		for _, key := range keys {
+			t, ok := source[key]
+			if !ok {
+				continue
+			}
+			var b bytes.Buffer
+			err := t.Execute(&b, data)
+			if err != nil {
+				return "", fmt.Errorf("failed rendering template >%s< >%v<", key, err)
+			}
+			return b.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("no narrative template for command >%s< outcome >%s< perspective >%s<", command, outcome, perspective)
+}
+
+// Outcome returns what resulted from an action
+func Outcome(set record.ActionRecordSet) string {
+
+	switch set.ActionRec.ResolvedCommand {
+	case record.ActionCommandAttack:
+		if set.TargetActionCharacterRec != nil && set.TargetActionCharacterRec.CurrentHealth <= 0 {
+			return OutcomeKill
+		}
+		if set.TargetActionMonsterRec != nil && set.TargetActionMonsterRec.CurrentHealth <= 0 {
+			return OutcomeKill
+		}
+		return OutcomeHit
+	case record.ActionCommandLook, record.ActionCommandUse, record.ActionCommandSpawn:
+		switch {
+		case set.TargetActionObjectRec != nil:
+			return OutcomeObject
+		case set.TargetActionCharacterRec != nil:
+			return OutcomeCharacter
+		case set.TargetActionMonsterRec != nil:
+			return OutcomeMonster
+		case set.ActionRec.ResolvedTargetLocationDirection.String != "":
+			return OutcomeDirection
+		}
+		return OutcomeLocation
+	}
+
+	return OutcomeSuccess
+}
+
+// Perspective returns how the character instance relates to an action
+func Perspective(set record.ActionRecordSet, characterInstanceID string) string {
+
+	if characterInstanceID == "" {
+		return PerspectiveObserver
+	}
+
+	if set.ActionCharacterRec != nil && set.ActionCharacterRec.CharacterInstanceID == characterInstanceID {
+		return PerspectiveActor
+	}
+
+	if set.TargetActionCharacterRec != nil && set.TargetActionCharacterRec.CharacterInstanceID == characterInstanceID {
+		return PerspectiveTarget
+	}
+
+	command := set.ActionRec.ResolvedCommand
+	if (command == record.ActionCommandMove || command == record.ActionCommandTeleport) &&
+		set.TargetLocation != nil && set.TargetLocation.LocationInstanceViewRec != nil {
+		for _, rec := range set.TargetLocation.ActionCharacterRecs {
+			if rec.CharacterInstanceID == characterInstanceID {
+				return PerspectiveArrival
+			}
+		}
+	}
+
+	return PerspectiveObserver
+}
+
+func newData(set record.ActionRecordSet, perspective string, language string) Data {
+
+	direction := set.ActionRec.ResolvedTargetLocationDirection.String
+
+	w := englishWords
+	if lw, ok := languageWords[language]; ok {
+		w = lw
+	}
+
+	data := Data{
+		Perspective: perspective,
+		Actor:       w.someone,
+		Direction:   locale.Direction(language, direction),
+		direction:   direction,
+	}
+	if w != englishWords {
+		data.words = w
+	}
+
+	switch {
+	case perspective == PerspectiveActor:
+		data.Actor = w.you
+	case set.ActionCharacterRec != nil:
+		data.Actor = set.ActionCharacterRec.Name
+	case set.ActionMonsterRec != nil:
+		data.Actor = set.ActionMonsterRec.Name
+	case set.ActionRec.SystemActor.Valid:
+		data.Actor = set.ActionRec.SystemActor.String
+	}
+
+	switch {
+	case perspective == PerspectiveTarget:
+		data.Target = w.youTarget
+	case set.TargetActionCharacterRec != nil:
+		data.Target = set.TargetActionCharacterRec.Name
+	case set.TargetActionMonsterRec != nil:
+		data.Target = set.TargetActionMonsterRec.Name
+	}
+
+	switch {
+	case set.TargetActionObjectRec != nil:
+		data.Object = set.TargetActionObjectRec.Name
+	case set.EquippedActionObjectRec != nil:
+		data.Object = set.EquippedActionObjectRec.Name
+	case set.StashedActionObjectRec != nil:
+		data.Object = set.StashedActionObjectRec.Name
+	case set.DroppedActionObjectRec != nil:
+		data.Object = set.DroppedActionObjectRec.Name
+	}
+
+	// Attacks are made with the equipped object when there is one
+	if set.ActionRec.ResolvedCommand == record.ActionCommandAttack && set.EquippedActionObjectRec != nil {
+		data.Weapon = set.EquippedActionObjectRec.Name
+		data.Object = ""
+	}
+
+	if set.CurrentLocation != nil && set.CurrentLocation.LocationInstanceViewRec != nil {
+		data.Location = set.CurrentLocation.LocationInstanceViewRec.Name
+	}
+	if set.TargetLocation != nil && set.TargetLocation.LocationInstanceViewRec != nil {
+		data.TargetLocation = set.TargetLocation.LocationInstanceViewRec.Name
+	}
+
+	return data
+}
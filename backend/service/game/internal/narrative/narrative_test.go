@@ -0,0 +1,636 @@
+package narrative
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// Golden files are updated with:
+//
+//	go test ./internal/narrative/... -update
+var update = flag.Bool("update", false, "update golden files")
+
+const (
+	actorID    string = "actor-character-instance-id"
+	targetID   string = "target-character-instance-id"
+	observerID string = "observer-character-instance-id"
+	arrivalID  string = "arrival-character-instance-id"
+)
+
+var readers = map[string]string{
+	PerspectiveActor:    actorID,
+	PerspectiveTarget:   targetID,
+	PerspectiveObserver: observerID,
+	PerspectiveArrival:  arrivalID,
+}
+
+type narrativeTestCase struct {
+	name    string
+	set     record.ActionRecordSet
+	readers []string
+}
+
+func actionRec(command string) *record.Action {
+	return &record.Action{
+		ResolvedCommand: command,
+	}
+}
+
+func character(id, name string, health int) *record.ActionCharacter {
+	return &record.ActionCharacter{
+		CharacterInstanceID: id,
+		Name:                name,
+		Health:              40,
+		CurrentHealth:       health,
+	}
+}
+
+func monster(name string, health int) *record.ActionMonster {
+	return &record.ActionMonster{
+		MonsterInstanceID: strings.ToLower(strings.ReplaceAll(name, " ", "-")),
+		Name:              name,
+		Health:            30,
+		CurrentHealth:     health,
+	}
+}
+
+func object(name string) *record.ActionObject {
+	return &record.ActionObject{
+		Name: name,
+	}
+}
+
+func location(name string, characterRecs ...*record.ActionCharacter) *record.ActionLocationRecordSet {
+	rec := &record.LocationInstanceView{
+		Name: name,
+	}
+	rec.ID = strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	return &record.ActionLocationRecordSet{
+		LocationInstanceViewRec: rec,
+		ActionCharacterRecs:     characterRecs,
+	}
+}
+
+func direction(rec *record.Action, dir string) *record.Action {
+	rec.ResolvedTargetLocationDirection = null.NullStringFromString(dir)
+	return rec
+}
+
+func systemAction(command string) *record.Action {
+	rec := actionRec(command)
+	rec.SystemActor = sql.NullString{String: "Game Master Gandalf", Valid: true}
+	return rec
+}
+
+// narrativeTestCases are keyed by command. Move actions are recorded at the
+// location moved to so observers left behind are not at the target location.
+func narrativeTestCases() map[string][]narrativeTestCase {
+
+	actor := func() *record.ActionCharacter { return character(actorID, "Barricade", 40) }
+	target := func(health int) *record.ActionCharacter { return character(targetID, "Legislate", health) }
+	observer := func() *record.ActionCharacter { return character(observerID, "Bolster", 40) }
+	arrival := func() *record.ActionCharacter { return character(arrivalID, "Vigilant", 40) }
+
+	everyone := []string{PerspectiveActor, PerspectiveObserver}
+	targeted := []string{PerspectiveActor, PerspectiveTarget, PerspectiveObserver}
+	watched := []string{PerspectiveObserver}
+
+	return map[string][]narrativeTestCase{
+		record.ActionCommandMove: {
+			{
+				name: "Character moves north",
+				set: record.ActionRecordSet{
+					ActionRec:          direction(actionRec(record.ActionCommandMove), "north"),
+					ActionCharacterRec: actor(),
+					CurrentLocation:    location("Cave Tunnel", actor(), arrival()),
+					TargetLocation:     location("Cave Tunnel", actor(), arrival()),
+				},
+				readers: []string{PerspectiveActor, PerspectiveObserver, PerspectiveArrival},
+			},
+			{
+				name: "Character moves down",
+				set: record.ActionRecordSet{
+					ActionRec:          direction(actionRec(record.ActionCommandMove), "down"),
+					ActionCharacterRec: actor(),
+					CurrentLocation:    location("Dark Room", actor(), arrival()),
+					TargetLocation:     location("Dark Room", actor(), arrival()),
+				},
+				readers: []string{PerspectiveActor, PerspectiveObserver, PerspectiveArrival},
+			},
+			{
+				name: "Monster moves southwest",
+				set: record.ActionRecordSet{
+					ActionRec:        direction(actionRec(record.ActionCommandMove), "southwest"),
+					ActionMonsterRec: monster("Angry Goblin", 30),
+					CurrentLocation:  location("Narrow Tunnel", arrival()),
+					TargetLocation:   location("Narrow Tunnel", arrival()),
+				},
+				readers: []string{PerspectiveObserver, PerspectiveArrival},
+			},
+		},
+		record.ActionCommandLook: {
+			{
+				name: "Character looks around",
+				set: record.ActionRecordSet{
+					ActionRec:          actionRec(record.ActionCommandLook),
+					ActionCharacterRec: actor(),
+					CurrentLocation:    location("Cave Entrance"),
+					TargetLocation:     location("Cave Entrance"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character looks up",
+				set: record.ActionRecordSet{
+					ActionRec:          direction(actionRec(record.ActionCommandLook), "up"),
+					ActionCharacterRec: actor(),
+					CurrentLocation:    location("Dark Room"),
+					TargetLocation:     location("Cave Tunnel"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character looks at an object",
+				set: record.ActionRecordSet{
+					ActionRec:             actionRec(record.ActionCommandLook),
+					ActionCharacterRec:    actor(),
+					TargetActionObjectRec: object("Torch"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character looks at an object with flavour",
+				set: record.ActionRecordSet{
+					ActionRec:             actionRec(record.ActionCommandLook),
+					ActionCharacterRec:    actor(),
+					TargetActionObjectRec: object("Silver Key"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character looks at a character",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandLook),
+					ActionCharacterRec:       actor(),
+					TargetActionCharacterRec: target(40),
+				},
+				readers: targeted,
+			},
+			{
+				name: "Character looks at a monster",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandLook),
+					ActionCharacterRec:     actor(),
+					TargetActionMonsterRec: monster("Cave Bat", 30),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character looks at a monster with flavour",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandLook),
+					ActionCharacterRec:     actor(),
+					TargetActionMonsterRec: monster("Grumpy Dwarf", 30),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Monster looks at a character",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandLook),
+					ActionMonsterRec:         monster("Grumpy Dwarf", 30),
+					TargetActionCharacterRec: target(40),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver},
+			},
+		},
+		record.ActionCommandUse: {
+			{
+				name: "Character uses an object",
+				set: record.ActionRecordSet{
+					ActionRec:             actionRec(record.ActionCommandUse),
+					ActionCharacterRec:    actor(),
+					TargetActionObjectRec: object("Silver Key"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character uses a character",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandUse),
+					ActionCharacterRec:       actor(),
+					TargetActionCharacterRec: target(40),
+				},
+				readers: targeted,
+			},
+			{
+				name: "Character uses a monster",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandUse),
+					ActionCharacterRec:     actor(),
+					TargetActionMonsterRec: monster("Giant Grey Rat", 30),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character uses nothing",
+				set: record.ActionRecordSet{
+					ActionRec:          actionRec(record.ActionCommandUse),
+					ActionCharacterRec: actor(),
+				},
+				readers: everyone,
+			},
+		},
+		record.ActionCommandEquip: {
+			{
+				name: "Character equips an object",
+				set: record.ActionRecordSet{
+					ActionRec:               actionRec(record.ActionCommandEquip),
+					ActionCharacterRec:      actor(),
+					EquippedActionObjectRec: object("Silver Key"),
+					TargetActionObjectRec:   object("Silver Key"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character equips an object with flavour",
+				set: record.ActionRecordSet{
+					ActionRec:               actionRec(record.ActionCommandEquip),
+					ActionCharacterRec:      actor(),
+					EquippedActionObjectRec: object("Rusted Sword"),
+					TargetActionObjectRec:   object("Rusted Sword"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Monster equips an object",
+				set: record.ActionRecordSet{
+					ActionRec:               actionRec(record.ActionCommandEquip),
+					ActionMonsterRec:        monster("Angry Goblin", 30),
+					EquippedActionObjectRec: object("Bone Dagger"),
+					TargetActionObjectRec:   object("Bone Dagger"),
+				},
+				readers: watched,
+			},
+		},
+		record.ActionCommandStash: {
+			{
+				name: "Character stashes an object",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandStash),
+					ActionCharacterRec:     actor(),
+					StashedActionObjectRec: object("Yellow Chewed Bone"),
+					TargetActionObjectRec:  object("Yellow Chewed Bone"),
+				},
+				readers: everyone,
+			},
+		},
+		record.ActionCommandDrop: {
+			{
+				name: "Character drops an object",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandDrop),
+					ActionCharacterRec:     actor(),
+					DroppedActionObjectRec: object("Rusted Sword"),
+					TargetActionObjectRec:  object("Rusted Sword"),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Monster drops an object",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandDrop),
+					ActionMonsterRec:       monster("Giant Grey Rat", 30),
+					DroppedActionObjectRec: object("Yellow Chewed Bone"),
+					TargetActionObjectRec:  object("Yellow Chewed Bone"),
+				},
+				readers: watched,
+			},
+		},
+		record.ActionCommandAttack: {
+			{
+				name: "Character hits a character",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandAttack),
+					ActionCharacterRec:       actor(),
+					TargetActionCharacterRec: target(20),
+				},
+				readers: targeted,
+			},
+			{
+				name: "Character kills a character with a weapon",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandAttack),
+					ActionCharacterRec:       actor(),
+					EquippedActionObjectRec:  object("Bone Dagger"),
+					TargetActionCharacterRec: target(0),
+				},
+				readers: targeted,
+			},
+			{
+				name: "Character hits a monster",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandAttack),
+					ActionCharacterRec:     actor(),
+					TargetActionMonsterRec: monster("Angry Goblin", 12),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character hits a monster with a flavoured weapon",
+				set: record.ActionRecordSet{
+					ActionRec:               actionRec(record.ActionCommandAttack),
+					ActionCharacterRec:      actor(),
+					EquippedActionObjectRec: object("Rusted Sword"),
+					TargetActionMonsterRec:  monster("Angry Goblin", 12),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character kills a monster",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandAttack),
+					ActionCharacterRec:     actor(),
+					TargetActionMonsterRec: monster("Angry Goblin", -3),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Character kills a flavoured monster",
+				set: record.ActionRecordSet{
+					ActionRec:              actionRec(record.ActionCommandAttack),
+					ActionCharacterRec:     actor(),
+					TargetActionMonsterRec: monster("Giant Grey Rat", 0),
+				},
+				readers: everyone,
+			},
+			{
+				name: "Monster hits a character",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandAttack),
+					ActionMonsterRec:         monster("Cave Bat", 30),
+					TargetActionCharacterRec: target(30),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver},
+			},
+			{
+				name: "Flavoured monster hits a character",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandAttack),
+					ActionMonsterRec:         monster("Grumpy Dwarf", 30),
+					TargetActionCharacterRec: target(30),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver},
+			},
+			{
+				name: "Flavoured monster kills a character",
+				set: record.ActionRecordSet{
+					ActionRec:                actionRec(record.ActionCommandAttack),
+					ActionMonsterRec:         monster("Giant Grey Rat", 30),
+					TargetActionCharacterRec: target(0),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver},
+			},
+		},
+		record.ActionCommandTeleport: {
+			{
+				name: "Game master teleports a character",
+				set: record.ActionRecordSet{
+					ActionRec:                systemAction(record.ActionCommandTeleport),
+					TargetActionCharacterRec: target(40),
+					CurrentLocation:          location("Cave Entrance", observer()),
+					TargetLocation:           location("Dark Room", target(40), arrival()),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver, PerspectiveArrival},
+			},
+		},
+		record.ActionCommandSpawn: {
+			{
+				name: "Game master spawns a monster",
+				set: record.ActionRecordSet{
+					ActionRec:              systemAction(record.ActionCommandSpawn),
+					TargetActionMonsterRec: monster("Grumpy Dwarf", 30),
+				},
+				readers: watched,
+			},
+			{
+				name: "Game master spawns an object",
+				set: record.ActionRecordSet{
+					ActionRec:             systemAction(record.ActionCommandSpawn),
+					TargetActionObjectRec: object("Silver Key"),
+				},
+				readers: watched,
+			},
+		},
+		record.ActionCommandHeal: {
+			{
+				name: "Game master heals a character",
+				set: record.ActionRecordSet{
+					ActionRec:                systemAction(record.ActionCommandHeal),
+					TargetActionCharacterRec: target(40),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver},
+			},
+			{
+				name: "Game master heals a monster",
+				set: record.ActionRecordSet{
+					ActionRec:              systemAction(record.ActionCommandHeal),
+					TargetActionMonsterRec: monster("Angry Goblin", 30),
+				},
+				readers: watched,
+			},
+		},
+		record.ActionCommandKill: {
+			{
+				name: "Game master kills a character",
+				set: record.ActionRecordSet{
+					ActionRec:                systemAction(record.ActionCommandKill),
+					TargetActionCharacterRec: target(0),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver},
+			},
+			{
+				name: "Game master kills a monster",
+				set: record.ActionRecordSet{
+					ActionRec:              systemAction(record.ActionCommandKill),
+					TargetActionMonsterRec: monster("Angry Goblin", 0),
+				},
+				readers: watched,
+			},
+		},
+		record.ActionCommandKick: {
+			{
+				name: "Game master kicks a character",
+				set: record.ActionRecordSet{
+					ActionRec:                systemAction(record.ActionCommandKick),
+					TargetActionCharacterRec: target(40),
+				},
+				readers: []string{PerspectiveTarget, PerspectiveObserver},
+			},
+		},
+	}
+}
+
+func TestNarrateGolden(t *testing.T) {
+
+	flavour, err := DefaultFlavour()
+	require.NoError(t, err, "DefaultFlavour returns without error")
+
+	n, err := NewNarrator(flavour)
+	require.NoError(t, err, "NewNarrator returns without error")
+
+	commands := []string{
+		record.ActionCommandMove,
+		record.ActionCommandLook,
+		record.ActionCommandUse,
+		record.ActionCommandEquip,
+		record.ActionCommandStash,
+		record.ActionCommandDrop,
+		record.ActionCommandAttack,
+		record.ActionCommandTeleport,
+		record.ActionCommandSpawn,
+		record.ActionCommandHeal,
+		record.ActionCommandKill,
+		record.ActionCommandKick,
+	}
+
+	testCases := narrativeTestCases()
+	require.Len(t, testCases, len(commands), "Every command has golden test cases")
+
+	// English golden files are in testdata, other languages in a directory
+	// named for the language
+	for _, language := range locale.Languages {
+		dir := "testdata"
+		if language != locale.Default {
+			dir = filepath.Join(dir, language)
+		}
+
+		for _, command := range commands {
+			t.Run(language+"/"+command, func(t *testing.T) {
+
+				var b strings.Builder
+				for _, tc := range testCases[command] {
+					fmt.Fprintf(&b, "# %s\n", tc.name)
+					for _, perspective := range tc.readers {
+						narrative, err := n.Narrate(tc.set, readers[perspective], language)
+						require.NoError(t, err, "Narrate returns without error >%s< >%s<", tc.name, perspective)
+						require.Equal(t, perspective, Perspective(tc.set, readers[perspective]), "Perspective equals expected >%s<", tc.name)
+						fmt.Fprintf(&b, "%s: %s\n", perspective, narrative)
+					}
+					b.WriteString("\n")
+				}
+
+				golden := filepath.Join(dir, command+".golden")
+				if *update {
+					err := os.MkdirAll(dir, 0755)
+					require.NoError(t, err, "Golden file directory is created without error")
+					err = os.WriteFile(golden, []byte(b.String()), 0644)
+					require.NoError(t, err, "Golden file writes without error")
+				}
+
+				expect, err := os.ReadFile(golden)
+				require.NoError(t, err, "Golden file reads without error")
+				require.Equal(t, string(expect), b.String(), "Narratives equal golden file >%s<", golden)
+			})
+		}
+	}
+}
+
+func TestLanguageTemplates(t *testing.T) {
+
+	for _, language := range locale.Languages {
+		if language == locale.Default {
+			continue
+		}
+		templates, ok := languageTemplates[language]
+		require.True(t, ok, "Language >%s< has templates", language)
+		_, ok = languageWords[language]
+		require.True(t, ok, "Language >%s< has words", language)
+
+		for key := range defaultTemplates {
+			_, ok := templates[key]
+			require.True(t, ok, "Language >%s< has template >%s<", language, key)
+		}
+	}
+}
+
+func TestNewNarrator(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		flavour Flavour
+		wantErr bool
+	}{
+		{
+			name:    "No flavour",
+			flavour: Flavour{},
+		},
+		{
+			name: "Invalid monster template",
+			flavour: Flavour{
+				Monsters: map[string]MonsterFlavour{
+					"Cave Bat": {
+						Acting: map[string]string{
+							"attack.hit": "{{.Actor} flaps at {{.Target}}.",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid object template",
+			flavour: Flavour{
+				Objects: map[string]map[string]string{
+					"Torch": {
+						"look.object": "{{if .Object}}A torch.",
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNarrator(tt.flavour)
+			if tt.wantErr {
+				require.Error(t, err, "NewNarrator returns with error")
+				return
+			}
+			require.NoError(t, err, "NewNarrator returns without error")
+		})
+	}
+}
+
+func TestNarrateFlavourNameCase(t *testing.T) {
+
+	n, err := NewNarrator(Flavour{
+		Objects: map[string]map[string]string{
+			"torch": {
+				"drop.success": "{{.Actor}} {{.Verb \"toss\" \"tosses\"}} {{the .Object}} aside, still burning.",
+			},
+		},
+	})
+	require.NoError(t, err, "NewNarrator returns without error")
+
+	narrative, err := n.Narrate(record.ActionRecordSet{
+		ActionRec:              actionRec(record.ActionCommandDrop),
+		ActionCharacterRec:     character(actorID, "Barricade", 40),
+		DroppedActionObjectRec: object("Torch"),
+		TargetActionObjectRec:  object("Torch"),
+	}, actorID, locale.English)
+	require.NoError(t, err, "Narrate returns without error")
+	require.Equal(t, "You toss the Torch aside, still burning.", narrative, "Flavour is found regardless of name case")
+}
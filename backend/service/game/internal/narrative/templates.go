@@ -0,0 +1,148 @@
+package narrative
+
+import (
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+)
+
+// defaultTemplates are keyed by "command.outcome" with optional
+// "command.outcome.perspective" variants for when a perspective reads
+// differently. Arrival perspectives use observer templates when there is no
+// arrival template.
+var defaultTemplates = map[string]string{
+	// Move
+	"move.success.actor":    `You move {{.Direction}} to {{.TargetLocation}}.`,
+	"move.success.observer": `{{.Actor}} heads {{.Heading}}.`,
+	"move.success.arrival":  `{{.Actor}} arrives from {{.From}}.`,
+
+	// Look
+	"look.location.actor":  `You look around {{.Location}}.`,
+	"look.location":        `{{.Actor}} looks around.`,
+	"look.direction.actor": `You look {{.Direction}} towards {{.TargetLocation}}.`,
+	"look.direction":       `{{.Actor}} looks {{.Heading}}.`,
+	"look.object":          `{{.Actor}} {{.Verb "look" "looks"}} at {{the .Object}}.`,
+	"look.character":       `{{.Actor}} {{.Verb "look" "looks"}} at {{.Target}}.`,
+	"look.monster":         `{{.Actor}} {{.Verb "look" "looks"}} at {{.Target}}.`,
+
+	// Use
+	"use.location":  `{{.Actor}} {{.Verb "fumble" "fumbles"}} about but {{.Verb "find" "finds"}} nothing to use.`,
+	"use.object":    `{{.Actor}} {{.Verb "use" "uses"}} {{the .Object}}.`,
+	"use.character": `{{.Actor}} {{.Verb "reach" "reaches"}} towards {{.Target}}.`,
+	"use.monster":   `{{.Actor}} {{.Verb "reach" "reaches"}} towards {{.Target}}.`,
+
+	// Equip, stash and drop
+	"equip.success": `{{.Actor}} {{.Verb "equip" "equips"}} {{the .Object}}.`,
+	"stash.success": `{{.Actor}} {{.Verb "stash" "stashes"}} {{the .Object}}.`,
+	"drop.success":  `{{.Actor}} {{.Verb "drop" "drops"}} {{the .Object}}.`,
+
+	// Attack
+	"attack.hit":  `{{.Actor}} {{.Verb "attack" "attacks"}} {{.Target}}{{with .Weapon}} with {{the .}}{{end}}.`,
+	"attack.kill": `{{.Actor}} {{.Verb "kill" "kills"}} {{.Target}}{{with .Weapon}} with {{the .}}{{end}}.`,
+
+	// Game master interventions
+	"teleport.success":         `{{.Actor}} teleports {{.Target}} to {{.TargetLocation}}.`,
+	"teleport.success.arrival": `{{.Target}} appears in a flash of light.`,
+	"spawn.object":             `{{.Actor}} conjures {{the .Object}} out of thin air.`,
+	"spawn.monster":            `{{.Actor}} conjures {{.Target}} out of thin air.`,
+	"heal.success":             `{{.Actor}} heals {{.Target}}.`,
+	"kill.success":             `{{.Actor}} strikes {{.Target}} down.`,
+	"kick.success":             `{{.Actor}} removes {{.Target}} from the dungeon.`,
+}
+
+// languageTemplates are the templates of languages other than English keyed
+// by language. Every English template key must have a template in each
+// language, "command.outcome.target" variants are needed where the reader
+// being the target changes the word order.
+var languageTemplates = map[string]map[string]string{
+	locale.French: {
+		// Move
+		"move.success.actor":    `Vous allez {{.Heading}} jusqu'à {{.TargetLocation}}.`,
+		"move.success.observer": `{{.Actor}} part {{.Heading}}.`,
+		"move.success.arrival":  `{{.Actor}} arrive {{.From}}.`,
+
+		// Look
+		"look.location.actor":   `Vous examinez les environs de {{.Location}}.`,
+		"look.location":         `{{.Actor}} jette un coup d'œil alentour.`,
+		"look.direction.actor":  `Vous regardez {{.Heading}} et apercevez {{.TargetLocation}}.`,
+		"look.direction":        `{{.Actor}} regarde {{.Heading}}.`,
+		"look.object":           `{{.Actor}} {{.Verb "regardez" "regarde"}} {{.Object}}.`,
+		"look.character":        `{{.Actor}} {{.Verb "regardez" "regarde"}} {{.Target}}.`,
+		"look.character.target": `{{.Actor}} vous regarde.`,
+		"look.monster":          `{{.Actor}} {{.Verb "regardez" "regarde"}} {{.Target}}.`,
+
+		// Use
+		"use.location":  `{{.Actor}} {{.Verb "tâtonnez" "tâtonne"}} mais {{.Verb "ne trouvez" "ne trouve"}} rien à utiliser.`,
+		"use.object":    `{{.Actor}} {{.Verb "utilisez" "utilise"}} {{.Object}}.`,
+		"use.character": `{{.Actor}} {{.Verb "tendez" "tend"}} la main vers {{.Target}}.`,
+		"use.monster":   `{{.Actor}} {{.Verb "tendez" "tend"}} la main vers {{.Target}}.`,
+
+		// Equip, stash and drop
+		"equip.success": `{{.Actor}} {{.Verb "équipez" "équipe"}} {{.Object}}.`,
+		"stash.success": `{{.Actor}} {{.Verb "rangez" "range"}} {{.Object}}.`,
+		"drop.success":  `{{.Actor}} {{.Verb "lâchez" "lâche"}} {{.Object}}.`,
+
+		// Attack
+		"attack.hit":         `{{.Actor}} {{.Verb "attaquez" "attaque"}} {{.Target}}{{with .Weapon}} avec {{.}}{{end}}.`,
+		"attack.hit.target":  `{{.Actor}} vous attaque{{with .Weapon}} avec {{.}}{{end}}.`,
+		"attack.kill":        `{{.Actor}} {{.Verb "tuez" "tue"}} {{.Target}}{{with .Weapon}} avec {{.}}{{end}}.`,
+		"attack.kill.target": `{{.Actor}} vous tue{{with .Weapon}} avec {{.}}{{end}}.`,
+
+		// Game master interventions
+		"teleport.success":         `{{.Actor}} téléporte {{.Target}} vers {{.TargetLocation}}.`,
+		"teleport.success.target":  `{{.Actor}} vous téléporte vers {{.TargetLocation}}.`,
+		"teleport.success.arrival": `{{.Target}} apparaît dans un éclair de lumière.`,
+		"spawn.object":             `{{.Actor}} fait apparaître {{.Object}} de nulle part.`,
+		"spawn.monster":            `{{.Actor}} fait apparaître {{.Target}} de nulle part.`,
+		"heal.success":             `{{.Actor}} soigne {{.Target}}.`,
+		"heal.success.target":      `{{.Actor}} vous soigne.`,
+		"kill.success":             `{{.Actor}} terrasse {{.Target}}.`,
+		"kill.success.target":      `{{.Actor}} vous terrasse.`,
+		"kick.success":             `{{.Actor}} expulse {{.Target}} du donjon.`,
+		"kick.success.target":      `{{.Actor}} vous expulse du donjon.`,
+	},
+	locale.Spanish: {
+		// Move
+		"move.success.actor":    `Te mueves {{.Heading}} hasta {{.TargetLocation}}.`,
+		"move.success.observer": `{{.Actor}} se marcha {{.Heading}}.`,
+		"move.success.arrival":  `{{.Actor}} llega {{.From}}.`,
+
+		// Look
+		"look.location.actor":   `Miras a tu alrededor en {{.Location}}.`,
+		"look.location":         `{{.Actor}} mira a su alrededor.`,
+		"look.direction.actor":  `Miras {{.Heading}} y ves {{.TargetLocation}}.`,
+		"look.direction":        `{{.Actor}} mira {{.Heading}}.`,
+		"look.object":           `{{.Actor}} {{.Verb "miras" "mira"}} {{.Object}}.`,
+		"look.character":        `{{.Actor}} {{.Verb "miras" "mira"}} a {{.Target}}.`,
+		"look.character.target": `{{.Actor}} te mira.`,
+		"look.monster":          `{{.Actor}} {{.Verb "miras" "mira"}} a {{.Target}}.`,
+
+		// Use
+		"use.location":  `{{.Actor}} {{.Verb "rebuscas" "rebusca"}} pero no {{.Verb "encuentras" "encuentra"}} nada que usar.`,
+		"use.object":    `{{.Actor}} {{.Verb "usas" "usa"}} {{.Object}}.`,
+		"use.character": `{{.Actor}} {{.Verb "tiendes" "tiende"}} la mano hacia {{.Target}}.`,
+		"use.monster":   `{{.Actor}} {{.Verb "tiendes" "tiende"}} la mano hacia {{.Target}}.`,
+
+		// Equip, stash and drop
+		"equip.success": `{{.Actor}} {{.Verb "equipas" "equipa"}} {{.Object}}.`,
+		"stash.success": `{{.Actor}} {{.Verb "guardas" "guarda"}} {{.Object}}.`,
+		"drop.success":  `{{.Actor}} {{.Verb "sueltas" "suelta"}} {{.Object}}.`,
+
+		// Attack
+		"attack.hit":         `{{.Actor}} {{.Verb "atacas" "ataca"}} a {{.Target}}{{with .Weapon}} con {{.}}{{end}}.`,
+		"attack.hit.target":  `{{.Actor}} te ataca{{with .Weapon}} con {{.}}{{end}}.`,
+		"attack.kill":        `{{.Actor}} {{.Verb "matas" "mata"}} a {{.Target}}{{with .Weapon}} con {{.}}{{end}}.`,
+		"attack.kill.target": `{{.Actor}} te mata{{with .Weapon}} con {{.}}{{end}}.`,
+
+		// Game master interventions
+		"teleport.success":         `{{.Actor}} teletransporta a {{.Target}} a {{.TargetLocation}}.`,
+		"teleport.success.target":  `{{.Actor}} te teletransporta a {{.TargetLocation}}.`,
+		"teleport.success.arrival": `{{.Target}} aparece en un destello de luz.`,
+		"spawn.object":             `{{.Actor}} hace aparecer {{.Object}} de la nada.`,
+		"spawn.monster":            `{{.Actor}} hace aparecer a {{.Target}} de la nada.`,
+		"heal.success":             `{{.Actor}} cura a {{.Target}}.`,
+		"heal.success.target":      `{{.Actor}} te cura.`,
+		"kill.success":             `{{.Actor}} abate a {{.Target}}.`,
+		"kill.success.target":      `{{.Actor}} te abate.`,
+		"kick.success":             `{{.Actor}} expulsa a {{.Target}} de la mazmorra.`,
+		"kick.success.target":      `{{.Actor}} te expulsa de la mazmorra.`,
+	},
+}
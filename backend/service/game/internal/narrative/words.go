@@ -0,0 +1,90 @@
+package narrative
+
+import (
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+)
+
+// words are the words of a language narratives are assembled from besides the
+// templates themselves
+type words struct {
+	// you is the reader as the actor and youTarget the reader as the target
+	you       string
+	youTarget string
+	// someone is an actor that cannot be named
+	someone string
+	// headings are the way something is heading by direction
+	headings map[string]string
+	// froms are where something arrives from by the direction it moved in
+	froms map[string]string
+}
+
+// englishWords are the words of English narratives, headings and arrivals are
+// derived from direction names
+var englishWords = &words{
+	you:       "You",
+	youTarget: "you",
+	someone:   "Someone",
+}
+
+var languageWords = map[string]*words{
+	locale.French: {
+		you:       "Vous",
+		youTarget: "vous",
+		someone:   "Quelqu'un",
+		headings: map[string]string{
+			"north":     "vers le nord",
+			"northeast": "vers le nord-est",
+			"east":      "vers l'est",
+			"southeast": "vers le sud-est",
+			"south":     "vers le sud",
+			"southwest": "vers le sud-ouest",
+			"west":      "vers l'ouest",
+			"northwest": "vers le nord-ouest",
+			"up":        "vers le haut",
+			"down":      "vers le bas",
+		},
+		froms: map[string]string{
+			"north":     "du sud",
+			"northeast": "du sud-ouest",
+			"east":      "de l'ouest",
+			"southeast": "du nord-ouest",
+			"south":     "du nord",
+			"southwest": "du nord-est",
+			"west":      "de l'est",
+			"northwest": "du sud-est",
+			"up":        "d'en bas",
+			"down":      "d'en haut",
+			"":          "de nulle part",
+		},
+	},
+	locale.Spanish: {
+		you:       "Tú",
+		youTarget: "ti",
+		someone:   "Alguien",
+		headings: map[string]string{
+			"north":     "hacia el norte",
+			"northeast": "hacia el noreste",
+			"east":      "hacia el este",
+			"southeast": "hacia el sureste",
+			"south":     "hacia el sur",
+			"southwest": "hacia el suroeste",
+			"west":      "hacia el oeste",
+			"northwest": "hacia el noroeste",
+			"up":        "hacia arriba",
+			"down":      "hacia abajo",
+		},
+		froms: map[string]string{
+			"north":     "desde el sur",
+			"northeast": "desde el suroeste",
+			"east":      "desde el oeste",
+			"southeast": "desde el noroeste",
+			"south":     "desde el norte",
+			"southwest": "desde el noreste",
+			"west":      "desde el este",
+			"northwest": "desde el sureste",
+			"up":        "desde abajo",
+			"down":      "desde arriba",
+			"":          "de la nada",
+		},
+	},
+}
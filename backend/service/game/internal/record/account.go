@@ -0,0 +1,21 @@
+package record
+
+import "gitlab.com/alienspaces/go-mud/backend/core/repository"
+
+const (
+	FieldAccountEmail string = "email"
+)
+
+const (
+	AccountRolePlayer     string = "player"
+	AccountRoleGameMaster string = "game_master"
+	AccountRoleAdmin      string = "admin"
+)
+
+type Account struct {
+	Name         string `db:"name"`
+	Email        string `db:"email"`
+	PasswordHash string `db:"password_hash"`
+	Role         string `db:"role"`
+	repository.Record
+}
@@ -16,12 +16,30 @@ const (
 	ActionCommandAttack string = "attack"
 )
 
+// System action commands are interventions made by a game master rather than
+// by a character or monster
+const (
+	ActionCommandTeleport string = "teleport"
+	ActionCommandSpawn    string = "spawn"
+	ActionCommandHeal     string = "heal"
+	ActionCommandKill     string = "kill"
+	ActionCommandKick     string = "kick"
+)
+
+const (
+	FieldActionSystemActor                       string = "system_actor"
+	FieldActionResolvedCommand                   string = "resolved_command"
+	FieldActionResolvedTargetCharacterInstanceID string = "resolved_target_character_instance_id"
+	FieldActionResolvedTargetLocationInstanceID  string = "resolved_target_location_instance_id"
+)
+
 type Action struct {
 	DungeonInstanceID                 string         `db:"dungeon_instance_id"`
 	LocationInstanceID                string         `db:"location_instance_id"`
 	CharacterInstanceID               sql.NullString `db:"character_instance_id"`
 	MonsterInstanceID                 sql.NullString `db:"monster_instance_id"`
-	SerialNumber                      sql.NullInt16  `db:"serial_number,readonly"`
+	SystemActor                       sql.NullString `db:"system_actor"`
+	SerialNumber                      sql.NullInt32  `db:"serial_number,readonly"`
 	TurnNumber                        int            `db:"turn_number"`
 	ResolvedCommand                   string         `db:"resolved_command"`
 	ResolvedEquippedObjectInstanceID  sql.NullString `db:"resolved_equipped_object_instance_id"`
@@ -0,0 +1,25 @@
+package record
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+)
+
+const (
+	FieldAPIKeyKeyHash   string = "key_hash"
+	FieldAPIKeyRevokedAt string = "revoked_at"
+)
+
+type APIKey struct {
+	Owner     string         `db:"owner"`
+	KeyPrefix string         `db:"key_prefix"`
+	KeyHash   string         `db:"key_hash"`
+	Scopes    pq.StringArray `db:"scopes"`
+	AccountID sql.NullString `db:"account_id"`
+	ExpiresAt sql.NullTime   `db:"expires_at"`
+	RevokedAt sql.NullTime   `db:"revoked_at"`
+	repository.Record
+}
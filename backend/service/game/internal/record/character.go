@@ -1,17 +1,26 @@
 package record
 
-import "gitlab.com/alienspaces/go-mud/backend/core/repository"
+import (
+	"database/sql"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+)
+
+const (
+	FieldCharacterAccountID string = "account_id"
+)
 
 type Character struct {
-	Name             string `db:"name"`
-	Strength         int    `db:"strength"`
-	Dexterity        int    `db:"dexterity"`
-	Intelligence     int    `db:"intelligence"`
-	Health           int    `db:"health"`
-	Fatigue          int    `db:"fatigue"`
-	Coins            int    `db:"coins"`
-	ExperiencePoints int    `db:"experience_points"`
-	AttributePoints  int    `db:"attribute_points"`
+	AccountID        sql.NullString `db:"account_id"`
+	Name             string         `db:"name"`
+	Strength         int            `db:"strength"`
+	Dexterity        int            `db:"dexterity"`
+	Intelligence     int            `db:"intelligence"`
+	Health           int            `db:"health"`
+	Fatigue          int            `db:"fatigue"`
+	Coins            int            `db:"coins"`
+	ExperiencePoints int            `db:"experience_points"`
+	AttributePoints  int            `db:"attribute_points"`
 	repository.Record
 }
 
@@ -1,6 +1,8 @@
 package record
 
 import (
+	"database/sql"
+
 	"gitlab.com/alienspaces/go-mud/backend/core/repository"
 )
 
@@ -11,8 +13,19 @@ type Dungeon struct {
 	repository.Record
 }
 
+const (
+	FieldDungeonInstanceFailureCount  string = "failure_count"
+	FieldDungeonInstanceQuarantinedAt string = "quarantined_at"
+)
+
 type DungeonInstance struct {
-	DungeonID string `db:"dungeon_id"`
+	DungeonID     string         `db:"dungeon_id"`
+	FailureCount  int            `db:"failure_count"`
+	LastError     sql.NullString `db:"last_error"`
+	LastErrorAt   sql.NullTime   `db:"last_error_at"`
+	QuarantinedAt sql.NullTime   `db:"quarantined_at"`
+	EmptySince    sql.NullTime   `db:"empty_since"`
+	Seed          int64          `db:"seed"`
 	repository.Record
 }
 
@@ -22,3 +35,16 @@ type DungeonInstanceView struct {
 	Description string `db:"description"`
 	repository.Record
 }
+
+const (
+	FieldDungeonInstanceLeaseDungeonInstanceID string = "dungeon_instance_id"
+	FieldDungeonInstanceLeaseDaemonID          string = "daemon_id"
+	FieldDungeonInstanceLeaseExpiresAt         string = "expires_at"
+)
+
+type DungeonInstanceLease struct {
+	DungeonInstanceID string         `db:"dungeon_instance_id"`
+	DaemonID          sql.NullString `db:"daemon_id"`
+	ExpiresAt         sql.NullTime   `db:"expires_at"`
+	repository.Record
+}
@@ -24,6 +24,62 @@ type Location struct {
 	repository.Record
 }
 
+// LocationDirections are the directions a location may link to another location
+var LocationDirections = []string{
+	"north",
+	"northeast",
+	"east",
+	"southeast",
+	"south",
+	"southwest",
+	"west",
+	"northwest",
+	"up",
+	"down",
+}
+
+// LocationOppositeDirections is the direction leading back for each direction
+var LocationOppositeDirections = map[string]string{
+	"north":     "south",
+	"northeast": "southwest",
+	"east":      "west",
+	"southeast": "northwest",
+	"south":     "north",
+	"southwest": "northeast",
+	"west":      "east",
+	"northwest": "southeast",
+	"up":        "down",
+	"down":      "up",
+}
+
+// DirectionLocationID returns the linked location ID of a direction so it may be
+// read or assigned, nil when the direction is unknown
+func (l *Location) DirectionLocationID(direction string) *sql.NullString {
+	switch direction {
+	case "north":
+		return &l.NorthLocationID
+	case "northeast":
+		return &l.NortheastLocationID
+	case "east":
+		return &l.EastLocationID
+	case "southeast":
+		return &l.SoutheastLocationID
+	case "south":
+		return &l.SouthLocationID
+	case "southwest":
+		return &l.SouthwestLocationID
+	case "west":
+		return &l.WestLocationID
+	case "northwest":
+		return &l.NorthwestLocationID
+	case "up":
+		return &l.UpLocationID
+	case "down":
+		return &l.DownLocationID
+	}
+	return nil
+}
+
 type LocationObject struct {
 	LocationID         string `db:"location_id"`
 	ObjectID           string `db:"object_id"`
@@ -75,3 +131,31 @@ type LocationInstanceView struct {
 	DownLocationInstanceID      sql.NullString `db:"down_location_instance_id"`
 	repository.Record
 }
+
+// DirectionLocationInstanceID returns the linked location instance ID of a
+// direction, nil when the direction is unknown
+func (l *LocationInstanceView) DirectionLocationInstanceID(direction string) *sql.NullString {
+	switch direction {
+	case "north":
+		return &l.NorthLocationInstanceID
+	case "northeast":
+		return &l.NortheastLocationInstanceID
+	case "east":
+		return &l.EastLocationInstanceID
+	case "southeast":
+		return &l.SoutheastLocationInstanceID
+	case "south":
+		return &l.SouthLocationInstanceID
+	case "southwest":
+		return &l.SouthwestLocationInstanceID
+	case "west":
+		return &l.WestLocationInstanceID
+	case "northwest":
+		return &l.NorthwestLocationInstanceID
+	case "up":
+		return &l.UpLocationInstanceID
+	case "down":
+		return &l.DownLocationInstanceID
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package record
+
+import (
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+)
+
+const (
+	FieldTranslationResourceID string = "resource_id"
+	FieldTranslationLanguage   string = "language"
+	FieldTranslationField      string = "field"
+)
+
+const (
+	TranslationResourceTypeDungeon  string = "dungeon"
+	TranslationResourceTypeLocation string = "location"
+	TranslationResourceTypeMonster  string = "monster"
+	TranslationResourceTypeObject   string = "object"
+)
+
+const (
+	TranslationFieldName                string = "name"
+	TranslationFieldDescription         string = "description"
+	TranslationFieldDescriptionDetailed string = "description_detailed"
+)
+
+// Translation is the text of a dungeon, location, monster or object field in a
+// language other than English
+type Translation struct {
+	ResourceType string `db:"resource_type"`
+	ResourceID   string `db:"resource_id"`
+	Language     string `db:"language"`
+	Field        string `db:"field"`
+	Value        string `db:"value"`
+	repository.Record
+}
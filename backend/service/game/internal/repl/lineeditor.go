@@ -0,0 +1,466 @@
+package repl
+
+// LineEditor reads lines from a terminal with editing, history and tab
+// completion. It understands the handful of control keys and VT100 escape
+// sequences that terminals send, which is enough for typing sentences and
+// avoids depending on a terminal library.
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Keys
+const (
+	keyCtrlA     rune = 1
+	keyCtrlC     rune = 3
+	keyCtrlD     rune = 4
+	keyCtrlE     rune = 5
+	keyBackspace rune = 8
+	keyTab       rune = 9
+	keyLineFeed  rune = 10
+	keyCtrlK     rune = 11
+	keyReturn    rune = 13
+	keyCtrlU     rune = 21
+	keyEscape    rune = 27
+	keyDelete    rune = 127
+)
+
+// maxHistory is the number of lines kept in history
+const maxHistory int = 500
+
+// CompleteFunc returns the words or names the text before the cursor may be
+// completed with
+type CompleteFunc func(text string) []string
+
+// LineEditor -
+type LineEditor struct {
+	in  *bufio.Reader
+	out io.Writer
+	// Raw is set when the terminal delivers keys as they are typed without
+	// echoing them, otherwise lines are read as the terminal delivers them
+	Raw bool
+	// History is the lines read, oldest first
+	History []string
+	// Complete returns completion candidates for the tab key
+	Complete CompleteFunc
+}
+
+// NewLineEditor -
+func NewLineEditor(in io.Reader, out io.Writer) *LineEditor {
+	return &LineEditor{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// MakeRaw puts the terminal on standard input into a mode that delivers keys
+// as they are typed without echoing them, and returns a function restoring
+// the previous mode. An error is returned when standard input is not a
+// terminal.
+func MakeRaw() (func() error, error) {
+
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode()&os.ModeCharDevice == 0 {
+		return nil, fmt.Errorf("standard input is not a terminal")
+	}
+
+	state, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+
+	// Output processing is left enabled so line feeds are still written as
+	// carriage return line feeds
+	if _, err := stty("-icanon", "-echo", "-isig", "min", "1", "time", "0"); err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		_, err := stty(strings.TrimSpace(state))
+		return err
+	}, nil
+}
+
+// Width returns the number of columns of the terminal on standard input, or
+// zero when unknown
+func Width() int {
+	out, err := stty("size")
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0
+	}
+	width, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed stty >%s< >%v<", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// ReadLine writes the prompt and returns the next line without surrounding
+// space. Non empty lines are added to history. io.EOF is returned when input
+// ends or ctrl-d is pressed on an empty line.
+func (e *LineEditor) ReadLine(prompt string) (string, error) {
+
+	if _, err := io.WriteString(e.out, prompt); err != nil {
+		return "", err
+	}
+
+	var line string
+	var err error
+	if e.Raw {
+		line, err = e.readRaw(prompt)
+	} else {
+		line, err = e.readCooked()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	line = strings.TrimSpace(line)
+	e.addHistory(line)
+
+	return line, nil
+}
+
+// ReadPassword writes the prompt and returns the next line without echoing
+// it or adding it to history
+func (e *LineEditor) ReadPassword(prompt string) (string, error) {
+
+	if _, err := io.WriteString(e.out, prompt); err != nil {
+		return "", err
+	}
+
+	if !e.Raw {
+		line, err := e.readCooked()
+		return strings.TrimSpace(line), err
+	}
+
+	buf := []rune{}
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case keyReturn, keyLineFeed:
+			_, err := io.WriteString(e.out, "\n")
+			return string(buf), err
+		case keyCtrlC, keyCtrlD:
+			_, _ = io.WriteString(e.out, "\n")
+			return "", io.EOF
+		case keyBackspace, keyDelete:
+			if len(buf) > 0 {
+				buf = buf[:len(buf)-1]
+			}
+		default:
+			if unicode.IsPrint(r) {
+				buf = append(buf, r)
+			}
+		}
+	}
+}
+
+func (e *LineEditor) readCooked() (string, error) {
+	line, err := e.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		return line, nil
+	}
+	return line, err
+}
+
+func (e *LineEditor) addHistory(line string) {
+	if line == "" {
+		return
+	}
+	if len(e.History) > 0 && e.History[len(e.History)-1] == line {
+		return
+	}
+	e.History = append(e.History, line)
+	if len(e.History) > maxHistory {
+		e.History = e.History[len(e.History)-maxHistory:]
+	}
+}
+
+// edit is the line being edited
+type edit struct {
+	buf []rune
+	pos int
+}
+
+func (ed *edit) set(text string) {
+	ed.buf = []rune(text)
+	ed.pos = len(ed.buf)
+}
+
+func (ed *edit) insert(r ...rune) {
+	buf := append([]rune{}, ed.buf[:ed.pos]...)
+	buf = append(buf, r...)
+	ed.buf = append(buf, ed.buf[ed.pos:]...)
+	ed.pos += len(r)
+}
+
+func (ed *edit) backspace() {
+	if ed.pos == 0 {
+		return
+	}
+	ed.buf = append(ed.buf[:ed.pos-1], ed.buf[ed.pos:]...)
+	ed.pos--
+}
+
+func (ed *edit) delete() {
+	if ed.pos == len(ed.buf) {
+		return
+	}
+	ed.buf = append(ed.buf[:ed.pos], ed.buf[ed.pos+1:]...)
+}
+
+func (e *LineEditor) readRaw(prompt string) (string, error) {
+
+	ed := edit{}
+
+	// History position, len(History) is the line being typed
+	hpos := len(e.History)
+	typed := ""
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case keyReturn, keyLineFeed:
+			_, err := io.WriteString(e.out, "\n")
+			return string(ed.buf), err
+		case keyCtrlD:
+			if len(ed.buf) == 0 {
+				_, _ = io.WriteString(e.out, "\n")
+				return "", io.EOF
+			}
+			ed.delete()
+		case keyCtrlC:
+			// Abandon the line
+			_, err := io.WriteString(e.out, "^C\n")
+			return "", err
+		case keyBackspace, keyDelete:
+			ed.backspace()
+		case keyCtrlA:
+			ed.pos = 0
+		case keyCtrlE:
+			ed.pos = len(ed.buf)
+		case keyCtrlK:
+			ed.buf = ed.buf[:ed.pos]
+		case keyCtrlU:
+			ed.buf = ed.buf[ed.pos:]
+			ed.pos = 0
+		case keyTab:
+			if err := e.complete(prompt, &ed); err != nil {
+				return "", err
+			}
+		case keyEscape:
+			seq, err := e.readEscape()
+			if err != nil {
+				return "", err
+			}
+			switch seq {
+			case "[A", "OA":
+				// Up, older history
+				if hpos > 0 {
+					if hpos == len(e.History) {
+						typed = string(ed.buf)
+					}
+					hpos--
+					ed.set(e.History[hpos])
+				}
+			case "[B", "OB":
+				// Down, newer history
+				if hpos < len(e.History) {
+					hpos++
+					if hpos == len(e.History) {
+						ed.set(typed)
+					} else {
+						ed.set(e.History[hpos])
+					}
+				}
+			case "[C", "OC":
+				if ed.pos < len(ed.buf) {
+					ed.pos++
+				}
+			case "[D", "OD":
+				if ed.pos > 0 {
+					ed.pos--
+				}
+			case "[H", "OH", "[1~":
+				ed.pos = 0
+			case "[F", "OF", "[4~":
+				ed.pos = len(ed.buf)
+			case "[3~":
+				ed.delete()
+			}
+		default:
+			if unicode.IsPrint(r) {
+				ed.insert(r)
+			}
+		}
+
+		if err := e.redraw(prompt, &ed); err != nil {
+			return "", err
+		}
+	}
+}
+
+// readEscape reads the remainder of an escape sequence, for example "[A"
+func (e *LineEditor) readEscape() (string, error) {
+
+	r, _, err := e.in.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	if r != '[' && r != 'O' {
+		return string(r), nil
+	}
+
+	seq := []rune{r}
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		seq = append(seq, r)
+		// Sequences end with a letter or tilde
+		if unicode.IsLetter(r) || r == '~' {
+			return string(seq), nil
+		}
+	}
+}
+
+// redraw rewrites the prompt and line and moves the cursor to its position
+func (e *LineEditor) redraw(prompt string, ed *edit) error {
+
+	b := strings.Builder{}
+	b.WriteString("\r\x1b[K")
+	b.WriteString(prompt)
+	b.WriteString(string(ed.buf))
+	if back := len(ed.buf) - ed.pos; back > 0 {
+		b.WriteString(fmt.Sprintf("\x1b[%dD", back))
+	}
+
+	_, err := io.WriteString(e.out, b.String())
+	return err
+}
+
+// complete completes the text before the cursor with the candidate it is the
+// start of, or the longest start shared by several candidates. When several
+// candidates share no longer start they are listed.
+func (e *LineEditor) complete(prompt string, ed *edit) error {
+
+	if e.Complete == nil {
+		return nil
+	}
+
+	text := string(ed.buf[:ed.pos])
+	start, matches := Completions(text, e.Complete(text))
+	if len(matches) == 0 {
+		return nil
+	}
+
+	typed := []rune(text)[start:]
+	replace := []rune(matches[0] + " ")
+	if len(matches) > 1 {
+		replace = []rune(commonPrefix(matches))
+	}
+
+	if len(replace) > len(typed) || len(matches) == 1 {
+		rest := append([]rune{}, ed.buf[ed.pos:]...)
+		ed.buf = append(ed.buf[:start], replace...)
+		ed.pos = len(ed.buf)
+		ed.buf = append(ed.buf, rest...)
+		return nil
+	}
+
+	// Nothing more to complete, list the candidates
+	_, err := io.WriteString(e.out, "\n"+strings.Join(matches, "  ")+"\n")
+	return err
+}
+
+// Completions returns the candidates the end of the text is the start of and
+// the rune index the completed text starts at. Candidates may contain several
+// words, the earliest word of the text that starts a candidate is completed so
+// "attack angry g" completes to "attack angry goblin". Matching ignores case.
+func Completions(text string, candidates []string) (int, []string) {
+
+	runes := []rune(text)
+
+	// Word starts, earliest first, ending with the position after the text
+	// which matches every candidate
+	starts := []int{}
+	for idx := range runes {
+		if !unicode.IsSpace(runes[idx]) && (idx == 0 || unicode.IsSpace(runes[idx-1])) {
+			starts = append(starts, idx)
+		}
+	}
+	if len(runes) == 0 || unicode.IsSpace(runes[len(runes)-1]) {
+		starts = append(starts, len(runes))
+	}
+
+	for _, start := range starts {
+		typed := strings.ToLower(string(runes[start:]))
+		matches := []string{}
+		seen := map[string]struct{}{}
+		for _, candidate := range candidates {
+			lower := strings.ToLower(candidate)
+			if _, ok := seen[lower]; ok {
+				continue
+			}
+			if strings.HasPrefix(lower, typed) {
+				seen[lower] = struct{}{}
+				matches = append(matches, candidate)
+			}
+		}
+		if len(matches) > 0 {
+			return start, matches
+		}
+	}
+
+	return len(runes), nil
+}
+
+// commonPrefix returns the longest start shared by the candidates ignoring
+// case, in the case of the first candidate
+func commonPrefix(candidates []string) string {
+
+	prefix := []rune(candidates[0])
+	for _, candidate := range candidates[1:] {
+		runes := []rune(candidate)
+		n := 0
+		for n < len(prefix) && n < len(runes) && unicode.ToLower(prefix[n]) == unicode.ToLower(runes[n]) {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	return string(prefix)
+}
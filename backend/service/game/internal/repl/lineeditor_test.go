@@ -0,0 +1,196 @@
+package repl
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLineRaw(t *testing.T) {
+
+	names := []string{"north", "Angry Goblin", "Angry Guard", "Rusted Sword"}
+
+	tests := []struct {
+		name    string
+		history []string
+		in      string
+		expect  []string
+	}{
+		{
+			name:   "Typed",
+			in:     "look\rmove north\n",
+			expect: []string{"look", "move north"},
+		},
+		{
+			name:   "Backspace and delete",
+			in:     "lool\x7fk\rmovx\be\r",
+			expect: []string{"look", "move"},
+		},
+		{
+			name:   "Cursor movement",
+			in:     "lok\x1b[Do\x1b[C\r\x01x\x05y\r",
+			expect: []string{"look", "xy"},
+		},
+		{
+			name:   "Kill to end and start",
+			in:     "look north\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x0b\r" + "look north\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x15\r",
+			expect: []string{"look", "north"},
+		},
+		{
+			name:    "History",
+			history: []string{"look", "move north"},
+			in:      "\x1b[A\r\x1b[A\x1b[A\x1b[A\r\x1b[A\x1b[A\x1b[B\r",
+			expect:  []string{"move north", "look", "look"},
+		},
+		{
+			name:    "History returns to typed line",
+			history: []string{"look"},
+			in:      "mo\x1b[A\x1b[Bve\r",
+			expect:  []string{"move"},
+		},
+		{
+			name:   "Complete single candidate",
+			in:     "move n\tlook rus\t\r",
+			expect: []string{"move north look Rusted Sword"},
+		},
+		{
+			name:   "Complete common start",
+			in:     "attack ang\t\r",
+			expect: []string{"attack Angry G"},
+		},
+		{
+			name:   "Complete several words",
+			in:     "attack angry go\t\r",
+			expect: []string{"attack Angry Goblin"},
+		},
+		{
+			name:   "Complete nothing",
+			in:     "attack troll\t\r",
+			expect: []string{"attack troll"},
+		},
+		{
+			name:   "Abandon line",
+			in:     "attack\x03look\r",
+			expect: []string{"", "look"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			out := &strings.Builder{}
+			e := NewLineEditor(strings.NewReader(tt.in), out)
+			e.Raw = true
+			e.History = append([]string{}, tt.history...)
+			e.Complete = func(text string) []string {
+				return names
+			}
+
+			for _, expect := range tt.expect {
+				line, err := e.ReadLine("> ")
+				require.NoError(t, err, "ReadLine returns without error")
+				require.Equal(t, expect, line, "ReadLine returns the line")
+			}
+
+			_, err := e.ReadLine("> ")
+			require.ErrorIs(t, err, io.EOF, "ReadLine returns EOF")
+		})
+	}
+}
+
+func TestReadLineRawEndOfInput(t *testing.T) {
+
+	e := NewLineEditor(strings.NewReader("look\r\x04"), &strings.Builder{})
+	e.Raw = true
+
+	line, err := e.ReadLine("> ")
+	require.NoError(t, err, "ReadLine returns without error")
+	require.Equal(t, "look", line, "ReadLine returns the line")
+
+	_, err = e.ReadLine("> ")
+	require.ErrorIs(t, err, io.EOF, "ReadLine returns EOF on ctrl-d")
+
+	require.Equal(t, []string{"look"}, e.History, "History contains lines read")
+}
+
+func TestReadLineCooked(t *testing.T) {
+
+	e := NewLineEditor(strings.NewReader("look\n\nmove north\nlook\nlook"), &strings.Builder{})
+
+	expect := []string{"look", "", "move north", "look", "look"}
+	for _, line := range expect {
+		got, err := e.ReadLine("> ")
+		require.NoError(t, err, "ReadLine returns without error")
+		require.Equal(t, line, got, "ReadLine returns the line")
+	}
+
+	_, err := e.ReadLine("> ")
+	require.ErrorIs(t, err, io.EOF, "ReadLine returns EOF")
+
+	require.Equal(t, []string{"look", "move north", "look"}, e.History, "History skips empty and repeated lines")
+}
+
+func TestReadPasswordRaw(t *testing.T) {
+
+	out := &strings.Builder{}
+	e := NewLineEditor(strings.NewReader("secrex\x7ft\r"), out)
+	e.Raw = true
+
+	password, err := e.ReadPassword("Password: ")
+	require.NoError(t, err, "ReadPassword returns without error")
+	require.Equal(t, "secret", password, "ReadPassword returns the password")
+	require.Equal(t, "Password: \n", out.String(), "Password is not echoed")
+	require.Empty(t, e.History, "Password is not added to history")
+}
+
+func TestCompletions(t *testing.T) {
+
+	candidates := []string{"north", "northeast", "Angry Goblin", "angry goblin", "Rusted Sword"}
+
+	tests := []struct {
+		name        string
+		text        string
+		expectStart int
+		expect      []string
+	}{
+		{
+			name:        "Empty",
+			text:        "",
+			expectStart: 0,
+			expect:      []string{"north", "northeast", "Angry Goblin", "Rusted Sword"},
+		},
+		{
+			name:        "Word",
+			text:        "move nor",
+			expectStart: 5,
+			expect:      []string{"north", "northeast"},
+		},
+		{
+			name:        "After space",
+			text:        "look ",
+			expectStart: 5,
+			expect:      []string{"north", "northeast", "Angry Goblin", "Rusted Sword"},
+		},
+		{
+			name:        "Several words",
+			text:        "attack ANGRY g",
+			expectStart: 7,
+			expect:      []string{"Angry Goblin"},
+		},
+		{
+			name:        "No match",
+			text:        "attack troll",
+			expectStart: 12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, matches := Completions(tt.text, candidates)
+			require.Equal(t, tt.expectStart, start, "Completions returns the start")
+			require.Equal(t, tt.expect, matches, "Completions returns the matches")
+		})
+	}
+}
@@ -0,0 +1,569 @@
+package repl
+
+// REPL is an interactive terminal client for playing the game through the
+// API. Lines starting with a slash are client commands for accounts,
+// characters and dungeons, every other line is a sentence submitted as an
+// action by the selected character.
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/client"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/terminal"
+)
+
+const (
+	ansiReset string = "\x1b[0m"
+	ansiRed   string = "\x1b[31m"
+)
+
+// defaultAttribute is the strength, dexterity and intelligence of created
+// characters when not provided, together they are the attribute points a new
+// character has
+const defaultAttribute int = 12
+
+// errQuit ends the REPL
+var errQuit = errors.New("quit")
+
+// playerVerbs are the command verbs completed at the start of a sentence
+var playerVerbs = []string{
+	record.ActionCommandMove,
+	record.ActionCommandLook,
+	record.ActionCommandUse,
+	record.ActionCommandStash,
+	record.ActionCommandEquip,
+	record.ActionCommandDrop,
+	record.ActionCommandAttack,
+}
+
+type command struct {
+	usage       string
+	description string
+	run         func(args []string) error
+}
+
+// REPL -
+type REPL struct {
+	Client  *client.Client
+	Editor  *LineEditor
+	Out     io.Writer
+	Options terminal.Options
+
+	commands map[string]command
+
+	dungeons   []schema.DungeonData
+	characters []schema.DungeonCharacterData
+
+	// character is the selected character
+	character *schema.DungeonCharacterData
+	// dungeon is the dungeon the selected character is in
+	dungeon *schema.DungeonCharacterDungeonData
+	// location is where the most recent action left the selected character
+	location schema.ActionLocation
+	// inventory is the names of objects the selected character has equipped
+	// or stashed
+	inventory []string
+}
+
+// NewREPL -
+func NewREPL(c *client.Client, e *LineEditor, out io.Writer, o terminal.Options) *REPL {
+
+	r := &REPL{
+		Client:  c,
+		Editor:  e,
+		Out:     out,
+		Options: o,
+	}
+
+	c.SetLanguage(o.Language)
+	e.Complete = r.complete
+
+	r.commands = map[string]command{
+		"/help": {
+			usage:       "/help",
+			description: "List commands",
+			run:         r.help,
+		},
+		"/register": {
+			usage:       "/register <name> [email]",
+			description: "Register an account and log in",
+			run:         r.register,
+		},
+		"/login": {
+			usage:       "/login [email]",
+			description: "Log in to an account",
+			run:         r.login,
+		},
+		"/dungeons": {
+			usage:       "/dungeons",
+			description: "List dungeons",
+			run:         r.listDungeons,
+		},
+		"/characters": {
+			usage:       "/characters",
+			description: "List your characters",
+			run:         r.listCharacters,
+		},
+		"/create": {
+			usage:       "/create <name> [strength dexterity intelligence]",
+			description: fmt.Sprintf("Create and select a character, attributes default to %d", defaultAttribute),
+			run:         r.createCharacter,
+		},
+		"/select": {
+			usage:       "/select <number or name>",
+			description: "Select a character",
+			run:         r.selectCharacter,
+		},
+		"/enter": {
+			usage:       "/enter <number or name>",
+			description: "Enter a dungeon with the selected character",
+			run:         r.enterDungeon,
+		},
+		"/exit": {
+			usage:       "/exit",
+			description: "Exit the dungeon with the selected character",
+			run:         r.exitDungeon,
+		},
+		"/quit": {
+			usage:       "/quit",
+			description: "Quit, characters stay in their dungeons",
+			run: func(args []string) error {
+				return errQuit
+			},
+		},
+	}
+
+	return r
+}
+
+// Run reads and runs lines until input ends or the quit command
+func (r *REPL) Run() error {
+
+	r.printf("Type /help to list commands.\n")
+
+	for {
+		line, err := r.Editor.ReadLine(r.prompt())
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = r.runLine(line)
+		if err == errQuit {
+			return nil
+		}
+		if err != nil {
+			r.printError(err)
+		}
+	}
+}
+
+func (r *REPL) runLine(line string) error {
+
+	if line == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(line, "/") {
+		fields := strings.Fields(line)
+		cmd, ok := r.commands[strings.ToLower(fields[0])]
+		if !ok {
+			return fmt.Errorf("unknown command %s, type /help to list commands", fields[0])
+		}
+		return cmd.run(fields[1:])
+	}
+
+	if r.dungeon == nil {
+		return fmt.Errorf("not in a dungeon, type /help to list commands")
+	}
+
+	return r.action(line)
+}
+
+func (r *REPL) prompt() string {
+	if r.character == nil {
+		return "> "
+	}
+	if r.dungeon == nil {
+		return fmt.Sprintf("[%s] > ", r.character.Name)
+	}
+	return fmt.Sprintf("[%s@%s] > ", r.character.Name, r.dungeon.Name)
+}
+
+func (r *REPL) printf(format string, args ...interface{}) {
+	fmt.Fprintf(r.Out, format, args...)
+}
+
+func (r *REPL) printError(err error) {
+	msg := "Error: " + client.ErrorMessage(err)
+	if r.Options.ANSI {
+		msg = ansiRed + msg + ansiReset
+	}
+	r.printf("%s\n", msg)
+}
+
+func (r *REPL) help(args []string) error {
+
+	names := []string{}
+	for name := range r.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cmd := r.commands[name]
+		r.printf("  %-50s %s\n", cmd.usage, cmd.description)
+	}
+	r.printf("Any other line is a sentence for the selected character, for example \"look\" or \"move north\".\n")
+
+	return nil
+}
+
+func (r *REPL) register(args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage %s", r.commands["/register"].usage)
+	}
+
+	email, err := r.argOrPrompt(args[1:], "Email: ")
+	if err != nil {
+		return err
+	}
+	password, err := r.Editor.ReadPassword("Password: ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Client.Register(args[0], email, password); err != nil {
+		return err
+	}
+
+	return r.Login(email, password)
+}
+
+func (r *REPL) login(args []string) error {
+
+	email, err := r.argOrPrompt(args, "Email: ")
+	if err != nil {
+		return err
+	}
+	password, err := r.Editor.ReadPassword("Password: ")
+	if err != nil {
+		return err
+	}
+
+	return r.Login(email, password)
+}
+
+// Login logs in to the account and lists its characters
+func (r *REPL) Login(email, password string) error {
+
+	if _, err := r.Client.Login(email, password); err != nil {
+		return err
+	}
+
+	r.character = nil
+	r.dungeon = nil
+	r.printf("Logged in as %s.\n", email)
+
+	return r.listCharacters(nil)
+}
+
+func (r *REPL) argOrPrompt(args []string, prompt string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	return r.Editor.ReadLine(prompt)
+}
+
+func (r *REPL) listDungeons(args []string) error {
+
+	dungeons, err := r.Client.Dungeons()
+	if err != nil {
+		return err
+	}
+	r.dungeons = dungeons
+
+	if len(dungeons) == 0 {
+		r.printf("There are no dungeons.\n")
+		return nil
+	}
+
+	for idx, d := range dungeons {
+		r.printf("  %d. %s - %s\n", idx+1, d.Name, d.Description)
+	}
+
+	return nil
+}
+
+func (r *REPL) listCharacters(args []string) error {
+
+	characters, err := r.Client.Characters()
+	if err != nil {
+		return err
+	}
+	r.characters = characters
+
+	if len(characters) == 0 {
+		r.printf("You have no characters, create one with %s.\n", r.commands["/create"].usage)
+		return nil
+	}
+
+	for idx, c := range characters {
+		where := ""
+		if c.Dungeon != nil {
+			where = " in " + c.Dungeon.Name
+		}
+		r.printf("  %d. %s, health %d/%d%s\n", idx+1, c.Name, c.CurrentHealth, c.Health, where)
+	}
+
+	return nil
+}
+
+func (r *REPL) createCharacter(args []string) error {
+
+	if len(args) != 1 && len(args) != 4 {
+		return fmt.Errorf("usage %s", r.commands["/create"].usage)
+	}
+
+	attributes := []int{defaultAttribute, defaultAttribute, defaultAttribute}
+	for idx, arg := range args[1:] {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("attribute %q is not a number", arg)
+		}
+		attributes[idx] = value
+	}
+
+	c, err := r.Client.CreateCharacter(args[0], attributes[0], attributes[1], attributes[2])
+	if err != nil {
+		return err
+	}
+
+	r.characters = append(r.characters, *c)
+
+	return r.useCharacter(c)
+}
+
+func (r *REPL) selectCharacter(args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage %s", r.commands["/select"].usage)
+	}
+
+	if len(r.characters) == 0 {
+		if err := r.listCharacters(nil); err != nil {
+			return err
+		}
+	}
+
+	names := []string{}
+	for idx := range r.characters {
+		names = append(names, r.characters[idx].Name)
+	}
+
+	idx, err := choose(names, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	c := r.characters[idx]
+
+	return r.useCharacter(&c)
+}
+
+// useCharacter selects the character and looks around when the character is in a
+// dungeon
+func (r *REPL) useCharacter(c *schema.DungeonCharacterData) error {
+
+	r.character = c
+	r.dungeon = c.Dungeon
+	r.location = schema.ActionLocation{}
+	r.inventory = nil
+
+	if r.dungeon == nil {
+		r.printf("Selected %s, enter a dungeon with %s.\n", c.Name, r.commands["/enter"].usage)
+		return nil
+	}
+
+	r.printf("Selected %s in %s.\n", c.Name, r.dungeon.Name)
+
+	return r.action(locale.Verb(r.Options.Language, record.ActionCommandLook))
+}
+
+func (r *REPL) enterDungeon(args []string) error {
+
+	if r.character == nil {
+		return fmt.Errorf("no character selected, select one with %s", r.commands["/select"].usage)
+	}
+	if r.dungeon != nil {
+		return fmt.Errorf("%s is already in %s, exit with /exit", r.character.Name, r.dungeon.Name)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("usage %s", r.commands["/enter"].usage)
+	}
+
+	if len(r.dungeons) == 0 {
+		dungeons, err := r.Client.Dungeons()
+		if err != nil {
+			return err
+		}
+		r.dungeons = dungeons
+	}
+
+	names := []string{}
+	for idx := range r.dungeons {
+		names = append(names, r.dungeons[idx].Name)
+	}
+
+	idx, err := choose(names, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	c, err := r.Client.EnterDungeon(r.dungeons[idx].ID, r.character.ID)
+	if err != nil {
+		return err
+	}
+
+	if c.Dungeon == nil {
+		c.Dungeon = &schema.DungeonCharacterDungeonData{
+			ID:   r.dungeons[idx].ID,
+			Name: r.dungeons[idx].Name,
+		}
+	}
+
+	return r.useCharacter(c)
+}
+
+func (r *REPL) exitDungeon(args []string) error {
+
+	if r.character == nil || r.dungeon == nil {
+		return fmt.Errorf("no character in a dungeon")
+	}
+
+	if _, err := r.Client.ExitDungeon(r.dungeon.ID, r.character.ID); err != nil {
+		return err
+	}
+
+	r.printf("%s has left %s.\n", r.character.Name, r.dungeon.Name)
+
+	r.character.Dungeon = nil
+	r.dungeon = nil
+	r.location = schema.ActionLocation{}
+	r.inventory = nil
+
+	return nil
+}
+
+// action submits the sentence and renders the actions that have occurred
+// since the character's previous action
+func (r *REPL) action(sentence string) error {
+
+	data, err := r.Client.Action(r.dungeon.ID, r.character.ID, sentence)
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		last := data[len(data)-1]
+		r.location = last.Location
+		if last.Character != nil && last.Character.Name == r.character.Name {
+			r.inventory = nil
+			for _, o := range last.Character.EquippedObjects {
+				r.inventory = append(r.inventory, o.Name)
+			}
+			for _, o := range last.Character.StashedObjects {
+				r.inventory = append(r.inventory, o.Name)
+			}
+		}
+	}
+
+	return terminal.Render(r.Out, data, r.Options)
+}
+
+// complete returns the candidates the text may be completed with, command
+// names and their arguments for client commands and verbs, exits and the
+// names of what the character can see or carries for sentences
+func (r *REPL) complete(text string) []string {
+
+	if strings.HasPrefix(text, "/") {
+		fields := strings.Fields(text)
+		if len(fields) <= 1 && !strings.HasSuffix(text, " ") {
+			names := []string{}
+			for name := range r.commands {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return names
+		}
+
+		names := []string{}
+		switch strings.ToLower(fields[0]) {
+		case "/select":
+			for _, c := range r.characters {
+				names = append(names, c.Name)
+			}
+		case "/enter":
+			for _, d := range r.dungeons {
+				names = append(names, d.Name)
+			}
+		}
+		return names
+	}
+
+	candidates := []string{}
+	if len(strings.Fields(text)) <= 1 && !strings.HasSuffix(text, " ") {
+		for _, verb := range playerVerbs {
+			candidates = append(candidates, locale.Verb(r.Options.Language, verb))
+		}
+	}
+
+	for _, direction := range r.location.Directions {
+		candidates = append(candidates, locale.Direction(r.Options.Language, direction))
+	}
+	for _, c := range r.location.Characters {
+		if r.character != nil && c.Name == r.character.Name {
+			continue
+		}
+		candidates = append(candidates, c.Name)
+	}
+	for _, m := range r.location.Monsters {
+		candidates = append(candidates, m.Name)
+	}
+	for _, o := range r.location.Objects {
+		candidates = append(candidates, o.Name)
+	}
+
+	return append(candidates, r.inventory...)
+}
+
+// choose returns the index of the name chosen by its number from one or by
+// its name ignoring case
+func choose(names []string, choice string) (int, error) {
+
+	if n, err := strconv.Atoi(choice); err == nil {
+		if n < 1 || n > len(names) {
+			return 0, fmt.Errorf("choose a number from 1 to %d", len(names))
+		}
+		return n - 1, nil
+	}
+
+	for idx, name := range names {
+		if strings.EqualFold(name, choice) {
+			return idx, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%q not found", choice)
+}
@@ -0,0 +1,194 @@
+package repl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/log"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/client"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/terminal"
+)
+
+const (
+	testDungeonID   string = "2d3a5c50-34a4-4b73-9d0a-7e2b7d6e3d8f"
+	testCharacterID string = "8b3f4c5a-9e0e-4f59-9d8d-4e2b6d7b1a5c"
+	testToken       string = "token"
+)
+
+// api is a game server handling the requests the REPL makes
+func api(t *testing.T, sentences *[]string) *httptest.Server {
+
+	write := func(w http.ResponseWriter, status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		require.NoError(t, json.NewEncoder(w).Encode(v), "Encode returns without error")
+	}
+
+	character := schema.DungeonCharacterData{
+		ID:            testCharacterID,
+		Name:          "Barricade",
+		Health:        20,
+		CurrentHealth: 20,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		req := schema.LoginRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req), "Decode returns without error")
+		if req.Data.Password != "secret" {
+			write(w, http.StatusUnauthorized, []coreerror.Error{
+				{ErrorCode: coreerror.Unauthenticated, Message: "account email or password is incorrect"},
+			})
+			return
+		}
+		write(w, http.StatusOK, schema.LoginResponse{Data: []schema.LoginData{{Token: testToken}}})
+	})
+	mux.HandleFunc("/api/v1/characters", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer "+testToken, r.Header.Get("Authorization"), "Request is authenticated")
+		write(w, http.StatusOK, schema.CharacterResponse{Data: []schema.DungeonCharacterData{character}})
+	})
+	mux.HandleFunc("/api/v1/dungeons", func(w http.ResponseWriter, r *http.Request) {
+		write(w, http.StatusOK, schema.DungeonResponse{Data: []schema.DungeonData{
+			{ID: testDungeonID, Name: "Cave", Description: "A dark cave."},
+		}})
+	})
+	mux.HandleFunc("/api/v1/dungeons/"+testDungeonID+"/characters/"+testCharacterID+"/enter", func(w http.ResponseWriter, r *http.Request) {
+		c := character
+		c.Dungeon = &schema.DungeonCharacterDungeonData{ID: testDungeonID, Name: "Cave"}
+		write(w, http.StatusOK, schema.DungeonCharacterResponse{Data: []schema.DungeonCharacterData{c}})
+	})
+	mux.HandleFunc("/api/v1/dungeons/"+testDungeonID+"/characters/"+testCharacterID+"/exit", func(w http.ResponseWriter, r *http.Request) {
+		write(w, http.StatusOK, schema.DungeonCharacterResponse{Data: []schema.DungeonCharacterData{character}})
+	})
+	mux.HandleFunc("/api/v1/dungeons/"+testDungeonID+"/characters/"+testCharacterID+"/actions", func(w http.ResponseWriter, r *http.Request) {
+		req := schema.ActionRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req), "Decode returns without error")
+		*sentences = append(*sentences, req.Data.Sentence)
+
+		if req.Data.Sentence == "dance" {
+			write(w, http.StatusBadRequest, []coreerror.Error{
+				{ErrorCode: coreerror.InvalidAction, Message: "command empty or not recognised"},
+			})
+			return
+		}
+
+		write(w, http.StatusOK, schema.ActionResponse{Data: []schema.ActionResponseData{
+			{
+				Command:   record.ActionCommandLook,
+				Narrative: "You look around.",
+				Location: schema.ActionLocation{
+					Name:       "Cave Entrance",
+					Directions: []string{"north"},
+					Characters: schema.ActionLocationCharacters{{Name: "Barricade"}},
+					Monsters:   schema.ActionLocationMonsters{{Name: "Angry Goblin"}},
+				},
+				Character: &schema.ActionCharacter{
+					Name:           "Barricade",
+					StashedObjects: []schema.ActionObject{{Name: "Silver Key"}},
+				},
+			},
+		}})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newTestREPL(t *testing.T, host, in string) (*REPL, *strings.Builder) {
+
+	l, err := log.NewLoggerWithConfig(log.Config{Level: "error"})
+	require.NoError(t, err, "NewLoggerWithConfig returns without error")
+
+	c, err := client.NewClient(l, host)
+	require.NoError(t, err, "NewClient returns without error")
+
+	out := &strings.Builder{}
+	e := NewLineEditor(strings.NewReader(in), out)
+
+	return NewREPL(c, e, out, terminal.Options{}), out
+}
+
+func TestREPL(t *testing.T) {
+
+	sentences := []string{}
+	server := api(t, &sentences)
+	defer server.Close()
+
+	in := strings.Join([]string{
+		"look",
+		"/login barricade@example.com",
+		"wrong",
+		"/login barricade@example.com",
+		"secret",
+		"/dungeons",
+		"/select barricade",
+		"/enter 1",
+		"dance",
+		"/exit",
+		"/quit",
+		"look",
+	}, "\n")
+
+	r, out := newTestREPL(t, server.URL, in)
+
+	err := r.Run()
+	require.NoError(t, err, "Run returns without error")
+
+	require.Equal(t, []string{"look", "dance"}, sentences, "Sentences in a dungeon are submitted")
+
+	for _, expect := range []string{
+		"Error: not in a dungeon",
+		"Error: account email or password is incorrect",
+		"Logged in as barricade@example.com.",
+		"1. Barricade, health 20/20",
+		"1. Cave - A dark cave.",
+		"Selected Barricade, enter a dungeon",
+		"[Barricade] > ",
+		"Selected Barricade in Cave.",
+		"You look around.",
+		"Cave Entrance",
+		"Exits: north",
+		"Monsters: Angry Goblin",
+		"[Barricade@Cave] > ",
+		"Error: command empty or not recognised",
+		"Barricade has left Cave.",
+	} {
+		require.Contains(t, out.String(), expect, "Output contains >%s<", expect)
+	}
+}
+
+func TestREPLComplete(t *testing.T) {
+
+	sentences := []string{}
+	server := api(t, &sentences)
+	defer server.Close()
+
+	r, _ := newTestREPL(t, server.URL, "")
+
+	require.NoError(t, r.Login("barricade@example.com", "secret"), "Login returns without error")
+	require.NoError(t, r.runLine("/dungeons"), "Listing dungeons returns without error")
+
+	require.Contains(t, r.complete("/en"), "/enter", "Commands are completed")
+	require.Equal(t, []string{"Cave"}, r.complete("/enter "), "Dungeons are completed")
+	require.Equal(t, []string{"Barricade"}, r.complete("/select "), "Characters are completed")
+
+	require.NoError(t, r.runLine("/select 1"), "Selecting returns without error")
+	require.NoError(t, r.runLine("/enter Cave"), "Entering returns without error")
+
+	candidates := r.complete("")
+	require.Contains(t, candidates, "attack", "Verbs are completed")
+	require.Contains(t, candidates, "north", "Exits are completed")
+	require.Contains(t, candidates, "Angry Goblin", "Monsters are completed")
+	require.Contains(t, candidates, "Silver Key", "Stashed objects are completed")
+	require.NotContains(t, candidates, "Barricade", "The character itself is not completed")
+
+	candidates = r.complete("attack ")
+	require.NotContains(t, candidates, "attack", "Verbs are only completed at the start")
+}
@@ -0,0 +1,190 @@
+package dungeoninstancelease
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/core/tag"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/preparer"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/repositor"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	// TableName - underlying database table name used for configuration
+	TableName string = "dungeon_instance_lease"
+)
+
+// Repository -
+type Repository struct {
+	repository.Repository
+}
+
+var _ repositor.Repositor = &Repository{}
+
+// NewRepository -
+func NewRepository(l logger.Logger, p preparer.Repository, tx *sqlx.Tx) (*Repository, error) {
+
+	r := &Repository{
+		repository.Repository{
+			Log:     l,
+			Prepare: p,
+			Tx:      tx,
+
+			// Config
+			Config: repository.Config{
+				TableName:   TableName,
+				Attributes:  tag.GetFieldTagValues(record.DungeonInstanceLease{}, "db"),
+				ArrayFields: tag.GetArrayFieldTagValues(record.DungeonInstanceLease{}, "db"),
+			},
+		},
+	}
+
+	err := r.Init()
+	if err != nil {
+		l.Warn("failed new repository >%v<", err)
+		return nil, err
+	}
+
+	// prepare
+	err = p.Prepare(r, preparer.ExcludePreparation{})
+	if err != nil {
+		l.Warn("failed preparing repository >%v<", err)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewRecord -
+func (r *Repository) NewRecord() *record.DungeonInstanceLease {
+	return &record.DungeonInstanceLease{}
+}
+
+// NewRecordArray -
+func (r *Repository) NewRecordArray() []*record.DungeonInstanceLease {
+	return []*record.DungeonInstanceLease{}
+}
+
+// GetOne -
+func (r *Repository) GetOne(id string, lock *coresql.Lock) (*record.DungeonInstanceLease, error) {
+	rec := r.NewRecord()
+	if err := r.GetOneRec(id, rec, lock); err != nil {
+		r.Log.Warn("failed statement execution >%v<", err)
+		return nil, err
+	}
+	return rec, nil
+}
+
+// GetMany -
+func (r *Repository) GetMany(opts *coresql.Options) ([]*record.DungeonInstanceLease, error) {
+
+	recs := r.NewRecordArray()
+
+	rows, err := r.GetManyRecs(opts)
+	if err != nil {
+		r.Log.Warn("failed statement execution >%v<", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rec := r.NewRecord()
+		err := rows.StructScan(rec)
+		if err != nil {
+			r.Log.Warn("failed executing struct scan >%v<", err)
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+
+	r.Log.Debug("fetched >%d< records", len(recs))
+
+	return recs, nil
+}
+
+// CreateOne -
+func (r *Repository) CreateOne(rec *record.DungeonInstanceLease) error {
+
+	if rec.ID == "" {
+		rec.ID = repository.NewRecordID()
+	}
+	rec.CreatedAt = repository.NewRecordTimestamp()
+
+	err := r.CreateOneRec(rec)
+	if err != nil {
+		rec.CreatedAt = time.Time{}
+		r.Log.Warn("failed statement execution >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateOne -
+func (r *Repository) UpdateOne(rec *record.DungeonInstanceLease) error {
+
+	origUpdatedAt := rec.UpdatedAt
+	rec.UpdatedAt = repository.NewRecordNullTimestamp()
+
+	err := r.UpdateOneRec(rec)
+	if err != nil {
+		rec.UpdatedAt = origUpdatedAt
+		r.Log.Warn("failed statement execution >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// RenewDaemonLeases extends the expiry of every lease held by the daemon and
+// returns the renewed records. Rows are matched on the daemon rather than read
+// and locked first, so renewing is never skipped because another daemon is
+// claiming unrelated leases.
+func (r *Repository) RenewDaemonLeases(daemonID string, expiresAt time.Time) ([]*record.DungeonInstanceLease, error) {
+
+	querySQL := fmt.Sprintf(`
+UPDATE %s SET
+expires_at = :expires_at,
+updated_at = :updated_at
+WHERE daemon_id = :daemon_id
+AND   deleted_at IS NULL
+RETURNING %s
+`,
+		r.TableName(),
+		strings.Join(r.Attributes(), ", "))
+
+	params := map[string]any{
+		"daemon_id":  daemonID,
+		"expires_at": expiresAt,
+		"updated_at": repository.NewRecordNullTimestamp(),
+	}
+
+	rows, err := r.Tx.NamedQuery(querySQL, params)
+	if err != nil {
+		r.Log.Warn("failed statement execution >%v<", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recs := r.NewRecordArray()
+	for rows.Next() {
+		rec := r.NewRecord()
+		err := rows.StructScan(rec)
+		if err != nil {
+			r.Log.Warn("failed executing struct scan >%v<", err)
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+
+	r.Log.Debug("renewed >%d< records", len(recs))
+
+	return recs, nil
+}
@@ -0,0 +1,303 @@
+package test
+
+// NOTE: repository tests are run is the public space so we are
+// able to use common setup and teardown tooling for all repositories
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestCreateOne(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "Default dependencies returns without error")
+
+	h, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	tests := []struct {
+		name           string
+		deleteExisting bool
+		rec            func(data harness.Data) *record.DungeonInstanceLease
+		err            bool
+	}{
+		{
+			name:           "Without ID",
+			deleteExisting: true,
+			rec: func(data harness.Data) *record.DungeonInstanceLease {
+				return &record.DungeonInstanceLease{
+					DungeonInstanceID: data.DungeonInstanceRecs[0].ID,
+				}
+			},
+			err: false,
+		},
+		{
+			name:           "With ID",
+			deleteExisting: true,
+			rec: func(data harness.Data) *record.DungeonInstanceLease {
+				rec := &record.DungeonInstanceLease{
+					DungeonInstanceID: data.DungeonInstanceRecs[0].ID,
+				}
+				id, _ := uuid.NewRandom()
+				rec.ID = id.String()
+				return rec
+			},
+			err: false,
+		},
+		{
+			name:           "With existing dungeon instance lease",
+			deleteExisting: false,
+			rec: func(data harness.Data) *record.DungeonInstanceLease {
+				return &record.DungeonInstanceLease{
+					DungeonInstanceID: data.DungeonInstanceRecs[0].ID,
+				}
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Logf("Run test >%s<", tc.name)
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			// Test harness
+			_, err = h.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = h.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// repository
+			r := h.Model.(*model.Model).DungeonInstanceLeaseRepository()
+			require.NotNil(t, r, "Repository is not nil")
+
+			// A dungeon instance may only have a single current lease
+			if tc.deleteExisting {
+				for _, dilRec := range h.Data.DungeonInstanceLeaseRecs {
+					if dilRec.DungeonInstanceID == h.Data.DungeonInstanceRecs[0].ID {
+						err = r.DeleteOne(dilRec.ID)
+						require.NoError(t, err, "DeleteOne returns without error")
+					}
+				}
+			}
+
+			rec := tc.rec(h.Data)
+
+			err = r.CreateOne(rec)
+			if tc.err == true {
+				require.Error(t, err, "CreateOne returns error")
+				return
+			}
+			require.NoError(t, err, "CreateOne returns without error")
+			require.NotEmpty(t, rec.CreatedAt, "CreateOne returns record with CreatedAt")
+		})
+	}
+}
+
+func TestGetOne(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "Default dependencies returns without error")
+
+	h, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	tests := []struct {
+		name string
+		id   func() string
+		err  bool
+	}{
+		{
+			name: "With ID",
+			id: func() string {
+				return h.Data.DungeonInstanceLeaseRecs[0].ID
+			},
+			err: false,
+		},
+		{
+			name: "Without ID",
+			id: func() string {
+				return ""
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Logf("Run test >%s<", tc.name)
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			// harness setup
+			_, err = h.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = h.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// repository
+			r := h.Model.(*model.Model).DungeonInstanceLeaseRepository()
+			require.NotNil(t, r, "Repository is not nil")
+
+			rec, err := r.GetOne(tc.id(), nil)
+			if tc.err == true {
+				require.Error(t, err, "GetOne returns error")
+				return
+			}
+			require.NoError(t, err, "GetOne returns without error")
+			require.NotNil(t, rec, "GetOne returns record")
+			require.NotEmpty(t, rec.ID, "Record ID is not empty")
+		})
+	}
+}
+
+func TestUpdateOne(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "Default dependencies returns without error")
+
+	h, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	require.NoError(t, err, "NewTesting returns without error")
+
+	tests := []struct {
+		name string
+		rec  func() *record.DungeonInstanceLease
+		err  bool
+	}{
+		{
+			name: "With ID",
+			rec: func() *record.DungeonInstanceLease {
+				rec := *h.Data.DungeonInstanceLeaseRecs[0]
+				return &rec
+			},
+			err: false,
+		},
+		{
+			name: "Without ID",
+			rec: func() *record.DungeonInstanceLease {
+				rec := *h.Data.DungeonInstanceLeaseRecs[0]
+				rec.ID = ""
+				return &rec
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Logf("Run test >%s<", tc.name)
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			// harness setup
+			_, err = h.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = h.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// repository
+			r := h.Model.(*model.Model).DungeonInstanceLeaseRepository()
+			require.NotNil(t, r, "Repository is not nil")
+
+			rec := tc.rec()
+
+			err := r.UpdateOne(rec)
+			if tc.err == true {
+				require.Error(t, err, "UpdateOne returns error")
+				return
+			}
+			require.NoError(t, err, "UpdateOne returns without error")
+			require.NotEmpty(t, rec.UpdatedAt, "UpdateOne returns record with UpdatedAt")
+		})
+	}
+}
+
+func TestDeleteOne(t *testing.T) {
+
+	// harness
+	config := harness.DefaultDataConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(t, err, "Default dependencies returns without error")
+
+	h, err := harness.NewTesting(c, l, s, config)
+	require.NoError(t, err, "NewTesting returns without error")
+
+	tests := []struct {
+		name string
+		id   func() string
+		err  bool
+	}{
+		{
+			name: "With ID",
+			id: func() string {
+				return h.Data.DungeonInstanceLeaseRecs[0].ID
+			},
+			err: false,
+		},
+		{
+			name: "Without ID",
+			id: func() string {
+				return ""
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+
+		t.Logf("Run test >%s<", tc.name)
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			// harness setup
+			_, err = h.Setup()
+			require.NoError(t, err, "Setup returns without error")
+			defer func() {
+				err = h.Teardown()
+				require.NoError(t, err, "Teardown returns without error")
+			}()
+
+			// repository
+			r := h.Model.(*model.Model).DungeonInstanceLeaseRepository()
+			require.NotNil(t, r, "Repository is not nil")
+
+			err := r.DeleteOne(tc.id())
+			if tc.err == true {
+				require.Error(t, err, "DeleteOne returns error")
+				return
+			}
+			require.NoError(t, err, "DeleteOne returns without error")
+
+			rec, err := r.GetOne(tc.id(), nil)
+			require.Error(t, err, "GetOne returns error")
+			require.Nil(t, rec, "GetOne does not return record")
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package translation
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/core/tag"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/preparer"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/repositor"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	// TableName - underlying database table name used for configuration
+	TableName string = "translation"
+)
+
+// Repository -
+type Repository struct {
+	repository.Repository
+}
+
+var _ repositor.Repositor = &Repository{}
+
+// NewRepository -
+func NewRepository(l logger.Logger, p preparer.Repository, tx *sqlx.Tx) (*Repository, error) {
+
+	r := &Repository{
+		repository.Repository{
+			Log:     l,
+			Prepare: p,
+			Tx:      tx,
+
+			// Config
+			Config: repository.Config{
+				TableName:   TableName,
+				Attributes:  tag.GetFieldTagValues(record.Translation{}, "db"),
+				ArrayFields: tag.GetArrayFieldTagValues(record.Translation{}, "db"),
+			},
+		},
+	}
+
+	err := r.Init()
+	if err != nil {
+		l.Warn("failed new repository >%v<", err)
+		return nil, err
+	}
+
+	// prepare
+	err = p.Prepare(r, preparer.ExcludePreparation{})
+	if err != nil {
+		l.Warn("failed preparing repository >%v<", err)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// NewRecord -
+func (r *Repository) NewRecord() *record.Translation {
+	return &record.Translation{}
+}
+
+// NewRecordArray -
+func (r *Repository) NewRecordArray() []*record.Translation {
+	return []*record.Translation{}
+}
+
+// GetOne -
+func (r *Repository) GetOne(id string, lock *coresql.Lock) (*record.Translation, error) {
+	rec := r.NewRecord()
+	if err := r.GetOneRec(id, rec, lock); err != nil {
+		r.Log.Warn("failed statement execution >%v<", err)
+		return nil, err
+	}
+	return rec, nil
+}
+
+// GetMany -
+func (r *Repository) GetMany(opts *coresql.Options) ([]*record.Translation, error) {
+
+	recs := r.NewRecordArray()
+
+	rows, err := r.GetManyRecs(opts)
+	if err != nil {
+		r.Log.Warn("failed statement execution >%v<", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rec := r.NewRecord()
+		err := rows.StructScan(rec)
+		if err != nil {
+			r.Log.Warn("failed executing struct scan >%v<", err)
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+
+	r.Log.Debug("fetched >%d< records", len(recs))
+
+	return recs, nil
+}
+
+// CreateOne -
+func (r *Repository) CreateOne(rec *record.Translation) error {
+
+	if rec.ID == "" {
+		rec.ID = repository.NewRecordID()
+	}
+	rec.CreatedAt = repository.NewRecordTimestamp()
+
+	err := r.CreateOneRec(rec)
+	if err != nil {
+		rec.CreatedAt = time.Time{}
+		r.Log.Warn("failed statement execution >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateOne -
+func (r *Repository) UpdateOne(rec *record.Translation) error {
+
+	origUpdatedAt := rec.UpdatedAt
+	rec.UpdatedAt = repository.NewRecordNullTimestamp()
+
+	err := r.UpdateOneRec(rec)
+	if err != nil {
+		rec.UpdatedAt = origUpdatedAt
+		r.Log.Warn("failed statement execution >%v<", err)
+		return err
+	}
+
+	return nil
+}
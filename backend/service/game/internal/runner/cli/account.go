@@ -0,0 +1,41 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// AssignAccountRole assigns a role to the account with the provided email.
+// Assigning the first admin role is only possible from the command line.
+func (rnr *Runner) AssignAccountRole(c *cli.Context) error {
+
+	rnr.Log.Info("** Assign Account Role **")
+
+	email := c.String("email")
+	role := c.String("role")
+
+	rec, err := rnr.Model.(*model.Model).GetAccountRecByEmail(email)
+	if err != nil {
+		rnr.Log.Warn("Failed getting account record >%v<", err)
+		return err
+	}
+
+	if rec == nil {
+		err := fmt.Errorf("account with email >%s< does not exist", email)
+		rnr.Log.Warn(err.Error())
+		return err
+	}
+
+	err = rnr.Model.(*model.Model).AssignAccountRole(rec, role)
+	if err != nil {
+		rnr.Log.Warn("Failed assigning account role >%v<", err)
+		return err
+	}
+
+	rnr.Log.Info("Assigned account ID >%s< role >%s<", rec.ID, rec.Role)
+
+	return nil
+}
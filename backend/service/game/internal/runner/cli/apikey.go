@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// IssueAPIKey issues an API key and writes the key to standard output. The key
+// is not stored and cannot be shown again.
+func (rnr *Runner) IssueAPIKey(c *cli.Context) error {
+
+	rnr.Log.Info("** Issue API Key **")
+
+	args := &model.IssueAPIKeyArgs{
+		Owner:  c.String("owner"),
+		Scopes: c.StringSlice("scope"),
+	}
+
+	if email := c.String("account-email"); email != "" {
+		accountRec, err := rnr.Model.(*model.Model).GetAccountRecByEmail(email)
+		if err != nil {
+			rnr.Log.Warn("Failed getting account record >%v<", err)
+			return err
+		}
+		if accountRec == nil {
+			err := fmt.Errorf("account with email >%s< does not exist", email)
+			rnr.Log.Warn(err.Error())
+			return err
+		}
+		args.AccountID = accountRec.ID
+	}
+
+	if expiresIn := c.Duration("expires-in"); expiresIn > 0 {
+		args.ExpiresAt = time.Now().UTC().Add(expiresIn)
+	}
+
+	key, rec, err := rnr.Model.(*model.Model).IssueAPIKey(args)
+	if err != nil {
+		rnr.Log.Warn("Failed issuing API key >%v<", err)
+		return err
+	}
+
+	rnr.Log.Info("Issued API key ID >%s< prefix >%s< owner >%s<", rec.ID, rec.KeyPrefix, rec.Owner)
+
+	fmt.Println(key)
+
+	return nil
+}
+
+// RevokeAPIKey revokes an API key so it no longer authenticates requests
+func (rnr *Runner) RevokeAPIKey(c *cli.Context) error {
+
+	rnr.Log.Info("** Revoke API Key **")
+
+	rec, err := rnr.Model.(*model.Model).RevokeAPIKey(c.String("id"))
+	if err != nil {
+		rnr.Log.Warn("Failed revoking API key >%v<", err)
+		return err
+	}
+
+	rnr.Log.Info("Revoked API key ID >%s< prefix >%s< owner >%s<", rec.ID, rec.KeyPrefix, rec.Owner)
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// ImportDungeon creates a dungeon from a YAML or JSON dungeon definition file.
+func (rnr *Runner) ImportDungeon(c *cli.Context) error {
+
+	rnr.Log.Info("** Import Dungeon **")
+
+	filename := c.Args().First()
+	if filename == "" {
+		return fmt.Errorf("dungeon definition file is required")
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		rnr.Log.Warn("Failed reading dungeon definition file >%s< >%v<", filename, err)
+		return err
+	}
+
+	d, err := model.UnmarshalDungeonDefinition(data)
+	if err != nil {
+		rnr.Log.Warn("Failed unmarshalling dungeon definition >%v<", err)
+		return err
+	}
+
+	rec, err := rnr.Model.(*model.Model).ImportDungeonDefinition(d)
+	if err != nil {
+		rnr.Log.Warn("Failed importing dungeon definition >%v<", err)
+		return err
+	}
+
+	rnr.Log.Info("Imported dungeon >%s< from >%s< as dungeon ID >%s<", rec.Name, filename, rec.ID)
+
+	fmt.Println(rec.ID)
+
+	return nil
+}
+
+// ExportDungeon writes the definition of a dungeon to a file, or to standard
+// output when no file is provided.
+func (rnr *Runner) ExportDungeon(c *cli.Context) error {
+
+	rnr.Log.Info("** Export Dungeon **")
+
+	dungeonName := c.Args().First()
+	if dungeonName == "" {
+		return fmt.Errorf("dungeon name is required")
+	}
+
+	filename := c.String("file")
+
+	format := c.String("format")
+	if format == "" {
+		format = model.DungeonDefinitionFormatYAML
+		if strings.EqualFold(filepath.Ext(filename), ".json") {
+			format = model.DungeonDefinitionFormatJSON
+		}
+	}
+
+	d, err := rnr.Model.(*model.Model).ExportDungeonDefinition(dungeonName)
+	if err != nil {
+		rnr.Log.Warn("Failed exporting dungeon definition >%v<", err)
+		return err
+	}
+
+	data, err := model.MarshalDungeonDefinition(d, format)
+	if err != nil {
+		rnr.Log.Warn("Failed marshalling dungeon definition >%v<", err)
+		return err
+	}
+
+	if filename == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	err = os.WriteFile(filename, data, 0644)
+	if err != nil {
+		rnr.Log.Warn("Failed writing dungeon definition file >%s< >%v<", filename, err)
+		return err
+	}
+
+	rnr.Log.Info("Wrote dungeon >%s< definition to >%s<", dungeonName, filename)
+
+	return nil
+}
+
+// LintDungeon writes every issue found with a dungeon definition file or a
+// dungeon, failing when any issue is an error.
+func (rnr *Runner) LintDungeon(c *cli.Context) error {
+
+	rnr.Log.Info("** Lint Dungeon **")
+
+	filename := c.String("file")
+	dungeonName := c.String("name")
+
+	var d *model.DungeonDefinition
+	var err error
+
+	switch {
+	case filename != "":
+		var data []byte
+		data, err = os.ReadFile(filename)
+		if err != nil {
+			rnr.Log.Warn("Failed reading dungeon definition file >%s< >%v<", filename, err)
+			return err
+		}
+
+		d, err = model.UnmarshalDungeonDefinition(data)
+		if err != nil {
+			rnr.Log.Warn("Failed unmarshalling dungeon definition >%v<", err)
+			return err
+		}
+	case dungeonName != "":
+		d, err = rnr.Model.(*model.Model).ExportDungeonDefinition(dungeonName)
+		if err != nil {
+			rnr.Log.Warn("Failed exporting dungeon definition >%v<", err)
+			return err
+		}
+	default:
+		return fmt.Errorf("one of a dungeon definition file or dungeon name is required")
+	}
+
+	issues := d.Lint()
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+
+	if model.DungeonIssuesHaveErrors(issues) {
+		return fmt.Errorf("dungeon >%s< has errors", d.Name)
+	}
+
+	rnr.Log.Info("Dungeon >%s< has >%d< issues and no errors", d.Name, len(issues))
+
+	return nil
+}
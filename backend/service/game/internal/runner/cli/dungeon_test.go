@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+func Test_dungeonDefinitionFiles(t *testing.T) {
+
+	config := SeedDataConfig()
+
+	tests := []struct {
+		dungeonName string
+		filename    string
+	}{
+		{
+			dungeonName: "Cave",
+			filename:    "../../data/cave/cave.yaml",
+		},
+		{
+			dungeonName: "Cabin",
+			filename:    "../../data/cabin/cabin.yaml",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.dungeonName, func(t *testing.T) {
+
+			expect, err := config.DungeonDefinition(tc.dungeonName)
+			require.NoError(t, err, "DungeonDefinition returns without error")
+
+			data, err := os.ReadFile(tc.filename)
+			require.NoError(t, err, "ReadFile returns without error")
+
+			d, err := model.UnmarshalDungeonDefinition(data)
+			require.NoError(t, err, "UnmarshalDungeonDefinition returns without error")
+			require.Equal(t, expect, d, "Dungeon definition file equals seed data configuration")
+			require.False(t, model.DungeonIssuesHaveErrors(d.Lint()), "Dungeon definition has no lint errors")
+
+			expectData, err := model.MarshalDungeonDefinition(expect, model.DungeonDefinitionFormatYAML)
+			require.NoError(t, err, "MarshalDungeonDefinition returns without error")
+			require.Equal(t, string(expectData), string(data), "Dungeon definition file is in the canonical format")
+
+			// JSON definitions read back the same
+			jsonData, err := model.MarshalDungeonDefinition(d, model.DungeonDefinitionFormatJSON)
+			require.NoError(t, err, "MarshalDungeonDefinition returns without error")
+
+			jsonDefinition, err := model.UnmarshalDungeonDefinition(jsonData)
+			require.NoError(t, err, "UnmarshalDungeonDefinition returns without error")
+			require.Equal(t, d, jsonDefinition, "JSON dungeon definition equals YAML dungeon definition")
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// SnapshotInstance writes a JSON snapshot of a dungeon instance to a file, or
+// to standard output when no file is provided.
+func (rnr *Runner) SnapshotInstance(c *cli.Context) error {
+
+	rnr.Log.Info("** Snapshot Dungeon Instance **")
+
+	dungeonInstanceID := c.String("dungeon-instance-id")
+
+	s, err := rnr.Model.(*model.Model).SnapshotDungeonInstance(dungeonInstanceID)
+	if err != nil {
+		rnr.Log.Warn("Failed snapshot dungeon instance >%v<", err)
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		rnr.Log.Warn("Failed marshalling dungeon instance snapshot >%v<", err)
+		return err
+	}
+
+	filename := c.String("file")
+	if filename == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	err = os.WriteFile(filename, data, 0644)
+	if err != nil {
+		rnr.Log.Warn("Failed writing dungeon instance snapshot file >%s< >%v<", filename, err)
+		return err
+	}
+
+	rnr.Log.Info("Wrote dungeon instance ID >%s< snapshot to >%s<", dungeonInstanceID, filename)
+
+	return nil
+}
+
+// RestoreInstance restores a JSON dungeon instance snapshot into a new dungeon
+// instance.
+func (rnr *Runner) RestoreInstance(c *cli.Context) error {
+
+	rnr.Log.Info("** Restore Dungeon Instance **")
+
+	filename := c.String("file")
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		rnr.Log.Warn("Failed reading dungeon instance snapshot file >%s< >%v<", filename, err)
+		return err
+	}
+
+	s := &model.DungeonInstanceSnapshot{}
+	err = json.Unmarshal(data, s)
+	if err != nil {
+		rnr.Log.Warn("Failed unmarshalling dungeon instance snapshot >%v<", err)
+		return err
+	}
+
+	rs, err := rnr.Model.(*model.Model).RestoreDungeonInstanceSnapshot(s)
+	if err != nil {
+		rnr.Log.Warn("Failed restoring dungeon instance snapshot >%v<", err)
+		return err
+	}
+
+	rnr.Log.Info("Restored dungeon instance ID >%s< snapshot as dungeon instance ID >%s<", s.DungeonInstanceID, rs.DungeonInstanceRec.ID)
+
+	fmt.Println(rs.DungeonInstanceRec.ID)
+
+	return nil
+}
+
+// ReplayInstance replays the action log of a dungeon instance and writes a turn
+// by turn timeline to a file, or to standard output when no file is provided.
+func (rnr *Runner) ReplayInstance(c *cli.Context) error {
+
+	rnr.Log.Info("** Replay Dungeon Instance **")
+
+	format := c.String("format")
+	if format != outputFormatText && format != outputFormatJSON {
+		return fmt.Errorf("format >%s< is not one of text or json", format)
+	}
+
+	dungeonInstanceID := c.String("dungeon-instance-id")
+
+	replay, err := rnr.Model.(*model.Model).ReplayDungeonInstance(dungeonInstanceID)
+	if err != nil {
+		rnr.Log.Warn("Failed replaying dungeon instance >%v<", err)
+		return err
+	}
+
+	if len(replay.Divergences) > 0 {
+		rnr.Log.Warn("Dungeon instance ID >%s< replay diverged from the recorded state >%d< times", dungeonInstanceID, len(replay.Divergences))
+	}
+
+	buf := &bytes.Buffer{}
+	if format == outputFormatJSON {
+		data, err := json.MarshalIndent(replay, "", "  ")
+		if err != nil {
+			rnr.Log.Warn("Failed marshalling dungeon instance replay >%v<", err)
+			return err
+		}
+		buf.Write(data)
+		buf.WriteString("\n")
+	} else {
+		err = replay.WriteText(buf)
+		if err != nil {
+			rnr.Log.Warn("Failed writing dungeon instance replay >%v<", err)
+			return err
+		}
+	}
+
+	filename := c.String("file")
+	if filename == "" {
+		fmt.Print(buf.String())
+		return nil
+	}
+
+	err = os.WriteFile(filename, buf.Bytes(), 0644)
+	if err != nil {
+		rnr.Log.Warn("Failed writing dungeon instance replay file >%s< >%v<", filename, err)
+		return err
+	}
+
+	rnr.Log.Info("Wrote dungeon instance ID >%s< replay to >%s<", dungeonInstanceID, filename)
+
+	return nil
+}
+
+// CheckInstances checks the invariants of a dungeon instance, or every dungeon
+// instance when no dungeon instance is provided, and writes the violations
+// found. Violations are repaired when requested, otherwise an error is returned
+// when violations are found.
+func (rnr *Runner) CheckInstances(c *cli.Context) error {
+
+	rnr.Log.Info("** Check Dungeon Instances **")
+
+	format := c.String("format")
+	if format != outputFormatText && format != outputFormatJSON {
+		return fmt.Errorf("format >%s< is not one of text or json", format)
+	}
+
+	m := rnr.Model.(*model.Model)
+
+	dungeonInstanceIDs := []string{}
+	if id := c.String("dungeon-instance-id"); id != "" {
+		dungeonInstanceIDs = append(dungeonInstanceIDs, id)
+	} else {
+		recs, err := m.GetDungeonInstanceRecs(nil)
+		if err != nil {
+			rnr.Log.Warn("Failed getting dungeon instance records >%v<", err)
+			return err
+		}
+		for _, rec := range recs {
+			dungeonInstanceIDs = append(dungeonInstanceIDs, rec.ID)
+		}
+	}
+
+	results := []*model.DungeonInstanceConsistency{}
+	violations := 0
+	for _, id := range dungeonInstanceIDs {
+		result, err := m.CheckDungeonInstanceConsistency(&model.CheckDungeonInstanceConsistencyArgs{
+			DungeonInstanceID: id,
+			Repair:            c.Bool("repair"),
+		})
+		if err != nil {
+			rnr.Log.Warn("Failed checking dungeon instance ID >%s< consistency >%v<", id, err)
+			return err
+		}
+		for _, v := range result.Violations {
+			if !v.Repaired {
+				violations++
+			}
+		}
+		results = append(results, result)
+	}
+
+	if format == outputFormatJSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			rnr.Log.Warn("Failed marshalling dungeon instance consistency >%v<", err)
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, result := range results {
+			if len(result.Violations) == 0 {
+				continue
+			}
+			err := result.WriteText(os.Stdout)
+			if err != nil {
+				rnr.Log.Warn("Failed writing dungeon instance consistency >%v<", err)
+				return err
+			}
+		}
+		fmt.Printf("Checked >%d< dungeon instances\n", len(results))
+	}
+
+	if violations > 0 {
+		return fmt.Errorf("found >%d< dungeon instance invariant violations", violations)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/generator"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// GenerateDungeon creates a procedurally generated dungeon, or writes the
+// generated dungeon definition to a file when a file is provided.
+func (rnr *Runner) GenerateDungeon(c *cli.Context) error {
+
+	rnr.Log.Info("** Generate Dungeon **")
+
+	args := generator.Args{
+		Name:            c.String("name"),
+		Seed:            c.Int64("seed"),
+		Size:            c.Int("size"),
+		BranchingFactor: c.Int("branching-factor"),
+		Levels:          c.Int("levels"),
+		MonsterChance:   c.Int("monster-chance"),
+		ObjectChance:    c.Int("object-chance"),
+	}
+
+	if poolFilename := c.String("pool"); poolFilename != "" {
+		data, err := os.ReadFile(poolFilename)
+		if err != nil {
+			rnr.Log.Warn("Failed reading pool file >%s< >%v<", poolFilename, err)
+			return err
+		}
+
+		// YAML is a superset of JSON so either format decodes
+		err = yaml.Unmarshal(data, &args.Pool)
+		if err != nil {
+			rnr.Log.Warn("Failed unmarshalling pool >%v<", err)
+			return err
+		}
+	}
+
+	d, err := generator.Generate(args)
+	if err != nil {
+		rnr.Log.Warn("Failed generating dungeon >%v<", err)
+		return err
+	}
+
+	filename := c.String("file")
+	if filename != "" {
+		format := model.DungeonDefinitionFormatYAML
+		if strings.EqualFold(filepath.Ext(filename), ".json") {
+			format = model.DungeonDefinitionFormatJSON
+		}
+
+		data, err := model.MarshalDungeonDefinition(d, format)
+		if err != nil {
+			rnr.Log.Warn("Failed marshalling dungeon definition >%v<", err)
+			return err
+		}
+
+		err = os.WriteFile(filename, data, 0644)
+		if err != nil {
+			rnr.Log.Warn("Failed writing dungeon definition file >%s< >%v<", filename, err)
+			return err
+		}
+
+		rnr.Log.Info("Wrote generated dungeon >%s< seed >%d< definition to >%s<", d.Name, args.Seed, filename)
+
+		return nil
+	}
+
+	rec, err := rnr.Model.(*model.Model).ImportDungeonDefinition(d)
+	if err != nil {
+		rnr.Log.Warn("Failed importing generated dungeon definition >%v<", err)
+		return err
+	}
+
+	rnr.Log.Info("Generated dungeon >%s< seed >%d< as dungeon ID >%s<", rec.Name, args.Seed, rec.ID)
+
+	fmt.Println(rec.ID)
+
+	return nil
+}
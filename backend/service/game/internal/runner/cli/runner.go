@@ -9,6 +9,7 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/core/type/configurer"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/generator"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
 )
 
@@ -52,6 +53,248 @@ func NewRunner(c configurer.Configurer, l logger.Logger) (*Runner, error) {
 				Usage:   "Load production seed data",
 				Action:  r.LoadSeedData,
 			},
+			{
+				Name:   "snapshot-instance",
+				Usage:  "Write a JSON snapshot of a dungeon instance",
+				Action: r.SnapshotInstance,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "dungeon-instance-id",
+						Usage:    "Dungeon instance ID to snapshot",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "File to write the snapshot to, defaults to standard output",
+					},
+				},
+			},
+			{
+				Name:   "restore-instance",
+				Usage:  "Restore a JSON dungeon instance snapshot into a new dungeon instance",
+				Action: r.RestoreInstance,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "file",
+						Usage:    "File to read the snapshot from",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "replay-instance",
+				Usage:  "Replay the action log of a dungeon instance as a turn by turn timeline, flagging divergences from the recorded state",
+				Action: r.ReplayInstance,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "dungeon-instance-id",
+						Usage:    "Dungeon instance ID to replay",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Timeline format, one of text or json",
+						Value: outputFormatText,
+					},
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "File to write the timeline to, defaults to standard output",
+					},
+				},
+			},
+			{
+				Name:   "check-instances",
+				Usage:  "Check dungeon instance invariants and report violations, optionally repairing them",
+				Action: r.CheckInstances,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "dungeon-instance-id",
+						Usage: "Dungeon instance ID to check, defaults to every dungeon instance",
+					},
+					&cli.BoolFlag{
+						Name:  "repair",
+						Usage: "Repair violations as they are found",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Report format, one of text or json",
+						Value: outputFormatText,
+					},
+				},
+			},
+			{
+				Name:   "simulate",
+				Usage:  "Run turns of a new dungeon instance as fast as possible and summarise deaths, movements and object changes",
+				Action: r.Simulate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "dungeon",
+						Usage:    "Name of the dungeon to simulate",
+						Required: true,
+					},
+					&cli.IntFlag{
+						Name:  "turns",
+						Usage: "Number of turns to run",
+						Value: 100,
+					},
+					&cli.Int64Flag{
+						Name:  "seed",
+						Usage: "Seed of the dungeon instance, random when not provided",
+					},
+					&cli.StringFlag{
+						Name:  "script",
+						Usage: "YAML or JSON file of scripted characters to place in the dungeon instance",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Summary format, one of text or json",
+						Value: outputFormatText,
+					},
+					&cli.BoolFlag{
+						Name:  "keep",
+						Usage: "Keep the simulated dungeon instance, by default simulated records are discarded",
+					},
+				},
+			},
+			{
+				Name:      "import-dungeon",
+				Usage:     "Create a dungeon from a YAML or JSON dungeon definition file",
+				ArgsUsage: "<file>",
+				Action:    r.ImportDungeon,
+			},
+			{
+				Name:      "export-dungeon",
+				Usage:     "Write the YAML or JSON definition of a dungeon",
+				ArgsUsage: "<name>",
+				Action:    r.ExportDungeon,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "File to write the definition to, defaults to standard output",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Definition format, one of yaml or json, defaults to json for files ending in .json otherwise yaml",
+					},
+				},
+			},
+			{
+				Name:   "lint-dungeon",
+				Usage:  "Report unreachable locations, one-way exits, dead ends, missing definitions and other dungeon issues",
+				Action: r.LintDungeon,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "YAML or JSON dungeon definition file to lint",
+					},
+					&cli.StringFlag{
+						Name:  "name",
+						Usage: "Name of a dungeon to lint",
+					},
+				},
+			},
+			{
+				Name:   "generate-dungeon",
+				Usage:  "Create a procedurally generated dungeon, the same seed and options always generate the same dungeon",
+				Action: r.GenerateDungeon,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "name",
+						Usage:    "Name of the dungeon",
+						Required: true,
+					},
+					&cli.Int64Flag{
+						Name:  "seed",
+						Usage: "Seed the dungeon is generated from",
+					},
+					&cli.IntFlag{
+						Name:  "size",
+						Usage: "Number of locations on each level",
+						Value: generator.DefaultSize,
+					},
+					&cli.IntFlag{
+						Name:  "branching-factor",
+						Usage: "Most exits leading on from a location to new locations, between 1 and 8",
+						Value: generator.DefaultBranchingFactor,
+					},
+					&cli.IntFlag{
+						Name:  "levels",
+						Usage: "Number of levels joined by up and down exits",
+						Value: generator.DefaultLevels,
+					},
+					&cli.IntFlag{
+						Name:  "monster-chance",
+						Usage: "Percent chance a location has a monster",
+						Value: generator.DefaultMonsterChance,
+					},
+					&cli.IntFlag{
+						Name:  "object-chance",
+						Usage: "Percent chance a location has an object",
+						Value: generator.DefaultObjectChance,
+					},
+					&cli.StringFlag{
+						Name:  "pool",
+						Usage: "YAML or JSON file of the monsters and objects to pick from with their rarity, defaults to a built in pool",
+					},
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "File to write the definition to instead of creating the dungeon",
+					},
+				},
+			},
+			{
+				Name:   "assign-account-role",
+				Usage:  "Assign a role to an account",
+				Action: r.AssignAccountRole,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "email",
+						Usage:    "Email of the account",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "role",
+						Usage:    "Role to assign, one of player, game_master or admin",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "issue-api-key",
+				Usage:  "Issue an API key for bots and service-to-service calls, the key is written to standard output once",
+				Action: r.IssueAPIKey,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "owner",
+						Usage:    "Label identifying who or what uses the key",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:  "scope",
+						Usage: "Permission granted to the key, one of play, dungeon_instance:manage, dungeon:author or account:manage, may be repeated",
+					},
+					&cli.StringFlag{
+						Name:  "account-email",
+						Usage: "Email of the account the key plays characters for",
+					},
+					&cli.DurationFlag{
+						Name:  "expires-in",
+						Usage: "Duration until the key expires, the key does not expire when not provided",
+					},
+				},
+			},
+			{
+				Name:   "revoke-api-key",
+				Usage:  "Revoke an API key",
+				Action: r.RevokeAPIKey,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "API key ID to revoke",
+						Required: true,
+					},
+				},
+			},
 			{
 				Name:    "test",
 				Aliases: []string{"t"},
@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// Output formats
+const (
+	outputFormatText string = "text"
+	outputFormatJSON string = "json"
+)
+
+// Simulate runs turns of a new dungeon instance without waiting between turns
+// and writes a summary of deaths, movements and object changes. Simulated
+// records are discarded unless they are to be kept.
+func (rnr *Runner) Simulate(c *cli.Context) error {
+
+	rnr.Log.Info("** Simulate Dungeon Instance **")
+
+	format := c.String("format")
+	if format != outputFormatText && format != outputFormatJSON {
+		return fmt.Errorf("format >%s< is not one of text or json", format)
+	}
+
+	args := &model.SimulateDungeonInstanceArgs{
+		DungeonName: c.String("dungeon"),
+		Turns:       c.Int("turns"),
+		Seed:        c.Int64("seed"),
+	}
+
+	filename := c.String("script")
+	if filename != "" {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			rnr.Log.Warn("Failed reading simulation script file >%s< >%v<", filename, err)
+			return err
+		}
+
+		args.Script, err = model.UnmarshalSimulationScript(data)
+		if err != nil {
+			rnr.Log.Warn("Failed unmarshalling simulation script >%v<", err)
+			return err
+		}
+	}
+
+	sim, err := rnr.Model.(*model.Model).SimulateDungeonInstance(args)
+	if err != nil {
+		rnr.Log.Warn("Failed simulating dungeon instance >%v<", err)
+		return err
+	}
+
+	// Discard the simulated records, leaving a new transaction to commit
+	if !c.Bool("keep") {
+		err = rnr.Model.Rollback()
+		if err != nil {
+			rnr.Log.Warn("Failed rolling back simulation >%v<", err)
+			return err
+		}
+
+		err = rnr.InitModelTx()
+		if err != nil {
+			rnr.Log.Warn("Failed initialising model transaction >%v<", err)
+			return err
+		}
+	}
+
+	if format == outputFormatJSON {
+		data, err := json.MarshalIndent(sim, "", "  ")
+		if err != nil {
+			rnr.Log.Warn("Failed marshalling simulation >%v<", err)
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	return sim.WriteText(os.Stdout)
+}
@@ -28,6 +28,10 @@ func (rnr *Runner) LoadTestData(c *cli.Context) error {
 		return err
 	}
 
+	for _, accountRec := range h.Data.AccountRecs {
+		rnr.Log.Info("Test account email >%s< password >%s<", accountRec.Email, testDataAccountPassword)
+	}
+
 	rnr.Log.Info("All done")
 
 	return nil
@@ -4,6 +4,13 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/data/cabin"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/data/cave"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	testDataAccountName     string = "Test Account"
+	testDataAccountEmail    string = "test@example.com"
+	testDataAccountPassword string = "test-password"
 )
 
 // The following data is test data used to seed a test game server
@@ -18,12 +25,26 @@ func TestDataConfig() harness.DataConfig {
 	var characterConfig = cave.CharacterConfig()
 	characterConfig = append(characterConfig, cabin.CharacterConfig()...)
 
+	// Test data characters are owned by the test account
+	for idx := range characterConfig {
+		characterConfig[idx].AccountName = testDataAccountName
+	}
+
 	var dungeonConfig = []harness.DungeonConfig{
 		cave.DungeonConfig(),
 		cabin.DungeonConfig(),
 	}
 
 	d := harness.DataConfig{
+		AccountConfig: []harness.AccountConfig{
+			{
+				Record: record.Account{
+					Name:  testDataAccountName,
+					Email: testDataAccountEmail,
+				},
+				Password: testDataAccountPassword,
+			},
+		},
 		ObjectConfig:    objectConfig,
 		MonsterConfig:   monsterConfig,
 		CharacterConfig: characterConfig,
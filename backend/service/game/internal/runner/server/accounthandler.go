@@ -0,0 +1,250 @@
+package runner
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	getAccount  string = "get-account"
+	postAccount string = "post-account"
+	postLogin   string = "post-login"
+)
+
+func (rnr *Runner) AccountHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
+
+	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		getAccount: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/account",
+			HandlerFunc: rnr.getAccountHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/account",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/account",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Get the authenticated account.",
+			},
+		},
+		postAccount: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/accounts",
+			HandlerFunc: rnr.postAccountHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypePublic,
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/account",
+						Name:     "create.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/account",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/account",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Register an account.",
+			},
+		},
+		postLogin: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/login",
+			HandlerFunc: rnr.postLoginHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypePublic,
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/account",
+						Name:     "login.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/account",
+						Name:     "login.response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/account",
+							Name:     "login.data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document: true,
+				Description: "Login to an account. The returned token is provided as a Bearer " +
+					"token in the Authorization header of authenticated requests.",
+			},
+		},
+	})
+}
+
+// getAccountHandler -
+func (rnr *Runner) getAccountHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getAccountHandler")
+	l.Info("** Get account handler **")
+
+	accountID := authenticatedAccountID(l, r)
+
+	rec, err := m.(*model.Model).GetAccountRec(accountID, nil)
+	if err != nil {
+		l.Warn("failed getting account record >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("account", accountID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.AccountResponse{
+		Data: []schema.AccountData{
+			accountResponseData(rec),
+		},
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// postAccountHandler -
+func (rnr *Runner) postAccountHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAccountHandler")
+	l.Info("** Post account handler **")
+
+	req := &schema.AccountRequest{}
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Registering account name >%s< email >%s<", req.Data.Name, req.Data.Email)
+
+	rec, err := m.(*model.Model).RegisterAccount(&model.RegisterAccountArgs{
+		Name:     req.Data.Name,
+		Email:    req.Data.Email,
+		Password: req.Data.Password,
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.AccountResponse{
+		Data: []schema.AccountData{
+			accountResponseData(rec),
+		},
+	}
+
+	err = server.WriteResponse(l, w, http.StatusCreated, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// postLoginHandler -
+func (rnr *Runner) postLoginHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postLoginHandler")
+	l.Info("** Post login handler **")
+
+	req := &schema.LoginRequest{}
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec, err := m.(*model.Model).LoginAccount(req.Data.Email, req.Data.Password)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	token, expiresAt, err := rnr.encodeAccountToken(rec)
+	if err != nil {
+		l.Warn("failed encoding account token >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.LoginResponse{
+		Data: []schema.LoginData{
+			{
+				AccountID: rec.ID,
+				Token:     token,
+				ExpiresAt: expiresAt,
+			},
+		},
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+func accountResponseData(rec *record.Account) schema.AccountData {
+	return schema.AccountData{
+		ID:        rec.ID,
+		Name:      rec.Name,
+		Email:     rec.Email,
+		Role:      rec.Role,
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: rec.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,304 @@
+package runner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+)
+
+func TestAccountHandler(t *testing.T) {
+
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectResponseBody func(data harness.Data) *schema.AccountResponse
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.AccountResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "register with valid attributes",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAccount]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.AccountRequest{
+						Data: schema.AccountRequestData{
+							Name:     gofakeit.Name(),
+							Email:    gofakeit.Email(),
+							Password: gofakeit.Password(true, true, true, false, false, 12),
+						},
+					}
+					return &req
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "register with an email that is taken",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAccount]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.AccountRequest{
+						Data: schema.AccountRequestData{
+							Name:     gofakeit.Name(),
+							Email:    data.AccountRecs[0].Email,
+							Password: gofakeit.Password(true, true, true, false, false, 12),
+						},
+					}
+					return &req
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "register with a short password",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAccount]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.AccountRequest{
+						Data: schema.AccountRequestData{
+							Name:     gofakeit.Name(),
+							Email:    gofakeit.Email(),
+							Password: "short",
+						},
+					}
+					return &req
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get authenticated account",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAccount]
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectResponseBody: func(data harness.Data) *schema.AccountResponse {
+				res := schema.AccountResponse{
+					Data: []schema.AccountData{
+						{
+							ID:    data.AccountRecs[0].ID,
+							Name:  data.AccountRecs[0].Name,
+							Email: data.AccountRecs[0].Email,
+						},
+					},
+				}
+				return &res
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get account without authorization",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAccount]
+				},
+				RequestHeaders: func(data harness.Data) map[string]string {
+					headers := map[string]string{
+						"Authorization": "",
+					}
+					return headers
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get account with an API key",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAccount]
+				},
+				APIKeyOwner:     harness.APIKeyOwnerPlayBot,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get account with an invalid token",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAccount]
+				},
+				RequestHeaders: func(data harness.Data) map[string]string {
+					headers := map[string]string{
+						"Authorization": "Bearer not.a.token",
+					}
+					return headers
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Logf("Running test >%s<", testCase.Name)
+
+		t.Run(testCase.Name, func(t *testing.T) {
+
+			testFunc := func(method string, body interface{}) {
+
+				if testCase.TestResponseCode() != http.StatusOK && testCase.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				var responseBody *schema.AccountResponse
+				if body != nil {
+					responseBody = body.(*schema.AccountResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				require.Len(t, responseBody.Data, 1, "Response body data length equals expected")
+
+				if testCase.expectResponseBody != nil {
+					expectResponseBody := testCase.expectResponseBody(th.Data)
+					for idx, expectData := range expectResponseBody.Data {
+						require.Equal(t, expectData.ID, responseBody.Data[idx].ID, "Account ID equals expected")
+						require.Equal(t, expectData.Name, responseBody.Data[idx].Name, "Account name equals expected")
+						require.Equal(t, expectData.Email, responseBody.Data[idx].Email, "Account email equals expected")
+					}
+				}
+
+				for _, data := range responseBody.Data {
+					require.NotEmpty(t, data.ID, "Account ID is not empty")
+					require.False(t, data.CreatedAt.IsZero(), "CreatedAt is not zero")
+				}
+			}
+
+			RunTestCase(t, th, &testCase, testFunc)
+		})
+	}
+}
+
+func TestPostLoginHandler(t *testing.T) {
+
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.LoginResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "login with valid credentials",
+			HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+				return rnr.HandlerConfig[postLogin]
+			},
+			RequestBody: func(data harness.Data) interface{} {
+				req := schema.LoginRequest{
+					Data: schema.LoginRequestData{
+						Email:    data.AccountRecs[0].Email,
+						Password: harness.AccountPasswordDefault,
+					},
+				}
+				return &req
+			},
+			ResponseDecoder: testCaseResponseDecoder,
+			ResponseCode:    http.StatusOK,
+		},
+		{
+			Name: "login with an invalid password",
+			HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+				return rnr.HandlerConfig[postLogin]
+			},
+			RequestBody: func(data harness.Data) interface{} {
+				req := schema.LoginRequest{
+					Data: schema.LoginRequestData{
+						Email:    data.AccountRecs[0].Email,
+						Password: "not-the-password",
+					},
+				}
+				return &req
+			},
+			ResponseDecoder: testCaseResponseDecoder,
+			ResponseCode:    http.StatusUnauthorized,
+		},
+		{
+			Name: "login with an unknown email",
+			HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+				return rnr.HandlerConfig[postLogin]
+			},
+			RequestBody: func(data harness.Data) interface{} {
+				req := schema.LoginRequest{
+					Data: schema.LoginRequestData{
+						Email:    gofakeit.Email(),
+						Password: harness.AccountPasswordDefault,
+					},
+				}
+				return &req
+			},
+			ResponseDecoder: testCaseResponseDecoder,
+			ResponseCode:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Logf("Running test >%s<", testCase.Name)
+
+		t.Run(testCase.Name, func(t *testing.T) {
+
+			testFunc := func(method string, body interface{}) {
+
+				if testCase.TestResponseCode() != http.StatusOK {
+					return
+				}
+
+				require.NotNil(t, body, "Response body is not nil")
+				responseBody := body.(*schema.LoginResponse)
+
+				require.Len(t, responseBody.Data, 1, "Response body data length equals expected")
+				require.Equal(t, th.Data.AccountRecs[0].ID, responseBody.Data[0].AccountID, "Account ID equals expected")
+				require.NotEmpty(t, responseBody.Data[0].Token, "Token is not empty")
+				require.False(t, responseBody.Data[0].ExpiresAt.IsZero(), "ExpiresAt is not zero")
+			}
+
+			RunTestCase(t, th, &testCase, testFunc)
+		})
+	}
+}
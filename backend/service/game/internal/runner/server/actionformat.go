@@ -0,0 +1,116 @@
+package runner
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/terminal"
+)
+
+// Action response media types
+const (
+	mediaTypeJSON string = "application/json"
+	mediaTypeText string = "text/plain"
+	mediaTypeANSI string = "text/x-ansi"
+)
+
+// actionMediaTypes are the media types action responses are available in, in
+// order of preference when the Accept header ranks them equally
+var actionMediaTypes = []string{
+	mediaTypeJSON,
+	mediaTypeText,
+	mediaTypeANSI,
+}
+
+// negotiateActionMediaType returns the action response media type most
+// preferred by the Accept header, "text/x-ansi, text/plain;q=0.5" returns
+// ANSI text. JSON is returned when the header is empty or accepts none of
+// the action response media types.
+func negotiateActionMediaType(accept string) string {
+
+	if strings.TrimSpace(accept) == "" {
+		return mediaTypeJSON
+	}
+
+	best := mediaTypeJSON
+	bestQ := 0.0
+	for _, mediaType := range actionMediaTypes {
+		q := acceptQuality(accept, mediaType)
+		if q > bestQ {
+			best = mediaType
+			bestQ = q
+		}
+	}
+
+	return best
+}
+
+// acceptQuality returns the quality the Accept header gives a media type,
+// the most specific matching media range applies
+func acceptQuality(accept, mediaType string) float64 {
+
+	specificity := -1
+	quality := 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		s := -1
+		switch {
+		case mediaRange == mediaType:
+			s = 2
+		case mediaRange == strings.Split(mediaType, "/")[0]+"/*":
+			s = 1
+		case mediaRange == "*/*":
+			s = 0
+		}
+		if s <= specificity {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		specificity = s
+		quality = q
+	}
+
+	return quality
+}
+
+// writeActionResponse writes the action response in the media type negotiated
+// from the Accept header, the JSON response data is the source of the plain
+// and ANSI text formats
+func writeActionResponse(l logger.Logger, w http.ResponseWriter, r *http.Request, res schema.ActionResponse) error {
+
+	w.Header().Add("Vary", "Accept")
+
+	mediaType := negotiateActionMediaType(r.Header.Get("Accept"))
+
+	l.Info("Negotiated action response media type >%s< from >%s<", mediaType, r.Header.Get("Accept"))
+
+	if mediaType == mediaTypeJSON {
+		return server.WriteResponse(l, w, http.StatusOK, res)
+	}
+
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	return terminal.Render(w, res.Data, terminal.Options{
+		ANSI:     mediaType == mediaTypeANSI,
+		Language: requestLanguage(r),
+	})
+}
@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateActionMediaType(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		accept string
+		expect string
+	}{
+		{
+			name:   "Without an accept header",
+			expect: mediaTypeJSON,
+		},
+		{
+			name:   "Anything",
+			accept: "*/*",
+			expect: mediaTypeJSON,
+		},
+		{
+			name:   "JSON",
+			accept: "application/json",
+			expect: mediaTypeJSON,
+		},
+		{
+			name:   "Plain text",
+			accept: "text/plain",
+			expect: mediaTypeText,
+		},
+		{
+			name:   "Any text",
+			accept: "text/*",
+			expect: mediaTypeText,
+		},
+		{
+			name:   "ANSI text preferred over plain text",
+			accept: "text/x-ansi, text/plain;q=0.5",
+			expect: mediaTypeANSI,
+		},
+		{
+			name:   "Plain text preferred over JSON",
+			accept: "application/json;q=0.1, text/plain; charset=utf-8",
+			expect: mediaTypeText,
+		},
+		{
+			name:   "Plain text not acceptable",
+			accept: "text/*, text/plain;q=0",
+			expect: mediaTypeANSI,
+		},
+		{
+			name:   "Unsupported media type",
+			accept: "image/png",
+			expect: mediaTypeJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, negotiateActionMediaType(tt.accept), "Negotiated media type equals expected")
+		})
+	}
+}
@@ -2,7 +2,9 @@ package runner
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 
@@ -14,22 +16,79 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 const (
+	getActions string = "get-actions"
 	postAction string = "post-action"
 )
 
+const (
+	// actionHistoryLimit is the maximum number of actions returned for a
+	// single action history request
+	actionHistoryLimit int = 100
+	// actionHistoryPollInterval is how often new actions are checked for
+	// while waiting for a long poll action history request
+	actionHistoryPollInterval time.Duration = 500 * time.Millisecond
+)
+
 func (rnr *Runner) ActionHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
 
 	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		getActions: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/dungeons/:dungeon_id/characters/:character_id/actions",
+			HandlerFunc: rnr.GetActionsHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					QueryParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/action",
+							Name:     "query.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document: true,
+				Description: "Get dungeon character actions. Returns up to 100 actions visible to the " +
+					"character with a serial number greater than 'after_serial'. When 'timeout' " +
+					"seconds is provided and there are no actions the request waits up to the " +
+					"timeout for new actions. Actions are rendered as plain text with " +
+					"'Accept: text/plain' or as ANSI coloured text with 'Accept: text/x-ansi'.",
+			},
+		},
 		postAction: {
 			Method:      http.MethodPost,
 			Path:        "/api/v1/dungeons/:dungeon_id/characters/:character_id/actions",
 			HandlerFunc: rnr.PostActionHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -57,13 +116,244 @@ func (rnr *Runner) ActionHandlerConfig(hc map[string]server.HandlerConfig) map[s
 				},
 			},
 			DocumentationConfig: server.DocumentationConfig{
-				Document:    true,
-				Description: "Create a dungeon character action.",
+				Document: true,
+				Description: "Create a dungeon character action. Actions are rendered as plain text " +
+					"with 'Accept: text/plain' or as ANSI coloured text with 'Accept: text/x-ansi'.",
 			},
 		},
 	})
 }
 
+// GetActionsHandler -
+func (rnr *Runner) GetActionsHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "GetActionsHandler")
+	l.Info("** Get actions handler **")
+
+	// Path parameters
+	dungeonID := pp.ByName("dungeon_id")
+	characterID := pp.ByName("character_id")
+
+	afterSerialNumber := 0
+	if params, ok := qp.Params["after_serial"]; ok && len(params) > 0 {
+		var err error
+		afterSerialNumber, err = strconv.Atoi(params[0].Val)
+		if err != nil || afterSerialNumber < 0 {
+			err := coreerror.NewParamError("after_serial >%s< is not a valid serial number", params[0].Val)
+			server.WriteError(l, w, err)
+			return err
+		}
+	}
+
+	timeout := time.Duration(0)
+	if params, ok := qp.Params["timeout"]; ok && len(params) > 0 {
+		seconds, err := strconv.Atoi(params[0].Val)
+		if err != nil || seconds < 0 {
+			err := coreerror.NewParamError("timeout >%s< is not a valid number of seconds", params[0].Val)
+			server.WriteError(l, w, err)
+			return err
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	dungeonRec, err := m.(*model.Model).GetDungeonRec(dungeonID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if dungeonRec == nil {
+		err := coreerror.NewNotFoundError("dungeon", dungeonID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	characterRec, err := m.(*model.Model).GetCharacterRec(characterID, nil)
+	if err != nil {
+		l.Warn("failed getting character record >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if characterRec == nil {
+		err := coreerror.NewNotFoundError("character", characterID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = authorizeCharacterRec(l, r, characterRec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	characterInstanceRec, err := m.(*model.Model).GetCharacterInstance(characterID)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	if characterInstanceRec == nil {
+		err := model.NewActionInvalidCharacterError(characterID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	if timeout > 0 {
+		// Allow the response to be written after waiting
+		err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + eventStreamWriteTimeout))
+		if err != nil && err != http.ErrNotSupported {
+			l.Warn("failed setting write deadline >%v<", err)
+			server.WriteError(l, w, err)
+			return err
+		}
+	}
+
+	l.Info("Getting character instance ID >%s< actions after serial number >%d< timeout >%s<", characterInstanceRec.ID, afterSerialNumber, timeout)
+
+	actionRecs, err := m.(*model.Model).GetCharacterInstanceVisibleActionRecs(characterInstanceRec, afterSerialNumber, actionHistoryLimit)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	data, err := rnr.actionHistoryResponseData(l, r, m.(*model.Model), characterInstanceRec.ID, actionRecs)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	if len(data) == 0 && timeout > 0 {
+		// The request transaction is released before waiting so the wait does
+		// not hold a database connection, every poll uses its own transaction
+		err = m.Rollback()
+		if err != nil {
+			l.Warn("failed releasing request transaction >%v<", err)
+			server.WriteError(l, w, err)
+			return err
+		}
+
+		data, err = rnr.waitCharacterInstanceVisibleActions(l, r, characterInstanceRec.ID, afterSerialNumber, timeout)
+		if err != nil {
+			server.WriteError(l, w, err)
+			return err
+		}
+	}
+
+	l.Info("Responding with >%d< actions", len(data))
+
+	res := schema.ActionResponse{
+		Data: data,
+	}
+
+	err = writeActionResponse(l, w, r, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// actionHistoryResponseData returns the response data for action records as seen by
+// the character instance
+func (rnr *Runner) actionHistoryResponseData(l logger.Logger, r *http.Request, m *model.Model, characterInstanceID string, actionRecs []*record.Action) ([]schema.ActionResponseData, error) {
+
+	data := []schema.ActionResponseData{}
+	for _, rec := range actionRecs {
+
+		rs, err := m.GetActionRecordSet(rec.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = m.TranslateActionRecordSet(rs)
+		if err != nil {
+			return nil, err
+		}
+
+		responseData, err := actionResponseData(l, rnr.narrator, *rs, characterInstanceID, requestLanguage(r))
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, *responseData)
+	}
+
+	return data, nil
+}
+
+// waitCharacterInstanceVisibleActions waits up to the timeout for actions visible to the
+// character after the provided serial number. Each poll uses a new database transaction
+// so actions committed since the previous poll are visible and a database connection is
+// only held while polling.
+func (rnr *Runner) waitCharacterInstanceVisibleActions(l logger.Logger, r *http.Request, characterInstanceID string, serialNumber int, timeout time.Duration) ([]schema.ActionResponseData, error) {
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	pollTicker := time.NewTicker(actionHistoryPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return []schema.ActionResponseData{}, nil
+		case <-deadline.C:
+			return []schema.ActionResponseData{}, nil
+		case <-pollTicker.C:
+			data, exited, err := rnr.pollCharacterInstanceVisibleActions(l, r, characterInstanceID, serialNumber)
+			if err != nil {
+				return nil, err
+			}
+			if exited || len(data) > 0 {
+				return data, nil
+			}
+		}
+	}
+}
+
+// pollCharacterInstanceVisibleActions returns actions visible to the character after the
+// provided serial number within its own database transaction
+func (rnr *Runner) pollCharacterInstanceVisibleActions(l logger.Logger, r *http.Request, characterInstanceID string, serialNumber int) (data []schema.ActionResponseData, exited bool, err error) {
+
+	m, err := rnr.initModeller(l)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		if rerr := m.Rollback(); rerr != nil {
+			l.Warn("failed model rollback >%v<", rerr)
+		}
+	}()
+
+	m.SetLanguage(requestLanguage(r))
+
+	// The character may have moved or exited the dungeon while waiting
+	rec, err := m.GetCharacterInstanceRec(characterInstanceID, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if rec == nil {
+		return []schema.ActionResponseData{}, true, nil
+	}
+
+	actionRecs, err := m.GetCharacterInstanceVisibleActionRecs(rec, serialNumber, actionHistoryLimit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err = rnr.actionHistoryResponseData(l, r, m, rec.ID, actionRecs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, false, nil
+}
+
 // PostActionHandler -
 func (rnr *Runner) PostActionHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
 	l = loggerWithFunctionContext(l, "PostActionHandler")
@@ -105,6 +395,12 @@ func (rnr *Runner) PostActionHandler(w http.ResponseWriter, r *http.Request, pp
 		return err
 	}
 
+	err = authorizeCharacterRec(l, r, characterRec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	req := &schema.ActionRequest{}
 
 	req, err = server.ReadRequest(l, r, req)
@@ -178,7 +474,13 @@ func (rnr *Runner) PostActionHandler(w http.ResponseWriter, r *http.Request, pp
 			return err
 		}
 
-		responseData, err := actionResponseData(l, *rs)
+		err = m.(*model.Model).TranslateActionRecordSet(rs)
+		if err != nil {
+			server.WriteError(l, w, err)
+			return err
+		}
+
+		responseData, err := actionResponseData(l, rnr.narrator, *rs, characterInstanceRec.ID, requestLanguage(r))
 		if err != nil {
 			server.WriteError(l, w, err)
 			return err
@@ -223,7 +525,7 @@ func (rnr *Runner) PostActionHandler(w http.ResponseWriter, r *http.Request, pp
 		}
 	}
 
-	err = server.WriteResponse(l, w, http.StatusOK, res)
+	err = writeActionResponse(l, w, r, res)
 	if err != nil {
 		l.Warn("failed writing response >%v<", err)
 		return err
@@ -6,12 +6,16 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
 	"gitlab.com/alienspaces/go-mud/backend/core/server"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 func TestPostActionHandler(t *testing.T) {
@@ -93,7 +97,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -140,7 +144,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Barricade looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", cRec.Name),
+							Narrative: fmt.Sprintf("You look around %s.", lRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -264,7 +268,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -311,7 +315,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Barricade moves north
 						{
 							Command:   "move",
-							Narrative: fmt.Sprintf("%s moves north", cRec.Name),
+							Narrative: fmt.Sprintf("You move north to %s.", tlRec.Name),
 							Location: schema.ActionLocation{
 								Name:        tlRec.Name,
 								Description: tlRec.Description,
@@ -430,7 +434,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -477,7 +481,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Barricade looks north
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks north", cRec.Name),
+							Narrative: fmt.Sprintf("You look north towards %s.", tlRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -592,7 +596,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -638,7 +642,7 @@ func TestPostActionHandler(t *testing.T) {
 						},
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks %s", cRec.Name, loRec.Name),
+							Narrative: fmt.Sprintf("You look at the %s.", loRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -744,7 +748,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -790,7 +794,7 @@ func TestPostActionHandler(t *testing.T) {
 						},
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks %s", cRec.Name, tmRec.Name),
+							Narrative: fmt.Sprintf("You look at %s.", tmRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -910,7 +914,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -956,7 +960,7 @@ func TestPostActionHandler(t *testing.T) {
 						},
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks %s", cRec.Name, tcRec.Name),
+							Narrative: fmt.Sprintf("You look at %s.", tcRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -1074,7 +1078,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -1120,7 +1124,7 @@ func TestPostActionHandler(t *testing.T) {
 						},
 						{
 							Command:   "stash",
-							Narrative: fmt.Sprintf("%s stashes %s", cRec.Name, toRec.Name),
+							Narrative: fmt.Sprintf("You stash the %s.", toRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -1231,7 +1235,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -1277,7 +1281,7 @@ func TestPostActionHandler(t *testing.T) {
 						},
 						{
 							Command:   "equip",
-							Narrative: fmt.Sprintf("%s equips %s", cRec.Name, toRec.Name),
+							Narrative: fmt.Sprintf("You equip the %s.", toRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -1387,7 +1391,7 @@ func TestPostActionHandler(t *testing.T) {
 						// Grumpy Dwarf looks
 						{
 							Command:   "look",
-							Narrative: fmt.Sprintf("%s looks", mRec.Name),
+							Narrative: fmt.Sprintf("%s looks around.", mRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -1433,7 +1437,7 @@ func TestPostActionHandler(t *testing.T) {
 						},
 						{
 							Command:   "drop",
-							Narrative: fmt.Sprintf("%s drops %s", cRec.Name, toRec.Name),
+							Narrative: fmt.Sprintf("You drop the %s.", toRec.Name),
 							Location: schema.ActionLocation{
 								Name:        lRec.Name,
 								Description: lRec.Description,
@@ -1894,3 +1898,274 @@ func TestPostActionHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetActionsHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		afterSerialNumber func(data harness.Data) int
+		// createAction creates an action for the character while the request
+		// waits for actions
+		createAction     bool
+		expectActionIDs  func(data harness.Data) []string
+		excludeActionIDs func(data harness.Data) []string
+		expectEmpty      bool
+		expectMinWait    time.Duration
+	}
+
+	testCaseHandlerConfig := func(rnr *Runner) server.HandlerConfig {
+		return rnr.HandlerConfig[getActions]
+	}
+
+	// All actions are fetched for "Barricade" in the "Cave"
+	testCaseRequestPathParams := func(data harness.Data) map[string]string {
+		dRec, _ := data.GetDungeonRecByName(harness.DungeonNameCave)
+		cRec, _ := data.GetCharacterRecByName(harness.CharacterNameBarricade)
+
+		params := map[string]string{
+			":dungeon_id":   dRec.ID,
+			":character_id": cRec.ID,
+		}
+		return params
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.ActionResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	characterInstanceRec := func(data harness.Data) *record.CharacterInstance {
+		cRec, _ := data.GetCharacterRecByName(harness.CharacterNameBarricade)
+		for _, ciRec := range data.CharacterInstanceRecs {
+			if ciRec.CharacterID == cRec.ID {
+				return ciRec
+			}
+		}
+		return nil
+	}
+
+	// The "look" action performed by "Barricade" when the harness was setup
+	characterActionRec := func(data harness.Data) *record.Action {
+		ciRec := characterInstanceRec(data)
+		for _, aRec := range data.ActionRecs {
+			if null.NullStringToString(aRec.CharacterInstanceID) == ciRec.ID {
+				return aRec
+			}
+		}
+		return nil
+	}
+
+	// The serial number of the most recent action in the dungeon instance
+	latestSerialNumber := func(data harness.Data) int {
+		ciRec := characterInstanceRec(data)
+		serialNumber := 0
+		for _, aRec := range data.ActionRecs {
+			if aRec.DungeonInstanceID == ciRec.DungeonInstanceID && int(null.NullInt32ToInt32(aRec.SerialNumber)) > serialNumber {
+				serialNumber = int(null.NullInt32ToInt32(aRec.SerialNumber))
+			}
+		}
+		return serialNumber
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name:              "get actions after a serial number",
+				HandlerConfig:     testCaseHandlerConfig,
+				RequestPathParams: testCaseRequestPathParams,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			afterSerialNumber: func(data harness.Data) int {
+				return int(null.NullInt32ToInt32(characterActionRec(data).SerialNumber)) - 1
+			},
+			expectActionIDs: func(data harness.Data) []string {
+				return []string{characterActionRec(data).ID}
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name:              "get actions excludes actions at or before the serial number",
+				HandlerConfig:     testCaseHandlerConfig,
+				RequestPathParams: testCaseRequestPathParams,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			afterSerialNumber: func(data harness.Data) int {
+				return int(null.NullInt32ToInt32(characterActionRec(data).SerialNumber))
+			},
+			excludeActionIDs: func(data harness.Data) []string {
+				return []string{characterActionRec(data).ID}
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name:              "get actions returns no actions when the wait times out",
+				HandlerConfig:     testCaseHandlerConfig,
+				RequestPathParams: testCaseRequestPathParams,
+				RequestQueryParams: func(data harness.Data) map[string]interface{} {
+					return map[string]interface{}{
+						"timeout": 1,
+					}
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			afterSerialNumber: latestSerialNumber,
+			expectEmpty:       true,
+			expectMinWait:     time.Second,
+		},
+		{
+			TestCase: TestCase{
+				Name:              "get actions waits for a new action",
+				HandlerConfig:     testCaseHandlerConfig,
+				RequestPathParams: testCaseRequestPathParams,
+				RequestQueryParams: func(data harness.Data) map[string]interface{} {
+					return map[string]interface{}{
+						"timeout": 10,
+					}
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			afterSerialNumber: latestSerialNumber,
+			createAction:      true,
+		},
+		{
+			TestCase: TestCase{
+				Name:              "get actions for a character owned by another account",
+				HandlerConfig:     testCaseHandlerConfig,
+				RequestPathParams: testCaseRequestPathParams,
+				AccountName:       harness.AccountNameOther,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Logf("Running test >%s<", testCase.Name)
+
+		t.Run(testCase.Name, func(t *testing.T) {
+
+			afterSerialNumber := 0
+			if testCase.afterSerialNumber != nil {
+				afterSerialNumber = testCase.afterSerialNumber(th.Data)
+			}
+
+			requestQueryParams := testCase.RequestQueryParams
+			testCase.RequestQueryParams = func(data harness.Data) map[string]interface{} {
+				params := map[string]interface{}{}
+				if requestQueryParams != nil {
+					params = requestQueryParams(data)
+				}
+				if testCase.afterSerialNumber != nil {
+					params["after_serial"] = afterSerialNumber
+				}
+				return params
+			}
+
+			// The action is created and committed in its own transaction
+			// after the request has started waiting
+			created := make(chan *record.ActionRecordSet, 1)
+			if testCase.createAction {
+				ciRec := characterInstanceRec(th.Data)
+				go func() {
+					defer close(created)
+
+					time.Sleep(500 * time.Millisecond)
+
+					_, err := th.InitTx()
+					if err != nil {
+						t.Logf("failed init tx >%v<", err)
+						return
+					}
+
+					rs, err := th.Model.(*model.Model).ProcessCharacterAction(ciRec.DungeonInstanceID, ciRec.ID, "look")
+					if err != nil {
+						t.Logf("failed processing character action >%v<", err)
+						_ = th.RollbackTx()
+						return
+					}
+
+					err = th.CommitTx()
+					if err != nil {
+						t.Logf("failed commit tx >%v<", err)
+						return
+					}
+
+					created <- rs
+				}()
+			}
+
+			var responseBody *schema.ActionResponse
+
+			testFunc := func(method string, body interface{}) {
+				if testCase.TestResponseCode() != http.StatusOK {
+					return
+				}
+
+				require.NotNil(t, body, "Response body is not nil")
+				responseBody = body.(*schema.ActionResponse)
+
+				for idx, data := range responseBody.Data {
+					require.Greater(t, int(data.SerialNumber), afterSerialNumber, "Response action serial number is after the requested serial number")
+					if idx > 0 {
+						require.Greater(t, data.SerialNumber, responseBody.Data[idx-1].SerialNumber, "Response actions are ordered by serial number")
+					}
+				}
+			}
+
+			start := time.Now()
+			RunTestCase(t, th, &testCase, testFunc)
+			waited := time.Since(start)
+
+			if testCase.TestResponseCode() != http.StatusOK {
+				return
+			}
+
+			responseActionIDs := []string{}
+			for _, data := range responseBody.Data {
+				responseActionIDs = append(responseActionIDs, data.ID)
+			}
+
+			if testCase.createAction {
+				rs, ok := <-created
+				require.True(t, ok, "Action is created while waiting")
+				th.AddActionTeardownID(rs.ActionRec.ID)
+				require.Contains(t, responseActionIDs, rs.ActionRec.ID, "Response contains the action created while waiting")
+			}
+
+			if testCase.expectActionIDs != nil {
+				for _, id := range testCase.expectActionIDs(th.Data) {
+					require.Contains(t, responseActionIDs, id, "Response contains expected action")
+				}
+			}
+
+			if testCase.excludeActionIDs != nil {
+				for _, id := range testCase.excludeActionIDs(th.Data) {
+					require.NotContains(t, responseActionIDs, id, "Response does not contain excluded action")
+				}
+			}
+
+			if testCase.expectEmpty {
+				require.Empty(t, responseBody.Data, "Response contains no actions")
+			}
+
+			require.GreaterOrEqual(t, waited, testCase.expectMinWait, "Request waited for actions")
+		})
+	}
+}
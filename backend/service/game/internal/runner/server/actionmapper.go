@@ -1,16 +1,16 @@
 package runner
 
 import (
-	"strings"
-
 	"gitlab.com/alienspaces/go-mud/backend/core/null"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/narrative"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
-// actionResponseData -
-func actionResponseData(l logger.Logger, rs record.ActionRecordSet) (*schema.ActionResponseData, error) {
+// actionResponseData - The narrative is told from the point of view of the
+// character instance, an empty character instance ID is told as an observer
+func actionResponseData(l logger.Logger, narrator *narrative.Narrator, rs record.ActionRecordSet, characterInstanceID string, language string) (*schema.ActionResponseData, error) {
 
 	actionRec := rs.ActionRec
 
@@ -135,11 +135,9 @@ func actionResponseData(l logger.Logger, rs record.ActionRecordSet) (*schema.Act
 		}
 	}
 
-	narrative, err := actionNarrativeResponseData(
-		l,
-		rs,
-	)
+	narration, err := narrator.Narrate(rs, characterInstanceID, language)
 	if err != nil {
+		l.Warn("failed narrating action >%v<", err)
 		return nil, err
 	}
 
@@ -147,11 +145,12 @@ func actionResponseData(l logger.Logger, rs record.ActionRecordSet) (*schema.Act
 		ID:              actionRec.ID,
 		Command:         actionRec.ResolvedCommand,
 		TurnNumber:      actionRec.TurnNumber,
-		SerialNumber:    null.NullInt16ToInt16(actionRec.SerialNumber),
-		Narrative:       narrative,
+		SerialNumber:    null.NullInt32ToInt32(actionRec.SerialNumber),
+		Narrative:       narration,
 		Location:        *locationData,
 		Character:       characterData,
 		Monster:         monsterData,
+		SystemActor:     actionRec.SystemActor.String,
 		EquippedObject:  equippedActionLocationObject,
 		StashedObject:   stashedActionLocationObject,
 		DroppedObject:   droppedActionLocationObject,
@@ -416,45 +415,3 @@ func actionLocationResponseData(l logger.Logger, recordSet *record.ActionLocatio
 
 	return data, nil
 }
-
-// actionNarrativeResponseData -
-func actionNarrativeResponseData(l logger.Logger, set record.ActionRecordSet) (string, error) {
-
-	desc := ""
-	if set.ActionCharacterRec != nil {
-		desc += set.ActionCharacterRec.Name
-	} else if set.ActionMonsterRec != nil {
-		desc += set.ActionMonsterRec.Name
-	}
-
-	switch set.ActionRec.ResolvedCommand {
-	case record.ActionCommandAttack:
-		desc += " attacks "
-	case record.ActionCommandMove:
-		desc += " moves "
-	case record.ActionCommandLook:
-		desc += " looks "
-	case record.ActionCommandStash:
-		desc += " stashes "
-	case record.ActionCommandEquip:
-		desc += " equips "
-	case record.ActionCommandDrop:
-		desc += " drops "
-	default:
-		// no-op
-	}
-
-	if set.TargetActionCharacterRec != nil {
-		desc += set.TargetActionCharacterRec.Name
-	} else if set.TargetActionMonsterRec != nil {
-		desc += set.TargetActionMonsterRec.Name
-	} else if set.TargetActionObjectRec != nil {
-		desc += set.TargetActionObjectRec.Name
-	} else if set.TargetLocation != nil {
-		desc += set.ActionRec.ResolvedTargetLocationDirection.String
-	}
-
-	desc = strings.TrimRight(desc, " ")
-
-	return desc, nil
-}
@@ -0,0 +1,121 @@
+package runner
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+const (
+	putAdminAccountRole string = "put-admin-account-role"
+)
+
+func (rnr *Runner) AdminAccountHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
+
+	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		putAdminAccountRole: {
+			Method:      http.MethodPut,
+			Path:        "/api/v1/admin/accounts/:account_id/role",
+			HandlerFunc: rnr.putAdminAccountRoleHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionAccountManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/account",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/account",
+						Name:     "role.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/account",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/account",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document: true,
+				Description: "Assign the role of an account. Players play their own characters, game " +
+					"masters also manage dungeon instances and admins also manage accounts.",
+			},
+		},
+	})
+}
+
+// putAdminAccountRoleHandler -
+func (rnr *Runner) putAdminAccountRoleHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "putAdminAccountRoleHandler")
+
+	// Path parameters
+	id := pp.ByName("account_id")
+
+	req := &schema.AccountRoleRequest{}
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec, err := m.(*model.Model).GetAccountRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("account", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Account ID >%s< assigning account ID >%s< role >%s<", authenticatedAccountID(l, r), id, req.Data.Role)
+
+	err = m.(*model.Model).AssignAccountRole(rec, req.Data.Role)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.AccountResponse{
+		Data: []schema.AccountData{
+			accountResponseData(rec),
+		},
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package runner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestAdminAccountHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectRole string
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.AccountResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	accountRoleRequestBody := func(role string) func(data harness.Data) interface{} {
+		return func(data harness.Data) interface{} {
+			req := schema.AccountRoleRequest{
+				Data: schema.AccountRoleRequestData{
+					Role: role,
+				},
+			}
+			return &req
+		}
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "PUT - Assign game master role as an admin",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putAdminAccountRole]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					accountRec, _ := data.GetAccountRecByName(harness.AccountNameOther)
+					params := map[string]string{
+						":account_id": accountRec.ID,
+					}
+					return params
+				},
+				RequestBody:     accountRoleRequestBody(record.AccountRoleGameMaster),
+				AccountRole:     record.AccountRoleAdmin,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectRole: record.AccountRoleGameMaster,
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Assign admin role as a game master",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putAdminAccountRole]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					accountRec, _ := data.GetAccountRecByRole(record.AccountRoleGameMaster)
+					params := map[string]string{
+						":account_id": accountRec.ID,
+					}
+					return params
+				},
+				RequestBody:  accountRoleRequestBody(record.AccountRoleAdmin),
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Assign admin role as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putAdminAccountRole]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":account_id": data.AccountRecs[0].ID,
+					}
+					return params
+				},
+				RequestBody:  accountRoleRequestBody(record.AccountRoleAdmin),
+				AccountRole:  record.AccountRolePlayer,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Assign unknown role",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putAdminAccountRole]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":account_id": data.AccountRecs[0].ID,
+					}
+					return params
+				},
+				RequestBody:  accountRoleRequestBody("overlord"),
+				AccountRole:  record.AccountRoleAdmin,
+				ResponseCode: http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Assign role to non-existant account",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putAdminAccountRole]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":account_id": "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+					}
+					return params
+				},
+				RequestBody:  accountRoleRequestBody(record.AccountRolePlayer),
+				AccountRole:  record.AccountRoleAdmin,
+				ResponseCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+
+		t.Logf("Running test >%s<", testCase.Name)
+
+		t.Run(testCase.Name, func(t *testing.T) {
+
+			testFunc := func(method string, body interface{}) {
+
+				if testCase.TestResponseCode() != http.StatusOK {
+					return
+				}
+
+				require.NotNil(t, body, "Response body is not nil")
+				responseBody := body.(*schema.AccountResponse)
+
+				require.Len(t, responseBody.Data, 1, "Response body data length equals expected")
+				require.Equal(t, testCase.expectRole, responseBody.Data[0].Role, "Account role equals expected")
+			}
+
+			RunTestCase(t, th, &testCase, testFunc)
+		})
+	}
+}
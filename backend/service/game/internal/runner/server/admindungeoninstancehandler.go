@@ -0,0 +1,930 @@
+package runner
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	getAdminDungeonInstances             string = "get-admin-dungeon-instances"
+	getAdminDungeonInstance              string = "get-admin-dungeon-instance"
+	deleteAdminDungeonInstance           string = "delete-admin-dungeon-instance"
+	postAdminDungeonInstanceUnquarantine string = "post-admin-dungeon-instance-unquarantine"
+	postAdminDungeonInstanceSpawn        string = "post-admin-dungeon-instance-spawn"
+	postAdminCharacterInstanceTeleport   string = "post-admin-character-instance-teleport"
+	postAdminCharacterInstanceHeal       string = "post-admin-character-instance-heal"
+	postAdminCharacterInstanceKill       string = "post-admin-character-instance-kill"
+	postAdminCharacterInstanceKick       string = "post-admin-character-instance-kick"
+	postAdminMonsterInstanceHeal         string = "post-admin-monster-instance-heal"
+	postAdminMonsterInstanceKill         string = "post-admin-monster-instance-kill"
+)
+
+func (rnr *Runner) AdminDungeonInstanceHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
+
+	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		getAdminDungeonInstances: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/admin/dungeon-instances",
+			HandlerFunc: rnr.getAdminDungeonInstancesHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					QueryParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "query.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeoninstance",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeoninstance",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document: true,
+				Description: "List dungeon instances with their turn number, locations, characters and monsters. " +
+					"When 'failing' is true only dungeon instances that are failing are returned and " +
+					"when 'quarantined' is true only dungeon instances that are quarantined are returned.",
+			},
+		},
+		postAdminDungeonInstanceUnquarantine: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/unquarantine",
+			HandlerFunc: rnr.postAdminDungeonInstanceUnquarantineHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeoninstance",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeoninstance",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Unquarantine a dungeon instance so it is processed again.",
+			},
+		},
+		getAdminDungeonInstance: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id",
+			HandlerFunc: rnr.getAdminDungeonInstanceHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeoninstance",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeoninstance",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Get a dungeon instance with its turn number, locations, characters and monsters.",
+			},
+		},
+		deleteAdminDungeonInstance: {
+			Method:      http.MethodDelete,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id",
+			HandlerFunc: rnr.deleteAdminDungeonInstanceHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeoninstance",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeoninstance",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Force delete a dungeon instance. Every character is kicked from the dungeon instance before it is deleted.",
+			},
+		},
+		postAdminDungeonInstanceSpawn: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/spawn",
+			HandlerFunc: rnr.postAdminDungeonInstanceSpawnHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeoninstance",
+						Name:     "spawn.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Spawn a monster or object instance at a location instance.",
+			},
+		},
+		postAdminCharacterInstanceTeleport: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/characters/:character_instance_id/teleport",
+			HandlerFunc: rnr.postAdminCharacterInstanceTeleportHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "character.path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeoninstance",
+						Name:     "teleport.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Teleport a character instance to a location instance.",
+			},
+		},
+		postAdminCharacterInstanceHeal: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/characters/:character_instance_id/heal",
+			HandlerFunc: rnr.postAdminCharacterInstanceHealHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "character.path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Restore a character instance to full health and fatigue.",
+			},
+		},
+		postAdminCharacterInstanceKill: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/characters/:character_instance_id/kill",
+			HandlerFunc: rnr.postAdminCharacterInstanceKillHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "character.path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Kill a character instance.",
+			},
+		},
+		postAdminCharacterInstanceKick: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/characters/:character_instance_id/kick",
+			HandlerFunc: rnr.postAdminCharacterInstanceKickHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "character.path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Kick a character instance from the dungeon instance, the character keeps their experience, coins and objects.",
+			},
+		},
+		postAdminMonsterInstanceHeal: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/monsters/:monster_instance_id/heal",
+			HandlerFunc: rnr.postAdminMonsterInstanceHealHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "monster.path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Restore a monster instance to full health and fatigue.",
+			},
+		},
+		postAdminMonsterInstanceKill: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeon-instances/:dungeon_instance_id/monsters/:monster_instance_id/kill",
+			HandlerFunc: rnr.postAdminMonsterInstanceKillHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonInstanceManage,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeoninstance",
+							Name:     "monster.path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/action",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/action",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Kill a monster instance.",
+			},
+		},
+	})
+}
+
+// getAdminDungeonInstancesHandler -
+func (rnr *Runner) getAdminDungeonInstancesHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getAdminDungeonInstancesHandler")
+
+	failing, err := boolQueryParam(qp, "failing")
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	quarantined, err := boolQueryParam(qp, "quarantined")
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	var recs []*record.DungeonInstance
+	if failing || quarantined {
+		l.Info("Querying failing dungeon instance records quarantined >%t<", quarantined)
+		recs, err = m.(*model.Model).GetFailingDungeonInstanceRecs(quarantined)
+	} else {
+		l.Info("Querying dungeon instance records")
+		recs, err = m.(*model.Model).GetDungeonInstanceRecs(
+			&coresql.Options{
+				OrderBy: []coresql.OrderBy{
+					{
+						Col:       "created_at",
+						Direction: coresql.OrderDirectionASC,
+					},
+				},
+			},
+		)
+	}
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Assign response properties
+	data := []schema.DungeonInstanceData{}
+	for _, rec := range recs {
+
+		// Response data
+		responseData, err := rnr.dungeonInstanceResponseData(l, m.(*model.Model), rec)
+		if err != nil {
+			server.WriteError(l, w, err)
+			return err
+		}
+
+		data = append(data, responseData)
+	}
+
+	res := schema.DungeonInstanceResponse{
+		Data: data,
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// postAdminDungeonInstanceUnquarantineHandler -
+func (rnr *Runner) postAdminDungeonInstanceUnquarantineHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminDungeonInstanceUnquarantineHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_instance_id")
+
+	l.Info("Unquarantining dungeon instance ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetDungeonInstanceRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("dungeon instance", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	if !rec.QuarantinedAt.Valid {
+		err := coreerror.NewInvalidActionError("dungeon instance is not quarantined")
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec, err = m.(*model.Model).UnquarantineDungeonInstance(id)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Response data
+	data, err := rnr.dungeonInstanceResponseData(l, m.(*model.Model), rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.DungeonInstanceResponse{
+		Data: []schema.DungeonInstanceData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// getAdminDungeonInstanceHandler -
+func (rnr *Runner) getAdminDungeonInstanceHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getAdminDungeonInstanceHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_instance_id")
+
+	l.Info("Getting dungeon instance ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetDungeonInstanceRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("dungeon instance", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Response data
+	data, err := rnr.dungeonInstanceResponseData(l, m.(*model.Model), rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.DungeonInstanceResponse{
+		Data: []schema.DungeonInstanceData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// deleteAdminDungeonInstanceHandler responds with the dungeon instance as it was
+// before it was deleted
+func (rnr *Runner) deleteAdminDungeonInstanceHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "deleteAdminDungeonInstanceHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_instance_id")
+
+	l.Info("Deleting dungeon instance ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetDungeonInstanceRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("dungeon instance", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Response data
+	data, err := rnr.dungeonInstanceResponseData(l, m.(*model.Model), rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = m.(*model.Model).ForceDeleteDungeonInstance(&model.ForceDeleteDungeonInstanceArgs{
+		SystemActor:       authenticatedUserName(l, r),
+		DungeonInstanceID: id,
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.DungeonInstanceResponse{
+		Data: []schema.DungeonInstanceData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// postAdminDungeonInstanceSpawnHandler -
+func (rnr *Runner) postAdminDungeonInstanceSpawnHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminDungeonInstanceSpawnHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_instance_id")
+
+	req := &schema.DungeonInstanceSpawnRequest{}
+
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	var rs *record.ActionRecordSet
+	if req.Data.MonsterID != "" {
+		l.Info("Spawning monster ID >%s< in dungeon instance ID >%s<", req.Data.MonsterID, id)
+		rs, err = m.(*model.Model).SpawnMonsterInstance(&model.SpawnMonsterInstanceArgs{
+			SystemActor:        authenticatedUserName(l, r),
+			DungeonInstanceID:  id,
+			LocationInstanceID: req.Data.LocationInstanceID,
+			MonsterID:          req.Data.MonsterID,
+		})
+	} else {
+		l.Info("Spawning object ID >%s< in dungeon instance ID >%s<", req.Data.ObjectID, id)
+		rs, err = m.(*model.Model).SpawnObjectInstance(&model.SpawnObjectInstanceArgs{
+			SystemActor:        authenticatedUserName(l, r),
+			DungeonInstanceID:  id,
+			LocationInstanceID: req.Data.LocationInstanceID,
+			ObjectID:           req.Data.ObjectID,
+		})
+	}
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeAdminActionResponse(l, w, http.StatusCreated, rs)
+}
+
+// postAdminCharacterInstanceTeleportHandler -
+func (rnr *Runner) postAdminCharacterInstanceTeleportHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminCharacterInstanceTeleportHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_instance_id")
+	characterInstanceID := pp.ByName("character_instance_id")
+
+	req := &schema.DungeonInstanceTeleportRequest{}
+
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Teleporting character instance ID >%s< to location instance ID >%s<", characterInstanceID, req.Data.LocationInstanceID)
+
+	rs, err := m.(*model.Model).TeleportCharacterInstance(&model.TeleportCharacterInstanceArgs{
+		SystemActor:         authenticatedUserName(l, r),
+		DungeonInstanceID:   id,
+		CharacterInstanceID: characterInstanceID,
+		LocationInstanceID:  req.Data.LocationInstanceID,
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeAdminActionResponse(l, w, http.StatusOK, rs)
+}
+
+// postAdminCharacterInstanceHealHandler -
+func (rnr *Runner) postAdminCharacterInstanceHealHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminCharacterInstanceHealHandler")
+
+	rs, err := m.(*model.Model).HealEntityInstance(&model.EntityInstanceInterventionArgs{
+		SystemActor:       authenticatedUserName(l, r),
+		DungeonInstanceID: pp.ByName("dungeon_instance_id"),
+		EntityType:        model.EntityTypeCharacter,
+		EntityInstanceID:  pp.ByName("character_instance_id"),
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeAdminActionResponse(l, w, http.StatusOK, rs)
+}
+
+// postAdminCharacterInstanceKillHandler -
+func (rnr *Runner) postAdminCharacterInstanceKillHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminCharacterInstanceKillHandler")
+
+	rs, err := m.(*model.Model).KillEntityInstance(&model.EntityInstanceInterventionArgs{
+		SystemActor:       authenticatedUserName(l, r),
+		DungeonInstanceID: pp.ByName("dungeon_instance_id"),
+		EntityType:        model.EntityTypeCharacter,
+		EntityInstanceID:  pp.ByName("character_instance_id"),
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeAdminActionResponse(l, w, http.StatusOK, rs)
+}
+
+// postAdminCharacterInstanceKickHandler -
+func (rnr *Runner) postAdminCharacterInstanceKickHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminCharacterInstanceKickHandler")
+
+	rs, err := m.(*model.Model).KickCharacterInstance(&model.KickCharacterInstanceArgs{
+		SystemActor:         authenticatedUserName(l, r),
+		DungeonInstanceID:   pp.ByName("dungeon_instance_id"),
+		CharacterInstanceID: pp.ByName("character_instance_id"),
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeAdminActionResponse(l, w, http.StatusOK, rs)
+}
+
+// postAdminMonsterInstanceHealHandler -
+func (rnr *Runner) postAdminMonsterInstanceHealHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminMonsterInstanceHealHandler")
+
+	rs, err := m.(*model.Model).HealEntityInstance(&model.EntityInstanceInterventionArgs{
+		SystemActor:       authenticatedUserName(l, r),
+		DungeonInstanceID: pp.ByName("dungeon_instance_id"),
+		EntityType:        model.EntityTypeMonster,
+		EntityInstanceID:  pp.ByName("monster_instance_id"),
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeAdminActionResponse(l, w, http.StatusOK, rs)
+}
+
+// postAdminMonsterInstanceKillHandler -
+func (rnr *Runner) postAdminMonsterInstanceKillHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminMonsterInstanceKillHandler")
+
+	rs, err := m.(*model.Model).KillEntityInstance(&model.EntityInstanceInterventionArgs{
+		SystemActor:       authenticatedUserName(l, r),
+		DungeonInstanceID: pp.ByName("dungeon_instance_id"),
+		EntityType:        model.EntityTypeMonster,
+		EntityInstanceID:  pp.ByName("monster_instance_id"),
+	})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeAdminActionResponse(l, w, http.StatusOK, rs)
+}
+
+// writeAdminActionResponse writes the system action recorded for an intervention,
+// interventions are always narrated in the language dungeon content is authored in
+func (rnr *Runner) writeAdminActionResponse(l logger.Logger, w http.ResponseWriter, status int, rs *record.ActionRecordSet) error {
+
+	responseData, err := actionResponseData(l, rnr.narrator, *rs, "", locale.Default)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.ActionResponse{
+		Data: []schema.ActionResponseData{
+			*responseData,
+		},
+	}
+
+	err = server.WriteResponse(l, w, status, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// boolQueryParam returns the value of a boolean query parameter, false when the
+// query parameter is not provided
+func boolQueryParam(qp *queryparam.QueryParams, name string) (bool, error) {
+	params, ok := qp.Params[name]
+	if !ok || len(params) == 0 {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(params[0].Val)
+	if err != nil {
+		return false, coreerror.NewParamError("%s >%s< is not a valid boolean", name, params[0].Val)
+	}
+
+	return value, nil
+}
+
+// dungeonInstanceResponseData returns dungeon instance response data including the
+// current turn number and the locations, characters and monsters of the instance
+func (rnr *Runner) dungeonInstanceResponseData(l logger.Logger, m *model.Model, rec *record.DungeonInstance) (schema.DungeonInstanceData, error) {
+
+	dungeonRec, err := m.GetDungeonRec(rec.DungeonID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		return schema.DungeonInstanceData{}, err
+	}
+
+	if dungeonRec == nil {
+		return schema.DungeonInstanceData{}, coreerror.NewNotFoundError("dungeon", rec.DungeonID)
+	}
+
+	data, err := rnr.RecordToDungeonInstanceResponseData(*rec, *dungeonRec)
+	if err != nil {
+		return schema.DungeonInstanceData{}, err
+	}
+
+	data.TurnNumber, err = m.GetDungeonInstanceTurnNumber(rec.ID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance turn number >%v<", err)
+		return schema.DungeonInstanceData{}, err
+	}
+
+	recordSet, err := m.GetDungeonInstanceViewRecordSet(rec.ID)
+	if err != nil {
+		l.Warn("failed getting dungeon instance view record set >%v<", err)
+		return schema.DungeonInstanceData{}, err
+	}
+
+	rnr.RecordSetToDungeonInstanceOccupantResponseData(&data, recordSet)
+
+	return data, nil
+}
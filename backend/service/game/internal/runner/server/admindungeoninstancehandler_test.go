@@ -0,0 +1,557 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestAdminDungeonInstanceHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	// Quarantine the first dungeon instance
+	_, err = th.InitTx()
+	require.NoError(t, err, "InitTx returns without error")
+
+	_, err = th.Model.(*model.Model).RecordDungeonInstanceFailure(&model.RecordDungeonInstanceFailureArgs{
+		DungeonInstanceID: th.Data.DungeonInstanceRecs[0].ID,
+		Error:             fmt.Errorf("test failure"),
+		MaxFailures:       1,
+	})
+	require.NoError(t, err, "RecordDungeonInstanceFailure returns without error")
+
+	err = th.CommitTx()
+	require.NoError(t, err, "CommitTx returns without error")
+
+	type testCase struct {
+		TestCase
+		expectResponseBody func(data harness.Data) *schema.DungeonInstanceResponse
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.DungeonInstanceResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "GET - Get quarantined",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAdminDungeonInstances]
+				},
+				RequestQueryParams: func(data harness.Data) map[string]interface{} {
+					params := map[string]interface{}{
+						"quarantined": true,
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					return nil
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectResponseBody: func(data harness.Data) *schema.DungeonInstanceResponse {
+				res := schema.DungeonInstanceResponse{
+					Data: []schema.DungeonInstanceData{
+						{
+							ID:           data.DungeonInstanceRecs[0].ID,
+							DungeonID:    data.DungeonInstanceRecs[0].DungeonID,
+							FailureCount: 1,
+							LastError:    "test failure",
+						},
+					},
+				}
+				return &res
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get all",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAdminDungeonInstances]
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get one",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAdminDungeonInstance]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": data.DungeonInstanceRecs[0].ID,
+					}
+					return params
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectResponseBody: func(data harness.Data) *schema.DungeonInstanceResponse {
+				res := schema.DungeonInstanceResponse{
+					Data: []schema.DungeonInstanceData{
+						{
+							ID:           data.DungeonInstanceRecs[0].ID,
+							DungeonID:    data.DungeonInstanceRecs[0].DungeonID,
+							FailureCount: 1,
+							LastError:    "test failure",
+						},
+					},
+				}
+				return &res
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "DELETE - Force delete",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[deleteAdminDungeonInstance]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": data.DungeonInstanceRecs[0].ID,
+					}
+					return params
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectResponseBody: func(data harness.Data) *schema.DungeonInstanceResponse {
+				res := schema.DungeonInstanceResponse{
+					Data: []schema.DungeonInstanceData{
+						{
+							ID:           data.DungeonInstanceRecs[0].ID,
+							DungeonID:    data.DungeonInstanceRecs[0].DungeonID,
+							FailureCount: 1,
+							LastError:    "test failure",
+						},
+					},
+				}
+				return &res
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Unquarantine quarantined",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminDungeonInstanceUnquarantine]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": data.DungeonInstanceRecs[0].ID,
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					return nil
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectResponseBody: func(data harness.Data) *schema.DungeonInstanceResponse {
+				res := schema.DungeonInstanceResponse{
+					Data: []schema.DungeonInstanceData{
+						{
+							ID:           data.DungeonInstanceRecs[0].ID,
+							DungeonID:    data.DungeonInstanceRecs[0].DungeonID,
+							FailureCount: 0,
+							LastError:    "test failure",
+						},
+					},
+				}
+				return &res
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Unquarantine non-existant",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminDungeonInstanceUnquarantine]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					return nil
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusNotFound,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get quarantined with an API key",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAdminDungeonInstances]
+				},
+				RequestQueryParams: func(data harness.Data) map[string]interface{} {
+					params := map[string]interface{}{
+						"quarantined": true,
+					}
+					return params
+				},
+				APIKeyOwner:     harness.APIKeyOwnerAnalytics,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get quarantined as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getAdminDungeonInstances]
+				},
+				AccountRole:  record.AccountRolePlayer,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Unquarantine as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminDungeonInstanceUnquarantine]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": data.DungeonInstanceRecs[0].ID,
+					}
+					return params
+				},
+				AccountRole:  record.AccountRolePlayer,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK {
+					return
+				}
+
+				var responseBody *schema.DungeonInstanceResponse
+				if body != nil {
+					responseBody = body.(*schema.DungeonInstanceResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+
+				// Validate response body
+				if tc.expectResponseBody != nil {
+					expectResponseBody := tc.expectResponseBody(th.Data)
+					require.Equal(t, len(expectResponseBody.Data), len(responseBody.Data), "Response body data length equals expected")
+
+					// Validate response body data
+					for idx, expectData := range expectResponseBody.Data {
+						require.Equal(t, expectData.ID, responseBody.Data[idx].ID, "ID equals expected")
+						require.Equal(t, expectData.DungeonID, responseBody.Data[idx].DungeonID, "DungeonID equals expected")
+						require.Equal(t, expectData.FailureCount, responseBody.Data[idx].FailureCount, "FailureCount equals expected")
+						require.Equal(t, expectData.LastError, responseBody.Data[idx].LastError, "LastError equals expected")
+					}
+				}
+
+				for _, data := range responseBody.Data {
+					require.NotEmpty(t, data.Locations, "Locations is not empty")
+				}
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
+
+func TestAdminDungeonInstanceInterventionHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectCommand string
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.ActionResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	characterInstancePathParams := func(data harness.Data) map[string]string {
+		params := map[string]string{
+			":dungeon_instance_id":   data.CharacterInstanceRecs[0].DungeonInstanceID,
+			":character_instance_id": data.CharacterInstanceRecs[0].ID,
+		}
+		return params
+	}
+
+	monsterInstancePathParams := func(data harness.Data) map[string]string {
+		params := map[string]string{
+			":dungeon_instance_id": data.MonsterInstanceRecs[0].DungeonInstanceID,
+			":monster_instance_id": data.MonsterInstanceRecs[0].ID,
+		}
+		return params
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "POST - Teleport character instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminCharacterInstanceTeleport]
+				},
+				RequestPathParams: characterInstancePathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					characterInstanceRec := data.CharacterInstanceRecs[0]
+					req := schema.DungeonInstanceTeleportRequest{}
+					for _, rec := range data.LocationInstanceRecs {
+						if rec.DungeonInstanceID == characterInstanceRec.DungeonInstanceID && rec.ID != characterInstanceRec.LocationInstanceID {
+							req.Data.LocationInstanceID = rec.ID
+							break
+						}
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectCommand: record.ActionCommandTeleport,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Teleport character instance to current location",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminCharacterInstanceTeleport]
+				},
+				RequestPathParams: characterInstancePathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonInstanceTeleportRequest{
+						Data: schema.DungeonInstanceTeleportRequestData{
+							LocationInstanceID: data.CharacterInstanceRecs[0].LocationInstanceID,
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Spawn monster instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminDungeonInstanceSpawn]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": data.CharacterInstanceRecs[0].DungeonInstanceID,
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonInstanceSpawnRequest{
+						Data: schema.DungeonInstanceSpawnRequestData{
+							LocationInstanceID: data.CharacterInstanceRecs[0].LocationInstanceID,
+							MonsterID:          data.MonsterRecs[0].ID,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+			expectCommand: record.ActionCommandSpawn,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Spawn object instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminDungeonInstanceSpawn]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": data.CharacterInstanceRecs[0].DungeonInstanceID,
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonInstanceSpawnRequest{
+						Data: schema.DungeonInstanceSpawnRequestData{
+							LocationInstanceID: data.CharacterInstanceRecs[0].LocationInstanceID,
+							ObjectID:           data.ObjectRecs[0].ID,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+			expectCommand: record.ActionCommandSpawn,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Heal character instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminCharacterInstanceHeal]
+				},
+				RequestPathParams: characterInstancePathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			expectCommand: record.ActionCommandHeal,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Kill character instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminCharacterInstanceKill]
+				},
+				RequestPathParams: characterInstancePathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			expectCommand: record.ActionCommandKill,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Kick character instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminCharacterInstanceKick]
+				},
+				RequestPathParams: characterInstancePathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			expectCommand: record.ActionCommandKick,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Heal monster instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminMonsterInstanceHeal]
+				},
+				RequestPathParams: monsterInstancePathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			expectCommand: record.ActionCommandHeal,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Kill monster instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminMonsterInstanceKill]
+				},
+				RequestPathParams: monsterInstancePathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			expectCommand: record.ActionCommandKill,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Kill monster instance in another dungeon instance",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminMonsterInstanceKill]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_instance_id": "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+						":monster_instance_id": data.MonsterInstanceRecs[0].ID,
+					}
+					return params
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusNotFound,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Kill character instance as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postAdminCharacterInstanceKill]
+				},
+				RequestPathParams: characterInstancePathParams,
+				AccountRole:       record.AccountRolePlayer,
+				ResponseCode:      http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK && tc.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				require.NotNil(t, body, "Response body is not nil")
+				responseBody := body.(*schema.ActionResponse)
+
+				require.Len(t, responseBody.Data, 1, "Response body data length equals expected")
+				require.Equal(t, tc.expectCommand, responseBody.Data[0].Command, "Command equals expected")
+				require.Equal(t, harness.AccountNameGameMaster, responseBody.Data[0].SystemActor, "SystemActor equals expected")
+				require.Nil(t, responseBody.Data[0].Character, "Character is nil")
+				require.Nil(t, responseBody.Data[0].Monster, "Monster is nil")
+				require.NotEmpty(t, responseBody.Data[0].Narrative, "Narrative is not empty")
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package runner
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	corejwt "gitlab.com/alienspaces/go-mud/backend/core/jwt"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	jwtIssuer string = "go-mud"
+)
+
+// accountClaims are the claims of an account token, the subject is the account ID
+type accountClaims struct {
+	jwt.RegisteredClaims
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+var (
+	generatedJWTPrivateKey     *rsa.PrivateKey
+	generatedJWTPrivateKeyErr  error
+	generatedJWTPrivateKeyOnce sync.Once
+)
+
+// loadJWTPrivateKey reads the PEM encoded RSA private key from the configured path
+// or, when an ephemeral key is enabled for development and testing, generates a
+// key that is shared by every runner in this process.
+func loadJWTPrivateKey(l logger.Logger, path string, ephemeral bool) (*rsa.PrivateKey, error) {
+
+	if path == "" {
+		if !ephemeral {
+			return nil, fmt.Errorf("JWT private key path is not configured and an ephemeral key is not enabled")
+		}
+		generatedJWTPrivateKeyOnce.Do(func() {
+			l.Warn("JWT ephemeral key is enabled, generating a key, account tokens will not be valid after restart or across servers")
+			generatedJWTPrivateKey, generatedJWTPrivateKeyErr = rsa.GenerateKey(rand.Reader, 2048)
+		})
+		return generatedJWTPrivateKey, generatedJWTPrivateKeyErr
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		l.Warn("failed reading JWT private key path >%s< >%v<", path, err)
+		return nil, err
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("JWT private key path >%s< does not contain a PEM encoded key", path)
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("JWT private key path >%s< does not contain an RSA key", path)
+		}
+		return rsaKey, nil
+	default:
+		return nil, fmt.Errorf("JWT private key path >%s< contains unsupported PEM block type >%s<", path, block.Type)
+	}
+}
+
+// encodeAccountToken returns a signed token for the account and when it expires
+func (rnr *Runner) encodeAccountToken(rec *record.Account) (string, time.Time, error) {
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(rnr.config.JWTTokenDuration)
+
+	claims := accountClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    jwtIssuer,
+			Subject:   rec.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Name:  rec.Name,
+		Email: rec.Email,
+	}
+
+	token, err := corejwt.Encode(claims, rnr.jwtPrivateKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// authenticateRequest authenticates a request with either a Bearer account token in
+// the Authorization header or an API key in the X-API-Key header.
+func (rnr *Runner) authenticateRequest(l logger.Logger, m modeller.Modeller, r *http.Request) (server.AuthenticatedRequest, error) {
+	l = loggerWithFunctionContext(l, "authenticateRequest")
+
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		return rnr.authenticateAccountToken(l, m.(*model.Model), authorization)
+	}
+
+	return rnr.authenticateAPIKey(l, m.(*model.Model), r.Header.Get(server.HeaderXAPIKey))
+}
+
+// authenticateAccountToken verifies the Bearer account token and that the account
+// still exists.
+func (rnr *Runner) authenticateAccountToken(l logger.Logger, m *model.Model, authorization string) (server.AuthenticatedRequest, error) {
+
+	tokenString, err := corejwt.GetJWT(authorization)
+	if err != nil {
+		l.Warn("failed getting JWT >%v<", err)
+		return server.AuthenticatedRequest{}, err
+	}
+
+	claims := accountClaims{}
+	err = corejwt.Decode(&claims, tokenString, &rnr.jwtPrivateKey.PublicKey)
+	if err != nil {
+		l.Warn("failed decoding JWT >%v<", err)
+		return server.AuthenticatedRequest{}, err
+	}
+
+	if claims.Issuer != jwtIssuer || claims.Subject == "" {
+		err := fmt.Errorf("JWT issuer >%s< subject >%s< is invalid", claims.Issuer, claims.Subject)
+		l.Warn(err.Error())
+		return server.AuthenticatedRequest{}, err
+	}
+
+	accountRec, err := m.GetAccountRec(claims.Subject, nil)
+	if err != nil {
+		l.Warn("failed getting account record >%v<", err)
+		return server.AuthenticatedRequest{}, err
+	}
+
+	if accountRec == nil {
+		err := fmt.Errorf("JWT account ID >%s< does not exist", claims.Subject)
+		l.Warn(err.Error())
+		return server.AuthenticatedRequest{}, err
+	}
+
+	return server.AuthenticatedRequest{
+		Type: server.AuthenticatedTypeUser,
+		User: server.AuthenticatedUser{
+			ID:    accountRec.ID,
+			Name:  accountRec.Name,
+			Email: accountRec.Email,
+		},
+		Permissions: accountPermissions(accountRec),
+	}, nil
+}
+
+// authenticateAPIKey verifies the API key has not been revoked or expired. API keys
+// issued for an account authenticate as that account with the permissions of the
+// key scopes.
+func (rnr *Runner) authenticateAPIKey(l logger.Logger, m *model.Model, key string) (server.AuthenticatedRequest, error) {
+
+	apiKeyRec, err := m.AuthenticateAPIKey(key)
+	if err != nil {
+		l.Warn("failed authenticating API key >%v<", err)
+		return server.AuthenticatedRequest{}, err
+	}
+
+	if apiKeyRec == nil {
+		err := fmt.Errorf("API key is unknown, revoked or expired")
+		l.Warn(err.Error())
+		return server.AuthenticatedRequest{}, err
+	}
+
+	return server.AuthenticatedRequest{
+		Type: server.AuthenticatedTypeAPIKey,
+		User: server.AuthenticatedUser{
+			ID:   null.NullStringToString(apiKeyRec.AccountID),
+			Name: apiKeyRec.Owner,
+		},
+		Permissions: apiKeyPermissions(apiKeyRec),
+	}, nil
+}
+
+// authenticatedAccountID returns the account ID of an authenticated request, requests
+// authenticated with an API key that was not issued for an account have no account ID
+func authenticatedAccountID(l logger.Logger, r *http.Request) string {
+	auth := server.AuthData(l, r)
+	if auth == nil {
+		return ""
+	}
+	accountID, _ := auth.User.ID.(string)
+	return accountID
+}
+
+// authenticatedUserName returns the account name of an authenticated request, or the
+// owner of the API key when the request was authenticated with an API key
+func authenticatedUserName(l logger.Logger, r *http.Request) string {
+	auth := server.AuthData(l, r)
+	if auth == nil {
+		return ""
+	}
+	return auth.User.Name
+}
+
+// authorizeCharacterRec returns an error when the authenticated account does not
+// own the character
+func authorizeCharacterRec(l logger.Logger, r *http.Request, characterRec *record.Character) error {
+
+	accountID := authenticatedAccountID(l, r)
+	if accountID == "" || accountID != null.NullStringToString(characterRec.AccountID) {
+		l.Warn("account ID >%s< does not own character ID >%s<", accountID, characterRec.ID)
+		return coreerror.NewUnauthorizedError()
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package runner
+
+import (
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	permissionPlay                  server.AuthorizedPermission = server.AuthorizedPermission(model.PermissionPlay)
+	permissionDungeonInstanceManage server.AuthorizedPermission = server.AuthorizedPermission(model.PermissionDungeonInstanceManage)
+	permissionDungeonAuthor         server.AuthorizedPermission = server.AuthorizedPermission(model.PermissionDungeonAuthor)
+	permissionAccountManage         server.AuthorizedPermission = server.AuthorizedPermission(model.PermissionAccountManage)
+)
+
+// accountPermissions returns the permissions granted to an account by its role
+func accountPermissions(rec *record.Account) []server.AuthorizedPermission {
+	return toAuthorizedPermissions(model.AccountRolePermissions[rec.Role])
+}
+
+// apiKeyPermissions returns the permissions granted to an API key by its scopes
+func apiKeyPermissions(rec *record.APIKey) []server.AuthorizedPermission {
+	return toAuthorizedPermissions(rec.Scopes)
+}
+
+func toAuthorizedPermissions(permissions []string) []server.AuthorizedPermission {
+	authorizedPermissions := []server.AuthorizedPermission{}
+	for _, permission := range permissions {
+		authorizedPermissions = append(authorizedPermissions, server.AuthorizedPermission(permission))
+	}
+	return authorizedPermissions
+}
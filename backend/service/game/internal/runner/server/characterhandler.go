@@ -7,8 +7,10 @@ import (
 
 	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
 	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
 	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
@@ -32,7 +34,11 @@ func (rnr *Runner) CharacterHandlerConfig(hc map[string]server.HandlerConfig) ma
 			HandlerFunc: rnr.getCharactersHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateParamsConfig: &server.ValidateParamsConfig{
 					QueryParamSchema: &jsonschema.SchemaWithReferences{
@@ -66,7 +72,11 @@ func (rnr *Runner) CharacterHandlerConfig(hc map[string]server.HandlerConfig) ma
 			HandlerFunc: rnr.getCharacterHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateParamsConfig: &server.ValidateParamsConfig{
 					PathParamSchema: &jsonschema.SchemaWithReferences{
@@ -100,7 +110,11 @@ func (rnr *Runner) CharacterHandlerConfig(hc map[string]server.HandlerConfig) ma
 			HandlerFunc: rnr.postCharacterHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -127,10 +141,18 @@ func (rnr *Runner) CharacterHandlerConfig(hc map[string]server.HandlerConfig) ma
 			},
 		},
 		putCharacter: {
-			Method:           http.MethodPut,
-			Path:             "/api/v1/characters/:character_id",
-			HandlerFunc:      rnr.putCharacterHandler,
-			MiddlewareConfig: server.MiddlewareConfig{},
+			Method:      http.MethodPut,
+			Path:        "/api/v1/characters/:character_id",
+			HandlerFunc: rnr.putCharacterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
+				},
+			},
 			DocumentationConfig: server.DocumentationConfig{
 				Document:    true,
 				Description: "Update a character.",
@@ -166,6 +188,12 @@ func (rnr *Runner) getCharacterHandler(w http.ResponseWriter, r *http.Request, p
 		return err
 	}
 
+	err = authorizeCharacterRec(l, r, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	recs = append(recs, rec)
 
 	// Assign response properties
@@ -208,13 +236,26 @@ func (rnr *Runner) getCharactersHandler(w http.ResponseWriter, r *http.Request,
 
 	opts := queryParamsToSQLOptions(qp)
 
-	l.Info("Querying character records with params >%#v<", qp)
+	// Only characters owned by the authenticated account, API keys that were not
+	// issued for an account own no characters
+	accountID := authenticatedAccountID(l, r)
 
-	recs, err := m.(*model.Model).GetCharacterRecs(opts)
-	if err != nil {
-		l.Warn("failed getting dungeon character records >%v<", err)
-		server.WriteError(l, w, err)
-		return err
+	var recs []*record.Character
+	if accountID != "" {
+		opts.Params = append(opts.Params, coresql.Param{
+			Col: record.FieldCharacterAccountID,
+			Val: accountID,
+		})
+
+		l.Info("Querying character records with params >%#v<", qp)
+
+		var err error
+		recs, err = m.(*model.Model).GetCharacterRecs(opts)
+		if err != nil {
+			l.Warn("failed getting dungeon character records >%v<", err)
+			server.WriteError(l, w, err)
+			return err
+		}
 	}
 
 	// Assign response properties
@@ -244,7 +285,7 @@ func (rnr *Runner) getCharactersHandler(w http.ResponseWriter, r *http.Request,
 
 	l.Info("Responding with >%#v<", res)
 
-	err = server.WriteResponse(l, w, http.StatusOK, res)
+	err := server.WriteResponse(l, w, http.StatusOK, res)
 	if err != nil {
 		l.Warn("failed writing response >%v<", err)
 		return err
@@ -265,7 +306,18 @@ func (rnr *Runner) postCharacterHandler(w http.ResponseWriter, r *http.Request,
 		return err
 	}
 
-	rec := record.Character{}
+	// Characters are owned by the account that creates them, API keys that were
+	// not issued for an account cannot create characters
+	accountID := authenticatedAccountID(l, r)
+	if accountID == "" {
+		err := coreerror.NewUnauthorizedError()
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec := record.Character{
+		AccountID: null.NullStringFromString(accountID),
+	}
 
 	// Record data
 	err = rnr.CharacterRequestDataToRecord(req.Data, &rec)
@@ -337,6 +389,12 @@ func (rnr *Runner) putCharacterHandler(w http.ResponseWriter, r *http.Request, p
 		return err
 	}
 
+	err = authorizeCharacterRec(l, r, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	req := &schema.CharacterRequest{}
 	req, err = server.ReadRequest(l, r, req)
 	if err != nil {
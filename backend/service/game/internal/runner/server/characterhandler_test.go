@@ -205,6 +205,102 @@ func TestGetCharacterHandler(t *testing.T) {
 				return &res
 			},
 		},
+		{
+			TestCase: TestCase{
+				Name: "get one with an API key issued for the owning account",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getCharacter]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":character_id": data.CharacterRecs[0].ID,
+					}
+					return params
+				},
+				APIKeyOwner:     harness.APIKeyOwnerPlayBot,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get one with an API key without the play scope",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getCharacter]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":character_id": data.CharacterRecs[0].ID,
+					}
+					return params
+				},
+				APIKeyOwner:     harness.APIKeyOwnerAnalytics,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get one with an unknown API key",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getCharacter]
+				},
+				RequestHeaders: func(data harness.Data) map[string]string {
+					headers := map[string]string{
+						"Authorization":      "",
+						server.HeaderXAPIKey: "gmk_00000000_unknown",
+					}
+					return headers
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":character_id": data.CharacterRecs[0].ID,
+					}
+					return params
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusUnauthorized,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get one owned by another account",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getCharacter]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":character_id": data.CharacterRecs[0].ID,
+					}
+					return params
+				},
+				AccountName:     harness.AccountNameOther,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "get one without authorization",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getCharacter]
+				},
+				RequestHeaders: func(data harness.Data) map[string]string {
+					headers := map[string]string{
+						"Authorization": "",
+					}
+					return headers
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":character_id": data.CharacterRecs[0].ID,
+					}
+					return params
+				},
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusUnauthorized,
+			},
+		},
 		{
 			TestCase: TestCase{
 				Name: "Get one with unknown character id",
@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
@@ -11,6 +12,7 @@ func characterResponseData(l logger.Logger, characterRec *record.Character, rs *
 
 	data := schema.DungeonCharacterData{
 		ID:               characterRec.ID,
+		AccountID:        null.NullStringToString(characterRec.AccountID),
 		Name:             characterRec.Name,
 		Strength:         characterRec.Strength,
 		Dexterity:        characterRec.Dexterity,
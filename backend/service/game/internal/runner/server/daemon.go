@@ -15,48 +15,86 @@ type processState string
 const (
 	processStatePending processState = "pending"
 	processStateRunning processState = "running"
-	processStateDone    processState = "done"
 	processStateError   processState = "error"
 )
 
 const (
-	decayTurns int = 10
+	minDaemonRetryBackoff time.Duration = 1 * time.Second
+	maxDaemonRetryBackoff time.Duration = 1 * time.Minute
 )
 
+// daemonRetryBackoff is how long to wait before processing a dungeon instance
+// again after the provided number of consecutive failures.
+func daemonRetryBackoff(failures int) time.Duration {
+	backoff := minDaemonRetryBackoff
+	for i := 1; i < failures; i++ {
+		backoff *= 2
+		if backoff >= maxDaemonRetryBackoff {
+			return maxDaemonRetryBackoff
+		}
+	}
+	return backoff
+}
+
 type dungeonInstanceState struct {
-	turn  int
-	state processState
-	err   error
+	dungeonInstanceID string
+	turn              int
+	state             processState
+	err               error
+	// failures is the number of consecutive times processing has failed
+	failures int
+	// nextTurnAt is when the dungeon instance should next be processed
+	nextTurnAt time.Time
+	// index is the position of the dungeon instance in the scheduler queue,
+	// -1 when the dungeon instance is not pending
+	index int
 }
 
 type dungeonInstanceProcessingResult struct {
 	Error             error
 	DungeonInstanceID string
 	Turn              int
+	LeaseLost         bool
+	// NextTurnAt is when the dungeon instance should next be processed
+	NextTurnAt time.Time
+	// Duration is how long processing the dungeon instance took
+	Duration time.Duration
 }
 
-func daemonMergeDungeonInstanceStates(dungeonInstanceStates map[string]*dungeonInstanceState, diRecs []*record.DungeonInstance) map[string]*dungeonInstanceState {
-	for idx := range diRecs {
-		if _, ok := dungeonInstanceStates[diRecs[idx].ID]; !ok {
-			dungeonInstanceStates[diRecs[idx].ID] = &dungeonInstanceState{
-				state: processStatePending,
-			}
-		}
-	}
-	return dungeonInstanceStates
-}
+// daemonGetDungeonInstanceRecs renews and claims dungeon instance leases and
+// returns the dungeon instance records this daemon holds a lease for.
+func daemonGetDungeonInstanceRecs(l logger.Logger, m *model.Model, args *model.ClaimDungeonInstanceLeasesArgs) ([]*record.DungeonInstance, error) {
+	l = loggerWithFunctionContext(l, "daemonGetDungeonInstanceRecs")
 
-func daemonRemoveDungeonInstanceState(dungeonInstanceStates map[string]*dungeonInstanceState, diRec *record.DungeonInstance) map[string]*dungeonInstanceState {
+	result, err := m.ClaimDungeonInstanceLeases(args)
+	if err != nil {
+		l.Warn("failed claiming dungeon instance leases >%v<", err)
+		return nil, err
+	}
 
-	delete(dungeonInstanceStates, diRec.ID)
+	if result.ClaimedCount > 0 {
+		l.Info("Daemon ID >%s< claimed >%d< dungeon instance leases", args.DaemonID, result.ClaimedCount)
+	}
 
-	return dungeonInstanceStates
-}
+	if len(result.Records) == 0 {
+		return nil, nil
+	}
 
-func daemonGetDungeonInstanceRecs(l logger.Logger, m *model.Model) ([]*record.DungeonInstance, error) {
-	l = loggerWithFunctionContext(l, "daemonGetDungeonInstanceRecs")
+	dungeonInstanceIDs := []string{}
+	for idx := range result.Records {
+		dungeonInstanceIDs = append(dungeonInstanceIDs, result.Records[idx].DungeonInstanceID)
+	}
 
-	diRecs, err := m.GetDungeonInstanceRecs(nil)
+	diRecs, err := m.GetDungeonInstanceRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "id",
+					Val: dungeonInstanceIDs,
+				},
+			},
+		},
+	)
 	if err != nil {
 		l.Warn("failed getting dungeon instance records >%v<", err)
 		return nil, err
@@ -88,6 +126,29 @@ func daemonDungeonInstanceEmpty(l logger.Logger, m *model.Model, dir *record.Dun
 	return len(ciRecs) == 0, nil
 }
 
+// daemonExitIdleCharacters exits characters from the dungeon instance that have
+// not performed an action within the idle timeout.
+func daemonExitIdleCharacters(l logger.Logger, m *model.Model, dir *record.DungeonInstance, idleTimeout time.Duration) error {
+	l = loggerWithFunctionContext(l, "daemonExitIdleCharacters")
+
+	ciRecs, err := m.GetIdleCharacterInstanceRecs(dir.ID, time.Now().UTC().Add(-idleTimeout))
+	if err != nil {
+		l.Warn("failed getting idle character instance records >%v<", err)
+		return err
+	}
+
+	for idx := range ciRecs {
+		l.Info("Exiting idle character ID >%s< from dungeon instance ID >%s<", ciRecs[idx].CharacterID, dir.ID)
+		err := m.CharacterExitDungeon(ciRecs[idx].CharacterID)
+		if err != nil {
+			l.Warn("failed exiting character ID >%s< >%v<", ciRecs[idx].CharacterID, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func daemonShutdownDungeonInstance(l logger.Logger, m *model.Model, dir *record.DungeonInstance) error {
 	l = loggerWithFunctionContext(l, "daemonShutdownDungeonInstance")
 
@@ -101,385 +162,360 @@ func daemonShutdownDungeonInstance(l logger.Logger, m *model.Model, dir *record.
 }
 
 // daemonInitCycle initialises a new database transaction and must commit or
-// rollback before returning. It takes an existing set of dungeon instance states,
-// fetches all existing dungeon instances, checks whether they are empty, and updates
-// the set of dungeon instance states by removing empty dungeon instances and adding
-// new dungeon instances.
-func (rnr *Runner) daemonInitCycle(l logger.Logger, dis map[string]*dungeonInstanceState) (map[string]*dungeonInstanceState, error) {
+// rollback before returning. It renews and claims dungeon instance leases, checks
+// whether leased dungeon instances are empty, and updates the scheduler by removing
+// empty and no longer leased dungeon instances and adding newly leased dungeon
+// instances. When checking consistency the invariants of leased dungeon
+// instances that are not being processed are checked after the transaction has
+// been committed, each in its own transaction.
+func (rnr *Runner) daemonInitCycle(l logger.Logger, s *daemonScheduler, checkConsistency bool) error {
 	l = loggerWithFunctionContext(l, "daemonInitCycle")
 
 	m, err := rnr.initModeller(l)
 	if err != nil {
 		l.Warn("failed initialising modeller >%v<", err)
-		return nil, err
+		return err
 	}
 
-	// Fetch all current dungeon instance records
-	diRecs, err := daemonGetDungeonInstanceRecs(l, m)
-	if err != nil {
-		err = m.Rollback()
-		if err != nil {
-			l.Warn("failed model rollback >%v<", err)
-			return nil, err
+	handleErr := func(err error) error {
+		rerr := m.Rollback()
+		if rerr != nil {
+			l.Warn("failed model rollback >%v<", rerr)
+			return fmt.Errorf("%v with %v", rerr, err)
 		}
+		return err
+	}
+
+	// Fetch all dungeon instance records this daemon holds a lease for
+	diRecs, err := daemonGetDungeonInstanceRecs(l, m, &model.ClaimDungeonInstanceLeasesArgs{
+		DaemonID:      rnr.config.DaemonID,
+		LeaseDuration: rnr.config.DaemonLeaseDuration,
+		MaxLeases:     rnr.config.DaemonMaxInstances,
+	})
+	if err != nil {
 		l.Warn("failed getting dungeon instance recs >%v<", err)
-		return nil, err
+		return handleErr(err)
 	}
 
 	l.Debug("Dungeon instance count >%d<", len(diRecs))
 
 	// When there are no characters instances in a particular dungeon instance
 	// for a certain period of time, delete the dungeon instance.
+	activeRecs := []*record.DungeonInstance{}
+	checkRecs := []*record.DungeonInstance{}
 	for idx := range diRecs {
-		empty, err := daemonDungeonInstanceEmpty(l, m, diRecs[idx])
-		if err != nil {
-			err = m.Rollback()
+
+		// Quarantined dungeon instances are not processed or shutdown until
+		// they have been unquarantined
+		if diRecs[idx].QuarantinedAt.Valid {
+			l.Debug("Skipping quarantined dungeon instance ID >%s<", diRecs[idx].ID)
+			continue
+		}
+
+		// Dungeon instances that are currently being processed are checked
+		// next cycle
+		if st := s.get(diRecs[idx].ID); st != nil && st.state == processStateRunning {
+			activeRecs = append(activeRecs, diRecs[idx])
+			continue
+		}
+
+		// Characters that have not performed an action for a certain period of
+		// time exit the dungeon so their progress is saved
+		if rnr.config.DaemonIdleCharacterTimeout > 0 {
+			err := daemonExitIdleCharacters(l, m, diRecs[idx], rnr.config.DaemonIdleCharacterTimeout)
 			if err != nil {
-				l.Warn("failed model rollback >%v<", err)
-				return nil, err
+				l.Warn("failed exiting dungeon instance ID >%s< idle characters >%v<", diRecs[idx].ID, err)
+				return handleErr(err)
 			}
+		}
+
+		empty, err := daemonDungeonInstanceEmpty(l, m, diRecs[idx])
+		if err != nil {
 			l.Warn("failed check if dungeon instance ID >%s< is empty >%v<", diRecs[idx].ID, err)
-			return nil, err
+			return handleErr(err)
+		}
+
+		emptyFor, err := m.UpdateDungeonInstanceEmptySince(diRecs[idx], empty)
+		if err != nil {
+			l.Warn("failed updating dungeon instance ID >%s< empty since >%v<", diRecs[idx].ID, err)
+			return handleErr(err)
 		}
 
-		if empty {
+		if empty && emptyFor >= rnr.config.DaemonEmptyGracePeriod {
+			l.Info("Shutting down dungeon instance ID >%s< empty for >%s<", diRecs[idx].ID, emptyFor)
 			err := daemonShutdownDungeonInstance(l, m, diRecs[idx])
 			if err != nil {
-				err = m.Rollback()
-				if err != nil {
-					l.Warn("failed model rollback >%v<", err)
-					return nil, err
-				}
 				l.Warn("failed shutting down dungeon instance ID >%s< >%v<", diRecs[idx].ID, err)
-				return nil, err
+				return handleErr(err)
 			}
-			dis = daemonRemoveDungeonInstanceState(dis, diRecs[idx])
+			continue
 		}
-	}
 
-	// Merge any new dungeon instance records with existing dungeon instance states
-	dis = daemonMergeDungeonInstanceStates(dis, diRecs)
+		activeRecs = append(activeRecs, diRecs[idx])
+		checkRecs = append(checkRecs, diRecs[idx])
+	}
 
 	err = m.Commit()
 	if err != nil {
 		l.Warn("failed model commit >%v<", err)
-		return nil, err
+		return err
 	}
 
-	return dis, nil
+	// Consistency is checked once leases have been committed so a failed check
+	// neither loses leases nor stops the daemon
+	if checkConsistency {
+		for idx := range checkRecs {
+			err := rnr.daemonCheckConsistency(l, checkRecs[idx].ID)
+			if err != nil {
+				l.Warn("failed checking dungeon instance ID >%s< consistency >%v<", checkRecs[idx].ID, err)
+			}
+		}
+	}
+
+	// Remove dungeon instances that have been shutdown or are no longer leased
+	// and schedule any newly leased dungeon instances for immediate processing
+	s.prune(activeRecs)
+	s.merge(activeRecs, time.Now())
+
+	return nil
+}
+
+const (
+	maxDaemonInitInterval time.Duration = 3 * time.Second
+)
+
+// daemonInitInterval is how often the daemon renews its dungeon instance leases
+// and checks for new and empty dungeon instances. Leases are renewed several
+// times within the lease duration so a single slow cycle does not lose leases.
+func (rnr *Runner) daemonInitInterval() time.Duration {
+	interval := rnr.config.DaemonLeaseDuration / 3
+	if interval > maxDaemonInitInterval {
+		interval = maxDaemonInitInterval
+	}
+	return interval
 }
 
 // RunDaemon is a long running background process that manages the server game loop.
 func (rnr *Runner) RunDaemon(args map[string]interface{}) error {
 	l := loggerWithFunctionContext(rnr.Log, "RunDaemon")
 
-	dungeonInstanceStates := make(map[string]*dungeonInstanceState)
+	s := newDaemonScheduler()
 
-	c := make(chan dungeonInstanceProcessingResult, 1)
+	p := rnr.newDaemonWorkerPool(l, rnr.config.DaemonWorkers)
+	defer p.stop()
 
 	cycles := 0
 
+	var nextInitAt time.Time
+
 	for keepRunning() {
 
-		l = loggerWithCycleContext(l, cycles)
+		now := time.Now()
 
-		dungeonInstanceStates, err := rnr.daemonInitCycle(l, dungeonInstanceStates)
-		if err != nil {
-			l.Warn("failed daemon init cycle >%v<", err)
-			return err
-		}
+		if !now.Before(nextInitAt) {
+			l = loggerWithCycleContext(l, cycles)
 
-		cycles++
-		if cycles%20 == 0 {
-			l.Info("Daemon cycle >%d<", cycles)
-		}
+			// Dungeon instance invariants are checked every so many cycles
+			checkConsistency := rnr.config.DaemonConsistencyCheckCycles > 0 && cycles%rnr.config.DaemonConsistencyCheckCycles == 0
 
-		// When there is nothing to process, wait and check for new instances again
-		if len(dungeonInstanceStates) == 0 {
-			time.Sleep(3000 * time.Millisecond)
-			continue
+			err := rnr.daemonInitCycle(l, s, checkConsistency)
+			if err != nil {
+				l.Warn("failed daemon init cycle >%v<", err)
+				return err
+			}
+
+			nextInitAt = now.Add(rnr.daemonInitInterval())
+
+			cycles++
+			if cycles%20 == 0 {
+				l.Info("Daemon cycle >%d< dungeon instance count >%d< busy workers >%d<", cycles, s.len(), p.busy)
+			}
 		}
 
-		runningCount := 0
-		for dungeonInstanceID := range dungeonInstanceStates {
-
-			l = loggerWithInstanceContext(l, dungeonInstanceID)
-
-			switch dungeonInstanceStates[dungeonInstanceID].state {
-			case processStatePending:
-				l.Debug("(pending) Kicking off dungeon instance ID >%s< turn >%d<", dungeonInstanceID, dungeonInstanceStates[dungeonInstanceID].turn)
-				dungeonInstanceStates[dungeonInstanceID].state = processStateRunning
-
-				go func(dungeonInstanceID string) {
-
-					var m *model.Model
-
-					handleErr := func(err error) {
-						l.Warn("failed with error >%v<", err)
-						if m != nil {
-							rerr := m.Rollback()
-							if rerr != nil {
-								l.Warn("failed model rollback >%v<", rerr)
-								err = fmt.Errorf("%v with %v", rerr, err)
-							}
-						}
-						c <- dungeonInstanceProcessingResult{
-							DungeonInstanceID: dungeonInstanceID,
-							Error:             err,
-						}
-					}
-
-					m, err := rnr.initModeller(l)
-					if err != nil {
-						handleErr(err)
-						return
-					}
-
-					result, err := processDungeonInstanceTurn(l, m, dungeonInstanceID)
-					if err != nil {
-						handleErr(err)
-						return
-					}
-
-					if result.incrementTurnResult.Incremented {
-						err = m.Commit()
-						if err != nil {
-							handleErr(err)
-							return
-						}
-					} else {
-						err = m.Rollback()
-						if err != nil {
-							handleErr(err)
-							return
-						}
-					}
-
-					c <- dungeonInstanceProcessingResult{
-						DungeonInstanceID: dungeonInstanceID,
-						Turn:              result.incrementTurnResult.Record.TurnNumber,
-					}
-
-				}(dungeonInstanceID)
-
-				runningCount++
-
-			case processStateError:
-				l.Warn("(error) Removing dungeon instance ID >%s< from processing >%v<", dungeonInstanceID, dungeonInstanceStates[dungeonInstanceID].err)
-				delete(dungeonInstanceStates, dungeonInstanceID)
-			case processStateDone:
-				l.Debug("(done) Enqueuing dungeon instance ID >%s< turn >%d<", dungeonInstanceID, dungeonInstanceStates[dungeonInstanceID].turn)
-				dungeonInstanceStates[dungeonInstanceID].state = processStatePending
-			default:
-				// no-op
+		// Dispatch every dungeon instance that is due while there are idle workers
+		for p.idle() > 0 {
+			st := s.popDue(now)
+			if st == nil {
+				break
 			}
+			l.Debug("Dispatching dungeon instance ID >%s< turn >%d<", st.dungeonInstanceID, st.turn)
+			p.dispatch(st.dungeonInstanceID)
 		}
 
-		// Wait for a result from one of the routines
-		if runningCount > 0 {
-			result := <-c
-			if result.Error != nil {
-				dungeonInstanceStates[result.DungeonInstanceID].state = processStateError
-				dungeonInstanceStates[result.DungeonInstanceID].err = result.Error
-				continue
+		// Wait for a result, the next dungeon instance to become due, or the
+		// next init cycle, whichever comes first
+		wait := nextInitAt.Sub(now)
+		if st := s.next(); st != nil && p.idle() > 0 {
+			if d := st.nextTurnAt.Sub(now); d < wait {
+				wait = d
 			}
+		}
 
-			dungeonInstanceStates[result.DungeonInstanceID].state = processStateDone
-			dungeonInstanceStates[result.DungeonInstanceID].turn = result.Turn
+		if result, ok := p.receive(wait); ok {
+			rnr.daemonHandleResult(l, s, result)
 		}
 	}
 
 	return nil
 }
 
-type processDungeonInstanceTurnResult struct {
-	incrementTurnResult             *model.IncrementDungeonInstanceTurnResult
-	monsterInstanceActionRecordSets []*record.ActionRecordSet
-}
-
-func processDungeonInstanceTurn(l logger.Logger, m *model.Model, dungeonInstanceID string) (*processDungeonInstanceTurnResult, error) {
-	l = loggerWithFunctionContext(l, "processDungeonInstanceTurn")
-	l = loggerWithInstanceContext(l, dungeonInstanceID)
+// daemonHandleResult updates the scheduler with the result of processing a
+// dungeon instance.
+func (rnr *Runner) daemonHandleResult(l logger.Logger, s *daemonScheduler, result dungeonInstanceProcessingResult) {
+	l = loggerWithInstanceContext(l, result.DungeonInstanceID)
 
-	// TODO: 10-implement-effects:
-	// Process any active effects that are still applied to the character.
+	st := s.get(result.DungeonInstanceID)
+	if st == nil {
+		return
+	}
 
-	pditr := processDungeonInstanceTurnResult{}
+	if result.LeaseLost {
+		l.Info("Removing dungeon instance ID >%s< from processing, lease lost", result.DungeonInstanceID)
+		s.remove(result.DungeonInstanceID)
+		return
+	}
 
-WHILE_RESULT_NOT_INCREMENTED:
-	for pditr.incrementTurnResult == nil || !pditr.incrementTurnResult.Incremented {
+	if result.Error != nil {
+		st.state = processStateError
+		st.err = result.Error
+		st.failures++
 
-		// Increment turn
-		iditr, err := m.IncrementDungeonInstanceTurn(&model.IncrementDungeonInstanceTurnArgs{
-			DungeonInstanceID: dungeonInstanceID,
-		})
+		diRec, err := rnr.daemonRecordFailure(l, result.DungeonInstanceID, result.Error)
 		if err != nil {
-			l.Warn("failed incrementing dungeon ID >%s< instance turn >%v<", dungeonInstanceID, err)
-			return nil, err
+			// Removed dungeon instances are merged again on the next init cycle
+			l.Warn("(error) Removing dungeon instance ID >%s< from processing >%v<", result.DungeonInstanceID, err)
+			s.remove(result.DungeonInstanceID)
+			return
 		}
 
-		if !iditr.Incremented && iditr.WaitMilliseconds > 0 {
-			l.Debug("Sleeping for >%d< milliseconds", iditr.WaitMilliseconds)
-			time.Sleep(time.Duration(iditr.WaitMilliseconds) * time.Millisecond)
-			continue WHILE_RESULT_NOT_INCREMENTED
+		if diRec.QuarantinedAt.Valid {
+			l.Warn("(error) Quarantined dungeon instance ID >%s< after >%d< failures >%v<", result.DungeonInstanceID, diRec.FailureCount, result.Error)
+			s.remove(result.DungeonInstanceID)
+			return
 		}
 
-		pditr.incrementTurnResult = iditr
+		st.failures = diRec.FailureCount
+		backoff := daemonRetryBackoff(st.failures)
 
-		// Decay dead characters and remove completely decayed characters
-		err = decayCharacters(l, m, dungeonInstanceID)
-		if err != nil {
-			l.Warn("failed decaying characters >%v<", err)
-			return nil, err
-		}
+		l.Warn("(error) Retrying dungeon instance ID >%s< failure >%d< in >%s< >%v<", result.DungeonInstanceID, st.failures, backoff, result.Error)
 
-		// Decay dead monsters and remove completely decayed monsters
-		err = decayMonsters(l, m, dungeonInstanceID)
-		if err != nil {
-			l.Warn("failed decaying monsters >%v<", err)
-			return nil, err
-		}
+		s.schedule(result.DungeonInstanceID, time.Now().Add(backoff))
+		return
+	}
 
-		// Process monster instances
-		mrecs, err := m.GetMonsterInstanceRecs(
-			&coresql.Options{
-				Params: []coresql.Param{
-					{
-						Col: "dungeon_instance_id",
-						Val: dungeonInstanceID,
-					},
-				},
-			},
-		)
+	if st.failures > 0 {
+		err := rnr.daemonClearFailures(l, result.DungeonInstanceID)
 		if err != nil {
-			l.Warn("failed getting dungeon ID >%s< monster instance records >%v<", dungeonInstanceID, err)
-			return nil, err
+			// Clearing failures is attempted again after the next turn
+			l.Warn("failed clearing dungeon instance ID >%s< failures >%v<", result.DungeonInstanceID, err)
+		} else {
+			st.failures = 0
+			st.err = nil
 		}
+	}
 
-		l.Debug("Processing turn >%d< with >%d< monster instance records", iditr.Record.TurnNumber, len(mrecs))
+	if result.Turn != 0 {
+		st.turn = result.Turn
+	}
 
-		for idx := range mrecs {
-			l.Info("Processing monster instance ID >%s< monster ID >%s< Name >%s<", mrecs[idx].ID, mrecs[idx].MonsterID)
-			dmar, err := m.DecideMonsterAction(mrecs[idx].ID)
-			if err != nil {
-				l.Warn("failed deciding monster instance ID >%s< action >%v<", mrecs[idx].ID, err)
-				return nil, err
-			}
+	l.Debug("Scheduling dungeon instance ID >%s< turn >%d< at >%s<", result.DungeonInstanceID, st.turn, result.NextTurnAt)
 
-			l.Info("Monster instance ID >%s< Sentence >%s<", dmar.MonsterInstanceID, dmar.Sentence)
+	s.schedule(result.DungeonInstanceID, result.NextTurnAt)
+}
 
-			if dmar.Sentence == "" {
-				l.Info("Monster instance ID >%s< not doing anything this turn", dmar.MonsterInstanceID)
-				continue
-			}
+// daemonRecordFailure persists a dungeon instance processing failure, quarantining
+// the dungeon instance when it has failed too many consecutive times.
+func (rnr *Runner) daemonRecordFailure(l logger.Logger, dungeonInstanceID string, processErr error) (*record.DungeonInstance, error) {
+	l = loggerWithFunctionContext(l, "daemonRecordFailure")
 
-			ars, err := m.ProcessMonsterAction(dmar.DungeonInstanceID, dmar.MonsterInstanceID, dmar.Sentence)
-			if err != nil {
-				l.Warn("failed processing monster action >%s< action >%v<", dmar.Sentence, err)
-				return nil, err
-			}
+	m, err := rnr.initModeller(l)
+	if err != nil {
+		l.Warn("failed initialising modeller >%v<", err)
+		return nil, err
+	}
 
-			l.Debug("Processed monster instance ID >%s< action >%#v<", dmar.MonsterInstanceID, ars.ActionRec)
-			pditr.monsterInstanceActionRecordSets = append(pditr.monsterInstanceActionRecordSets, ars)
+	diRec, err := m.RecordDungeonInstanceFailure(&model.RecordDungeonInstanceFailureArgs{
+		DungeonInstanceID: dungeonInstanceID,
+		Error:             processErr,
+		MaxFailures:       rnr.config.DaemonMaxFailures,
+	})
+	if err != nil {
+		l.Warn("failed recording dungeon instance failure >%v<", err)
+		if rerr := m.Rollback(); rerr != nil {
+			l.Warn("failed model rollback >%v<", rerr)
 		}
+		return nil, err
 	}
 
-	l.Debug("Processed dungeon instance ID >%s< turn >%d<", dungeonInstanceID, pditr.incrementTurnResult.Record.TurnNumber)
+	err = m.Commit()
+	if err != nil {
+		l.Warn("failed model commit >%v<", err)
+		return nil, err
+	}
 
-	return &pditr, nil
+	return diRec, nil
 }
 
-func decayCharacters(l logger.Logger, m *model.Model, dungeonInstanceID string) error {
-	l = loggerWithFunctionContext(l, "decayCharacters")
+// daemonClearFailures resets the persisted consecutive failure count of a dungeon
+// instance that has been successfully processed.
+func (rnr *Runner) daemonClearFailures(l logger.Logger, dungeonInstanceID string) error {
+	l = loggerWithFunctionContext(l, "daemonClearFailures")
 
-	// Decay dead character instances
-	crecs, err := m.GetCharacterInstanceRecs(
-		&coresql.Options{
-			Params: []coresql.Param{
-				{
-					Col: record.FieldCharacterInstanceDungeonInstanceID,
-					Val: dungeonInstanceID,
-				},
-				{
-					Col: record.FieldCharacterInstanceHealth,
-					Val: 0,
-					Op:  coresql.OpLessThanEqual,
-				},
-			},
-		},
-	)
+	m, err := rnr.initModeller(l)
 	if err != nil {
-		l.Warn("failed getting dungeon ID >%s< character instance records >%v<", dungeonInstanceID, err)
+		l.Warn("failed initialising modeller >%v<", err)
 		return err
 	}
 
-	for idx := range crecs {
-		l.Info("Decaying character instance ID >%s< character ID >%s<", crecs[idx].ID, crecs[idx].CharacterID)
-
-		crec := crecs[idx]
-		crec.Decay += 1
-
-		err := m.UpdateCharacterInstanceRec(crec)
-		if err != nil {
-			l.Warn("failed updating character instance ID >%s< decay >%v<", crec.ID, err)
-			return err
+	_, err = m.ClearDungeonInstanceFailures(dungeonInstanceID)
+	if err != nil {
+		l.Warn("failed clearing dungeon instance failures >%v<", err)
+		if rerr := m.Rollback(); rerr != nil {
+			l.Warn("failed model rollback >%v<", rerr)
 		}
+		return err
+	}
 
-		if crec.Decay >= decayTurns {
-			err := m.DeleteCharacterInstance(crec.CharacterID)
-			if err != nil {
-				l.Warn("failed exiting decayed character instance ID >%s< from dungeon >%v<", crec.ID, err)
-				return err
-			}
-		}
+	err = m.Commit()
+	if err != nil {
+		l.Warn("failed model commit >%v<", err)
+		return err
 	}
 
 	return nil
 }
 
-func decayMonsters(l logger.Logger, m *model.Model, dungeonInstanceID string) error {
-	l = loggerWithFunctionContext(l, "decayMonsters")
+// daemonCheckConsistency checks the invariants of a dungeon instance in its own
+// transaction, repairing violations when configured to.
+func (rnr *Runner) daemonCheckConsistency(l logger.Logger, dungeonInstanceID string) error {
+	l = loggerWithFunctionContext(l, "daemonCheckConsistency")
 
-	// Decay dead monster instances
-	mrecs, err := m.GetMonsterInstanceRecs(
-		&coresql.Options{
-			Params: []coresql.Param{
-				{
-					Col: record.FieldMonsterInstanceDungeonInstanceID,
-					Val: dungeonInstanceID,
-				},
-				{
-					Col: record.FieldMonsterInstanceHealth,
-					Val: 0,
-					Op:  coresql.OpLessThanEqual,
-				},
-			},
-		},
-	)
+	m, err := rnr.initModeller(l)
 	if err != nil {
-		l.Warn("failed getting dungeon ID >%s< monster instance records >%v<", dungeonInstanceID, err)
+		l.Warn("failed initialising modeller >%v<", err)
 		return err
 	}
 
-	for idx := range mrecs {
-		l.Info("Decaying monster instance ID >%s< monster ID >%s<", mrecs[idx].ID, mrecs[idx].MonsterID)
-
-		mrec := mrecs[idx]
-		mrec.Decay += 1
-
-		err := m.UpdateMonsterInstanceRec(mrec)
-		if err != nil {
-			l.Warn("failed updating monster instance ID >%s< decay >%v<", mrec.ID, err)
-			return err
+	c, err := m.CheckDungeonInstanceConsistency(&model.CheckDungeonInstanceConsistencyArgs{
+		DungeonInstanceID: dungeonInstanceID,
+		Repair:            rnr.config.DaemonConsistencyRepair,
+	})
+	if err != nil {
+		l.Warn("failed checking dungeon instance consistency >%v<", err)
+		if rerr := m.Rollback(); rerr != nil {
+			l.Warn("failed model rollback >%v<", rerr)
 		}
+		return err
+	}
 
-		if mrec.Decay >= decayTurns {
-			err := m.DeleteMonsterInstance(mrec.MonsterID)
-			if err != nil {
-				l.Warn("failed exiting decayed monster instance ID >%s< from dungeon >%v<", mrec.ID, err)
-				return err
-			}
-		}
+	err = m.Commit()
+	if err != nil {
+		l.Warn("failed model commit >%v<", err)
+		return err
+	}
+
+	if len(c.Violations) > 0 {
+		l.Warn("Dungeon instance ID >%s< has >%d< invariant violations repaired >%t<", dungeonInstanceID, len(c.Violations), rnr.config.DaemonConsistencyRepair)
 	}
 
 	return nil
@@ -0,0 +1,125 @@
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/dependencies"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// BenchmarkDaemonWorkerPool measures dungeon instance turn latency when a large
+// number of dungeon instances are being processed by the daemon worker pool.
+//
+//	go test -run XXX -bench BenchmarkDaemonWorkerPool ./internal/runner/server/...
+func BenchmarkDaemonWorkerPool(b *testing.B) {
+	for _, instanceCount := range []int{100, 250} {
+		b.Run(fmt.Sprintf("instances-%d", instanceCount), func(b *testing.B) {
+			benchmarkDaemonWorkerPool(b, instanceCount)
+		})
+	}
+}
+
+func benchmarkDaemonWorkerPool(b *testing.B, instanceCount int) {
+
+	// Add additional empty dungeon instances to the default test data
+	config := harness.DefaultDataConfig
+	config.DungeonConfig = append([]harness.DungeonConfig{}, config.DungeonConfig...)
+	dungeonConfig := config.DungeonConfig[0]
+	dungeonConfig.DungeonInstanceConfig = append([]harness.DungeonInstanceConfig{}, dungeonConfig.DungeonInstanceConfig...)
+	for len(dungeonConfig.DungeonInstanceConfig) < instanceCount {
+		dungeonConfig.DungeonInstanceConfig = append(dungeonConfig.DungeonInstanceConfig, harness.DungeonInstanceConfig{})
+	}
+	config.DungeonConfig[0] = dungeonConfig
+
+	c, l, s, err := dependencies.Default()
+	require.NoError(b, err, "Default dependencies returns without error")
+
+	th, err := harness.NewTesting(c, l, s, config)
+	require.NoError(b, err, "NewTesting returns without error")
+
+	th.ShouldCommitData = true
+
+	_, err = th.Setup()
+	require.NoError(b, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(b, err, "Test data teardown returns without error")
+	}()
+
+	rnr, err := NewRunner(th.Config, th.Log)
+	require.NoError(b, err, "NewRunner returns without error")
+
+	err = rnr.Init(th.Store)
+	require.NoError(b, err, "Runner init returns without error")
+
+	// Lease all test dungeon instances to the benchmark daemon
+	_, err = th.InitTx()
+	require.NoError(b, err, "InitTx returns without error")
+
+	for _, rec := range th.Data.DungeonInstanceLeaseRecs {
+		rec.DaemonID = null.NullStringFromString(rnr.config.DaemonID)
+		rec.ExpiresAt = null.NullTimeFromTime(time.Now().Add(time.Hour))
+		err := th.Model.(*model.Model).UpdateDungeonInstanceLeaseRec(rec)
+		require.NoError(b, err, "UpdateDungeonInstanceLeaseRec returns without error")
+	}
+
+	err = th.CommitTx()
+	require.NoError(b, err, "CommitTx returns without error")
+
+	// Processing is rolled back so every iteration processes the same turn
+	turnDuration := time.Duration(0)
+
+	p := rnr.newDaemonWorkerPool(th.Log, rnr.config.DaemonWorkers)
+	p.turnDuration = &turnDuration
+	p.rollback = true
+	defer p.stop()
+
+	durations := []time.Duration{}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pending := []string{}
+		for _, rec := range th.Data.DungeonInstanceRecs {
+			pending = append(pending, rec.ID)
+		}
+
+		// Keep every worker busy until all dungeon instances are processed
+		remaining := len(pending)
+		for remaining > 0 {
+			for p.idle() > 0 && len(pending) > 0 {
+				p.dispatch(pending[0])
+				pending = pending[1:]
+			}
+			result, ok := p.receive(time.Minute)
+			require.True(b, ok, "Processing dungeon instance completes")
+			require.NoError(b, result.Error, "Processing dungeon instance returns without error")
+			durations = append(durations, result.Duration)
+			remaining--
+		}
+	}
+
+	b.StopTimer()
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	percentile := func(pct int) float64 {
+		if len(durations) == 0 {
+			return 0
+		}
+		idx := (len(durations) - 1) * pct / 100
+		return float64(durations[idx].Microseconds()) / 1000
+	}
+
+	b.ReportMetric(float64(len(th.Data.DungeonInstanceRecs)), "instances")
+	b.ReportMetric(percentile(50), "p50-turn-ms")
+	b.ReportMetric(percentile(95), "p95-turn-ms")
+	b.ReportMetric(percentile(99), "p99-turn-ms")
+}
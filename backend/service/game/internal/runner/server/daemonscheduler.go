@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"container/heap"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// daemonScheduler tracks the processing state of every dungeon instance the
+// daemon holds a lease for and orders pending dungeon instances by the time
+// their next turn is due.
+type daemonScheduler struct {
+	states map[string]*dungeonInstanceState
+	queue  dungeonInstanceQueue
+}
+
+func newDaemonScheduler() *daemonScheduler {
+	return &daemonScheduler{
+		states: map[string]*dungeonInstanceState{},
+		queue:  dungeonInstanceQueue{},
+	}
+}
+
+// merge adds any dungeon instances that are not already known, scheduling
+// them to be processed at the provided time.
+func (s *daemonScheduler) merge(diRecs []*record.DungeonInstance, at time.Time) {
+	for idx := range diRecs {
+		if _, ok := s.states[diRecs[idx].ID]; ok {
+			continue
+		}
+		s.states[diRecs[idx].ID] = &dungeonInstanceState{
+			dungeonInstanceID: diRecs[idx].ID,
+			state:             processStatePending,
+			failures:          diRecs[idx].FailureCount,
+			index:             -1,
+		}
+		s.schedule(diRecs[idx].ID, at)
+	}
+}
+
+// prune removes dungeon instances that are not in the provided set of dungeon
+// instances. Dungeon instances that are currently running are removed once
+// they have completed their turn.
+func (s *daemonScheduler) prune(diRecs []*record.DungeonInstance) {
+	keep := map[string]bool{}
+	for idx := range diRecs {
+		keep[diRecs[idx].ID] = true
+	}
+	for dungeonInstanceID, st := range s.states {
+		if keep[dungeonInstanceID] || st.state == processStateRunning {
+			continue
+		}
+		s.remove(dungeonInstanceID)
+	}
+}
+
+// schedule sets the time a known dungeon instance should next be processed.
+func (s *daemonScheduler) schedule(dungeonInstanceID string, at time.Time) {
+	st, ok := s.states[dungeonInstanceID]
+	if !ok {
+		return
+	}
+	st.state = processStatePending
+	st.nextTurnAt = at
+	if st.index >= 0 {
+		heap.Fix(&s.queue, st.index)
+		return
+	}
+	heap.Push(&s.queue, st)
+}
+
+// remove stops tracking a dungeon instance.
+func (s *daemonScheduler) remove(dungeonInstanceID string) {
+	st, ok := s.states[dungeonInstanceID]
+	if !ok {
+		return
+	}
+	if st.index >= 0 {
+		heap.Remove(&s.queue, st.index)
+	}
+	delete(s.states, dungeonInstanceID)
+}
+
+// get returns the state of a known dungeon instance.
+func (s *daemonScheduler) get(dungeonInstanceID string) *dungeonInstanceState {
+	return s.states[dungeonInstanceID]
+}
+
+// next returns the pending dungeon instance with the earliest next turn time
+// without removing it from the schedule.
+func (s *daemonScheduler) next() *dungeonInstanceState {
+	if len(s.queue) == 0 {
+		return nil
+	}
+	return s.queue[0]
+}
+
+// popDue removes and returns the pending dungeon instance with the earliest
+// next turn time when that time is not after the provided time. The dungeon
+// instance is marked as running.
+func (s *daemonScheduler) popDue(now time.Time) *dungeonInstanceState {
+	st := s.next()
+	if st == nil || st.nextTurnAt.After(now) {
+		return nil
+	}
+	heap.Pop(&s.queue)
+	st.state = processStateRunning
+	return st
+}
+
+// len returns the number of known dungeon instances.
+func (s *daemonScheduler) len() int {
+	return len(s.states)
+}
+
+// dungeonInstanceQueue implements heap.Interface ordering dungeon instance
+// states by next turn time.
+type dungeonInstanceQueue []*dungeonInstanceState
+
+func (q dungeonInstanceQueue) Len() int { return len(q) }
+
+func (q dungeonInstanceQueue) Less(i, j int) bool {
+	return q[i].nextTurnAt.Before(q[j].nextTurnAt)
+}
+
+func (q dungeonInstanceQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *dungeonInstanceQueue) Push(x any) {
+	st := x.(*dungeonInstanceState)
+	st.index = len(*q)
+	*q = append(*q, st)
+}
+
+func (q *dungeonInstanceQueue) Pop() any {
+	old := *q
+	n := len(old)
+	st := old[n-1]
+	old[n-1] = nil
+	st.index = -1
+	*q = old[:n-1]
+	return st
+}
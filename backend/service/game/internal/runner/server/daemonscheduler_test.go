@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/repository"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestDaemonScheduler(t *testing.T) {
+
+	now := time.Now()
+
+	diRec := func(id string) *record.DungeonInstance {
+		return &record.DungeonInstance{Record: repository.Record{ID: id}}
+	}
+
+	s := newDaemonScheduler()
+
+	s.merge([]*record.DungeonInstance{diRec("a"), diRec("b"), diRec("c")}, now)
+	require.Equal(t, 3, s.len(), "Scheduler has three dungeon instances")
+
+	// Merging known dungeon instances does not reschedule them
+	s.schedule("a", now.Add(3*time.Second))
+	s.schedule("b", now.Add(1*time.Second))
+	s.schedule("c", now.Add(2*time.Second))
+	s.merge([]*record.DungeonInstance{diRec("a")}, now)
+
+	require.Nil(t, s.popDue(now), "No dungeon instance is due")
+
+	st := s.popDue(now.Add(5 * time.Second))
+	require.NotNil(t, st, "Dungeon instance is due")
+	require.Equal(t, "b", st.dungeonInstanceID, "Earliest dungeon instance is due first")
+	require.Equal(t, processStateRunning, st.state, "Due dungeon instance is running")
+
+	// Running dungeon instances are not pruned
+	s.prune([]*record.DungeonInstance{diRec("c")})
+	require.Equal(t, 2, s.len(), "Scheduler has two dungeon instances")
+	require.Nil(t, s.get("a"), "Dungeon instance a was pruned")
+	require.NotNil(t, s.get("b"), "Running dungeon instance b was not pruned")
+
+	s.schedule("b", now.Add(1*time.Second))
+	require.Equal(t, "b", s.next().dungeonInstanceID, "Rescheduled dungeon instance is next")
+
+	s.remove("b")
+	require.Equal(t, "c", s.next().dungeonInstanceID, "Remaining dungeon instance is next")
+
+	s.remove("c")
+	require.Nil(t, s.next(), "Scheduler is empty")
+	require.Equal(t, 0, s.len(), "Scheduler has no dungeon instances")
+}
+
+func TestDaemonRetryBackoff(t *testing.T) {
+
+	require.Equal(t, minDaemonRetryBackoff, daemonRetryBackoff(1), "First failure waits the minimum backoff")
+	require.Equal(t, 2*minDaemonRetryBackoff, daemonRetryBackoff(2), "Second failure doubles the backoff")
+	require.Equal(t, 8*minDaemonRetryBackoff, daemonRetryBackoff(4), "Fourth failure doubles the backoff again")
+	require.Equal(t, maxDaemonRetryBackoff, daemonRetryBackoff(100), "Backoff does not exceed the maximum")
+}
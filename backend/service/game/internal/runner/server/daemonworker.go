@@ -0,0 +1,179 @@
+package runner
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+// daemonWorkerPool processes dungeon instance turns with a bounded number of
+// concurrent workers. Dispatching is only done by the daemon loop, which tracks
+// busy workers, so dispatch never blocks.
+type daemonWorkerPool struct {
+	jobs    chan string
+	results chan dungeonInstanceProcessingResult
+	workers int
+	busy    int
+	wg      sync.WaitGroup
+
+	// turnDuration overrides the configured model turn duration
+	turnDuration *time.Duration
+	// rollback processing instead of committing, used when benchmarking so
+	// repeated processing does not leave data behind
+	rollback bool
+}
+
+func (rnr *Runner) newDaemonWorkerPool(l logger.Logger, workers int) *daemonWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &daemonWorkerPool{
+		jobs:    make(chan string, workers),
+		results: make(chan dungeonInstanceProcessingResult, workers),
+		workers: workers,
+	}
+
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go func(worker int) {
+			defer p.wg.Done()
+			wl := l.WithContext("worker", fmt.Sprintf("%d", worker))
+			for dungeonInstanceID := range p.jobs {
+				p.results <- rnr.daemonProcessDungeonInstance(wl, p, dungeonInstanceID)
+			}
+		}(i)
+	}
+
+	return p
+}
+
+// idle returns the number of workers available to process a dungeon instance
+func (p *daemonWorkerPool) idle() int {
+	return p.workers - p.busy
+}
+
+// dispatch hands a dungeon instance to an idle worker
+func (p *daemonWorkerPool) dispatch(dungeonInstanceID string) {
+	p.busy++
+	p.jobs <- dungeonInstanceID
+}
+
+// receive waits up to wait for the next processing result, returning false
+// when no dungeon instance completed processing in time
+func (p *daemonWorkerPool) receive(wait time.Duration) (dungeonInstanceProcessingResult, bool) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case result := <-p.results:
+		p.busy--
+		return result, true
+	case <-timer.C:
+		return dungeonInstanceProcessingResult{}, false
+	}
+}
+
+// stop waits for all in progress dungeon instances to complete
+func (p *daemonWorkerPool) stop() {
+	close(p.jobs)
+	go func() {
+		for range p.results {
+		}
+	}()
+	p.wg.Wait()
+	close(p.results)
+}
+
+// daemonProcessDungeonInstance processes a single dungeon instance turn within
+// its own database transaction.
+func (rnr *Runner) daemonProcessDungeonInstance(l logger.Logger, p *daemonWorkerPool, dungeonInstanceID string) dungeonInstanceProcessingResult {
+	l = loggerWithFunctionContext(l, "daemonProcessDungeonInstance")
+	l = loggerWithInstanceContext(l, dungeonInstanceID)
+
+	start := time.Now()
+
+	var m *model.Model
+
+	handleErr := func(err error) dungeonInstanceProcessingResult {
+		l.Warn("failed with error >%v<", err)
+		if m != nil {
+			rerr := m.Rollback()
+			if rerr != nil {
+				l.Warn("failed model rollback >%v<", rerr)
+				err = fmt.Errorf("%v with %v", rerr, err)
+			}
+		}
+		return dungeonInstanceProcessingResult{
+			DungeonInstanceID: dungeonInstanceID,
+			Error:             err,
+			Duration:          time.Since(start),
+		}
+	}
+
+	m, err := rnr.initModeller(l)
+	if err != nil {
+		return handleErr(err)
+	}
+
+	// Another daemon may have claimed the dungeon instance when this daemon
+	// failed to renew its lease in time
+	held, err := m.HoldsDungeonInstanceLease(dungeonInstanceID, rnr.config.DaemonID)
+	if err != nil {
+		return handleErr(err)
+	}
+
+	if !held {
+		l.Warn("Daemon ID >%s< no longer holds dungeon instance ID >%s< lease", rnr.config.DaemonID, dungeonInstanceID)
+		err = m.Rollback()
+		if err != nil {
+			return handleErr(err)
+		}
+		return dungeonInstanceProcessingResult{
+			DungeonInstanceID: dungeonInstanceID,
+			LeaseLost:         true,
+			Duration:          time.Since(start),
+		}
+	}
+
+	result, err := m.ProcessDungeonInstanceTurn(&model.ProcessDungeonInstanceTurnArgs{
+		DungeonInstanceID: dungeonInstanceID,
+		TurnDuration:      p.turnDuration,
+	})
+	if err != nil {
+		return handleErr(err)
+	}
+
+	if result.IncrementTurnResult.Incremented && !p.rollback {
+		err = m.Commit()
+	} else {
+		err = m.Rollback()
+	}
+	if err != nil {
+		return handleErr(err)
+	}
+
+	// Schedule the next turn
+	if !result.IncrementTurnResult.Incremented {
+		return dungeonInstanceProcessingResult{
+			DungeonInstanceID: dungeonInstanceID,
+			NextTurnAt:        time.Now().Add(time.Duration(result.IncrementTurnResult.WaitMilliseconds) * time.Millisecond),
+			Duration:          time.Since(start),
+		}
+	}
+
+	turnDuration := m.TurnDuration()
+	if p.turnDuration != nil {
+		turnDuration = *p.turnDuration
+	}
+
+	return dungeonInstanceProcessingResult{
+		DungeonInstanceID: dungeonInstanceID,
+		Turn:              result.IncrementTurnResult.Record.TurnNumber,
+		NextTurnAt:        time.Now().Add(turnDuration),
+		Duration:          time.Since(start),
+	}
+}
@@ -0,0 +1,412 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+const (
+	getDungeonCharacterEvents string = "get-dungeon-character-events"
+)
+
+const (
+	// eventStreamPollInterval is how often committed actions are checked for
+	eventStreamPollInterval time.Duration = 500 * time.Millisecond
+	// eventStreamKeepAliveInterval is how often a comment is sent to keep idle
+	// connections open through proxies
+	eventStreamKeepAliveInterval time.Duration = 15 * time.Second
+	// eventStreamWriteTimeout replaces the server write timeout for every write
+	// so the stream is not closed by the server
+	eventStreamWriteTimeout time.Duration = 10 * time.Second
+	// eventStreamLookback is the number of serial numbers before the last sent
+	// action that are checked again, action serial numbers are assigned when
+	// actions are created and transactions may commit out of order
+	eventStreamLookback int = 100
+)
+
+const (
+	eventTypeAction string = "action"
+	eventTypeExit   string = "exit"
+)
+
+func (rnr *Runner) DungeonCharacterEventHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
+
+	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		getDungeonCharacterEvents: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/dungeons/:dungeon_id/characters/:character_id/events",
+			HandlerFunc: rnr.GetDungeonCharacterEventsHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document: true,
+				Description: "Stream dungeon character events as server-sent events. Every action " +
+					"at the character's location, including monster actions, is sent as an " +
+					"'action' event once committed. An 'exit' event is sent when the character " +
+					"is no longer in the dungeon. Provide the Last-Event-ID header to resume " +
+					"after a previously received action.",
+			},
+		},
+	})
+}
+
+// GetDungeonCharacterEventsHandler -
+func (rnr *Runner) GetDungeonCharacterEventsHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "GetDungeonCharacterEventsHandler")
+	l.Info("** Get dungeon character events handler **")
+
+	// Path parameters
+	dungeonID := pp.ByName("dungeon_id")
+	characterID := pp.ByName("character_id")
+
+	dungeonRec, err := m.(*model.Model).GetDungeonRec(dungeonID, nil)
+	if err != nil {
+		l.Warn("failed getting dungeon record >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	if dungeonRec == nil {
+		err := coreerror.NewNotFoundError("dungeon", dungeonID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	characterRec, err := m.(*model.Model).GetCharacterRec(characterID, nil)
+	if err != nil {
+		l.Warn("failed getting character record >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	if characterRec == nil {
+		err := coreerror.NewNotFoundError("character", characterID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = authorizeCharacterRec(l, r, characterRec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	characterInstanceRec, err := m.(*model.Model).GetCharacterInstance(characterID)
+	if err != nil {
+		l.Warn("failed getting character instance record >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	if characterInstanceRec == nil {
+		err := model.NewActionInvalidCharacterError(characterID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resume after the last received action or start from the latest action
+	serialNumber, err := eventStreamStartSerialNumber(l, r, m.(*model.Model))
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// The request transaction is released before streaming so the stream does
+	// not hold a database connection, every poll uses its own transaction
+	err = m.Rollback()
+	if err != nil {
+		l.Warn("failed releasing request transaction >%v<", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	es := &eventStream{
+		w:            w,
+		rc:           rc,
+		serialNumber: serialNumber,
+		start:        serialNumber,
+		sent:         map[string]int{},
+		language:     requestLanguage(r),
+	}
+
+	err = es.flush(l)
+	if err != nil {
+		return nil
+	}
+
+	pollTicker := time.NewTicker(eventStreamPollInterval)
+	defer pollTicker.Stop()
+
+	keepAliveTicker := time.NewTicker(eventStreamKeepAliveInterval)
+	defer keepAliveTicker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			l.Info("Client closed character ID >%s< event stream", characterID)
+			return nil
+		case <-keepAliveTicker.C:
+			err := es.writeComment(l, "keep-alive")
+			if err != nil {
+				return nil
+			}
+		case <-pollTicker.C:
+			exited, err := rnr.pollDungeonCharacterEvents(l, es, characterID)
+			if err != nil {
+				// Response headers have been written so errors cannot be
+				// returned to the client, the client is expected to reconnect
+				l.Warn("failed polling character ID >%s< events >%v<", characterID, err)
+				return nil
+			}
+			if exited {
+				l.Info("Character ID >%s< has exited the dungeon, closing event stream", characterID)
+				return nil
+			}
+		}
+	}
+}
+
+// pollDungeonCharacterEvents writes any newly committed actions at the character's
+// location to the event stream. Each poll uses a new database transaction so
+// actions committed since the previous poll, including actions committed by other
+// server processes, are visible.
+func (rnr *Runner) pollDungeonCharacterEvents(l logger.Logger, es *eventStream, characterID string) (exited bool, err error) {
+
+	m, err := rnr.initModeller(l)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if rerr := m.Rollback(); rerr != nil {
+			l.Warn("failed model rollback >%v<", rerr)
+		}
+	}()
+
+	m.SetLanguage(es.language)
+
+	characterInstanceRec, err := m.GetCharacterInstance(characterID)
+	if err != nil {
+		return false, err
+	}
+
+	if characterInstanceRec == nil {
+		return true, es.writeEvent(l, eventTypeExit, "", map[string]string{"character_id": characterID})
+	}
+
+	afterSerialNumber := es.serialNumber - eventStreamLookback
+	if afterSerialNumber < 0 {
+		afterSerialNumber = 0
+	}
+
+	actionRecs, err := m.GetCharacterInstanceActionRecsAfterSerialNumber(characterInstanceRec, afterSerialNumber)
+	if err != nil {
+		return false, err
+	}
+
+	for _, actionRec := range actionRecs {
+		if _, ok := es.sent[actionRec.ID]; ok {
+			continue
+		}
+
+		// Actions at or before the serial number the stream started after
+		// were committed before the client connected
+		actionSerialNumber := int(null.NullInt32ToInt32(actionRec.SerialNumber))
+		if actionSerialNumber <= es.start {
+			es.sent[actionRec.ID] = actionSerialNumber
+			continue
+		}
+
+		rs, err := m.GetActionRecordSet(actionRec.ID)
+		if err != nil {
+			return false, err
+		}
+
+		err = m.TranslateActionRecordSet(rs)
+		if err != nil {
+			return false, err
+		}
+
+		data, err := actionResponseData(l, rnr.narrator, *rs, characterInstanceRec.ID, es.language)
+		if err != nil {
+			return false, err
+		}
+
+		err = es.writeEvent(l, eventTypeAction, strconv.Itoa(actionSerialNumber), data)
+		if err != nil {
+			return false, err
+		}
+
+		es.sent[actionRec.ID] = actionSerialNumber
+		if actionSerialNumber > es.serialNumber {
+			es.serialNumber = actionSerialNumber
+		}
+	}
+
+	es.prune()
+
+	return false, nil
+}
+
+// eventStreamStartSerialNumber returns the action serial number after which
+// actions are streamed.
+func eventStreamStartSerialNumber(l logger.Logger, r *http.Request, m *model.Model) (int, error) {
+
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		serialNumber, err := strconv.Atoi(lastEventID)
+		if err != nil || serialNumber < 0 {
+			return 0, coreerror.NewHeaderError("Last-Event-ID header value >%s< must be an action serial number", lastEventID)
+		}
+		return serialNumber, nil
+	}
+
+	return latestActionSerialNumber(l, m)
+}
+
+// latestActionSerialNumber returns the serial number of the latest action
+func latestActionSerialNumber(l logger.Logger, m *model.Model) (int, error) {
+
+	actionRecs, err := m.GetActionRecs(
+		&coresql.Options{
+			OrderBy: []coresql.OrderBy{
+				{
+					Col:       "serial_number",
+					Direction: coresql.OrderDirectionDESC,
+				},
+			},
+			Limit: 1,
+		},
+	)
+	if err != nil {
+		l.Warn("failed getting latest action record >%v<", err)
+		return 0, err
+	}
+
+	if len(actionRecs) == 0 {
+		return 0, nil
+	}
+
+	return int(null.NullInt32ToInt32(actionRecs[0].SerialNumber)), nil
+}
+
+// eventStream writes server-sent events
+type eventStream struct {
+	w  io.Writer
+	rc *http.ResponseController
+	// serialNumber is the highest action serial number sent
+	serialNumber int
+	// start is the serial number the stream started after
+	start int
+	// sent is the serial number of sent actions keyed by action ID
+	sent map[string]int
+	// language is the language actions are narrated in
+	language string
+}
+
+// prune forgets sent actions that are no longer within the lookback window
+func (es *eventStream) prune() {
+	for id, serialNumber := range es.sent {
+		if serialNumber <= es.serialNumber-eventStreamLookback {
+			delete(es.sent, id)
+		}
+	}
+}
+
+func (es *eventStream) writeEvent(l logger.Logger, event string, id string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		l.Warn("failed marshalling event data >%v<", err)
+		return err
+	}
+
+	err = es.extendWriteDeadline(l)
+	if err != nil {
+		return err
+	}
+
+	err = writeEventStreamEvent(es.w, event, id, b)
+	if err != nil {
+		l.Warn("failed writing event >%v<", err)
+		return err
+	}
+
+	return es.flush(l)
+}
+
+func (es *eventStream) writeComment(l logger.Logger, comment string) error {
+	err := es.extendWriteDeadline(l)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(es.w, ": %s\n\n", comment)
+	if err != nil {
+		l.Warn("failed writing comment >%v<", err)
+		return err
+	}
+
+	return es.flush(l)
+}
+
+func (es *eventStream) extendWriteDeadline(l logger.Logger) error {
+	if es.rc == nil {
+		return nil
+	}
+	err := es.rc.SetWriteDeadline(time.Now().Add(eventStreamWriteTimeout))
+	if err != nil && err != http.ErrNotSupported {
+		l.Warn("failed setting write deadline >%v<", err)
+		return err
+	}
+	return nil
+}
+
+func (es *eventStream) flush(l logger.Logger) error {
+	if es.rc == nil {
+		return nil
+	}
+	err := es.rc.Flush()
+	if err != nil {
+		l.Warn("failed flushing event stream >%v<", err)
+		return err
+	}
+	return nil
+}
+
+// writeEventStreamEvent writes a single server-sent event
+func writeEventStreamEvent(w io.Writer, event string, id string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteEventStreamEvent(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		event  string
+		id     string
+		data   []byte
+		expect string
+	}{
+		{
+			name:   "Action event with ID",
+			event:  eventTypeAction,
+			id:     "12",
+			data:   []byte(`{"command":"move"}`),
+			expect: "id: 12\nevent: action\ndata: {\"command\":\"move\"}\n\n",
+		},
+		{
+			name:   "Exit event without ID",
+			event:  eventTypeExit,
+			data:   []byte(`{"character_id":"abc"}`),
+			expect: "event: exit\ndata: {\"character_id\":\"abc\"}\n\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			err := writeEventStreamEvent(b, tc.event, tc.id, tc.data)
+			require.NoError(t, err, "writeEventStreamEvent returns without error")
+			require.Equal(t, tc.expect, b.String(), "Event is written in event stream format")
+		})
+	}
+}
+
+func TestEventStreamPrune(t *testing.T) {
+
+	es := &eventStream{
+		serialNumber: 250,
+		sent: map[string]int{
+			"a": 100,
+			"b": 150,
+			"c": 200,
+		},
+	}
+
+	es.prune()
+
+	require.NotContains(t, es.sent, "a", "Sent action outside lookback window is forgotten")
+	require.NotContains(t, es.sent, "b", "Sent action at edge of lookback window is forgotten")
+	require.Contains(t, es.sent, "c", "Sent action inside lookback window is remembered")
+}
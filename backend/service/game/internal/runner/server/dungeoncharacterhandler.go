@@ -30,7 +30,11 @@ func (rnr *Runner) DungeonCharacterHandlerConfig(hc map[string]server.HandlerCon
 			HandlerFunc: rnr.GetDungeonCharacterHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -56,7 +60,11 @@ func (rnr *Runner) DungeonCharacterHandlerConfig(hc map[string]server.HandlerCon
 			HandlerFunc: rnr.PostDungeonCharacterEnterHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -82,7 +90,11 @@ func (rnr *Runner) DungeonCharacterHandlerConfig(hc map[string]server.HandlerCon
 			HandlerFunc: rnr.PostDungeonCharacterExitHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -144,6 +156,12 @@ func (rnr *Runner) GetDungeonCharacterHandler(w http.ResponseWriter, r *http.Req
 		return err
 	}
 
+	err = authorizeCharacterRec(l, r, characterRec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	instanceViewRecordSet, err := rnr.getInstanceViewRecordSetByCharacterID(l, m, characterID)
 	if err != nil {
 		l.Warn("failed getting character instance record >%v<", err)
@@ -222,6 +240,12 @@ func (rnr *Runner) PostDungeonCharacterEnterHandler(w http.ResponseWriter, r *ht
 		return err
 	}
 
+	err = authorizeCharacterRec(l, r, characterRec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	l.Info("Entering dungeon ID >%s< with character ID >%s<", dungeonID, characterID)
 
 	characterInstanceRecordSet, err := m.(*model.Model).CharacterEnterDungeon(dungeonID, characterID)
@@ -310,6 +334,12 @@ func (rnr *Runner) PostDungeonCharacterExitHandler(w http.ResponseWriter, r *htt
 		return err
 	}
 
+	err = authorizeCharacterRec(l, r, characterRec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	instanceViewRecordSet, err := rnr.getInstanceViewRecordSetByCharacterID(l, m, characterID)
 	if err != nil {
 		l.Warn("failed getting character instance record >%v<", err)
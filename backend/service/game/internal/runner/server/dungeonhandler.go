@@ -12,12 +12,20 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/generator"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 const (
-	getDungeons string = "get-dungeons"
-	getDungeon  string = "get-dungeon"
+	getDungeons    string = "get-dungeons"
+	getDungeon     string = "get-dungeon"
+	postDungeon    string = "post-dungeon"
+	putDungeon     string = "put-dungeon"
+	deleteDungeon  string = "delete-dungeon"
+	getDungeonLint string = "get-dungeon-lint"
+
+	postAdminDungeonGenerate string = "post-admin-dungeon-generate"
 )
 
 func (rnr *Runner) DungeonHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
@@ -29,7 +37,11 @@ func (rnr *Runner) DungeonHandlerConfig(hc map[string]server.HandlerConfig) map[
 			HandlerFunc: rnr.getDungeonsHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -55,7 +67,11 @@ func (rnr *Runner) DungeonHandlerConfig(hc map[string]server.HandlerConfig) map[
 			HandlerFunc: rnr.getDungeonHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -75,6 +91,199 @@ func (rnr *Runner) DungeonHandlerConfig(hc map[string]server.HandlerConfig) map[
 				Description: "Get a dungeon.",
 			},
 		},
+		postDungeon: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/dungeons",
+			HandlerFunc: rnr.postDungeonHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeon",
+						Name:     "create.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeon",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeon",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Create a dungeon.",
+			},
+		},
+		putDungeon: {
+			Method:      http.MethodPut,
+			Path:        "/api/v1/dungeons/:dungeon_id",
+			HandlerFunc: rnr.putDungeonHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeon",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeon",
+						Name:     "update.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeon",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeon",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Update a dungeon.",
+			},
+		},
+		deleteDungeon: {
+			Method:      http.MethodDelete,
+			Path:        "/api/v1/dungeons/:dungeon_id",
+			HandlerFunc: rnr.deleteDungeonHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeon",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeon",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeon",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Delete a dungeon along with its locations and where monsters and objects spawn at its locations. Dungeons with dungeon instances cannot be deleted.",
+			},
+		},
+		getDungeonLint: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/dungeons/:dungeon_id/lint",
+			HandlerFunc: rnr.getDungeonLintHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeon",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeonlint",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeonlint",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Lint a dungeon reporting unreachable locations, missing or multiple default locations, one-way exits, dead ends, missing monsters or objects and locations spawning more monsters and objects than recommended.",
+			},
+		},
+		postAdminDungeonGenerate: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/admin/dungeons/generate",
+			HandlerFunc: rnr.postAdminDungeonGenerateHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeon",
+						Name:     "generate.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/dungeon",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/dungeon",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document: true,
+				Description: "Create a procedurally generated dungeon from the built in monster and object pool. " +
+					"The same seed, size, branching factor, levels and chances always generate the same dungeon.",
+			},
+		},
 	})
 }
 
@@ -100,6 +309,12 @@ func (rnr *Runner) getDungeonHandler(w http.ResponseWriter, r *http.Request, pp
 		return err
 	}
 
+	err = m.(*model.Model).TranslateDungeonRecs([]*record.Dungeon{rec})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	// Response data
 	data, err := rnr.RecordToDungeonResponseData(*rec)
 	if err != nil {
@@ -136,6 +351,12 @@ func (rnr *Runner) getDungeonsHandler(w http.ResponseWriter, r *http.Request, pp
 		return err
 	}
 
+	err = m.(*model.Model).TranslateDungeonRecs(recs)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	// Assign response properties
 	data := []schema.DungeonData{}
 	for _, rec := range recs {
@@ -164,3 +385,215 @@ func (rnr *Runner) getDungeonsHandler(w http.ResponseWriter, r *http.Request, pp
 
 	return nil
 }
+
+// postDungeonHandler -
+func (rnr *Runner) postDungeonHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postDungeonHandler")
+
+	req := &schema.DungeonRequest{}
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec := record.Dungeon{}
+
+	// Record data
+	err = rnr.DungeonRequestDataToRecord(req.Data, &rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Creating dungeon record >%#v<", rec)
+
+	err = m.(*model.Model).CreateDungeonRec(&rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeDungeonResponse(l, w, http.StatusCreated, &rec)
+}
+
+// putDungeonHandler -
+func (rnr *Runner) putDungeonHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "putDungeonHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_id")
+
+	l.Info("Updating dungeon ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetDungeonRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("dungeon", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.DungeonRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Record data
+	err = rnr.DungeonRequestDataToRecord(req.Data, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = m.(*model.Model).UpdateDungeonRec(rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeDungeonResponse(l, w, http.StatusOK, rec)
+}
+
+// deleteDungeonHandler responds with the dungeon as it was before it was deleted
+func (rnr *Runner) deleteDungeonHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "deleteDungeonHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_id")
+
+	l.Info("Deleting dungeon ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetDungeonRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("dungeon", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = m.(*model.Model).DeleteDungeon(id)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeDungeonResponse(l, w, http.StatusOK, rec)
+}
+
+// getDungeonLintHandler responds with every issue found with a dungeon
+func (rnr *Runner) getDungeonLintHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getDungeonLintHandler")
+
+	// Path parameters
+	id := pp.ByName("dungeon_id")
+
+	l.Info("Linting dungeon ID >%s<", id)
+
+	issues, err := m.(*model.Model).LintDungeon(id)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.DungeonLintResponse{
+		Data: []schema.DungeonLintData{},
+	}
+	for _, issue := range issues {
+		res.Data = append(res.Data, schema.DungeonLintData{
+			Severity: issue.Severity,
+			Code:     issue.Code,
+			Location: issue.Location,
+			Message:  issue.Message,
+		})
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// postAdminDungeonGenerateHandler -
+func (rnr *Runner) postAdminDungeonGenerateHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postAdminDungeonGenerateHandler")
+
+	req := &schema.DungeonGenerateRequest{}
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	args := generator.Args{
+		Name:            req.Data.Name,
+		Seed:            req.Data.Seed,
+		Size:            req.Data.Size,
+		BranchingFactor: req.Data.BranchingFactor,
+		Levels:          req.Data.Levels,
+		MonsterChance:   generator.DefaultMonsterChance,
+		ObjectChance:    generator.DefaultObjectChance,
+	}
+	if req.Data.MonsterChance != nil {
+		args.MonsterChance = *req.Data.MonsterChance
+	}
+	if req.Data.ObjectChance != nil {
+		args.ObjectChance = *req.Data.ObjectChance
+	}
+
+	l.Info("Generating dungeon >%s< seed >%d<", args.Name, args.Seed)
+
+	d, err := generator.Generate(args)
+	if err != nil {
+		err := coreerror.NewInvalidDataError("%v", err)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec, err := m.(*model.Model).ImportDungeonDefinition(d)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeDungeonResponse(l, w, http.StatusCreated, rec)
+}
+
+func (rnr *Runner) writeDungeonResponse(l logger.Logger, w http.ResponseWriter, status int, rec *record.Dungeon) error {
+
+	// Response data
+	data, err := rnr.RecordToDungeonResponseData(*rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.DungeonResponse{
+		Data: []schema.DungeonData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, status, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/core/server"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 func TestGetDungeonHandler(t *testing.T) {
@@ -128,3 +129,370 @@ func TestGetDungeonHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestDungeonAuthorHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectName string
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.DungeonResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "POST - Create dungeon",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeon]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonRequest{
+						Data: schema.DungeonData{
+							Name:        "Dark Tower",
+							Description: "A tall dark tower looms over the forest.",
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+			expectName: "Dark Tower",
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create dungeon without a name",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeon]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonRequest{
+						Data: schema.DungeonData{
+							Description: "A tall dark tower looms over the forest.",
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create dungeon as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeon]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonRequest{
+						Data: schema.DungeonData{
+							Name:        "Dark Tower",
+							Description: "A tall dark tower looms over the forest.",
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRolePlayer,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update dungeon",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putDungeon]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_id": data.DungeonRecs[0].ID,
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonRequest{
+						Data: schema.DungeonData{
+							Name:        data.DungeonRecs[0].Name + " Revisited",
+							Description: data.DungeonRecs[0].Description,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectName: harness.DungeonNameCave + " Revisited",
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update non-existant dungeon",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putDungeon]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_id": "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.DungeonRequest{
+						Data: schema.DungeonData{
+							Name:        "Dark Tower",
+							Description: "A tall dark tower looms over the forest.",
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusNotFound,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "DELETE - Delete dungeon with instances",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[deleteDungeon]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":dungeon_id": data.DungeonInstanceRecs[0].DungeonID,
+					}
+					return params
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusConflict,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK && tc.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				var responseBody *schema.DungeonResponse
+				if body != nil {
+					responseBody = body.(*schema.DungeonResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				require.Len(t, responseBody.Data, 1, "Response body data has one dungeon")
+				require.NotEmpty(t, responseBody.Data[0].ID, "Dungeon ID is not empty")
+				require.Equal(t, tc.expectName, responseBody.Data[0].Name, "Dungeon name equals expected")
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
+
+func TestGetDungeonLintHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	testCaseHandlerConfig := func(rnr *Runner) server.HandlerConfig {
+		return rnr.HandlerConfig[getDungeonLint]
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.DungeonLintResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	testCases := []TestCase{
+		{
+			Name:          "GET - Lint existing",
+			HandlerConfig: testCaseHandlerConfig,
+			RequestPathParams: func(data harness.Data) map[string]string {
+				params := map[string]string{
+					":dungeon_id": data.DungeonRecs[0].ID,
+				}
+				return params
+			},
+			AccountRole:     record.AccountRoleGameMaster,
+			ResponseDecoder: testCaseResponseDecoder,
+			ResponseCode:    http.StatusOK,
+		},
+		{
+			Name:          "GET - Lint non-existant",
+			HandlerConfig: testCaseHandlerConfig,
+			RequestPathParams: func(data harness.Data) map[string]string {
+				params := map[string]string{
+					":dungeon_id": "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+				}
+				return params
+			},
+			AccountRole:  record.AccountRoleGameMaster,
+			ResponseCode: http.StatusNotFound,
+		},
+		{
+			Name:          "GET - Lint as a player",
+			HandlerConfig: testCaseHandlerConfig,
+			RequestPathParams: func(data harness.Data) map[string]string {
+				params := map[string]string{
+					":dungeon_id": data.DungeonRecs[0].ID,
+				}
+				return params
+			},
+			AccountRole:  record.AccountRolePlayer,
+			ResponseCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK {
+					return
+				}
+
+				var responseBody *schema.DungeonLintResponse
+				if body != nil {
+					responseBody = body.(*schema.DungeonLintResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				for _, data := range responseBody.Data {
+					require.NotEqual(t, "error", data.Severity, "Harness dungeon has no lint errors >%#v<", data)
+				}
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
+
+func TestPostAdminDungeonGenerateHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	testCaseHandlerConfig := func(rnr *Runner) server.HandlerConfig {
+		return rnr.HandlerConfig[postAdminDungeonGenerate]
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.DungeonResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	testCases := []TestCase{
+		{
+			Name:          "POST - Generate dungeon",
+			HandlerConfig: testCaseHandlerConfig,
+			RequestBody: func(data harness.Data) interface{} {
+				req := schema.DungeonGenerateRequest{
+					Data: schema.DungeonGenerateData{
+						Name:   "Generated Depths",
+						Seed:   42,
+						Size:   6,
+						Levels: 2,
+					},
+				}
+				return &req
+			},
+			AccountRole:     record.AccountRoleGameMaster,
+			ResponseDecoder: testCaseResponseDecoder,
+			ResponseCode:    http.StatusCreated,
+		},
+		{
+			Name:          "POST - Generate dungeon with too few locations",
+			HandlerConfig: testCaseHandlerConfig,
+			RequestBody: func(data harness.Data) interface{} {
+				req := schema.DungeonGenerateRequest{
+					Data: schema.DungeonGenerateData{
+						Name: "Generated Depths",
+						Size: 1,
+					},
+				}
+				return &req
+			},
+			AccountRole:  record.AccountRoleGameMaster,
+			ResponseCode: http.StatusBadRequest,
+		},
+		{
+			Name:          "POST - Generate dungeon as a player",
+			HandlerConfig: testCaseHandlerConfig,
+			RequestBody: func(data harness.Data) interface{} {
+				req := schema.DungeonGenerateRequest{
+					Data: schema.DungeonGenerateData{
+						Name: "Generated Depths",
+					},
+				}
+				return &req
+			},
+			AccountRole:  record.AccountRolePlayer,
+			ResponseCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				var responseBody *schema.DungeonResponse
+				if body != nil {
+					responseBody = body.(*schema.DungeonResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				require.Len(t, responseBody.Data, 1, "Response body data has one dungeon")
+				require.NotEmpty(t, responseBody.Data[0].ID, "Dungeon ID is not empty")
+				require.Equal(t, "Generated Depths", responseBody.Data[0].Name, "Dungeon name equals expected")
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
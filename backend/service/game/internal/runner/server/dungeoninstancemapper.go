@@ -0,0 +1,74 @@
+package runner
+
+import (
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// RecordToDungeonInstanceResponseData -
+func (rnr *Runner) RecordToDungeonInstanceResponseData(dungeonInstanceRec record.DungeonInstance, dungeonRec record.Dungeon) (schema.DungeonInstanceData, error) {
+
+	data := schema.DungeonInstanceData{
+		ID:           dungeonInstanceRec.ID,
+		DungeonID:    dungeonInstanceRec.DungeonID,
+		Name:         dungeonRec.Name,
+		Description:  dungeonRec.Description,
+		FailureCount: dungeonInstanceRec.FailureCount,
+		LastError:    dungeonInstanceRec.LastError.String,
+		CreatedAt:    dungeonInstanceRec.CreatedAt,
+		UpdatedAt:    dungeonInstanceRec.UpdatedAt.Time,
+	}
+
+	if dungeonInstanceRec.LastErrorAt.Valid {
+		t := dungeonInstanceRec.LastErrorAt.Time
+		data.LastErrorAt = &t
+	}
+
+	if dungeonInstanceRec.QuarantinedAt.Valid {
+		t := dungeonInstanceRec.QuarantinedAt.Time
+		data.QuarantinedAt = &t
+	}
+
+	return data, nil
+}
+
+// RecordSetToDungeonInstanceOccupantResponseData adds the locations, characters and
+// monsters of a dungeon instance to dungeon instance response data
+func (rnr *Runner) RecordSetToDungeonInstanceOccupantResponseData(data *schema.DungeonInstanceData, recordSet *model.DungeonInstanceViewRecordSet) {
+
+	locationNames := map[string]string{}
+	for _, rec := range recordSet.LocationInstanceViewRecs {
+		locationNames[rec.ID] = rec.Name
+		data.Locations = append(data.Locations, schema.DungeonInstanceLocationData{
+			ID:         rec.ID,
+			LocationID: rec.LocationID,
+			Name:       rec.Name,
+			IsDefault:  rec.IsDefault,
+		})
+	}
+
+	for _, rec := range recordSet.CharacterInstanceViewRecs {
+		data.Characters = append(data.Characters, schema.DungeonInstanceOccupantData{
+			ID:                 rec.ID,
+			CharacterID:        rec.CharacterID,
+			Name:               rec.Name,
+			LocationInstanceID: rec.LocationInstanceID,
+			LocationName:       locationNames[rec.LocationInstanceID],
+			Health:             rec.Health,
+			CurrentHealth:      rec.CurrentHealth,
+		})
+	}
+
+	for _, rec := range recordSet.MonsterInstanceViewRecs {
+		data.Monsters = append(data.Monsters, schema.DungeonInstanceOccupantData{
+			ID:                 rec.ID,
+			MonsterID:          rec.MonsterID,
+			Name:               rec.Name,
+			LocationInstanceID: rec.LocationInstanceID,
+			LocationName:       locationNames[rec.LocationInstanceID],
+			Health:             rec.Health,
+			CurrentHealth:      rec.CurrentHealth,
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
 	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
 	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
 	"gitlab.com/alienspaces/go-mud/backend/core/server"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
@@ -17,8 +18,11 @@ import (
 )
 
 const (
-	getDungeonLocations string = "get-dungeon-locations"
-	getDungeonLocation  string = "get-dungeon-location"
+	getDungeonLocations   string = "get-dungeon-locations"
+	getDungeonLocation    string = "get-dungeon-location"
+	postDungeonLocation   string = "post-dungeon-location"
+	putDungeonLocation    string = "put-dungeon-location"
+	deleteDungeonLocation string = "delete-dungeon-location"
 )
 
 func (rnr *Runner) DungeonLocationHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
@@ -30,7 +34,11 @@ func (rnr *Runner) DungeonLocationHandlerConfig(hc map[string]server.HandlerConf
 			HandlerFunc: rnr.getDungeonLocationsHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -56,7 +64,11 @@ func (rnr *Runner) DungeonLocationHandlerConfig(hc map[string]server.HandlerConf
 			HandlerFunc: rnr.getDungeonLocationHandler,
 			MiddlewareConfig: server.MiddlewareConfig{
 				AuthenTypes: []server.AuthenticationType{
-					server.AuthenticationTypePublic,
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionPlay,
 				},
 				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
 					Main: jsonschema.Schema{
@@ -76,6 +88,132 @@ func (rnr *Runner) DungeonLocationHandlerConfig(hc map[string]server.HandlerConf
 				Description: "Get a dungeon location.",
 			},
 		},
+		postDungeonLocation: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations",
+			HandlerFunc: rnr.postDungeonLocationHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/dungeon",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/location",
+						Name:     "create.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/location",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/location",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Create a dungeon location. Direction links must be locations in the same dungeon.",
+			},
+		},
+		putDungeonLocation: {
+			Method:      http.MethodPut,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id",
+			HandlerFunc: rnr.putDungeonLocationHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/location",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/location",
+						Name:     "update.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/location",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/location",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Update a dungeon location. Direction links must be locations in the same dungeon.",
+			},
+		},
+		deleteDungeonLocation: {
+			Method:      http.MethodDelete,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id",
+			HandlerFunc: rnr.deleteDungeonLocationHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/location",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/location",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/location",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Delete a dungeon location along with where monsters and objects spawn at the location. Direction links from other locations to the location are removed. Locations of dungeons with dungeon instances cannot be deleted.",
+			},
+		},
 	})
 }
 
@@ -126,6 +264,12 @@ func (rnr *Runner) getDungeonLocationHandler(w http.ResponseWriter, r *http.Requ
 
 	recs = append(recs, locationRec)
 
+	err = m.(*model.Model).TranslateLocationRecs(recs)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	// Assign response properties
 	data := []schema.LocationData{}
 	for _, rec := range recs {
@@ -184,6 +328,12 @@ func (rnr *Runner) getDungeonLocationsHandler(w http.ResponseWriter, r *http.Req
 		return err
 	}
 
+	err = m.(*model.Model).TranslateLocationRecs(locationRecs)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
 	// Assign response properties
 	data := []schema.LocationData{}
 	for _, locationRecs := range locationRecs {
@@ -213,9 +363,179 @@ func (rnr *Runner) getDungeonLocationsHandler(w http.ResponseWriter, r *http.Req
 	return nil
 }
 
+// postDungeonLocationHandler -
+func (rnr *Runner) postDungeonLocationHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postDungeonLocationHandler")
+
+	// Path parameters
+	dungeonID := pp.ByName("dungeon_id")
+
+	dungeonRec, err := m.(*model.Model).GetDungeonRec(dungeonID, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if dungeonRec == nil {
+		err := coreerror.NewNotFoundError("dungeon", dungeonID)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.LocationRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec := record.Location{
+		DungeonID: dungeonRec.ID,
+	}
+
+	// Record data
+	err = rnr.LocationRequestDataToRecord(req.Data, &rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Creating dungeon ID >%s< location record >%#v<", dungeonID, rec)
+
+	err = m.(*model.Model).CreateLocationRec(&rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationResponse(l, w, http.StatusCreated, &rec)
+}
+
+// putDungeonLocationHandler -
+func (rnr *Runner) putDungeonLocationHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "putDungeonLocationHandler")
+
+	rec, err := rnr.getDungeonLocationRec(l, m.(*model.Model), pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.LocationRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Record data
+	err = rnr.LocationRequestDataToRecord(req.Data, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Updating location record >%#v<", rec)
+
+	err = m.(*model.Model).UpdateLocationRec(rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationResponse(l, w, http.StatusOK, rec)
+}
+
+// deleteDungeonLocationHandler responds with the location as it was before it was deleted
+func (rnr *Runner) deleteDungeonLocationHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "deleteDungeonLocationHandler")
+
+	rec, err := rnr.getDungeonLocationRec(l, m.(*model.Model), pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Deleting location ID >%s<", rec.ID)
+
+	err = m.(*model.Model).DeleteLocation(rec.ID)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationResponse(l, w, http.StatusOK, rec)
+}
+
+// getDungeonLocationRec returns a location of a dungeon or a not found error
+func (rnr *Runner) getDungeonLocationRec(l logger.Logger, m *model.Model, dungeonID, locationID string) (*record.Location, error) {
+
+	dungeonRec, err := m.GetDungeonRec(dungeonID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource not found
+	if dungeonRec == nil {
+		return nil, coreerror.NewNotFoundError("dungeon", dungeonID)
+	}
+
+	rec, err := m.GetLocationRec(locationID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource not found
+	if rec == nil || rec.DungeonID != dungeonRec.ID {
+		l.Warn("location ID >%s< not found in dungeon ID >%s<", locationID, dungeonID)
+		return nil, coreerror.NewNotFoundError("location", locationID)
+	}
+
+	return rec, nil
+}
+
+func (rnr *Runner) writeLocationResponse(l logger.Logger, w http.ResponseWriter, status int, rec *record.Location) error {
+
+	// Response data
+	data, err := rnr.RecordToLocationResponseData(*rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.LocationResponse{
+		Data: []schema.LocationData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, status, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
 // LocationRequestDataToRecord -
 func (rnr *Runner) LocationRequestDataToRecord(data schema.LocationData, rec *record.Location) error {
 
+	rec.Name = data.Name
+	rec.Description = data.Description
+	rec.IsDefault = data.Default
+	rec.NorthLocationID = null.NullStringFromString(data.NorthLocationID)
+	rec.NortheastLocationID = null.NullStringFromString(data.NorthEastLocationID)
+	rec.EastLocationID = null.NullStringFromString(data.EastLocationID)
+	rec.SoutheastLocationID = null.NullStringFromString(data.SouthEastLocationID)
+	rec.SouthLocationID = null.NullStringFromString(data.SouthLocationID)
+	rec.SouthwestLocationID = null.NullStringFromString(data.SouthWestLocationID)
+	rec.WestLocationID = null.NullStringFromString(data.WestLocationID)
+	rec.NorthwestLocationID = null.NullStringFromString(data.NorthWestLocationID)
+	rec.UpLocationID = null.NullStringFromString(data.UpLocationID)
+	rec.DownLocationID = null.NullStringFromString(data.DownLocationID)
+
 	return nil
 }
 
@@ -223,12 +543,23 @@ func (rnr *Runner) LocationRequestDataToRecord(data schema.LocationData, rec *re
 func (rnr *Runner) RecordToLocationResponseData(locationRec record.Location) (schema.LocationData, error) {
 
 	data := schema.LocationData{
-		ID:          locationRec.ID,
-		Name:        locationRec.Name,
-		Description: locationRec.Description,
-		Default:     locationRec.IsDefault,
-		CreatedAt:   locationRec.CreatedAt,
-		UpdatedAt:   locationRec.UpdatedAt.Time,
+		ID:                  locationRec.ID,
+		DungeonID:           locationRec.DungeonID,
+		Name:                locationRec.Name,
+		Description:         locationRec.Description,
+		Default:             locationRec.IsDefault,
+		NorthLocationID:     locationRec.NorthLocationID.String,
+		NorthEastLocationID: locationRec.NortheastLocationID.String,
+		EastLocationID:      locationRec.EastLocationID.String,
+		SouthEastLocationID: locationRec.SoutheastLocationID.String,
+		SouthLocationID:     locationRec.SouthLocationID.String,
+		SouthWestLocationID: locationRec.SouthwestLocationID.String,
+		WestLocationID:      locationRec.WestLocationID.String,
+		NorthWestLocationID: locationRec.NorthwestLocationID.String,
+		UpLocationID:        locationRec.UpLocationID.String,
+		DownLocationID:      locationRec.DownLocationID.String,
+		CreatedAt:           locationRec.CreatedAt,
+		UpdatedAt:           locationRec.UpdatedAt.Time,
 	}
 
 	return data, nil
@@ -11,6 +11,7 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/core/server"
 	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
 )
 
 func TestGetDungeonLocationHandler(t *testing.T) {
@@ -147,3 +148,198 @@ func TestGetDungeonLocationHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestDungeonLocationAuthorHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectName          string
+		expectSouthLocation func(data harness.Data) string
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.LocationResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	dungeonPathParams := func(data harness.Data) map[string]string {
+		params := map[string]string{
+			":dungeon_id": data.LocationRecs[0].DungeonID,
+		}
+		return params
+	}
+
+	locationPathParams := func(data harness.Data) map[string]string {
+		params := map[string]string{
+			":dungeon_id":  data.LocationRecs[0].DungeonID,
+			":location_id": data.LocationRecs[0].ID,
+		}
+		return params
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "POST - Create location linked to an existing location",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeonLocation]
+				},
+				RequestPathParams: dungeonPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationRequest{
+						Data: schema.LocationData{
+							Name:            "Hidden Alcove",
+							Description:     "A small alcove hidden behind a boulder.",
+							SouthLocationID: data.LocationRecs[0].ID,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+			expectName: "Hidden Alcove",
+			expectSouthLocation: func(data harness.Data) string {
+				return data.LocationRecs[0].ID
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create location linked to a non-existant location",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeonLocation]
+				},
+				RequestPathParams: dungeonPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationRequest{
+						Data: schema.LocationData{
+							Name:            "Hidden Alcove",
+							Description:     "A small alcove hidden behind a boulder.",
+							SouthLocationID: "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create location as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeonLocation]
+				},
+				RequestPathParams: dungeonPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationRequest{
+						Data: schema.LocationData{
+							Name:        "Hidden Alcove",
+							Description: "A small alcove hidden behind a boulder.",
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRolePlayer,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update location",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putDungeonLocation]
+				},
+				RequestPathParams: locationPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationRequest{
+						Data: schema.LocationData{
+							Name:            "Collapsed Cave Entrance",
+							Description:     data.LocationRecs[0].Description,
+							Default:         data.LocationRecs[0].IsDefault,
+							NorthLocationID: data.LocationRecs[0].NorthLocationID.String,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectName: "Collapsed Cave Entrance",
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update location linked to itself",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putDungeonLocation]
+				},
+				RequestPathParams: locationPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationRequest{
+						Data: schema.LocationData{
+							Name:            data.LocationRecs[0].Name,
+							Description:     data.LocationRecs[0].Description,
+							NorthLocationID: data.LocationRecs[0].ID,
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "DELETE - Delete location with dungeon instances",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[deleteDungeonLocation]
+				},
+				RequestPathParams: locationPathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseCode:      http.StatusConflict,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK && tc.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				var responseBody *schema.LocationResponse
+				if body != nil {
+					responseBody = body.(*schema.LocationResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				require.Len(t, responseBody.Data, 1, "Response body data has one location")
+				require.Equal(t, tc.expectName, responseBody.Data[0].Name, "Location name equals expected")
+
+				if tc.expectSouthLocation != nil {
+					require.Equal(t, tc.expectSouthLocation(th.Data), responseBody.Data[0].SouthLocationID, "South location ID equals expected")
+				}
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
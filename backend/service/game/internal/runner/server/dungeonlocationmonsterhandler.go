@@ -0,0 +1,432 @@
+package runner
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	getDungeonLocationMonsters   string = "get-dungeon-location-monsters"
+	postDungeonLocationMonster   string = "post-dungeon-location-monster"
+	putDungeonLocationMonster    string = "put-dungeon-location-monster"
+	deleteDungeonLocationMonster string = "delete-dungeon-location-monster"
+)
+
+func (rnr *Runner) DungeonLocationMonsterHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
+
+	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		getDungeonLocationMonsters: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/monsters",
+			HandlerFunc: rnr.getDungeonLocationMonstersHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/location",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationmonster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationmonster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "List the monsters that spawn at a dungeon location.",
+			},
+		},
+		postDungeonLocationMonster: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/monsters",
+			HandlerFunc: rnr.postDungeonLocationMonsterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/location",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationmonster",
+						Name:     "create.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationmonster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationmonster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Spawn a monster at a dungeon location.",
+			},
+		},
+		putDungeonLocationMonster: {
+			Method:      http.MethodPut,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/monsters/:location_monster_id",
+			HandlerFunc: rnr.putDungeonLocationMonsterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/locationmonster",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationmonster",
+						Name:     "update.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationmonster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationmonster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Update when a monster spawns at a dungeon location.",
+			},
+		},
+		deleteDungeonLocationMonster: {
+			Method:      http.MethodDelete,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/monsters/:location_monster_id",
+			HandlerFunc: rnr.deleteDungeonLocationMonsterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/locationmonster",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationmonster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationmonster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Stop a monster spawning at a dungeon location.",
+			},
+		},
+	})
+}
+
+// getDungeonLocationMonstersHandler -
+func (rnr *Runner) getDungeonLocationMonstersHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getDungeonLocationMonstersHandler")
+
+	locationRec, err := rnr.getDungeonLocationRec(l, m.(*model.Model), pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Querying location ID >%s< location monster records", locationRec.ID)
+
+	recs, err := m.(*model.Model).GetLocationMonsterRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "location_id",
+					Val: locationRec.ID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Assign response properties
+	data := []schema.LocationMonsterData{}
+	for _, rec := range recs {
+
+		// Response data
+		responseData, err := rnr.locationMonsterResponseData(m.(*model.Model), rec)
+		if err != nil {
+			server.WriteError(l, w, err)
+			return err
+		}
+
+		data = append(data, responseData)
+	}
+
+	res := schema.LocationMonsterResponse{
+		Data: data,
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// postDungeonLocationMonsterHandler -
+func (rnr *Runner) postDungeonLocationMonsterHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postDungeonLocationMonsterHandler")
+
+	locationRec, err := rnr.getDungeonLocationRec(l, m.(*model.Model), pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.LocationMonsterRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec := record.LocationMonster{
+		LocationID: locationRec.ID,
+		MonsterID:  req.Data.MonsterID,
+	}
+
+	// Record data
+	err = rnr.LocationMonsterRequestDataToRecord(req.Data, &rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Creating location monster record >%#v<", rec)
+
+	err = m.(*model.Model).CreateLocationMonsterRec(&rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationMonsterResponse(l, w, m.(*model.Model), http.StatusCreated, &rec)
+}
+
+// putDungeonLocationMonsterHandler -
+func (rnr *Runner) putDungeonLocationMonsterHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "putDungeonLocationMonsterHandler")
+
+	rec, err := rnr.getDungeonLocationMonsterRec(l, m.(*model.Model), pp)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.LocationMonsterRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Record data
+	err = rnr.LocationMonsterRequestDataToRecord(req.Data, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Updating location monster record >%#v<", rec)
+
+	err = m.(*model.Model).UpdateLocationMonsterRec(rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationMonsterResponse(l, w, m.(*model.Model), http.StatusOK, rec)
+}
+
+// deleteDungeonLocationMonsterHandler responds with the location monster as it was before it was deleted
+func (rnr *Runner) deleteDungeonLocationMonsterHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "deleteDungeonLocationMonsterHandler")
+
+	rec, err := rnr.getDungeonLocationMonsterRec(l, m.(*model.Model), pp)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Deleting location monster ID >%s<", rec.ID)
+
+	err = m.(*model.Model).DeleteLocationMonsterRec(rec.ID)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationMonsterResponse(l, w, m.(*model.Model), http.StatusOK, rec)
+}
+
+// getDungeonLocationMonsterRec returns a location monster of a dungeon location or a not found error
+func (rnr *Runner) getDungeonLocationMonsterRec(l logger.Logger, m *model.Model, pp httprouter.Params) (*record.LocationMonster, error) {
+
+	locationRec, err := rnr.getDungeonLocationRec(l, m, pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	id := pp.ByName("location_monster_id")
+
+	rec, err := m.GetLocationMonsterRec(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource not found
+	if rec == nil || rec.LocationID != locationRec.ID {
+		return nil, coreerror.NewNotFoundError("location monster", id)
+	}
+
+	return rec, nil
+}
+
+func (rnr *Runner) writeLocationMonsterResponse(l logger.Logger, w http.ResponseWriter, m *model.Model, status int, rec *record.LocationMonster) error {
+
+	// Response data
+	data, err := rnr.locationMonsterResponseData(m, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.LocationMonsterResponse{
+		Data: []schema.LocationMonsterData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, status, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+func (rnr *Runner) locationMonsterResponseData(m *model.Model, rec *record.LocationMonster) (schema.LocationMonsterData, error) {
+
+	monsterRec, err := m.GetMonsterRec(rec.MonsterID, nil)
+	if err != nil {
+		return schema.LocationMonsterData{}, err
+	}
+
+	if monsterRec == nil {
+		return schema.LocationMonsterData{}, coreerror.NewNotFoundError("monster", rec.MonsterID)
+	}
+
+	return rnr.RecordToLocationMonsterResponseData(*rec, *monsterRec)
+}
+
+// LocationMonsterRequestDataToRecord -
+func (rnr *Runner) LocationMonsterRequestDataToRecord(data schema.LocationMonsterData, rec *record.LocationMonster) error {
+
+	rec.SpawnMinutes = data.SpawnMinutes
+	rec.SpawnPercentChance = data.SpawnPercentChance
+
+	return nil
+}
+
+// RecordToLocationMonsterResponseData -
+func (rnr *Runner) RecordToLocationMonsterResponseData(rec record.LocationMonster, monsterRec record.Monster) (schema.LocationMonsterData, error) {
+
+	data := schema.LocationMonsterData{
+		ID:                 rec.ID,
+		LocationID:         rec.LocationID,
+		MonsterID:          rec.MonsterID,
+		Name:               monsterRec.Name,
+		SpawnMinutes:       rec.SpawnMinutes,
+		SpawnPercentChance: rec.SpawnPercentChance,
+		CreatedAt:          rec.CreatedAt,
+		UpdatedAt:          rec.UpdatedAt.Time,
+	}
+
+	return data, nil
+}
@@ -0,0 +1,220 @@
+package runner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestDungeonLocationMonsterHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectSpawnMinutes int
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.LocationMonsterResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	locationPathParams := func(data harness.Data) map[string]string {
+		locationRec, _ := data.GetLocationRecByID(data.LocationMonsterRecs[0].LocationID)
+		params := map[string]string{
+			":dungeon_id":  locationRec.DungeonID,
+			":location_id": locationRec.ID,
+		}
+		return params
+	}
+
+	locationMonsterPathParams := func(data harness.Data) map[string]string {
+		params := locationPathParams(data)
+		params[":location_monster_id"] = data.LocationMonsterRecs[0].ID
+		return params
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "GET - Get location monsters",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getDungeonLocationMonsters]
+				},
+				RequestPathParams: locationPathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			expectSpawnMinutes: -1,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create location monster",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeonLocationMonster]
+				},
+				RequestPathParams: locationPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationMonsterRequest{
+						Data: schema.LocationMonsterData{
+							MonsterID:          data.MonsterRecs[len(data.MonsterRecs)-1].ID,
+							SpawnMinutes:       10,
+							SpawnPercentChance: 50,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+			expectSpawnMinutes: 10,
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create location monster with non-existant monster",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postDungeonLocationMonster]
+				},
+				RequestPathParams: locationPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationMonsterRequest{
+						Data: schema.LocationMonsterData{
+							MonsterID:          "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+							SpawnMinutes:       10,
+							SpawnPercentChance: 50,
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusBadRequest,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update location monster",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putDungeonLocationMonster]
+				},
+				RequestPathParams: locationMonsterPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationMonsterRequest{
+						Data: schema.LocationMonsterData{
+							SpawnMinutes:       20,
+							SpawnPercentChance: 25,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectSpawnMinutes: 20,
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update location monster of another location",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putDungeonLocationMonster]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := locationMonsterPathParams(data)
+					for _, rec := range data.LocationMonsterRecs {
+						if rec.LocationID != data.LocationMonsterRecs[0].LocationID {
+							params[":location_monster_id"] = rec.ID
+							break
+						}
+					}
+					return params
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.LocationMonsterRequest{
+						Data: schema.LocationMonsterData{
+							SpawnMinutes:       20,
+							SpawnPercentChance: 25,
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusNotFound,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "DELETE - Delete location monster",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[deleteDungeonLocationMonster]
+				},
+				RequestPathParams: locationMonsterPathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+			expectSpawnMinutes: -1,
+		},
+		{
+			TestCase: TestCase{
+				Name: "DELETE - Delete location monster as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[deleteDungeonLocationMonster]
+				},
+				RequestPathParams: locationMonsterPathParams,
+				AccountRole:       record.AccountRolePlayer,
+				ResponseCode:      http.StatusForbidden,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK && tc.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				var responseBody *schema.LocationMonsterResponse
+				if body != nil {
+					responseBody = body.(*schema.LocationMonsterResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				require.NotEmpty(t, responseBody.Data, "Response body data is not empty")
+
+				for _, data := range responseBody.Data {
+					require.NotEmpty(t, data.Name, "Monster name is not empty")
+					if tc.expectSpawnMinutes >= 0 {
+						require.Equal(t, tc.expectSpawnMinutes, data.SpawnMinutes, "Spawn minutes equals expected")
+					}
+				}
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
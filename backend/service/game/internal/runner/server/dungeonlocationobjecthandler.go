@@ -0,0 +1,432 @@
+package runner
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	getDungeonLocationObjects   string = "get-dungeon-location-objects"
+	postDungeonLocationObject   string = "post-dungeon-location-object"
+	putDungeonLocationObject    string = "put-dungeon-location-object"
+	deleteDungeonLocationObject string = "delete-dungeon-location-object"
+)
+
+func (rnr *Runner) DungeonLocationObjectHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
+
+	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		getDungeonLocationObjects: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/objects",
+			HandlerFunc: rnr.getDungeonLocationObjectsHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/location",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationobject",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationobject",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "List the objects that spawn at a dungeon location.",
+			},
+		},
+		postDungeonLocationObject: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/objects",
+			HandlerFunc: rnr.postDungeonLocationObjectHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/location",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationobject",
+						Name:     "create.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationobject",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationobject",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Spawn an object at a dungeon location.",
+			},
+		},
+		putDungeonLocationObject: {
+			Method:      http.MethodPut,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/objects/:location_object_id",
+			HandlerFunc: rnr.putDungeonLocationObjectHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/locationobject",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationobject",
+						Name:     "update.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationobject",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationobject",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Update when an object spawns at a dungeon location.",
+			},
+		},
+		deleteDungeonLocationObject: {
+			Method:      http.MethodDelete,
+			Path:        "/api/v1/dungeons/:dungeon_id/locations/:location_id/objects/:location_object_id",
+			HandlerFunc: rnr.deleteDungeonLocationObjectHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/locationobject",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/locationobject",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/locationobject",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Stop an object spawning at a dungeon location.",
+			},
+		},
+	})
+}
+
+// getDungeonLocationObjectsHandler -
+func (rnr *Runner) getDungeonLocationObjectsHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getDungeonLocationObjectsHandler")
+
+	locationRec, err := rnr.getDungeonLocationRec(l, m.(*model.Model), pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Querying location ID >%s< location object records", locationRec.ID)
+
+	recs, err := m.(*model.Model).GetLocationObjectRecs(
+		&coresql.Options{
+			Params: []coresql.Param{
+				{
+					Col: "location_id",
+					Val: locationRec.ID,
+				},
+			},
+		},
+	)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Assign response properties
+	data := []schema.LocationObjectData{}
+	for _, rec := range recs {
+
+		// Response data
+		responseData, err := rnr.locationObjectResponseData(m.(*model.Model), rec)
+		if err != nil {
+			server.WriteError(l, w, err)
+			return err
+		}
+
+		data = append(data, responseData)
+	}
+
+	res := schema.LocationObjectResponse{
+		Data: data,
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// postDungeonLocationObjectHandler -
+func (rnr *Runner) postDungeonLocationObjectHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postDungeonLocationObjectHandler")
+
+	locationRec, err := rnr.getDungeonLocationRec(l, m.(*model.Model), pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.LocationObjectRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec := record.LocationObject{
+		LocationID: locationRec.ID,
+		ObjectID:   req.Data.ObjectID,
+	}
+
+	// Record data
+	err = rnr.LocationObjectRequestDataToRecord(req.Data, &rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Creating location object record >%#v<", rec)
+
+	err = m.(*model.Model).CreateLocationObjectRec(&rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationObjectResponse(l, w, m.(*model.Model), http.StatusCreated, &rec)
+}
+
+// putDungeonLocationObjectHandler -
+func (rnr *Runner) putDungeonLocationObjectHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "putDungeonLocationObjectHandler")
+
+	rec, err := rnr.getDungeonLocationObjectRec(l, m.(*model.Model), pp)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.LocationObjectRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Record data
+	err = rnr.LocationObjectRequestDataToRecord(req.Data, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Updating location object record >%#v<", rec)
+
+	err = m.(*model.Model).UpdateLocationObjectRec(rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationObjectResponse(l, w, m.(*model.Model), http.StatusOK, rec)
+}
+
+// deleteDungeonLocationObjectHandler responds with the location object as it was before it was deleted
+func (rnr *Runner) deleteDungeonLocationObjectHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "deleteDungeonLocationObjectHandler")
+
+	rec, err := rnr.getDungeonLocationObjectRec(l, m.(*model.Model), pp)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Deleting location object ID >%s<", rec.ID)
+
+	err = m.(*model.Model).DeleteLocationObjectRec(rec.ID)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeLocationObjectResponse(l, w, m.(*model.Model), http.StatusOK, rec)
+}
+
+// getDungeonLocationObjectRec returns a location object of a dungeon location or a not found error
+func (rnr *Runner) getDungeonLocationObjectRec(l logger.Logger, m *model.Model, pp httprouter.Params) (*record.LocationObject, error) {
+
+	locationRec, err := rnr.getDungeonLocationRec(l, m, pp.ByName("dungeon_id"), pp.ByName("location_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	id := pp.ByName("location_object_id")
+
+	rec, err := m.GetLocationObjectRec(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resource not found
+	if rec == nil || rec.LocationID != locationRec.ID {
+		return nil, coreerror.NewNotFoundError("location object", id)
+	}
+
+	return rec, nil
+}
+
+func (rnr *Runner) writeLocationObjectResponse(l logger.Logger, w http.ResponseWriter, m *model.Model, status int, rec *record.LocationObject) error {
+
+	// Response data
+	data, err := rnr.locationObjectResponseData(m, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.LocationObjectResponse{
+		Data: []schema.LocationObjectData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, status, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+func (rnr *Runner) locationObjectResponseData(m *model.Model, rec *record.LocationObject) (schema.LocationObjectData, error) {
+
+	objectRec, err := m.GetObjectRec(rec.ObjectID, nil)
+	if err != nil {
+		return schema.LocationObjectData{}, err
+	}
+
+	if objectRec == nil {
+		return schema.LocationObjectData{}, coreerror.NewNotFoundError("object", rec.ObjectID)
+	}
+
+	return rnr.RecordToLocationObjectResponseData(*rec, *objectRec)
+}
+
+// LocationObjectRequestDataToRecord -
+func (rnr *Runner) LocationObjectRequestDataToRecord(data schema.LocationObjectData, rec *record.LocationObject) error {
+
+	rec.SpawnMinutes = data.SpawnMinutes
+	rec.SpawnPercentChance = data.SpawnPercentChance
+
+	return nil
+}
+
+// RecordToLocationObjectResponseData -
+func (rnr *Runner) RecordToLocationObjectResponseData(rec record.LocationObject, objectRec record.Object) (schema.LocationObjectData, error) {
+
+	data := schema.LocationObjectData{
+		ID:                 rec.ID,
+		LocationID:         rec.LocationID,
+		ObjectID:           rec.ObjectID,
+		Name:               objectRec.Name,
+		SpawnMinutes:       rec.SpawnMinutes,
+		SpawnPercentChance: rec.SpawnPercentChance,
+		CreatedAt:          rec.CreatedAt,
+		UpdatedAt:          rec.UpdatedAt.Time,
+	}
+
+	return data, nil
+}
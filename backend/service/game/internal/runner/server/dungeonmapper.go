@@ -8,6 +8,9 @@ import (
 // DungeonRequestDataToRecord -
 func (rnr *Runner) DungeonRequestDataToRecord(data schema.DungeonData, rec *record.Dungeon) error {
 
+	rec.Name = data.Name
+	rec.Description = data.Description
+
 	return nil
 }
 
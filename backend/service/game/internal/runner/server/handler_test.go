@@ -32,6 +32,9 @@ type TestCaser interface {
 	TestResponseCode() int
 	TestShouldSetupTeardown() bool
 	TestShouldTxCommit() bool
+	TestAccountName() string
+	TestAccountRole() string
+	TestAPIKeyOwner() string
 }
 
 // TestCase is the base test case class for all tests cases to extend
@@ -48,6 +51,15 @@ type TestCase struct {
 	ResponseCode        int
 	ShouldSetupTeardown bool // Should running the test automatically run the harness data setup and teardown
 	ShouldTxCommit      bool // Should running the test automatically include the rollback header
+	// AccountName is the harness account the request is authenticated as, defaults to the first
+	// harness account. Provide an Authorization request header to override authentication.
+	AccountName string
+	// AccountRole authenticates the request as the first harness account with the role
+	// when no AccountName is provided.
+	AccountRole string
+	// APIKeyOwner authenticates the request with the X-API-Key header of the harness
+	// API key with the owner instead of an account token.
+	APIKeyOwner string
 }
 
 //lint:ignore U1000 - testing struct implements interface
@@ -127,6 +139,48 @@ func (t *TestCase) TestShouldTxCommit() bool {
 	return t.ShouldTxCommit
 }
 
+func (t *TestCase) TestAccountName() string {
+	return t.AccountName
+}
+
+func (t *TestCase) TestAccountRole() string {
+	return t.AccountRole
+}
+
+func (t *TestCase) TestAPIKeyOwner() string {
+	return t.APIKeyOwner
+}
+
+// testAuthorization returns a Bearer token Authorization header value for the named
+// harness account, the first harness account with the role when no name is provided,
+// the first harness account when neither is provided, or an empty string when there
+// are no harness accounts.
+func testAuthorization(rnr *Runner, data harness.Data, accountName string, accountRole string) (string, error) {
+	if len(data.AccountRecs) == 0 {
+		return "", nil
+	}
+
+	accountRec := data.AccountRecs[0]
+
+	var err error
+	switch {
+	case accountName != "":
+		accountRec, err = data.GetAccountRecByName(accountName)
+	case accountRole != "":
+		accountRec, err = data.GetAccountRecByRole(accountRole)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	token, _, err := rnr.encodeAccountToken(accountRec)
+	if err != nil {
+		return "", err
+	}
+
+	return "Bearer " + token, nil
+}
+
 func RunTestCase(t *testing.T, th *harness.Testing, tc TestCaser, tf func(method string, body interface{})) {
 
 	rnr, err := NewRunner(th.Config, th.Log)
@@ -222,6 +276,18 @@ func RunTestCase(t *testing.T, th *harness.Testing, tc TestCaser, tf func(method
 	// Request headers
 	requestHeaders := tc.TestRequestHeaders(th.Data)
 
+	if apiKeyOwner := tc.TestAPIKeyOwner(); apiKeyOwner != "" {
+		apiKeyRec, err := th.Data.GetAPIKeyRecByOwner(apiKeyOwner)
+		require.NoError(t, err, "GetAPIKeyRecByOwner returns without error")
+		requestHeaders[server.HeaderXAPIKey] = th.Data.APIKeys[apiKeyRec.ID]
+	} else if _, ok := requestHeaders["Authorization"]; !ok {
+		authorization, err := testAuthorization(rnr, th.Data, tc.TestAccountName(), tc.TestAccountRole())
+		require.NoError(t, err, "Test authorization returns without error")
+		if authorization != "" {
+			requestHeaders["Authorization"] = authorization
+		}
+	}
+
 	for headerKey, headerVal := range requestHeaders {
 		req.Header.Add(headerKey, headerVal)
 	}
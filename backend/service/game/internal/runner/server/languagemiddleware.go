@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+)
+
+type contextKey int
+
+const (
+	ctxKeyLanguage contextKey = 1
+)
+
+// LanguageMiddleware negotiates the language of the response from the
+// Accept-Language header. Error messages are translated into the language,
+// commands are parsed in the language and dungeon content and narratives are
+// translated into the language.
+func (rnr *Runner) LanguageMiddleware(hc server.HandlerConfig, h server.Handle) (server.Handle, error) {
+
+	handle := func(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+		l = loggerWithFunctionContext(l, "LanguageMiddleware")
+
+		language := locale.Negotiate(r.Header.Get("Accept-Language"))
+
+		l.Debug("Negotiated language >%s< from >%s<", language, r.Header.Get("Accept-Language"))
+
+		w.Header().Set("Content-Language", language)
+		w.Header().Add("Vary", "Accept-Language")
+
+		r = r.WithContext(context.WithValue(r.Context(), ctxKeyLanguage, language))
+
+		if mm, ok := m.(*model.Model); ok {
+			mm.SetLanguage(language)
+		}
+
+		return h(&localisedResponseWriter{ResponseWriter: w, language: language}, r, pp, qp, l, m)
+	}
+
+	return handle, nil
+}
+
+// requestLanguage returns the negotiated language of a request
+func requestLanguage(r *http.Request) string {
+	language, ok := r.Context().Value(ctxKeyLanguage).(string)
+	if !ok {
+		return locale.Default
+	}
+	return language
+}
+
+// localisedResponseWriter translates error messages into the negotiated language
+type localisedResponseWriter struct {
+	http.ResponseWriter
+	language string
+}
+
+var _ server.ErrorLocaliser = &localisedResponseWriter{}
+
+// LocaliseError translates the error message, schema validation messages are
+// produced by the schema validator and are not translated
+func (w *localisedResponseWriter) LocaliseError(e coreerror.Error) coreerror.Error {
+	e.Message = locale.Message(w.language, e.Message)
+	return e
+}
+
+// Unwrap returns the underlying response writer so flushing and write deadlines
+// are available through http.ResponseController
+func (w *localisedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,427 @@
+package runner
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/jsonschema"
+	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+const (
+	getMonsters   string = "get-monsters"
+	getMonster    string = "get-monster"
+	postMonster   string = "post-monster"
+	putMonster    string = "put-monster"
+	deleteMonster string = "delete-monster"
+)
+
+func (rnr *Runner) MonsterHandlerConfig(hc map[string]server.HandlerConfig) map[string]server.HandlerConfig {
+
+	return mergeHandlerConfigs(hc, map[string]server.HandlerConfig{
+		getMonsters: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/monsters",
+			HandlerFunc: rnr.getMonstersHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/monster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/monster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "List monsters.",
+			},
+		},
+		getMonster: {
+			Method:      http.MethodGet,
+			Path:        "/api/v1/monsters/:monster_id",
+			HandlerFunc: rnr.getMonsterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/monster",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/monster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/monster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Get a monster.",
+			},
+		},
+		postMonster: {
+			Method:      http.MethodPost,
+			Path:        "/api/v1/monsters",
+			HandlerFunc: rnr.postMonsterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/monster",
+						Name:     "create.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/monster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/monster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Create a monster.",
+			},
+		},
+		putMonster: {
+			Method:      http.MethodPut,
+			Path:        "/api/v1/monsters/:monster_id",
+			HandlerFunc: rnr.putMonsterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/monster",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateRequestSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/monster",
+						Name:     "update.request.schema.json",
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/monster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/monster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Update a monster.",
+			},
+		},
+		deleteMonster: {
+			Method:      http.MethodDelete,
+			Path:        "/api/v1/monsters/:monster_id",
+			HandlerFunc: rnr.deleteMonsterHandler,
+			MiddlewareConfig: server.MiddlewareConfig{
+				AuthenTypes: []server.AuthenticationType{
+					server.AuthenticationTypeJWT,
+					server.AuthenticationTypeAPIKey,
+				},
+				AuthzPermissions: []server.AuthorizedPermission{
+					permissionDungeonAuthor,
+				},
+				ValidateParamsConfig: &server.ValidateParamsConfig{
+					PathParamSchema: &jsonschema.SchemaWithReferences{
+						Main: jsonschema.Schema{
+							Location: "schema/game/monster",
+							Name:     "path.schema.json",
+						},
+					},
+				},
+				ValidateResponseSchema: &jsonschema.SchemaWithReferences{
+					Main: jsonschema.Schema{
+						Location: "schema/game/monster",
+						Name:     "response.schema.json",
+					},
+					References: []jsonschema.Schema{
+						{
+							Location: "schema/game/monster",
+							Name:     "data.schema.json",
+						},
+					},
+				},
+			},
+			DocumentationConfig: server.DocumentationConfig{
+				Document:    true,
+				Description: "Delete a monster along with the objects the monster carries. Monsters that spawn at a location or have monster instances cannot be deleted.",
+			},
+		},
+	})
+}
+
+// getMonstersHandler -
+func (rnr *Runner) getMonstersHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getMonstersHandler")
+
+	// Query parameters
+	opts := queryparam.ToSQLOptions(qp)
+
+	l.Info("Querying monster records with opts >%#v<", opts)
+
+	recs, err := m.(*model.Model).GetMonsterRecs(opts)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = m.(*model.Model).TranslateMonsterRecs(recs)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Assign response properties
+	data := []schema.MonsterData{}
+	for _, rec := range recs {
+
+		// Response data
+		responseData, err := rnr.RecordToMonsterResponseData(*rec)
+		if err != nil {
+			server.WriteError(l, w, err)
+			return err
+		}
+
+		data = append(data, responseData)
+	}
+
+	res := schema.MonsterResponse{
+		Data: data,
+	}
+
+	err = server.WriteResponse(l, w, http.StatusOK, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// getMonsterHandler -
+func (rnr *Runner) getMonsterHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "getMonsterHandler")
+
+	// Path parameters
+	id := pp.ByName("monster_id")
+
+	l.Info("Getting monster record ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetMonsterRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("monster", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = m.(*model.Model).TranslateMonsterRecs([]*record.Monster{rec})
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeMonsterResponse(l, w, http.StatusOK, rec)
+}
+
+// postMonsterHandler -
+func (rnr *Runner) postMonsterHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "postMonsterHandler")
+
+	req := &schema.MonsterRequest{}
+	req, err := server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	rec := record.Monster{}
+
+	// Record data
+	err = rnr.MonsterRequestDataToRecord(req.Data, &rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	l.Info("Creating monster record >%#v<", rec)
+
+	err = m.(*model.Model).CreateMonsterRec(&rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeMonsterResponse(l, w, http.StatusCreated, &rec)
+}
+
+// putMonsterHandler -
+func (rnr *Runner) putMonsterHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "putMonsterHandler")
+
+	// Path parameters
+	id := pp.ByName("monster_id")
+
+	l.Info("Updating monster ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetMonsterRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("monster", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	req := &schema.MonsterRequest{}
+	req, err = server.ReadRequest(l, r, req)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Record data
+	err = rnr.MonsterRequestDataToRecord(req.Data, rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = m.(*model.Model).UpdateMonsterRec(rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeMonsterResponse(l, w, http.StatusOK, rec)
+}
+
+// deleteMonsterHandler responds with the monster as it was before it was deleted
+func (rnr *Runner) deleteMonsterHandler(w http.ResponseWriter, r *http.Request, pp httprouter.Params, qp *queryparam.QueryParams, l logger.Logger, m modeller.Modeller) error {
+	l = loggerWithFunctionContext(l, "deleteMonsterHandler")
+
+	// Path parameters
+	id := pp.ByName("monster_id")
+
+	l.Info("Deleting monster ID >%s<", id)
+
+	rec, err := m.(*model.Model).GetMonsterRec(id, nil)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	// Resource not found
+	if rec == nil {
+		err := coreerror.NewNotFoundError("monster", id)
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	err = m.(*model.Model).DeleteMonster(id)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	return rnr.writeMonsterResponse(l, w, http.StatusOK, rec)
+}
+
+func (rnr *Runner) writeMonsterResponse(l logger.Logger, w http.ResponseWriter, status int, rec *record.Monster) error {
+
+	// Response data
+	data, err := rnr.RecordToMonsterResponseData(*rec)
+	if err != nil {
+		server.WriteError(l, w, err)
+		return err
+	}
+
+	res := schema.MonsterResponse{
+		Data: []schema.MonsterData{
+			data,
+		},
+	}
+
+	err = server.WriteResponse(l, w, status, res)
+	if err != nil {
+		l.Warn("failed writing response >%v<", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package runner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestMonsterHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectName string
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.MonsterResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	monsterPathParams := func(data harness.Data) map[string]string {
+		params := map[string]string{
+			":monster_id": data.MonsterRecs[0].ID,
+		}
+		return params
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "GET - Get all",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getMonsters]
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get existing",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getMonster]
+				},
+				RequestPathParams: monsterPathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get non-existant",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getMonster]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":monster_id": "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+					}
+					return params
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusNotFound,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create monster",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postMonster]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.MonsterRequest{
+						Data: schema.MonsterData{
+							Name:         "Giant Spider",
+							Description:  "A giant hairy spider with too many eyes.",
+							Strength:     12,
+							Dexterity:    14,
+							Intelligence: 6,
+							Health:       20,
+							Fatigue:      20,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+			expectName: "Giant Spider",
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create monster as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postMonster]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.MonsterRequest{
+						Data: schema.MonsterData{
+							Name:         "Giant Spider",
+							Description:  "A giant hairy spider with too many eyes.",
+							Strength:     12,
+							Dexterity:    14,
+							Intelligence: 6,
+							Health:       20,
+							Fatigue:      20,
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRolePlayer,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update monster",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putMonster]
+				},
+				RequestPathParams: monsterPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					rec := data.MonsterRecs[0]
+					req := schema.MonsterRequest{
+						Data: schema.MonsterData{
+							Name:         "Giant Spider",
+							Description:  rec.Description,
+							Strength:     rec.Strength,
+							Dexterity:    rec.Dexterity,
+							Intelligence: rec.Intelligence,
+							Health:       rec.Health,
+							Fatigue:      rec.Fatigue,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectName: "Giant Spider",
+		},
+		{
+			TestCase: TestCase{
+				Name: "DELETE - Delete monster spawning at a location",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[deleteMonster]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":monster_id": data.LocationMonsterRecs[0].MonsterID,
+					}
+					return params
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusConflict,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK && tc.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				var responseBody *schema.MonsterResponse
+				if body != nil {
+					responseBody = body.(*schema.MonsterResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				require.NotEmpty(t, responseBody.Data, "Response body data is not empty")
+
+				if tc.expectName != "" {
+					require.Len(t, responseBody.Data, 1, "Response body data has one monster")
+					require.Equal(t, tc.expectName, responseBody.Data[0].Name, "Monster name equals expected")
+				}
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package runner
+
+import (
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// MonsterRequestDataToRecord -
+func (rnr *Runner) MonsterRequestDataToRecord(data schema.MonsterData, rec *record.Monster) error {
+
+	rec.Name = data.Name
+	rec.Description = data.Description
+	rec.Strength = data.Strength
+	rec.Dexterity = data.Dexterity
+	rec.Intelligence = data.Intelligence
+	rec.Health = data.Health
+	rec.Fatigue = data.Fatigue
+	rec.Coins = data.Coins
+	rec.ExperiencePoints = data.ExperiencePoints
+	rec.AttributePoints = data.AttributePoints
+
+	return nil
+}
+
+// RecordToMonsterResponseData -
+func (rnr *Runner) RecordToMonsterResponseData(rec record.Monster) (schema.MonsterData, error) {
+
+	data := schema.MonsterData{
+		ID:               rec.ID,
+		Name:             rec.Name,
+		Description:      rec.Description,
+		Strength:         rec.Strength,
+		Dexterity:        rec.Dexterity,
+		Intelligence:     rec.Intelligence,
+		Health:           rec.Health,
+		Fatigue:          rec.Fatigue,
+		Coins:            rec.Coins,
+		ExperiencePoints: rec.ExperiencePoints,
+		AttributePoints:  rec.AttributePoints,
+		CreatedAt:        rec.CreatedAt,
+		UpdatedAt:        rec.UpdatedAt.Time,
+	}
+
+	return data, nil
+}
@@ -0,0 +1,199 @@
+package runner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/alienspaces/go-mud/backend/core/server"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/harness"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+func TestObjectHandler(t *testing.T) {
+
+	// Test harness
+	th, err := newTestHarness()
+	require.NoError(t, err, "New test data returns without error")
+
+	_, err = th.Setup()
+	require.NoError(t, err, "Test data setup returns without error")
+	defer func() {
+		err = th.Teardown()
+		require.NoError(t, err, "Test data teardown returns without error")
+	}()
+
+	type testCase struct {
+		TestCase
+		expectName string
+	}
+
+	testCaseResponseDecoder := func(body io.Reader) (interface{}, error) {
+		var responseBody *schema.ObjectResponse
+		err = json.NewDecoder(body).Decode(&responseBody)
+		return responseBody, err
+	}
+
+	objectPathParams := func(data harness.Data) map[string]string {
+		params := map[string]string{
+			":object_id": data.ObjectRecs[0].ID,
+		}
+		return params
+	}
+
+	testCases := []testCase{
+		{
+			TestCase: TestCase{
+				Name: "GET - Get all",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getObjects]
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get existing",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getObject]
+				},
+				RequestPathParams: objectPathParams,
+				AccountRole:       record.AccountRoleGameMaster,
+				ResponseDecoder:   testCaseResponseDecoder,
+				ResponseCode:      http.StatusOK,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "GET - Get non-existant",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[getObject]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":object_id": "17c19414-2d15-4d20-8fc3-36fc10341dc8",
+					}
+					return params
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusNotFound,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create object",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postObject]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.ObjectRequest{
+						Data: schema.ObjectData{
+							Name:                "Stuffed Spider",
+							Description:         "A stuffed giant spider.",
+							DescriptionDetailed: "A stuffed giant spider, its many eyes replaced with glass beads.",
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusCreated,
+			},
+			expectName: "Stuffed Spider",
+		},
+		{
+			TestCase: TestCase{
+				Name: "POST - Create object as a player",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[postObject]
+				},
+				RequestBody: func(data harness.Data) interface{} {
+					req := schema.ObjectRequest{
+						Data: schema.ObjectData{
+							Name:                "Stuffed Spider",
+							Description:         "A stuffed giant spider.",
+							DescriptionDetailed: "A stuffed giant spider, its many eyes replaced with glass beads.",
+						},
+					}
+					return &req
+				},
+				AccountRole:  record.AccountRolePlayer,
+				ResponseCode: http.StatusForbidden,
+			},
+		},
+		{
+			TestCase: TestCase{
+				Name: "PUT - Update object",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[putObject]
+				},
+				RequestPathParams: objectPathParams,
+				RequestBody: func(data harness.Data) interface{} {
+					rec := data.ObjectRecs[0]
+					req := schema.ObjectRequest{
+						Data: schema.ObjectData{
+							Name:                "Stuffed Spider",
+							Description:         rec.Description,
+							DescriptionDetailed: rec.DescriptionDetailed,
+						},
+					}
+					return &req
+				},
+				AccountRole:     record.AccountRoleGameMaster,
+				ResponseDecoder: testCaseResponseDecoder,
+				ResponseCode:    http.StatusOK,
+			},
+			expectName: "Stuffed Spider",
+		},
+		{
+			TestCase: TestCase{
+				Name: "DELETE - Delete object spawning at a location",
+				HandlerConfig: func(rnr *Runner) server.HandlerConfig {
+					return rnr.HandlerConfig[deleteObject]
+				},
+				RequestPathParams: func(data harness.Data) map[string]string {
+					params := map[string]string{
+						":object_id": data.LocationObjectRecs[0].ObjectID,
+					}
+					return params
+				},
+				AccountRole:  record.AccountRoleGameMaster,
+				ResponseCode: http.StatusConflict,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Logf("Running test >%s<", tc.Name)
+
+			testFunc := func(method string, body interface{}) {
+
+				if tc.TestResponseCode() != http.StatusOK && tc.TestResponseCode() != http.StatusCreated {
+					return
+				}
+
+				var responseBody *schema.ObjectResponse
+				if body != nil {
+					responseBody = body.(*schema.ObjectResponse)
+				}
+
+				require.NotNil(t, responseBody, "Response body is not nil")
+				require.NotEmpty(t, responseBody.Data, "Response body data is not empty")
+
+				if tc.expectName != "" {
+					require.Len(t, responseBody.Data, 1, "Response body data has one object")
+					require.Equal(t, tc.expectName, responseBody.Data[0].Name, "Object name equals expected")
+				}
+			}
+
+			RunTestCase(t, th, &tc, testFunc)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package runner
+
+import (
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// ObjectRequestDataToRecord -
+func (rnr *Runner) ObjectRequestDataToRecord(data schema.ObjectData, rec *record.Object) error {
+
+	rec.Name = data.Name
+	rec.Description = data.Description
+	rec.DescriptionDetailed = data.DescriptionDetailed
+
+	return nil
+}
+
+// RecordToObjectResponseData -
+func (rnr *Runner) RecordToObjectResponseData(rec record.Object) (schema.ObjectData, error) {
+
+	data := schema.ObjectData{
+		ID:                  rec.ID,
+		Name:                rec.Name,
+		Description:         rec.Description,
+		DescriptionDetailed: rec.DescriptionDetailed,
+		CreatedAt:           rec.CreatedAt,
+		UpdatedAt:           rec.UpdatedAt.Time,
+	}
+
+	return data, nil
+}
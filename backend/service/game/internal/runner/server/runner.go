@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"crypto/rsa"
 	"fmt"
 
 	"gitlab.com/alienspaces/go-mud/backend/core/queryparam"
@@ -11,6 +12,7 @@ import (
 	"gitlab.com/alienspaces/go-mud/backend/core/type/modeller"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/runnable"
 	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/narrative"
 )
 
 // Runner -
@@ -18,6 +20,10 @@ type Runner struct {
 	server.Runner
 	// Service specific configuration
 	config Config
+	// jwtPrivateKey signs and verifies account tokens
+	jwtPrivateKey *rsa.PrivateKey
+	// narrator describes actions from the point of view of each character
+	narrator *narrative.Narrator
 }
 
 // Fault -
@@ -44,21 +50,47 @@ func NewRunner(c configurer.Configurer, l logger.Logger) (*Runner, error) {
 		return nil, err
 	}
 
+	jwtPrivateKey, err := loadJWTPrivateKey(l, cfg.JWTPrivateKeyPath, cfg.JWTEphemeralKey)
+	if err != nil {
+		err := fmt.Errorf("failed loading JWT private key >%v<", err)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
+	narrator, err := newNarrator(cfg.NarrativeFlavourPath)
+	if err != nil {
+		err := fmt.Errorf("failed new narrator >%v<", err)
+		l.Warn(err.Error())
+		return nil, err
+	}
+
 	r := Runner{
-		Runner: *cr,
-		config: *cfg,
+		Runner:        *cr,
+		config:        *cfg,
+		jwtPrivateKey: jwtPrivateKey,
+		narrator:      narrator,
 	}
 
 	r.HandlerFunc = r.Handler
 	r.ModellerFunc = r.Modeller
 	r.RunDaemonFunc = r.RunDaemon
+	r.AuthenticateRequestFunc = r.authenticateRequest
+	r.HandlerMiddlewareFuncs = r.middlewareFuncs
 
 	// Handler configuration
-	hc := r.CharacterHandlerConfig(nil)
+	hc := r.AccountHandlerConfig(nil)
+	hc = r.CharacterHandlerConfig(hc)
 	hc = r.DungeonHandlerConfig(hc)
 	hc = r.DungeonCharacterHandlerConfig(hc)
 	hc = r.DungeonLocationHandlerConfig(hc)
+	hc = r.DungeonLocationMonsterHandlerConfig(hc)
+	hc = r.DungeonLocationObjectHandlerConfig(hc)
+	hc = r.MonsterHandlerConfig(hc)
+	hc = r.ObjectHandlerConfig(hc)
 	hc = r.ActionHandlerConfig(hc)
+	hc = r.DungeonCharacterEventHandlerConfig(hc)
+	hc = r.AdminDungeonInstanceHandlerConfig(hc)
+	hc = r.AdminAccountHandlerConfig(hc)
 	hc = r.DocumentationHandlerConfig(hc)
 
 	r.HandlerConfig = hc
@@ -66,6 +98,22 @@ func NewRunner(c configurer.Configurer, l logger.Logger) (*Runner, error) {
 	return &r, nil
 }
 
+// middlewareFuncs are the default middleware with language negotiation
+// applied once the database transaction has started so the model parses
+// commands and translates content in the negotiated language
+func (rnr *Runner) middlewareFuncs() []server.MiddlewareFunc {
+	return []server.MiddlewareFunc{
+		rnr.WaitMiddleware,
+		rnr.ParamMiddleware,
+		rnr.DataMiddleware,
+		rnr.AuthzMiddleware,
+		rnr.AuthenMiddleware,
+		rnr.LanguageMiddleware,
+		rnr.TxMiddleware,
+		rnr.CorrelationMiddleware,
+	}
+}
+
 // Modeller -
 func (rnr *Runner) Modeller(l logger.Logger) (modeller.Modeller, error) {
 	m, err := model.NewModel(rnr.Config, l, rnr.Store)
@@ -76,6 +124,21 @@ func (rnr *Runner) Modeller(l logger.Logger) (modeller.Modeller, error) {
 	return m, nil
 }
 
+// newNarrator returns a narrator with the flavour text from the path, or the
+// default flavour text when no path is configured
+func newNarrator(flavourPath string) (*narrative.Narrator, error) {
+
+	flavour, err := narrative.DefaultFlavour()
+	if flavourPath != "" {
+		flavour, err = narrative.LoadFlavour(flavourPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return narrative.NewNarrator(flavour)
+}
+
 func mergeHandlerConfigs(hc1 map[string]server.HandlerConfig, hc2 map[string]server.HandlerConfig) map[string]server.HandlerConfig {
 	if hc1 == nil {
 		hc1 = map[string]server.HandlerConfig{}
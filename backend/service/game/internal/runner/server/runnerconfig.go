@@ -1,8 +1,16 @@
 package runner
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
 	"gitlab.com/alienspaces/go-mud/backend/core/server"
 	"gitlab.com/alienspaces/go-mud/backend/core/type/configurer"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/config"
 )
 
 // The following constants are used to source environment variables when
@@ -12,10 +20,59 @@ const (
 // EnvKeyAppAPIServerXxx
 )
 
+const (
+	defaultDaemonLeaseDuration        time.Duration = 30 * time.Second
+	defaultDaemonWorkers              int           = 10
+	defaultDaemonMaxFailures          int           = 5
+	defaultDaemonEmptyGracePeriod     time.Duration = 5 * time.Minute
+	defaultDaemonIdleCharacterTimeout time.Duration = 30 * time.Minute
+	defaultJWTTokenDuration           time.Duration = 24 * time.Hour
+	defaultTelnetPort                 int           = 4000
+)
+
 // Config includes core server Config along with additional service
 // specific configuration.
 type Config struct {
 	server.Config
+	// DaemonID identifies this server daemon when claiming dungeon instance leases
+	DaemonID string
+	// DaemonLeaseDuration is how long a claimed dungeon instance lease is held
+	// without being renewed before another daemon may claim it
+	DaemonLeaseDuration time.Duration
+	// DaemonMaxInstances is the maximum number of dungeon instances this server
+	// daemon will process, zero is unlimited
+	DaemonMaxInstances int
+	// DaemonWorkers is the number of dungeon instances this server daemon
+	// processes concurrently
+	DaemonWorkers int
+	// DaemonMaxFailures is the number of consecutive times a dungeon instance
+	// may fail processing before it is quarantined, zero never quarantines
+	DaemonMaxFailures int
+	// DaemonEmptyGracePeriod is how long a dungeon instance may be empty of
+	// characters before it is shutdown
+	DaemonEmptyGracePeriod time.Duration
+	// DaemonIdleCharacterTimeout is how long a character may go without
+	// performing an action before it exits the dungeon, zero never exits
+	// idle characters
+	DaemonIdleCharacterTimeout time.Duration
+	// DaemonConsistencyCheckCycles is the number of daemon cycles between
+	// dungeon instance invariant checks, zero never checks
+	DaemonConsistencyCheckCycles int
+	// DaemonConsistencyRepair repairs invariant violations found by the daemon
+	DaemonConsistencyRepair bool
+	// JWTPrivateKeyPath is the path to the PEM encoded RSA private key used to
+	// sign and verify account tokens
+	JWTPrivateKeyPath string
+	// JWTEphemeralKey generates a private key on startup when no private key
+	// path is configured, for development and testing only
+	JWTEphemeralKey bool
+	// JWTTokenDuration is how long an account token is valid for after login
+	JWTTokenDuration time.Duration
+	// NarrativeFlavourPath is the path to a YAML file of monster and object
+	// flavour text used when narrating actions
+	NarrativeFlavourPath string
+	// TelnetPort is the port the telnet server listens on
+	TelnetPort int
 	// Add here..
 	// AppAPIServerXxx
 }
@@ -26,7 +83,17 @@ func NewConfig(c configurer.Configurer) (*Config, error) {
 		return nil, err
 	}
 	cfg := Config{
-		Config: *ccfg,
+		Config:                     *ccfg,
+		DaemonID:                   c.Get(config.AppServerDaemonID),
+		DaemonLeaseDuration:        defaultDaemonLeaseDuration,
+		DaemonWorkers:              defaultDaemonWorkers,
+		DaemonMaxFailures:          defaultDaemonMaxFailures,
+		DaemonEmptyGracePeriod:     defaultDaemonEmptyGracePeriod,
+		DaemonIdleCharacterTimeout: defaultDaemonIdleCharacterTimeout,
+		JWTPrivateKeyPath:          c.Get(config.AppServerJWTPrivateKeyPath),
+		JWTTokenDuration:           defaultJWTTokenDuration,
+		NarrativeFlavourPath:       c.Get(config.AppServerNarrativeFlavourPath),
+		TelnetPort:                 defaultTelnetPort,
 		// Add here..
 		// AppAPIServerXxx: c.Get(EnvKeyAppAPIServerXxx),
 	}
@@ -37,5 +104,111 @@ func NewConfig(c configurer.Configurer) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.DaemonID == "" {
+		cfg.DaemonID, err = defaultDaemonID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v := c.Get(config.AppServerDaemonLeaseDuration); v != "" {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms <= 0 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonLeaseDuration, v)
+		}
+		cfg.DaemonLeaseDuration = time.Duration(ms) * time.Millisecond
+	}
+
+	if v := c.Get(config.AppServerDaemonMaxInstances); v != "" {
+		cfg.DaemonMaxInstances, err = strconv.Atoi(v)
+		if err != nil || cfg.DaemonMaxInstances < 0 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonMaxInstances, v)
+		}
+	}
+
+	if v := c.Get(config.AppServerDaemonWorkers); v != "" {
+		cfg.DaemonWorkers, err = strconv.Atoi(v)
+		if err != nil || cfg.DaemonWorkers < 1 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonWorkers, v)
+		}
+	}
+
+	if v := c.Get(config.AppServerDaemonMaxFailures); v != "" {
+		cfg.DaemonMaxFailures, err = strconv.Atoi(v)
+		if err != nil || cfg.DaemonMaxFailures < 0 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonMaxFailures, v)
+		}
+	}
+
+	if v := c.Get(config.AppServerDaemonEmptyGracePeriod); v != "" {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonEmptyGracePeriod, v)
+		}
+		cfg.DaemonEmptyGracePeriod = time.Duration(ms) * time.Millisecond
+	}
+
+	if v := c.Get(config.AppServerDaemonIdleCharacterTimeout); v != "" {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonIdleCharacterTimeout, v)
+		}
+		cfg.DaemonIdleCharacterTimeout = time.Duration(ms) * time.Millisecond
+	}
+
+	if v := c.Get(config.AppServerDaemonConsistencyCheckCycles); v != "" {
+		cfg.DaemonConsistencyCheckCycles, err = strconv.Atoi(v)
+		if err != nil || cfg.DaemonConsistencyCheckCycles < 0 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonConsistencyCheckCycles, v)
+		}
+	}
+
+	if v := c.Get(config.AppServerDaemonConsistencyRepair); v != "" {
+		cfg.DaemonConsistencyRepair, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerDaemonConsistencyRepair, v)
+		}
+	}
+
+	if v := c.Get(config.AppServerJWTEphemeralKey); v != "" {
+		cfg.JWTEphemeralKey, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerJWTEphemeralKey, v)
+		}
+	}
+
+	if cfg.JWTPrivateKeyPath == "" && !cfg.JWTEphemeralKey {
+		return nil, fmt.Errorf("missing configuration variable >%s<, set >%s< to true to generate a key for development and testing", config.AppServerJWTPrivateKeyPath, config.AppServerJWTEphemeralKey)
+	}
+
+	if v := c.Get(config.AppServerJWTTokenDuration); v != "" {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms <= 0 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerJWTTokenDuration, v)
+		}
+		cfg.JWTTokenDuration = time.Duration(ms) * time.Millisecond
+	}
+
+	if v := c.Get(config.AppServerTelnetPort); v != "" {
+		cfg.TelnetPort, err = strconv.Atoi(v)
+		if err != nil || cfg.TelnetPort < 1 || cfg.TelnetPort > 65535 {
+			return nil, fmt.Errorf("invalid configuration variable >%s< value >%s<", config.AppServerTelnetPort, v)
+		}
+	}
+
 	return &cfg, nil
 }
+
+// defaultDaemonID returns the host name, which is unique per pod, or a random
+// identifier when the host name is not available.
+func defaultDaemonID() (string, error) {
+	hostname, err := os.Hostname()
+	if err == nil && hostname != "" {
+		return hostname, nil
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return "daemon-" + id.String(), nil
+}
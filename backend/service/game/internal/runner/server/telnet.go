@@ -0,0 +1,640 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	coreerror "gitlab.com/alienspaces/go-mud/backend/core/error"
+	"gitlab.com/alienspaces/go-mud/backend/core/null"
+	coresql "gitlab.com/alienspaces/go-mud/backend/core/sql"
+	"gitlab.com/alienspaces/go-mud/backend/core/type/logger"
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/model"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/telnet"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/terminal"
+)
+
+const (
+	// telnetLoginAttempts is the number of times a player may fail to login
+	// before being disconnected
+	telnetLoginAttempts int = 3
+	// telnetQuit is typed to exit the dungeon and disconnect
+	telnetQuit string = "quit"
+)
+
+// RunTelnet listens for telnet connections from classic MUD clients. Players
+// login, select a character and a dungeon, and every line typed is processed as
+// a character action in its own database transaction, the same as the action
+// endpoint.
+func (rnr *Runner) RunTelnet(args map[string]interface{}) error {
+	l := loggerWithFunctionContext(rnr.Log, "RunTelnet")
+
+	addr := fmt.Sprintf(":%d", rnr.config.TelnetPort)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		l.Warn("failed listening on >%s< >%v<", addr, err)
+		return err
+	}
+
+	l.Info("Listening for telnet connections on >%s<", addr)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		l.Info("Received SIG >%v<, closing telnet listener", sig)
+		ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			l.Warn("failed accepting telnet connection >%v<", err)
+			continue
+		}
+		go rnr.serveTelnet(conn)
+	}
+}
+
+// telnetSession is a player connected with a telnet client
+type telnetSession struct {
+	l    logger.Logger
+	conn *telnet.Conn
+	// lines are the lines typed by the player, closed when the player
+	// disconnects
+	lines chan string
+	// language is the language commands are parsed in and text is rendered in
+	language      string
+	accountID     string
+	characterID   string
+	characterName string
+	// serialNumber is the highest action serial number rendered
+	serialNumber int
+	// start is the serial number the session started rendering actions after
+	start int
+	// sent is the serial number of rendered actions keyed by action ID
+	sent map[string]int
+}
+
+// telnetAction is an action to render
+type telnetAction struct {
+	id           string
+	serialNumber int
+	data         *schema.ActionResponseData
+}
+
+func (rnr *Runner) serveTelnet(nc net.Conn) {
+	l := loggerWithFunctionContext(rnr.Log, "serveTelnet").WithContext("remote", nc.RemoteAddr().String())
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		nc.Close()
+		l.Info("Telnet connection closed")
+	}()
+
+	l.Info("Telnet connection opened")
+
+	s := &telnetSession{
+		l:        l,
+		conn:     telnet.NewConn(nc),
+		lines:    make(chan string),
+		language: locale.Default,
+		sent:     map[string]int{},
+	}
+
+	err := s.conn.Negotiate()
+	if err != nil {
+		l.Warn("failed negotiating telnet options >%v<", err)
+		return
+	}
+
+	go func() {
+		defer close(s.lines)
+		for {
+			line, err := s.conn.ReadLine()
+			if err != nil {
+				return
+			}
+			select {
+			case s.lines <- line:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	s.write("Welcome to Go MUD!\n\n")
+
+	if !rnr.telnetLogin(s) {
+		return
+	}
+
+	if !rnr.telnetSelectCharacter(s) {
+		return
+	}
+
+	rnr.telnetPlay(s)
+}
+
+// telnetLogin returns whether the player logged in
+func (rnr *Runner) telnetLogin(s *telnetSession) bool {
+
+	for attempt := 0; attempt < telnetLoginAttempts; attempt++ {
+
+		email, ok := s.readLine("Email: ")
+		if !ok {
+			return false
+		}
+
+		err := s.conn.HideInput(true)
+		if err != nil {
+			return false
+		}
+
+		password, ok := s.readLine("Password: ")
+		if !ok {
+			return false
+		}
+
+		err = s.conn.HideInput(false)
+		if err != nil {
+			return false
+		}
+		s.write("\n")
+
+		var accountRec *record.Account
+		err = rnr.telnetTx(s, func(m *model.Model) (err error) {
+			accountRec, err = m.LoginAccount(email, password)
+			return err
+		})
+		if err != nil {
+			s.writeError(err)
+			continue
+		}
+
+		s.l.Info("Account ID >%s< logged in", accountRec.ID)
+		s.accountID = accountRec.ID
+
+		return true
+	}
+
+	return false
+}
+
+// telnetSelectCharacter returns whether the player selected a character that is
+// in a dungeon
+func (rnr *Runner) telnetSelectCharacter(s *telnetSession) bool {
+
+	var characterRecs []*record.Character
+	err := rnr.telnetTx(s, func(m *model.Model) (err error) {
+		characterRecs, err = m.GetCharacterRecs(
+			&coresql.Options{
+				Params: []coresql.Param{
+					{
+						Col: record.FieldCharacterAccountID,
+						Val: s.accountID,
+					},
+				},
+				OrderBy: []coresql.OrderBy{
+					{
+						Col: "created_at",
+					},
+				},
+			},
+		)
+		return err
+	})
+	if err != nil {
+		s.writeError(err)
+		return false
+	}
+
+	if len(characterRecs) == 0 {
+		s.write("You have no characters, create a character to play.\n")
+		return false
+	}
+
+	names := []string{}
+	for _, rec := range characterRecs {
+		names = append(names, rec.Name)
+	}
+
+	idx, ok := s.choose("Characters", "Select a character: ", names)
+	if !ok {
+		return false
+	}
+
+	characterRec := characterRecs[idx]
+	s.characterID = characterRec.ID
+	s.characterName = characterRec.Name
+
+	_ = s.conn.SendGMCP("Char.Name", map[string]string{"name": characterRec.Name})
+
+	var characterInstanceRec *record.CharacterInstance
+	err = rnr.telnetTx(s, func(m *model.Model) (err error) {
+		characterInstanceRec, err = m.GetCharacterInstance(characterRec.ID)
+		if err != nil {
+			return err
+		}
+		s.start, err = latestActionSerialNumber(s.l, m)
+		return err
+	})
+	if err != nil {
+		s.writeError(err)
+		return false
+	}
+	s.serialNumber = s.start
+
+	if characterInstanceRec != nil {
+		s.l.Info("Character ID >%s< is already in a dungeon", characterRec.ID)
+		return true
+	}
+
+	return rnr.telnetSelectDungeon(s)
+}
+
+// telnetSelectDungeon returns whether the selected character entered a dungeon
+func (rnr *Runner) telnetSelectDungeon(s *telnetSession) bool {
+
+	var dungeonRecs []*record.Dungeon
+	err := rnr.telnetTx(s, func(m *model.Model) (err error) {
+		dungeonRecs, err = m.GetDungeonRecs(nil)
+		if err != nil {
+			return err
+		}
+		return m.TranslateDungeonRecs(dungeonRecs)
+	})
+	if err != nil {
+		s.writeError(err)
+		return false
+	}
+
+	if len(dungeonRecs) == 0 {
+		s.write("There are no dungeons to enter.\n")
+		return false
+	}
+
+	names := []string{}
+	for _, rec := range dungeonRecs {
+		names = append(names, rec.Name)
+	}
+
+	idx, ok := s.choose("Dungeons", "Select a dungeon: ", names)
+	if !ok {
+		return false
+	}
+
+	err = rnr.telnetTx(s, func(m *model.Model) error {
+		_, err := m.CharacterEnterDungeon(dungeonRecs[idx].ID, s.characterID)
+		return err
+	})
+	if err != nil {
+		s.writeError(err)
+		return false
+	}
+
+	s.l.Info("Character ID >%s< entered dungeon ID >%s<", s.characterID, dungeonRecs[idx].ID)
+
+	return true
+}
+
+// telnetPlay processes each line typed as a character action and renders
+// actions by others at the character's location as they are committed
+func (rnr *Runner) telnetPlay(s *telnetSession) {
+
+	rnr.telnetAction(s, record.ActionCommandLook)
+	s.prompt()
+
+	pollTicker := time.NewTicker(eventStreamPollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case line, ok := <-s.lines:
+			if !ok {
+				// The character stays in the dungeon until it exits when idle
+				s.l.Info("Character ID >%s< disconnected", s.characterID)
+				return
+			}
+			if strings.EqualFold(line, telnetQuit) {
+				err := rnr.telnetTx(s, func(m *model.Model) error {
+					return m.CharacterExitDungeon(s.characterID)
+				})
+				if err != nil {
+					s.writeError(err)
+				}
+				s.write("Goodbye!\n")
+				return
+			}
+			if line != "" {
+				rnr.telnetAction(s, line)
+			}
+			s.prompt()
+		case <-pollTicker.C:
+			exited, rendered := rnr.telnetPoll(s)
+			if exited {
+				s.write("\nYou are no longer in the dungeon.\n")
+				return
+			}
+			if rendered {
+				s.prompt()
+			}
+		}
+	}
+}
+
+// telnetAction processes a line typed as a character action and renders every
+// action since the character's previous action
+func (rnr *Runner) telnetAction(s *telnetSession, sentence string) {
+
+	var actions []telnetAction
+	err := rnr.telnetTx(s, func(m *model.Model) error {
+		characterInstanceRec, err := m.GetCharacterInstance(s.characterID)
+		if err != nil {
+			return err
+		}
+
+		if characterInstanceRec == nil {
+			return model.NewActionInvalidCharacterError(s.characterID)
+		}
+
+		rs, err := m.ProcessCharacterAction(
+			characterInstanceRec.DungeonInstanceID,
+			characterInstanceRec.ID,
+			strings.ToLower(sentence),
+		)
+		if err != nil {
+			return err
+		}
+
+		actionRecs, err := m.GetActionRecsSincePreviousAction(rs.ActionRec)
+		if err != nil {
+			return err
+		}
+
+		actions, err = rnr.telnetActions(s, m, characterInstanceRec.ID, actionRecs)
+		return err
+	})
+	if err != nil {
+		s.writeError(err)
+		return
+	}
+
+	s.render(actions, false)
+}
+
+// telnetPoll renders newly committed actions at the character's location, each
+// poll uses a new database transaction so actions committed by other players
+// and the daemon are visible
+func (rnr *Runner) telnetPoll(s *telnetSession) (exited bool, rendered bool) {
+
+	var actions []telnetAction
+	err := rnr.telnetTx(s, func(m *model.Model) error {
+		characterInstanceRec, err := m.GetCharacterInstance(s.characterID)
+		if err != nil {
+			return err
+		}
+
+		if characterInstanceRec == nil {
+			exited = true
+			return nil
+		}
+
+		afterSerialNumber := s.serialNumber - eventStreamLookback
+		if afterSerialNumber < 0 {
+			afterSerialNumber = 0
+		}
+
+		actionRecs, err := m.GetCharacterInstanceActionRecsAfterSerialNumber(characterInstanceRec, afterSerialNumber)
+		if err != nil {
+			return err
+		}
+
+		actions, err = rnr.telnetActions(s, m, characterInstanceRec.ID, actionRecs)
+		return err
+	})
+	if err != nil {
+		s.l.Warn("failed polling character ID >%s< actions >%v<", s.characterID, err)
+		return false, false
+	}
+
+	if exited {
+		return true, false
+	}
+
+	if len(actions) == 0 {
+		return false, false
+	}
+
+	s.write("\n")
+	s.render(actions, true)
+
+	return false, true
+}
+
+// telnetActions returns the response data of actions that have not been
+// rendered, narrated from the point of view of the character
+func (rnr *Runner) telnetActions(s *telnetSession, m *model.Model, characterInstanceID string, actionRecs []*record.Action) ([]telnetAction, error) {
+
+	actions := []telnetAction{}
+	for _, actionRec := range actionRecs {
+		if _, ok := s.sent[actionRec.ID]; ok {
+			continue
+		}
+
+		// Actions at or before the serial number the session started after
+		// were committed before the character was selected
+		actionSerialNumber := int(null.NullInt32ToInt32(actionRec.SerialNumber))
+		if actionSerialNumber <= s.start {
+			s.sent[actionRec.ID] = actionSerialNumber
+			continue
+		}
+
+		rs, err := m.GetActionRecordSet(actionRec.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = m.TranslateActionRecordSet(rs)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := actionResponseData(s.l, rnr.narrator, *rs, characterInstanceID, s.language)
+		if err != nil {
+			return nil, err
+		}
+
+		actions = append(actions, telnetAction{
+			id:           actionRec.ID,
+			serialNumber: actionSerialNumber,
+			data:         data,
+		})
+	}
+
+	return actions, nil
+}
+
+// telnetTx runs the function in a new database transaction, the transaction is
+// committed when the function returns without error
+func (rnr *Runner) telnetTx(s *telnetSession, f func(m *model.Model) error) error {
+
+	m, err := rnr.initModeller(s.l)
+	if err != nil {
+		return err
+	}
+
+	m.SetLanguage(s.language)
+
+	err = f(m)
+	if err != nil {
+		if rerr := m.Rollback(); rerr != nil {
+			s.l.Warn("failed Tx rollback >%v<", rerr)
+		}
+		return err
+	}
+
+	err = m.Commit()
+	if err != nil {
+		s.l.Warn("failed Tx commit >%v<", err)
+		return err
+	}
+
+	return nil
+}
+
+// render writes the actions as text and sends the character's location and
+// vitals to GMCP clients, brief renders only the action narratives
+func (s *telnetSession) render(actions []telnetAction, brief bool) {
+
+	data := []schema.ActionResponseData{}
+	for _, action := range actions {
+		s.sent[action.id] = action.serialNumber
+		if action.serialNumber > s.serialNumber {
+			s.serialNumber = action.serialNumber
+		}
+		data = append(data, *action.data)
+	}
+
+	for id, serialNumber := range s.sent {
+		if serialNumber <= s.serialNumber-eventStreamLookback {
+			delete(s.sent, id)
+		}
+	}
+
+	if len(data) == 0 {
+		return
+	}
+
+	err := terminal.Render(s.conn, data, terminal.Options{
+		ANSI:     true,
+		Width:    s.conn.Width(),
+		Language: s.language,
+		Brief:    brief,
+	})
+	if err != nil {
+		s.l.Warn("failed rendering actions >%v<", err)
+		return
+	}
+
+	final := data[len(data)-1]
+	if brief {
+		return
+	}
+
+	_ = s.conn.SendGMCP("Room.Info", map[string]any{
+		"name":  final.Location.Name,
+		"exits": final.Location.Directions,
+	})
+
+	if final.Character != nil && final.Character.Name == s.characterName {
+		_ = s.conn.SendGMCP("Char.Vitals", map[string]int{
+			"hp":    final.Character.CurrentHealth,
+			"maxhp": final.Character.Health,
+			"fp":    final.Character.CurrentFatigue,
+			"maxfp": final.Character.Fatigue,
+		})
+	}
+}
+
+// choose lists the options and returns the index of the option chosen by
+// number or name
+func (s *telnetSession) choose(title, prompt string, options []string) (int, bool) {
+
+	s.write(title + ":\n")
+	for idx, option := range options {
+		s.write(fmt.Sprintf("  %d. %s\n", idx+1, option))
+	}
+
+	for {
+		line, ok := s.readLine(prompt)
+		if !ok {
+			return 0, false
+		}
+
+		if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, true
+		}
+
+		for idx, option := range options {
+			if strings.EqualFold(line, option) {
+				return idx, true
+			}
+		}
+	}
+}
+
+// readLine prompts and returns the next line typed, false when the player has
+// disconnected
+func (s *telnetSession) readLine(prompt string) (string, bool) {
+	err := s.conn.Prompt(prompt)
+	if err != nil {
+		return "", false
+	}
+	line, ok := <-s.lines
+	return line, ok
+}
+
+func (s *telnetSession) prompt() {
+	err := s.conn.Prompt("\n> ")
+	if err != nil {
+		s.l.Warn("failed writing prompt >%v<", err)
+	}
+}
+
+func (s *telnetSession) write(text string) {
+	_, err := s.conn.Write([]byte(text))
+	if err != nil {
+		s.l.Warn("failed writing text >%v<", err)
+	}
+}
+
+// writeError writes the error message translated into the session language,
+// the detail of internal errors is only logged
+func (s *telnetSession) writeError(err error) {
+	e, cerr := coreerror.ToError(err)
+	if cerr != nil || e.ErrorCode == coreerror.Internal {
+		s.l.Warn("failed processing >%v<", err)
+		s.write(s.message("An internal error has occurred.") + "\n")
+		return
+	}
+	s.write(s.message(e.Message) + "\n")
+}
+
+func (s *telnetSession) message(msg string) string {
+	return locale.Message(s.language, msg)
+}
@@ -0,0 +1,423 @@
+package telnet
+
+// Telnet speaks enough of the telnet protocol for classic MUD clients such as
+// Mudlet and plain telnet. The server offers to echo so password input can be
+// hidden, asks the client for its window size (NAWS) and offers the Generic
+// MUD Communication Protocol (GMCP) for structured data. Every other option is
+// refused.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Telnet commands
+const (
+	cmdSE   byte = 240
+	cmdNOP  byte = 241
+	cmdGA   byte = 249
+	cmdSB   byte = 250
+	cmdWILL byte = 251
+	cmdWONT byte = 252
+	cmdDO   byte = 253
+	cmdDONT byte = 254
+	cmdIAC  byte = 255
+)
+
+// Telnet options
+const (
+	OptionEcho byte = 1
+	OptionSGA  byte = 3
+	OptionNAWS byte = 31
+	OptionGMCP byte = 201
+)
+
+// maxLineLength is the longest line read, longer lines are truncated
+const maxLineLength int = 1024
+
+// maxSubnegotiationLength is the longest subnegotiation read, longer
+// subnegotiations are discarded
+const maxSubnegotiationLength int = 8192
+
+// Conn reads lines from and writes text to a telnet client
+type Conn struct {
+	r *bufio.Reader
+	w io.Writer
+
+	mu sync.Mutex
+	// us are the options enabled on the server side
+	us map[byte]bool
+	// them are the options enabled on the client side
+	them map[byte]bool
+	// pendingUs and pendingThem are options the server has asked to enable
+	// and has not had a reply for
+	pendingUs   map[byte]bool
+	pendingThem map[byte]bool
+	width       int
+	height      int
+	gmcp        []string
+}
+
+// supportedUs are the options the server enables when the client asks
+var supportedUs = map[byte]bool{
+	OptionEcho: true,
+	OptionSGA:  true,
+	OptionGMCP: true,
+}
+
+// supportedThem are the options the server accepts the client enabling
+var supportedThem = map[byte]bool{
+	OptionNAWS: true,
+}
+
+// NewConn returns a telnet connection over the reader and writer, usually a
+// net.Conn
+func NewConn(rw io.ReadWriter) *Conn {
+	return &Conn{
+		r:           bufio.NewReader(rw),
+		w:           rw,
+		us:          map[byte]bool{},
+		them:        map[byte]bool{},
+		pendingUs:   map[byte]bool{},
+		pendingThem: map[byte]bool{},
+	}
+}
+
+// Negotiate offers GMCP and suppressing go ahead and asks the client for its
+// window size. Replies are handled while reading lines.
+func (c *Conn) Negotiate() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.requestUs(OptionSGA)
+	if err != nil {
+		return err
+	}
+
+	err = c.requestUs(OptionGMCP)
+	if err != nil {
+		return err
+	}
+
+	return c.requestThem(OptionNAWS)
+}
+
+// HideInput asks the client to stop echoing input so passwords are not shown,
+// and to start echoing input again
+func (c *Conn) HideInput(hide bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hide {
+		return c.requestUs(OptionEcho)
+	}
+
+	if !c.us[OptionEcho] && !c.pendingUs[OptionEcho] {
+		return nil
+	}
+	c.us[OptionEcho] = false
+	c.pendingUs[OptionEcho] = false
+
+	return c.command(cmdWONT, OptionEcho)
+}
+
+// Width returns the client window width in columns, zero when the client has
+// not sent its window size
+func (c *Conn) Width() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.width
+}
+
+// Height returns the client window height in rows, zero when the client has
+// not sent its window size
+func (c *Conn) Height() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.height
+}
+
+// GMCP returns whether the client has agreed to receive GMCP messages
+func (c *Conn) GMCP() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.us[OptionGMCP]
+}
+
+// GMCPMessages returns and forgets the GMCP messages received from the client
+func (c *Conn) GMCPMessages() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	messages := c.gmcp
+	c.gmcp = nil
+	return messages
+}
+
+// SendGMCP sends a GMCP message with the data encoded as JSON when the client
+// has agreed to receive GMCP messages
+func (c *Conn) SendGMCP(pkg string, data any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.us[OptionGMCP] {
+		return nil
+	}
+
+	b := bytes.NewBufferString(pkg)
+	if data != nil {
+		d, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		b.WriteByte(' ')
+		b.Write(d)
+	}
+
+	msg := []byte{cmdIAC, cmdSB, OptionGMCP}
+	msg = append(msg, escape(b.Bytes())...)
+	msg = append(msg, cmdIAC, cmdSE)
+
+	_, err := c.w.Write(msg)
+	return err
+}
+
+// Write writes text to the client, line feeds are sent as carriage return
+// line feed pairs
+func (c *Conn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err := c.w.Write(escape(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))))
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Prompt writes a prompt followed by go ahead so clients that do not suppress
+// go ahead know the prompt is complete
+func (c *Conn) Prompt(prompt string) error {
+	_, err := c.Write([]byte(prompt))
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.us[OptionSGA] {
+		return nil
+	}
+
+	_, err = c.w.Write([]byte{cmdIAC, cmdGA})
+	return err
+}
+
+// ReadLine returns the next line typed by the player without the line ending,
+// telnet commands received while reading are handled
+func (c *Conn) ReadLine() (string, error) {
+
+	line := []byte{}
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+
+		switch b {
+		case cmdIAC:
+			err := c.readCommand()
+			if err != nil {
+				return "", err
+			}
+		case '\r':
+			// Carriage return is followed by line feed or NUL
+			next, err := c.r.Peek(1)
+			if err == nil && (next[0] == '\n' || next[0] == 0) {
+				_, _ = c.r.ReadByte()
+			}
+			return strings.TrimSpace(string(line)), nil
+		case '\n':
+			return strings.TrimSpace(string(line)), nil
+		case '\b', 127:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+			}
+		default:
+			if b < 32 {
+				continue
+			}
+			if len(line) < maxLineLength {
+				line = append(line, b)
+			}
+		}
+	}
+}
+
+// readCommand handles the telnet command following IAC
+func (c *Conn) readCommand() error {
+
+	cmd, err := c.r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case cmdWILL, cmdWONT, cmdDO, cmdDONT:
+		option, err := c.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.negotiate(cmd, option)
+	case cmdSB:
+		return c.readSubnegotiation()
+	}
+
+	// NOP, GA and the remaining commands need no reply
+	return nil
+}
+
+// negotiate replies to an option request from the client, replies are only
+// sent when the option changes state so negotiation does not loop
+func (c *Conn) negotiate(cmd, option byte) error {
+
+	switch cmd {
+	case cmdWILL:
+		pending := c.pendingThem[option]
+		c.pendingThem[option] = false
+		if !supportedThem[option] {
+			return c.command(cmdDONT, option)
+		}
+		if c.them[option] {
+			return nil
+		}
+		c.them[option] = true
+		if pending {
+			return nil
+		}
+		return c.command(cmdDO, option)
+	case cmdWONT:
+		pending := c.pendingThem[option]
+		c.pendingThem[option] = false
+		if !c.them[option] {
+			return nil
+		}
+		c.them[option] = false
+		if pending {
+			return nil
+		}
+		return c.command(cmdDONT, option)
+	case cmdDO:
+		pending := c.pendingUs[option]
+		c.pendingUs[option] = false
+		if !supportedUs[option] {
+			return c.command(cmdWONT, option)
+		}
+		if c.us[option] {
+			return nil
+		}
+		c.us[option] = true
+		if pending {
+			return nil
+		}
+		return c.command(cmdWILL, option)
+	case cmdDONT:
+		pending := c.pendingUs[option]
+		c.pendingUs[option] = false
+		if !c.us[option] {
+			return nil
+		}
+		c.us[option] = false
+		if pending {
+			return nil
+		}
+		return c.command(cmdWONT, option)
+	}
+
+	return nil
+}
+
+// readSubnegotiation reads a subnegotiation up to IAC SE
+func (c *Conn) readSubnegotiation() error {
+
+	option, err := c.r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	data := []byte{}
+	for {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b == cmdIAC {
+			next, err := c.r.ReadByte()
+			if err != nil {
+				return err
+			}
+			if next == cmdSE {
+				break
+			}
+			// Escaped IAC
+			b = next
+		}
+		if len(data) < maxSubnegotiationLength {
+			data = append(data, b)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch option {
+	case OptionNAWS:
+		if len(data) != 4 {
+			return nil
+		}
+		c.width = int(data[0])<<8 | int(data[1])
+		c.height = int(data[2])<<8 | int(data[3])
+	case OptionGMCP:
+		c.gmcp = append(c.gmcp, string(data))
+	}
+
+	return nil
+}
+
+// requestUs asks to enable an option on the server side
+func (c *Conn) requestUs(option byte) error {
+	if c.us[option] || c.pendingUs[option] {
+		return nil
+	}
+	c.pendingUs[option] = true
+	return c.command(cmdWILL, option)
+}
+
+// requestThem asks the client to enable an option
+func (c *Conn) requestThem(option byte) error {
+	if c.them[option] || c.pendingThem[option] {
+		return nil
+	}
+	c.pendingThem[option] = true
+	return c.command(cmdDO, option)
+}
+
+func (c *Conn) command(cmd, option byte) error {
+	_, err := c.w.Write([]byte{cmdIAC, cmd, option})
+	if err != nil {
+		return fmt.Errorf("failed writing telnet command >%d< option >%d< >%v<", cmd, option, err)
+	}
+	return nil
+}
+
+// escape doubles IAC bytes so they are not read as commands
+func escape(p []byte) []byte {
+	return bytes.ReplaceAll(p, []byte{cmdIAC}, []byte{cmdIAC, cmdIAC})
+}
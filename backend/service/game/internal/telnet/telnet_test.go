@@ -0,0 +1,185 @@
+package telnet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// client is what the telnet client sends and what the server writes
+type client struct {
+	in  *bytes.Buffer
+	out *bytes.Buffer
+}
+
+func (c *client) Read(p []byte) (int, error) {
+	return c.in.Read(p)
+}
+
+func (c *client) Write(p []byte) (int, error) {
+	return c.out.Write(p)
+}
+
+func newClient(in ...byte) *client {
+	return &client{
+		in:  bytes.NewBuffer(in),
+		out: &bytes.Buffer{},
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+
+	c := newClient(
+		// Client agrees to suppress go ahead, GMCP and sends its window size
+		cmdIAC, cmdDO, OptionSGA,
+		cmdIAC, cmdDO, OptionGMCP,
+		cmdIAC, cmdWILL, OptionNAWS,
+		cmdIAC, cmdSB, OptionNAWS, 0, 100, 0, 40, cmdIAC, cmdSE,
+		'l', 'o', 'o', 'k', '\r', '\n',
+	)
+
+	conn := NewConn(c)
+
+	err := conn.Negotiate()
+	require.NoError(t, err, "Negotiate returns without error")
+	require.Equal(t, []byte{
+		cmdIAC, cmdWILL, OptionSGA,
+		cmdIAC, cmdWILL, OptionGMCP,
+		cmdIAC, cmdDO, OptionNAWS,
+	}, c.out.Bytes(), "Negotiate offers options")
+	c.out.Reset()
+
+	line, err := conn.ReadLine()
+	require.NoError(t, err, "ReadLine returns without error")
+	require.Equal(t, "look", line, "ReadLine returns the line")
+	require.Empty(t, c.out.Bytes(), "Replies to requested options are not answered")
+
+	require.Equal(t, 100, conn.Width(), "Width equals the window width")
+	require.Equal(t, 40, conn.Height(), "Height equals the window height")
+	require.True(t, conn.GMCP(), "GMCP is enabled")
+}
+
+func TestNegotiateRefused(t *testing.T) {
+
+	c := newClient(
+		// Client refuses GMCP and NAWS and asks for an unsupported option
+		cmdIAC, cmdDONT, OptionGMCP,
+		cmdIAC, cmdWONT, OptionNAWS,
+		cmdIAC, cmdDO, 24,
+		'n', 'o', 'r', 't', 'h', '\n',
+	)
+
+	conn := NewConn(c)
+
+	err := conn.Negotiate()
+	require.NoError(t, err, "Negotiate returns without error")
+	c.out.Reset()
+
+	line, err := conn.ReadLine()
+	require.NoError(t, err, "ReadLine returns without error")
+	require.Equal(t, "north", line, "ReadLine returns the line")
+	require.Equal(t, []byte{cmdIAC, cmdWONT, 24}, c.out.Bytes(), "Unsupported option is refused")
+
+	require.Equal(t, 0, conn.Width(), "Width is zero without a window size")
+	require.False(t, conn.GMCP(), "GMCP is not enabled")
+
+	err = conn.SendGMCP("Room.Info", map[string]string{"name": "Cave Tunnel"})
+	require.NoError(t, err, "SendGMCP returns without error")
+	require.Equal(t, []byte{cmdIAC, cmdWONT, 24}, c.out.Bytes(), "GMCP is not sent when refused")
+}
+
+func TestReadLine(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		in     []byte
+		expect []string
+	}{
+		{
+			name:   "Line feed",
+			in:     []byte("look\nnorth\n"),
+			expect: []string{"look", "north"},
+		},
+		{
+			name:   "Carriage return NUL",
+			in:     []byte("look\r\x00north\r\n"),
+			expect: []string{"look", "north"},
+		},
+		{
+			name:   "Backspace",
+			in:     []byte("lool\bk\n"),
+			expect: []string{"look"},
+		},
+		{
+			name:   "Commands within a line",
+			in:     []byte{'l', 'o', cmdIAC, cmdNOP, 'o', 'k', '\n'},
+			expect: []string{"look"},
+		},
+		{
+			name:   "Surrounding space",
+			in:     []byte("  look  \n"),
+			expect: []string{"look"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := NewConn(newClient(tt.in...))
+			for _, expect := range tt.expect {
+				line, err := conn.ReadLine()
+				require.NoError(t, err, "ReadLine returns without error")
+				require.Equal(t, expect, line, "ReadLine returns the line")
+			}
+			_, err := conn.ReadLine()
+			require.ErrorIs(t, err, io.EOF, "ReadLine returns EOF")
+		})
+	}
+}
+
+func TestHideInput(t *testing.T) {
+
+	c := newClient(cmdIAC, cmdDO, OptionEcho, 's', '\n')
+	conn := NewConn(c)
+
+	err := conn.HideInput(true)
+	require.NoError(t, err, "HideInput returns without error")
+	require.Equal(t, []byte{cmdIAC, cmdWILL, OptionEcho}, c.out.Bytes(), "HideInput offers to echo")
+	c.out.Reset()
+
+	_, err = conn.ReadLine()
+	require.NoError(t, err, "ReadLine returns without error")
+	require.Empty(t, c.out.Bytes(), "Agreement to echo is not answered")
+
+	err = conn.HideInput(false)
+	require.NoError(t, err, "HideInput returns without error")
+	require.Equal(t, []byte{cmdIAC, cmdWONT, OptionEcho}, c.out.Bytes(), "HideInput stops echoing")
+}
+
+func TestWrite(t *testing.T) {
+
+	c := newClient(cmdIAC, cmdDO, OptionGMCP, '\n')
+	conn := NewConn(c)
+
+	_, err := conn.Write([]byte("Cave Tunnel\nExits: north\n"))
+	require.NoError(t, err, "Write returns without error")
+	require.Equal(t, "Cave Tunnel\r\nExits: north\r\n", c.out.String(), "Write sends carriage return line feeds")
+	c.out.Reset()
+
+	err = conn.Prompt("> ")
+	require.NoError(t, err, "Prompt returns without error")
+	require.Equal(t, []byte{'>', ' ', cmdIAC, cmdGA}, c.out.Bytes(), "Prompt is followed by go ahead")
+	c.out.Reset()
+
+	_, err = conn.ReadLine()
+	require.NoError(t, err, "ReadLine returns without error")
+	c.out.Reset()
+
+	err = conn.SendGMCP("Room.Info", map[string]string{"name": "Cave Tunnel"})
+	require.NoError(t, err, "SendGMCP returns without error")
+	expect := []byte{cmdIAC, cmdSB, OptionGMCP}
+	expect = append(expect, []byte(`Room.Info {"name":"Cave Tunnel"}`)...)
+	expect = append(expect, cmdIAC, cmdSE)
+	require.Equal(t, expect, c.out.Bytes(), "SendGMCP sends the message as a subnegotiation")
+}
@@ -0,0 +1,202 @@
+package terminal
+
+// Terminal renders action responses as the classic text blocks of a MUD for
+// players using terminals and screen readers. Every action narrative is written
+// in order followed by the location the final action left the character in,
+// and what the character looked at when the final action was a look.
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// ANSI escape codes
+const (
+	ansiReset   string = "\x1b[0m"
+	ansiBold    string = "\x1b[1m"
+	ansiRed     string = "\x1b[31m"
+	ansiGreen   string = "\x1b[32m"
+	ansiYellow  string = "\x1b[33m"
+	ansiMagenta string = "\x1b[35m"
+	ansiCyan    string = "\x1b[36m"
+)
+
+// Options are how text is rendered
+type Options struct {
+	// ANSI colours location names, exits, characters, monsters and objects
+	// with ANSI escape codes
+	ANSI bool
+	// Width is the column text is wrapped at, zero does not wrap text
+	Width int
+	// Language is the language of labels and direction names, dungeon content
+	// and narratives are already in the language of the response
+	Language string
+	// Brief renders only the action narratives, for actions by others that
+	// did not move the character
+	Brief bool
+}
+
+// Render writes the text block of the actions
+func Render(w io.Writer, data []schema.ActionResponseData, o Options) error {
+
+	r := renderer{
+		Options: o,
+	}
+
+	for idx := range data {
+		if data[idx].Narrative == "" {
+			continue
+		}
+		r.paragraph("", data[idx].Narrative)
+	}
+
+	if len(data) > 0 && !o.Brief {
+		r.final(data[len(data)-1])
+	}
+
+	_, err := io.WriteString(w, r.String())
+	return err
+}
+
+type renderer struct {
+	strings.Builder
+	Options
+}
+
+// final renders where the final action left the character and what the
+// character looked at
+func (r *renderer) final(d schema.ActionResponseData) {
+
+	if d.Command != record.ActionCommandLook {
+		r.location(d.Location)
+		return
+	}
+
+	switch {
+	case d.TargetLocation != nil:
+		r.location(*d.TargetLocation)
+	case d.TargetMonster != nil:
+		r.location(d.Location)
+		r.entity(ansiRed, d.TargetMonster.Name, d.TargetMonster.Description, d.TargetMonster.EquippedObjects)
+	case d.TargetCharacter != nil:
+		r.location(d.Location)
+		r.entity(ansiYellow, d.TargetCharacter.Name, d.TargetCharacter.Description, d.TargetCharacter.EquippedObjects)
+	case d.TargetObject != nil:
+		r.location(d.Location)
+		r.entity(ansiMagenta, d.TargetObject.Name, d.TargetObject.Description, nil)
+	default:
+		r.location(d.Location)
+	}
+}
+
+func (r *renderer) location(l schema.ActionLocation) {
+
+	r.WriteString("\n")
+	r.line(ansiBold+ansiCyan, l.Name)
+	if l.Description != "" {
+		r.paragraph("", l.Description)
+	}
+
+	exits := []string{}
+	for _, direction := range l.Directions {
+		exits = append(exits, locale.Direction(r.Language, direction))
+	}
+	if len(exits) == 0 {
+		r.paragraph(ansiGreen, r.message("Exits: none"))
+	} else {
+		r.paragraph(ansiGreen, r.message(fmt.Sprintf("Exits: %s", strings.Join(exits, ", "))))
+	}
+
+	characters := []string{}
+	for _, c := range l.Characters {
+		characters = append(characters, c.Name)
+	}
+	if len(characters) > 0 {
+		r.paragraph(ansiYellow, r.message(fmt.Sprintf("Characters: %s", strings.Join(characters, ", "))))
+	}
+
+	monsters := []string{}
+	for _, m := range l.Monsters {
+		monsters = append(monsters, m.Name)
+	}
+	if len(monsters) > 0 {
+		r.paragraph(ansiRed, r.message(fmt.Sprintf("Monsters: %s", strings.Join(monsters, ", "))))
+	}
+
+	objects := []string{}
+	for _, o := range l.Objects {
+		objects = append(objects, o.Name)
+	}
+	if len(objects) > 0 {
+		r.paragraph(ansiMagenta, r.message(fmt.Sprintf("Objects: %s", strings.Join(objects, ", "))))
+	}
+}
+
+// entity renders the monster, character or object looked at
+func (r *renderer) entity(colour, name, description string, equipped []schema.ActionObject) {
+
+	r.WriteString("\n")
+	r.line(ansiBold+colour, name)
+	if description != "" {
+		r.paragraph("", description)
+	}
+
+	objects := []string{}
+	for _, o := range equipped {
+		objects = append(objects, o.Name)
+	}
+	if len(objects) > 0 {
+		r.paragraph(ansiMagenta, r.message(fmt.Sprintf("Equipped: %s", strings.Join(objects, ", "))))
+	}
+}
+
+func (r *renderer) message(msg string) string {
+	return locale.Message(r.Language, msg)
+}
+
+// paragraph writes text wrapped at the width
+func (r *renderer) paragraph(colour, text string) {
+	for _, line := range wrap(text, r.Width) {
+		r.line(colour, line)
+	}
+}
+
+// line writes a single line of text
+func (r *renderer) line(colour, text string) {
+	if r.ANSI && colour != "" {
+		r.WriteString(colour)
+		r.WriteString(text)
+		r.WriteString(ansiReset)
+	} else {
+		r.WriteString(text)
+	}
+	r.WriteString("\n")
+}
+
+// wrap splits text into lines no longer than the width, words longer than the
+// width are not split
+func wrap(text string, width int) []string {
+
+	words := strings.Fields(text)
+	if width <= 0 || len(words) == 0 {
+		return []string{strings.TrimSpace(text)}
+	}
+
+	lines := []string{}
+	line := words[0]
+	for _, word := range words[1:] {
+		if len([]rune(line))+1+len([]rune(word)) > width {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line += " " + word
+	}
+
+	return append(lines, line)
+}
@@ -0,0 +1,211 @@
+package terminal
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	schema "gitlab.com/alienspaces/go-mud/backend/schema/game"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/locale"
+	"gitlab.com/alienspaces/go-mud/backend/service/game/internal/record"
+)
+
+// Golden files are updated with:
+//
+//	go test ./internal/terminal/... -update
+var update = flag.Bool("update", false, "update golden files")
+
+func caveTunnel() schema.ActionLocation {
+	return schema.ActionLocation{
+		Name:        "Cave Tunnel",
+		Description: "A narrow tunnel winds through the rock, water dripping from the ceiling into shallow pools along the floor.",
+		Directions:  []string{"north", "south", "down"},
+		Characters: schema.ActionLocationCharacters{
+			{Name: "Barricade"},
+			{Name: "Legislate"},
+		},
+		Monsters: schema.ActionLocationMonsters{
+			{Name: "Angry Goblin"},
+		},
+		Objects: schema.ActionLocationObjects{
+			{Name: "Rusted Sword"},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+
+	darkRoom := schema.ActionLocation{
+		Name:        "Dark Room",
+		Description: "It is too dark to see much.",
+		Direction:   "down",
+	}
+
+	tests := []struct {
+		name    string
+		data    []schema.ActionResponseData
+		options Options
+	}{
+		{
+			name: "move",
+			data: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandMove,
+					Narrative: "Angry Goblin arrives from the south.",
+					Location:  caveTunnel(),
+				},
+				{
+					Command:   record.ActionCommandMove,
+					Narrative: "You move north to Cave Tunnel.",
+					Location:  caveTunnel(),
+				},
+			},
+		},
+		{
+			name: "move_wrapped",
+			data: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandMove,
+					Narrative: "You move north to Cave Tunnel.",
+					Location:  caveTunnel(),
+				},
+			},
+			options: Options{Width: 40},
+		},
+		{
+			name: "move_ansi",
+			data: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandMove,
+					Narrative: "You move north to Cave Tunnel.",
+					Location:  caveTunnel(),
+				},
+			},
+			options: Options{ANSI: true},
+		},
+		{
+			name: "move_fr",
+			data: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandMove,
+					Narrative: "Vous allez vers le nord jusqu'à Cave Tunnel.",
+					Location:  caveTunnel(),
+				},
+			},
+			options: Options{Language: locale.French},
+		},
+		{
+			name: "move_brief",
+			data: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandMove,
+					Narrative: "Angry Goblin arrives from the south.",
+					Location:  caveTunnel(),
+				},
+			},
+			options: Options{Brief: true},
+		},
+		{
+			name: "look_direction",
+			data: []schema.ActionResponseData{
+				{
+					Command:        record.ActionCommandLook,
+					Narrative:      "You look down towards Dark Room.",
+					Location:       caveTunnel(),
+					TargetLocation: &darkRoom,
+				},
+			},
+		},
+		{
+			name: "look_monster",
+			data: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandLook,
+					Narrative: "You look at Angry Goblin.",
+					Location:  caveTunnel(),
+					TargetMonster: &schema.ActionMonster{
+						Name:        "Angry Goblin",
+						Description: "A small angry goblin.",
+						EquippedObjects: []schema.ActionObject{
+							{Name: "Bone Dagger", IsEquipped: true},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "look_object",
+			data: []schema.ActionResponseData{
+				{
+					Command:   record.ActionCommandLook,
+					Narrative: "You look at the Rusted Sword.",
+					Location:  caveTunnel(),
+					TargetObject: &schema.ActionObject{
+						Name:        "Rusted Sword",
+						Description: "A rusted sword with a chipped blade.",
+					},
+				},
+			},
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			b := &strings.Builder{}
+			err := Render(b, tt.data, tt.options)
+			require.NoError(t, err, "Render returns without error")
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				err := os.WriteFile(golden, []byte(b.String()), 0644)
+				require.NoError(t, err, "WriteFile returns without error")
+			}
+
+			expect, err := os.ReadFile(golden)
+			require.NoError(t, err, "ReadFile returns without error")
+			require.Equal(t, string(expect), b.String(), "Rendered text equals golden file >%s<", golden)
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		text   string
+		width  int
+		expect []string
+	}{
+		{
+			name:   "No width",
+			text:   "A narrow tunnel winds through the rock.",
+			expect: []string{"A narrow tunnel winds through the rock."},
+		},
+		{
+			name:   "Wrapped",
+			text:   "A narrow tunnel winds through the rock.",
+			width:  16,
+			expect: []string{"A narrow tunnel", "winds through", "the rock."},
+		},
+		{
+			name:   "Long word",
+			text:   "An unbelievably long word.",
+			width:  6,
+			expect: []string{"An", "unbelievably", "long", "word."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, wrap(tt.text, tt.width), "Wrapped lines equal expected")
+		})
+	}
+}
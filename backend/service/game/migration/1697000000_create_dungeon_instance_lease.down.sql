@@ -0,0 +1,2 @@
+-- Drop dungeon instance lease
+DROP TABLE "dungeon_instance_lease";
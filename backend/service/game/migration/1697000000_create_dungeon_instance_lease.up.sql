@@ -0,0 +1,30 @@
+-- --
+-- -- dungeon_instance_lease
+-- --
+-- A dungeon instance lease is claimed by a single server daemon which is
+-- then responsible for processing dungeon instance turns. A daemon renews
+-- its leases every cycle, a lease that has expired may be claimed by any
+-- other daemon.
+CREATE TABLE "dungeon_instance_lease" (
+  "id" uuid CONSTRAINT dungeon_instance_lease_pk PRIMARY KEY DEFAULT gen_random_uuid(),
+  "dungeon_instance_id" uuid NOT NULL,
+  "daemon_id" text,
+  "expires_at" timestamp WITH TIME ZONE,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (current_timestamp),
+  "updated_at" timestamp WITH TIME ZONE,
+  "deleted_at" timestamp WITH TIME ZONE,
+  CONSTRAINT "dungeon_instance_lease_dungeon_instance_id_fk" FOREIGN KEY (dungeon_instance_id) REFERENCES dungeon_instance(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX "dungeon_instance_lease_dungeon_instance_id_uq" ON "dungeon_instance_lease" (dungeon_instance_id)
+WHERE
+  deleted_at IS NULL;
+
+-- Existing dungeon instances are available to be claimed by any daemon
+INSERT INTO "dungeon_instance_lease" (dungeon_instance_id)
+SELECT
+  id
+FROM
+  dungeon_instance
+WHERE
+  deleted_at IS NULL;
@@ -0,0 +1,6 @@
+-- Drop dungeon instance failure tracking
+ALTER TABLE "dungeon_instance"
+  DROP COLUMN "failure_count",
+  DROP COLUMN "last_error",
+  DROP COLUMN "last_error_at",
+  DROP COLUMN "quarantined_at";
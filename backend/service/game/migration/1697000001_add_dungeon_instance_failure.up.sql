@@ -0,0 +1,11 @@
+-- --
+-- -- dungeon_instance failure tracking
+-- --
+-- Dungeon instances that fail processing are retried with backoff by the
+-- server daemon. After too many consecutive failures a dungeon instance is
+-- quarantined and will not be processed until un-quarantined by an admin.
+ALTER TABLE "dungeon_instance"
+  ADD COLUMN "failure_count" integer NOT NULL DEFAULT 0,
+  ADD COLUMN "last_error" text,
+  ADD COLUMN "last_error_at" timestamp WITH TIME ZONE,
+  ADD COLUMN "quarantined_at" timestamp WITH TIME ZONE;
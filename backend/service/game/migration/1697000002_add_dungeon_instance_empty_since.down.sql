@@ -0,0 +1,3 @@
+-- Drop dungeon instance empty since
+ALTER TABLE "dungeon_instance"
+  DROP COLUMN "empty_since";
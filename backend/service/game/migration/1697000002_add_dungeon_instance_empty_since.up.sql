@@ -0,0 +1,8 @@
+-- --
+-- -- dungeon_instance empty since
+-- --
+-- Dungeon instances are only shutdown once they have been empty of characters
+-- for a grace period, so players returning shortly after leaving find the
+-- dungeon instance as they left it.
+ALTER TABLE "dungeon_instance"
+  ADD COLUMN "empty_since" timestamp WITH TIME ZONE;
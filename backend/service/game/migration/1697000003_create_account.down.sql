@@ -0,0 +1,6 @@
+-- Drop character account
+ALTER TABLE "character"
+  DROP COLUMN "account_id";
+
+-- Drop account
+DROP TABLE "account";
@@ -0,0 +1,33 @@
+-- --
+-- -- account
+-- --
+-- An account is registered by a player and owns the characters the player
+-- creates. Requests to play a character must be authenticated as the
+-- account that owns the character.
+CREATE TABLE "account" (
+  "id" uuid CONSTRAINT account_pk PRIMARY KEY DEFAULT gen_random_uuid(),
+  "name" text NOT NULL,
+  "email" text NOT NULL,
+  "password_hash" text NOT NULL,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (current_timestamp),
+  "updated_at" timestamp WITH TIME ZONE,
+  "deleted_at" timestamp WITH TIME ZONE,
+  CONSTRAINT "account_name_ck" CHECK (
+    char_length("name") BETWEEN 1
+    AND 256
+  )
+);
+
+CREATE UNIQUE INDEX "account_email_uq" ON "account" (lower(email))
+WHERE
+  deleted_at IS NULL;
+
+COMMENT ON TABLE "account" IS 'An account is registered by a player and owns the characters the player creates.';
+
+-- Characters created before accounts existed have no owning account and
+-- cannot be played until they are assigned to an account
+ALTER TABLE "character"
+  ADD COLUMN "account_id" uuid,
+  ADD CONSTRAINT "character_account_id_fk" FOREIGN KEY (account_id) REFERENCES account(id);
+
+CREATE INDEX "character_account_id_idx" ON "character" (account_id);
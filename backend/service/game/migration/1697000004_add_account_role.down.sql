@@ -0,0 +1,3 @@
+-- Drop account role
+ALTER TABLE "account"
+  DROP COLUMN "role";
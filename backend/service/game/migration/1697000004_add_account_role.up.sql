@@ -0,0 +1,10 @@
+-- --
+-- -- account role
+-- --
+-- An account role grants the permissions an authenticated account has. Game
+-- masters manage dungeon instances and admins also manage accounts.
+ALTER TABLE "account"
+  ADD COLUMN "role" text NOT NULL DEFAULT 'player',
+  ADD CONSTRAINT "account_role_ck" CHECK (
+    "role" IN ('player', 'game_master', 'admin')
+  );
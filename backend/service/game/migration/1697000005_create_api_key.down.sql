@@ -0,0 +1,2 @@
+-- Drop API key
+DROP TABLE "api_key";
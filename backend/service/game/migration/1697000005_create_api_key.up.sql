@@ -0,0 +1,32 @@
+-- --
+-- -- api_key
+-- --
+-- An API key authenticates automated clients such as play-testing bots and
+-- analytics jobs that cannot login interactively. Only a hash of the key is
+-- stored, the key itself is shown once when it is issued. The scopes of a key
+-- are the permissions it grants and a key issued for an account plays that
+-- account's characters.
+CREATE TABLE "api_key" (
+  "id" uuid CONSTRAINT api_key_pk PRIMARY KEY DEFAULT gen_random_uuid(),
+  "owner" text NOT NULL,
+  "key_prefix" text NOT NULL,
+  "key_hash" text NOT NULL,
+  "scopes" text [] NOT NULL DEFAULT '{}',
+  "account_id" uuid,
+  "expires_at" timestamp WITH TIME ZONE,
+  "revoked_at" timestamp WITH TIME ZONE,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (current_timestamp),
+  "updated_at" timestamp WITH TIME ZONE,
+  "deleted_at" timestamp WITH TIME ZONE,
+  CONSTRAINT "api_key_account_id_fk" FOREIGN KEY (account_id) REFERENCES account(id),
+  CONSTRAINT "api_key_owner_ck" CHECK (
+    char_length("owner") BETWEEN 1
+    AND 256
+  )
+);
+
+CREATE UNIQUE INDEX "api_key_key_hash_uq" ON "api_key" (key_hash);
+
+CREATE INDEX "api_key_account_id_idx" ON "api_key" (account_id);
+
+COMMENT ON TABLE "api_key" IS 'An API key authenticates automated clients that cannot login interactively.';
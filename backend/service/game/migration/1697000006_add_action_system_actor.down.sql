@@ -0,0 +1,75 @@
+-- Delete system actions
+DELETE FROM "action_character_object"
+WHERE action_character_id IN (
+    SELECT ac.id
+    FROM action_character ac
+      JOIN action a ON a.id = ac.action_id
+    WHERE a.system_actor IS NOT NULL
+  );
+
+DELETE FROM "action_character"
+WHERE action_id IN (
+    SELECT id
+    FROM action
+    WHERE system_actor IS NOT NULL
+  );
+
+DELETE FROM "action_monster_object"
+WHERE action_monster_id IN (
+    SELECT am.id
+    FROM action_monster am
+      JOIN action a ON a.id = am.action_id
+    WHERE a.system_actor IS NOT NULL
+  );
+
+DELETE FROM "action_monster"
+WHERE action_id IN (
+    SELECT id
+    FROM action
+    WHERE system_actor IS NOT NULL
+  );
+
+DELETE FROM "action_object"
+WHERE action_id IN (
+    SELECT id
+    FROM action
+    WHERE system_actor IS NOT NULL
+  );
+
+DELETE FROM "action"
+WHERE system_actor IS NOT NULL;
+
+-- Drop action system actor
+ALTER TABLE "action"
+  DROP CONSTRAINT "action_resolved_command_ck",
+  DROP CONSTRAINT "action_source_ck",
+  DROP CONSTRAINT "action_target_instance_id_ck",
+  DROP COLUMN "system_actor",
+  ADD CONSTRAINT "action_resolved_command_ck" CHECK (
+    resolved_command = 'move'
+    OR resolved_command = 'look'
+    OR resolved_command = 'use'
+    OR resolved_command = 'stash'
+    OR resolved_command = 'equip'
+    OR resolved_command = 'drop'
+    OR resolved_command = 'attack'
+  ),
+  ADD CONSTRAINT "action_character_or_monster_ck" CHECK (
+    (
+      CASE
+        WHEN character_instance_id IS NULL THEN 0
+        ELSE 1
+      END + CASE
+        WHEN monster_instance_id IS NULL THEN 0
+        ELSE 1
+      END
+    ) = 1
+  ),
+  ADD CONSTRAINT "action_target_instance_id_ck" CHECK (
+    num_nonnulls(
+      resolved_target_object_instance_id,
+      resolved_target_character_instance_id,
+      resolved_target_monster_instance_id,
+      resolved_target_location_instance_id
+    ) = 1
+  );
@@ -0,0 +1,46 @@
+-- --
+-- -- action system actor
+-- --
+-- System actions are interventions made by a game master or an API key rather
+-- than by a character or monster, for example teleporting a character. The
+-- system actor is the name of whoever made the intervention. A teleport
+-- targets both the character and the location the character was moved to.
+ALTER TABLE "action"
+  ADD COLUMN "system_actor" text,
+  DROP CONSTRAINT "action_resolved_command_ck",
+  DROP CONSTRAINT "action_character_or_monster_ck",
+  DROP CONSTRAINT "action_target_instance_id_ck",
+  ADD CONSTRAINT "action_resolved_command_ck" CHECK (
+    resolved_command IN (
+      'move',
+      'look',
+      'use',
+      'stash',
+      'equip',
+      'drop',
+      'attack',
+      'teleport',
+      'spawn',
+      'heal',
+      'kill',
+      'kick'
+    )
+  ),
+  ADD CONSTRAINT "action_source_ck" CHECK (
+    num_nonnulls(character_instance_id, monster_instance_id, system_actor) = 1
+  ),
+  ADD CONSTRAINT "action_target_instance_id_ck" CHECK (
+    num_nonnulls(
+      resolved_target_object_instance_id,
+      resolved_target_character_instance_id,
+      resolved_target_monster_instance_id,
+      resolved_target_location_instance_id
+    ) = 1
+    OR (
+      system_actor IS NOT NULL
+      AND num_nonnulls(
+        resolved_target_character_instance_id,
+        resolved_target_location_instance_id
+      ) = 2
+    )
+  );
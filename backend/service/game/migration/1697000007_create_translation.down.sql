@@ -0,0 +1,2 @@
+-- Drop translation
+DROP TABLE "translation";
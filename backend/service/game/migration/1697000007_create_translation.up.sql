@@ -0,0 +1,32 @@
+-- --
+-- -- translation
+-- --
+-- A translation is the text of a dungeon, location, monster or object field
+-- in a language other than English. Dungeon content is authored in English
+-- and fields without a translation are shown in English.
+CREATE TABLE "translation" (
+  "id" uuid CONSTRAINT translation_pk PRIMARY KEY DEFAULT gen_random_uuid(),
+  "resource_type" text NOT NULL,
+  "resource_id" uuid NOT NULL,
+  "language" text NOT NULL,
+  "field" text NOT NULL,
+  "value" text NOT NULL,
+  "created_at" timestamp WITH TIME ZONE NOT NULL DEFAULT (current_timestamp),
+  "updated_at" timestamp WITH TIME ZONE,
+  "deleted_at" timestamp WITH TIME ZONE,
+  CONSTRAINT "translation_resource_type_ck" CHECK (
+    "resource_type" IN ('dungeon', 'location', 'monster', 'object')
+  ),
+  CONSTRAINT "translation_field_ck" CHECK (
+    "field" IN ('name', 'description', 'description_detailed')
+  ),
+  CONSTRAINT "translation_value_ck" CHECK (char_length("value") > 0)
+);
+
+CREATE UNIQUE INDEX "translation_resource_language_field_uq" ON "translation" (resource_id, language, field)
+WHERE
+  deleted_at IS NULL;
+
+CREATE INDEX "translation_language_resource_id_idx" ON "translation" (language, resource_id);
+
+COMMENT ON TABLE "translation" IS 'A translation is the text of a dungeon, location, monster or object field in a language other than English.';
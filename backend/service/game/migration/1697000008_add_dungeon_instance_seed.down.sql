@@ -0,0 +1,3 @@
+-- Drop dungeon instance seed
+ALTER TABLE "dungeon_instance"
+  DROP COLUMN "seed";
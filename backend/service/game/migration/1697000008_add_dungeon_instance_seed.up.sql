@@ -0,0 +1,11 @@
+-- --
+-- -- dungeon_instance seed
+-- --
+-- Every random choice made in a dungeon instance, from spawning monsters and
+-- objects to monster actions, is drawn from a source derived from the dungeon
+-- instance seed so a dungeon instance can be reproduced.
+ALTER TABLE "dungeon_instance"
+  ADD COLUMN "seed" bigint NOT NULL DEFAULT floor(random() * 9223372036854775807)::bigint;
+
+ALTER TABLE "dungeon_instance"
+  ALTER COLUMN "seed" DROP DEFAULT;